@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/abi"
 	"github.com/vechain/thor/builtin/gen"
@@ -84,3 +85,18 @@ func TestABI(t *testing.T) {
 
 	}
 }
+
+func TestRevertReason(t *testing.T) {
+	data, err := abi.EncodeRevertReason("not allowed")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x08c379a0", hexutil.Encode(data[:4]))
+
+	reason, err := abi.DecodeRevertReason(data)
+	assert.Nil(t, err)
+	assert.Equal(t, "not allowed", reason)
+
+	_, err = abi.DecodeRevertReason(nil)
+	assert.NotNil(t, err)
+	_, err = abi.DecodeRevertReason(data[:10])
+	assert.NotNil(t, err)
+}
@@ -41,6 +41,16 @@ func (e *Event) Name() string {
 	return e.event.Name
 }
 
+// Anonymous returns whether the event is anonymous, which has no id in topics.
+func (e *Event) Anonymous() bool {
+	return e.event.Anonymous
+}
+
+// Inputs returns all arguments, including indexed ones.
+func (e *Event) Inputs() ethabi.Arguments {
+	return e.event.Inputs
+}
+
 // Encode encodes args to data.
 func (e *Event) Encode(args ...interface{}) ([]byte, error) {
 	return e.argsWithoutIndexed.Pack(args...)
@@ -33,6 +33,16 @@ func (m *Method) Const() bool {
 	return m.method.Const
 }
 
+// Inputs returns input arguments.
+func (m *Method) Inputs() ethabi.Arguments {
+	return m.method.Inputs
+}
+
+// Outputs returns output arguments.
+func (m *Method) Outputs() ethabi.Arguments {
+	return m.method.Outputs
+}
+
 // EncodeInput encode args to data, and the data is prefixed with method id.
 func (m *Method) EncodeInput(args ...interface{}) ([]byte, error) {
 	data, err := m.method.Inputs.Pack(args...)
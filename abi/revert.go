@@ -0,0 +1,50 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// revertSelector is the id of 'Error(string)', which solidity uses to encode revert reason.
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	revertArgs     ethabi.Arguments
+)
+
+func init() {
+	typ, err := ethabi.NewType("string")
+	if err != nil {
+		panic(err)
+	}
+	revertArgs = ethabi.Arguments{{Type: typ}}
+}
+
+// DecodeRevertReason decodes reason string from output data of reverted execution.
+// The data is expected to be encoded as 'Error(string)'.
+func DecodeRevertReason(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, revertSelector) {
+		return "", errors.New("data has incorrect prefix")
+	}
+	var reason string
+	if err := revertArgs.Unpack(&reason, data[len(revertSelector):]); err != nil {
+		return "", err
+	}
+	return reason, nil
+}
+
+// EncodeRevertReason encodes reason string as 'Error(string)'.
+func EncodeRevertReason(reason string) ([]byte, error) {
+	data, err := revertArgs.Pack(reason)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(nil), revertSelector...), data...), nil
+}
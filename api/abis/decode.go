@@ -0,0 +1,112 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abis
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/thor"
+)
+
+// DecodedEvent event decoded with registered ABI.
+// Params are keyed by argument name, or position if unnamed.
+// Integers are in decimal string, and bytes in hex.
+// Indexed params of dynamic types are kept as topic, which is the hash of the value.
+type DecodedEvent struct {
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params"`
+}
+
+func decodeEvent(ev *abi.Event, topics []thor.Bytes32, data []byte) (*DecodedEvent, error) {
+	var (
+		inputs     = ev.Inputs()
+		nonIndexed ethabi.Arguments
+		indexed    int
+	)
+	for _, input := range inputs {
+		if input.Indexed {
+			indexed++
+		} else {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+	if indexed != len(topics) {
+		return nil, errors.New("topics count mismatch")
+	}
+	values, err := nonIndexed.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{}, len(inputs))
+	for i, input := range inputs {
+		name := input.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		if !input.Indexed {
+			params[name] = jsonValue(values[0])
+			values = values[1:]
+			continue
+		}
+		topic := topics[0]
+		topics = topics[1:]
+		switch input.Type.T {
+		case ethabi.StringTy, ethabi.BytesTy, ethabi.SliceTy, ethabi.ArrayTy:
+			params[name] = topic.String()
+		default:
+			// static value is stored in topic as if abi encoded
+			arg := input
+			arg.Indexed = false
+			v, err := ethabi.Arguments{arg}.UnpackValues(topic[:])
+			if err != nil {
+				return nil, err
+			}
+			params[name] = jsonValue(v[0])
+		}
+	}
+	return &DecodedEvent{ev.Name(), params}, nil
+}
+
+// jsonValue converts decoded abi value into form friendly to JSON clients.
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool, string:
+		return v
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Address:
+		return thor.Address(v).String()
+	case *big.Int:
+		return v.String()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(v)
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return hexutil.Encode(data)
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return list
+	}
+	return v
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abis
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+)
+
+var abiPrefix = []byte("abi") // (prefix, contract address) -> abi json
+
+type entry struct {
+	raw []byte
+	abi *abi.ABI
+}
+
+// Registry keeps contract ABIs registered by node operator, to decode events of those contracts.
+type Registry struct {
+	store kv.GetPutter
+	lock  sync.RWMutex
+	abis  map[thor.Address]*entry
+}
+
+// New create a registry, and load ABIs persisted in store.
+// If store is nil, ABIs are kept in memory only.
+func New(store kv.GetPutter) (*Registry, error) {
+	r := &Registry{
+		store: store,
+		abis:  make(map[thor.Address]*entry),
+	}
+	if store == nil {
+		return r, nil
+	}
+	it := store.NewIterator(*kv.NewRangeWithBytesPrefix(abiPrefix))
+	defer it.Release()
+	for it.Next() {
+		// trie nodes are keyed by raw hashes in the same db, and some may start with the prefix
+		if len(it.Key()) != len(abiPrefix)+len(thor.Address{}) {
+			continue
+		}
+		addr := thor.BytesToAddress(it.Key()[len(abiPrefix):])
+		raw := append([]byte(nil), it.Value()...)
+		parsed, err := abi.New(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "load abi of "+addr.String())
+		}
+		r.abis[addr] = &entry{raw, parsed}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Register sets ABI of the contract, replacing the existing one.
+func (r *Registry) Register(addr thor.Address, raw []byte) error {
+	raw = bytes.TrimSpace(raw)
+	parsed, err := abi.New(raw)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.store != nil {
+		if err := r.store.Put(append(abiPrefix, addr[:]...), raw); err != nil {
+			return err
+		}
+	}
+	r.abis[addr] = &entry{raw, parsed}
+	return nil
+}
+
+// Unregister removes ABI of the contract. It returns false if not registered.
+func (r *Registry) Unregister(addr thor.Address) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.abis[addr]; !ok {
+		return false, nil
+	}
+	if r.store != nil {
+		if err := r.store.Delete(append(abiPrefix, addr[:]...)); err != nil {
+			return false, err
+		}
+	}
+	delete(r.abis, addr)
+	return true, nil
+}
+
+// Get returns ABI json of the contract.
+func (r *Registry) Get(addr thor.Address) ([]byte, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if e, ok := r.abis[addr]; ok {
+		return e.raw, true
+	}
+	return nil, false
+}
+
+// Addresses returns addresses of contracts with ABI registered, in ascending order.
+func (r *Registry) Addresses() []thor.Address {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	addrs := make([]thor.Address, 0, len(r.abis))
+	for addr := range r.abis {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	return addrs
+}
+
+// DecodeEvent decodes event with ABI registered for the emitting contract.
+// It returns nil if no ABI registered, or the event doesn't match any event in the ABI.
+func (r *Registry) DecodeEvent(addr thor.Address, topics []thor.Bytes32, data []byte) *DecodedEvent {
+	if len(topics) == 0 {
+		return nil
+	}
+	r.lock.RLock()
+	e, ok := r.abis[addr]
+	r.lock.RUnlock()
+	if !ok {
+		return nil
+	}
+	ev, ok := e.abi.EventByID(topics[0])
+	if !ok || ev.Anonymous() {
+		return nil
+	}
+	decoded, err := decodeEvent(ev, topics[1:], data)
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abis_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+const transferABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"","type":"bytes"}],"name":"Transfer","type":"event"}]`
+
+func TestRegistry(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	r, err := abis.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := thor.BytesToAddress([]byte("contract"))
+
+	assert.Error(t, r.Register(contract, []byte("not abi")))
+	assert.Nil(t, r.Register(contract, []byte(transferABI)))
+
+	raw, ok := r.Get(contract)
+	assert.True(t, ok)
+	assert.Equal(t, transferABI, string(raw))
+	assert.Equal(t, []thor.Address{contract}, r.Addresses())
+
+	// reload from store
+	r, err = abis.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []thor.Address{contract}, r.Addresses())
+
+	removed, err := r.Unregister(contract)
+	assert.Nil(t, err)
+	assert.True(t, removed)
+	removed, err = r.Unregister(contract)
+	assert.Nil(t, err)
+	assert.False(t, removed)
+
+	r, err = abis.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, r.Addresses())
+}
+
+func TestRegistrySkipsForeignKeys(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	// a trie node whose hash happens to start with the prefix
+	var key thor.Bytes32
+	copy(key[:], "abi")
+	if err := db.Put(key[:], []byte("not abi")); err != nil {
+		t.Fatal(err)
+	}
+	r, err := abis.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, r.Addresses())
+}
+
+func TestDecodeEvent(t *testing.T) {
+	r, err := abis.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := thor.BytesToAddress([]byte("contract"))
+	from := thor.BytesToAddress([]byte("from"))
+	to := thor.BytesToAddress([]byte("to"))
+
+	parsed, _ := abi.New([]byte(transferABI))
+	ev, _ := parsed.EventByName("Transfer")
+	data, err := ev.Encode(big.NewInt(100), []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	topics := []thor.Bytes32{ev.ID(), thor.BytesToBytes32(from[:]), thor.BytesToBytes32(to[:])}
+
+	// not registered
+	assert.Nil(t, r.DecodeEvent(contract, topics, data))
+
+	assert.Nil(t, r.Register(contract, []byte(transferABI)))
+	assert.Equal(t, &abis.DecodedEvent{
+		Name: "Transfer",
+		Params: map[string]interface{}{
+			"from":  from.String(),
+			"to":    to.String(),
+			"value": "100",
+			"3":     "0x0102",
+		},
+	}, r.DecodeEvent(contract, topics, data))
+
+	// unknown event
+	assert.Nil(t, r.DecodeEvent(contract, []thor.Bytes32{thor.BytesToBytes32([]byte("topic0"))}, data))
+	// indexed args mismatch
+	assert.Nil(t, r.DecodeEvent(contract, topics[:2], data))
+	// bad data
+	assert.Nil(t, r.DecodeEvent(contract, topics, data[:10]))
+}
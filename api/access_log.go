@@ -0,0 +1,111 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// AccessLog writes a record per API request, in format 'logfmt' or 'json'.
+type AccessLog struct {
+	w      io.Writer
+	format string
+	lock   sync.Mutex
+	now    func() time.Time
+}
+
+// accessRecord fields of an access log record.
+type accessRecord struct {
+	Time      string  `json:"time"`
+	Client    string  `json:"client"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Status    int     `json:"status"`
+	Size      int64   `json:"size"`
+	Latency   float64 `json:"latency_ms"`
+	Referer   string  `json:"referer,omitempty"`
+	UserAgent string  `json:"user_agent,omitempty"`
+}
+
+// NewAccessLog creates an access log writing to w.
+func NewAccessLog(w io.Writer, format string) (*AccessLog, error) {
+	switch format {
+	case "logfmt", "json":
+	default:
+		return nil, fmt.Errorf("unsupported access log format '%v'", format)
+	}
+	return &AccessLog{w: w, format: format, now: time.Now}, nil
+}
+
+// Handler wraps h to log requests. It should be wrapped by TrustProxy if any, to log the real client.
+func (l *AccessLog) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := l.now()
+		// the response writer of subscriptions must be kept as is to be hijacked
+		if websocket.IsWebSocketUpgrade(req) {
+			h.ServeHTTP(w, req)
+			l.write(req, start, http.StatusSwitchingProtocols, 0)
+			return
+		}
+		rec := &sizeRecorder{statusRecorder: statusRecorder{w, http.StatusOK}}
+		h.ServeHTTP(rec, req)
+		l.write(req, start, rec.status, rec.size)
+	})
+}
+
+func (l *AccessLog) write(req *http.Request, start time.Time, status int, size int64) {
+	r := &accessRecord{
+		Time:      start.UTC().Format(time.RFC3339Nano),
+		Client:    ClientIP(req),
+		Method:    req.Method,
+		Path:      req.URL.RequestURI(),
+		Status:    status,
+		Size:      size,
+		Latency:   float64(l.now().Sub(start)) / float64(time.Millisecond),
+		Referer:   req.Referer(),
+		UserAgent: req.UserAgent(),
+	}
+
+	var line []byte
+	if l.format == "json" {
+		line, _ = json.Marshal(r)
+	} else {
+		line = []byte(fmt.Sprintf("time=%s client=%s method=%s path=%s status=%d size=%d latency_ms=%.3f referer=%s user_agent=%s",
+			r.Time, r.Client, r.Method, strconv.Quote(r.Path), r.Status, r.Size, r.Latency, strconv.Quote(r.Referer), strconv.Quote(r.UserAgent)))
+	}
+	line = append(line, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.w.Write(line)
+}
+
+// sizeRecorder records status code and bytes of the response body.
+type sizeRecorder struct {
+	statusRecorder
+	size int64
+}
+
+func (r *sizeRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.size += int64(n)
+	return n, err
+}
+
+// Flush supports streaming responses.
+func (r *sizeRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
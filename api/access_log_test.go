@@ -0,0 +1,58 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLog(t *testing.T) {
+	_, err := NewAccessLog(&bytes.Buffer{}, "xml")
+	assert.Error(t, err)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	})
+	now := time.Unix(1530000000, 0)
+	do := func(format string) string {
+		var buf bytes.Buffer
+		l, err := NewAccessLog(&buf, format)
+		assert.Nil(t, err)
+		l.now = func() time.Time {
+			now = now.Add(1500 * time.Microsecond)
+			return now
+		}
+		req := httptest.NewRequest("GET", "/blocks/0x01?expanded=true", nil)
+		req.RemoteAddr = "1.2.3.4:5678"
+		l.Handler(h).ServeHTTP(httptest.NewRecorder(), req)
+		return buf.String()
+	}
+
+	line := do("logfmt")
+	assert.True(t, strings.HasSuffix(line, "\n"))
+	assert.Contains(t, line, `client=1.2.3.4 method=GET path="/blocks/0x01?expanded=true" status=404 size=9 latency_ms=1.500`)
+
+	var r accessRecord
+	assert.Nil(t, json.Unmarshal([]byte(do("json")), &r))
+	assert.Equal(t, accessRecord{
+		Time:    r.Time,
+		Client:  "1.2.3.4",
+		Method:  "GET",
+		Path:    "/blocks/0x01?expanded=true",
+		Status:  http.StatusNotFound,
+		Size:    9,
+		Latency: 1.5,
+	}, r)
+}
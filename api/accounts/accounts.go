@@ -6,44 +6,62 @@
 package accounts
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
 	"github.com/vechain/thor/xenv"
 )
 
+const (
+	defaultTxLimit    = 10
+	maxTxLimit        = 100
+	maxBatchAccounts  = 1000
+	maxHistorySamples = 100
+)
+
 type Accounts struct {
 	chain        *chain.Chain
 	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+	forkConfig   thor.ForkConfig
 }
 
-func New(chain *chain.Chain, stateCreator *state.Creator) *Accounts {
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB, forkConfig thor.ForkConfig) *Accounts {
 	return &Accounts{
 		chain,
 		stateCreator,
+		logDB,
+		forkConfig,
 	}
 }
 
 func (a *Accounts) getCode(addr thor.Address, stateRoot thor.Bytes32) ([]byte, error) {
 	state, err := a.stateCreator.NewState(stateRoot)
 	if err != nil {
-		return nil, err
+		return nil, utils.StateError(err)
 	}
 	code := state.GetCode(addr)
 	if err := state.Err(); err != nil {
-		return nil, err
+		return nil, utils.StateError(err)
 	}
 	return code, nil
 }
@@ -68,13 +86,13 @@ func (a *Accounts) handleGetCode(w http.ResponseWriter, req *http.Request) error
 func (a *Accounts) getAccount(addr thor.Address, header *block.Header) (*Account, error) {
 	state, err := a.stateCreator.NewState(header.StateRoot())
 	if err != nil {
-		return nil, err
+		return nil, utils.StateError(err)
 	}
 	b := state.GetBalance(addr)
 	code := state.GetCode(addr)
 	energy := state.GetEnergy(addr, header.Timestamp())
 	if err := state.Err(); err != nil {
-		return nil, err
+		return nil, utils.StateError(err)
 	}
 	return &Account{
 		Balance: math.HexOrDecimal256(*b),
@@ -83,14 +101,84 @@ func (a *Accounts) getAccount(addr thor.Address, header *block.Header) (*Account
 	}, nil
 }
 
+// BatchGetAccounts returns accounts of given addresses on the same state, in the same order.
+func (a *Accounts) BatchGetAccounts(addrs []thor.Address, header *block.Header) ([]*BatchAccount, error) {
+	state, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	results := make([]*BatchAccount, 0, len(addrs))
+	for _, addr := range addrs {
+		codeHash := state.GetCodeHash(addr)
+		results = append(results, &BatchAccount{
+			Address:  addr,
+			Balance:  math.HexOrDecimal256(*state.GetBalance(addr)),
+			Energy:   math.HexOrDecimal256(*state.GetEnergy(addr, header.Timestamp())),
+			HasCode:  !codeHash.IsZero(),
+			CodeHash: codeHash,
+		})
+	}
+	if err := state.Err(); err != nil {
+		return nil, utils.StateError(err)
+	}
+	return results, nil
+}
+
+// BalanceHistory returns balances of the account at each given block, computed from the block's state.
+func (a *Accounts) BalanceHistory(addr thor.Address, headers []*block.Header) ([]*BalanceSample, error) {
+	samples := make([]*BalanceSample, 0, len(headers))
+	for _, header := range headers {
+		state, err := a.stateCreator.NewState(header.StateRoot())
+		if err != nil {
+			return nil, utils.StateError(err)
+		}
+		balance := state.GetBalance(addr)
+		energy := state.GetEnergy(addr, header.Timestamp())
+		if err := state.Err(); err != nil {
+			return nil, utils.StateError(err)
+		}
+		samples = append(samples, &BalanceSample{
+			Block: transactions.BlockContext{
+				ID:        header.ID(),
+				Number:    header.Number(),
+				Timestamp: header.Timestamp(),
+			},
+			Balance: math.HexOrDecimal256(*balance),
+			Energy:  math.HexOrDecimal256(*energy),
+		})
+	}
+	return samples, nil
+}
+
+// ProjectEnergy computes energy of the account at the future timestamp, based on the state of the given block.
+func (a *Accounts) ProjectEnergy(addr thor.Address, timestamp uint64, header *block.Header) (*EnergyProjection, error) {
+	state, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	balance := state.GetBalance(addr)
+	energy := state.GetEnergy(addr, timestamp)
+	if err := state.Err(); err != nil {
+		return nil, utils.StateError(err)
+	}
+	growthRate := new(big.Int).Mul(balance, thor.EnergyGrowthRate)
+	growthRate.Div(growthRate, big.NewInt(1e18))
+	return &EnergyProjection{
+		Timestamp:  timestamp,
+		Balance:    math.HexOrDecimal256(*balance),
+		Energy:     math.HexOrDecimal256(*energy),
+		GrowthRate: math.HexOrDecimal256(*growthRate),
+	}, nil
+}
+
 func (a *Accounts) getStorage(addr thor.Address, key thor.Bytes32, stateRoot thor.Bytes32) (thor.Bytes32, error) {
 	state, err := a.stateCreator.NewState(stateRoot)
 	if err != nil {
-		return thor.Bytes32{}, err
+		return thor.Bytes32{}, utils.StateError(err)
 	}
 	storage := state.GetStorage(addr, key)
 	if err := state.Err(); err != nil {
-		return thor.Bytes32{}, err
+		return thor.Bytes32{}, utils.StateError(err)
 	}
 	return storage, nil
 }
@@ -111,11 +199,22 @@ func (a *Accounts) sterilizeOptions(options *ContractCall) {
 	}
 }
 
+func (a *Accounts) newRuntime(state *state.State, header *block.Header) (*runtime.Runtime, error) {
+	ctx, err := runtime.NewBlockContext(header)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.New(a.chain.NewSeeker(header.ParentID()), state, ctx, a.forkConfig), nil
+}
+
 //Call a contract with input
 func (a *Accounts) Call(to *thor.Address, body *ContractCall, header *block.Header) (output *VMOutput, err error) {
 	a.sterilizeOptions(body)
 	state, err := a.stateCreator.NewState(header.StateRoot())
 	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	if err := body.StateOverrides.apply(state, header.Timestamp()); err != nil {
 		return nil, err
 	}
 	v := big.Int(*body.Value)
@@ -125,15 +224,13 @@ func (a *Accounts) Call(to *thor.Address, body *ContractCall, header *block.Head
 	}
 	clause := tx.NewClause(to).WithData(data).WithValue(&v)
 	gp := (*big.Int)(body.GasPrice)
-	signer, _ := header.Signer()
-	rt := runtime.New(a.chain.NewSeeker(header.ParentID()), state,
-		&xenv.BlockContext{
-			Beneficiary: header.Beneficiary(),
-			Signer:      signer,
-			Number:      header.Number(),
-			Time:        header.Timestamp(),
-			GasLimit:    header.GasLimit(),
-			TotalScore:  header.TotalScore()})
+	rt, err := a.newRuntime(state, header)
+	if err != nil {
+		return nil, err
+	}
+	if body.ProfileGas {
+		rt.SetVMConfig(vm.Config{GasProfile: true})
+	}
 
 	vmout := rt.ExecuteClause(clause, 0, body.Gas, &xenv.TransactionContext{
 		Origin:     body.Caller,
@@ -144,12 +241,74 @@ func (a *Accounts) Call(to *thor.Address, body *ContractCall, header *block.Head
 		return nil, err
 	}
 	if err := state.Err(); err != nil {
-		return nil, err
+		return nil, utils.StateError(err)
 	}
 	return convertVMOutputWithInputGas(vmout, body.Gas), nil
 
 }
 
+//BatchCall executes clauses sequentially in one state context, as a transaction does.
+//Execution stops at the first reverted clause.
+func (a *Accounts) BatchCall(body *BatchCallData, header *block.Header) ([]*VMOutput, error) {
+	gas := body.Gas
+	if gas == 0 {
+		gas = math.MaxUint64
+	}
+	gp := new(big.Int)
+	if body.GasPrice != nil {
+		gp = (*big.Int)(body.GasPrice)
+	}
+	clauses := make([]*tx.Clause, len(body.Clauses))
+	for i, c := range body.Clauses {
+		var data []byte
+		if c.Data != "" {
+			var err error
+			if data, err = hexutil.Decode(c.Data); err != nil {
+				return nil, utils.BadRequest(err, fmt.Sprintf("clauses[%d].data", i))
+			}
+		}
+		v := big.Int(c.Value)
+		clauses[i] = tx.NewClause(c.To).WithData(data).WithValue(&v)
+	}
+
+	state, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	if err := body.StateOverrides.apply(state, header.Timestamp()); err != nil {
+		return nil, err
+	}
+	rt, err := a.newRuntime(state, header)
+	if err != nil {
+		return nil, err
+	}
+	if body.ProfileGas {
+		rt.SetVMConfig(vm.Config{GasProfile: true})
+	}
+	txCtx := &xenv.TransactionContext{
+		Origin:     body.Caller,
+		GasPrice:   gp,
+		ProvedWork: &big.Int{}}
+
+	outputs := make([]*VMOutput, 0, len(clauses))
+	for i, clause := range clauses {
+		vmout := rt.ExecuteClause(clause, uint32(i), gas, txCtx)
+		outputs = append(outputs, convertVMOutputWithInputGas(vmout, gas))
+		if vmout.VMErr != nil {
+			break
+		}
+		gas = vmout.LeftOverGas
+	}
+
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, err
+	}
+	if err := state.Err(); err != nil {
+		return nil, utils.StateError(err)
+	}
+	return outputs, nil
+}
+
 func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) error {
 	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
 	if err != nil {
@@ -166,6 +325,147 @@ func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) er
 	return utils.WriteJSON(w, acc)
 }
 
+func (a *Accounts) handleBatchGetAccounts(w http.ResponseWriter, req *http.Request) error {
+	var body BatchAccountQuery
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if len(body.Addresses) > maxBatchAccounts {
+		return utils.BadRequest(fmt.Errorf("exceeds %v", maxBatchAccounts), "addresses")
+	}
+	h, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	accs, err := a.BatchGetAccounts(body.Addresses, h)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, accs)
+}
+
+// parseHistoryRevisions parses blocks to sample from query, either a list of 'revisions' separated
+// by comma, or block numbers in range ['from', 'to'] every 'step' blocks.
+func (a *Accounts) parseHistoryRevisions(query url.Values) ([]*block.Header, error) {
+	var headers []*block.Header
+	if s := query.Get("revisions"); s != "" {
+		revisions := strings.Split(s, ",")
+		if len(revisions) > maxHistorySamples {
+			return nil, utils.BadRequest(fmt.Errorf("exceeds %v", maxHistorySamples), "revisions")
+		}
+		for _, rev := range revisions {
+			h, err := a.getBlockHeader(strings.TrimSpace(rev))
+			if err != nil {
+				if a.chain.IsNotFound(err) {
+					return nil, utils.BadRequest(errors.New("block not found"), "revisions")
+				}
+				return nil, utils.BadRequest(err, "revisions")
+			}
+			headers = append(headers, h)
+		}
+		return headers, nil
+	}
+
+	parseNum := func(name string, def uint64) (uint64, error) {
+		s := query.Get(name)
+		if s == "" {
+			return def, nil
+		}
+		n, err := strconv.ParseUint(s, 0, 32)
+		if err != nil {
+			return 0, utils.BadRequest(err, name)
+		}
+		return n, nil
+	}
+	best := uint64(a.chain.BestBlock().Header().Number())
+	from, err := parseNum("from", best)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseNum("to", best)
+	if err != nil {
+		return nil, err
+	}
+	step, err := parseNum("step", 1)
+	if err != nil {
+		return nil, err
+	}
+	if step == 0 {
+		return nil, utils.BadRequest(errors.New("should be positive"), "step")
+	}
+	if to > best {
+		to = best
+	}
+	if from > to {
+		return nil, utils.BadRequest(errors.New("should not be greater than 'to' or best block number"), "from")
+	}
+	if (to-from)/step+1 > maxHistorySamples {
+		return nil, utils.BadRequest(fmt.Errorf("samples exceed %v", maxHistorySamples), "step")
+	}
+	for n := from; n <= to; n += step {
+		h, err := a.chain.GetTrunkBlockHeader(uint32(n))
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
+
+func (a *Accounts) handleGetBalanceHistory(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	headers, err := a.parseHistoryRevisions(req.URL.Query())
+	if err != nil {
+		return err
+	}
+	samples, err := a.BalanceHistory(addr, headers)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, samples)
+}
+
+func (a *Accounts) handleProjectEnergy(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	best := a.chain.BestBlock().Header()
+	query := req.URL.Query()
+
+	var timestamp uint64
+	switch {
+	case query.Get("timestamp") != "":
+		if timestamp, err = strconv.ParseUint(query.Get("timestamp"), 0, 64); err != nil {
+			return utils.BadRequest(err, "timestamp")
+		}
+		if timestamp < best.Timestamp() {
+			return utils.BadRequest(errors.New("should not be earlier than best block"), "timestamp")
+		}
+	case query.Get("blockNumber") != "":
+		num, err := strconv.ParseUint(query.Get("blockNumber"), 0, 32)
+		if err != nil {
+			return utils.BadRequest(err, "blockNumber")
+		}
+		if num < uint64(best.Number()) {
+			return utils.BadRequest(errors.New("should not be less than best block number"), "blockNumber")
+		}
+		// assume no slot missed
+		timestamp = best.Timestamp() + (num-uint64(best.Number()))*thor.BlockInterval
+	default:
+		return utils.BadRequest(errors.New("timestamp or blockNumber required"), "query")
+	}
+
+	projection, err := a.ProjectEnergy(addr, timestamp, best)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, projection)
+}
+
 func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) error {
 	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
 	if err != nil {
@@ -212,6 +512,76 @@ func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request)
 	return utils.WriteJSON(w, output)
 }
 
+func (a *Accounts) handleBatchCall(w http.ResponseWriter, req *http.Request) error {
+	var body BatchCallData
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	h, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	outputs, err := a.BatchCall(&body, h)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, outputs)
+}
+
+// Transactions returns transactions sent by the given origin, in descending order by default.
+func (a *Accounts) Transactions(ctx context.Context, origin thor.Address, offset, limit uint64, order logdb.Order) ([]*AccountTx, error) {
+	txs, err := a.logDB.FilterTransactions(ctx, &logdb.TransactionFilter{
+		TxOrigin: origin,
+		Options: &logdb.Options{
+			Offset: offset,
+			Limit:  limit,
+		},
+		Order: order,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*AccountTx, 0, len(txs))
+	for _, txn := range txs {
+		result = append(result, convertAccountTx(txn))
+	}
+	return result, nil
+}
+
+func (a *Accounts) handleGetTransactions(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	query := req.URL.Query()
+	var (
+		offset uint64
+		limit  uint64 = defaultTxLimit
+		order         = logdb.DESC
+	)
+	if s := query.Get("offset"); s != "" {
+		if offset, err = strconv.ParseUint(s, 0, 0); err != nil {
+			return utils.BadRequest(err, "offset")
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if limit, err = strconv.ParseUint(s, 0, 0); err != nil {
+			return utils.BadRequest(err, "limit")
+		}
+		if limit > maxTxLimit {
+			return utils.BadRequest(fmt.Errorf("exceeds %v", maxTxLimit), "limit")
+		}
+	}
+	if query.Get("order") == string(logdb.ASC) {
+		order = logdb.ASC
+	}
+	txs, err := a.Transactions(req.Context(), addr, offset, limit, order)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, txs)
+}
+
 func (a *Accounts) getBlockHeader(revision string) (*block.Header, error) {
 	if revision == "" || revision == "best" {
 		return a.chain.BestBlock().Header(), nil
@@ -238,9 +608,22 @@ func (a *Accounts) Mount(root *mux.Router, pathPrefix string) {
 
 	sub.Path("/{address}/code").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetCode))
 
+	sub.Path("/{address}/transactions").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetTransactions))
+
+	sub.Path("/{address}/history").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetBalanceHistory))
+
+	sub.Path("/{address}/energy-projection").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleProjectEnergy))
+
 	sub.Path("/{address}/storage/{key}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStorage))
 	sub.Path("/{address}/storage/{key}").Queries("revision", "{revision}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStorage))
 
+	// must be registered before '/{address}'
+	sub.Path("/batch").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchGetAccounts))
+	sub.Path("/batch").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchGetAccounts))
+
+	sub.Path("/*").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchCall))
+	sub.Path("/*").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchCall))
+
 	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 	sub.Path("").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 
@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -23,8 +24,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	ABI "github.com/vechain/thor/abi"
 	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/packer"
 	"github.com/vechain/thor/state"
@@ -93,14 +96,25 @@ var bytecode = common.Hex2Bytes("608060405234801561001057600080fd5b5061012580610
 
 var runtimeBytecode = common.Hex2Bytes("6080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806324b8ba5f14604e578063bb4e3f4d14607b575b600080fd5b348015605957600080fd5b506079600480360381019080803560ff16906020019092919050505060cf565b005b348015608657600080fd5b5060b3600480360381019080803560ff169060200190929190803560ff16906020019092919050505060ec565b604051808260ff1660ff16815260200191505060405180910390f35b806000806101000a81548160ff021916908360ff16021790555050565b60008183019050929150505600a165627a7a723058201584add23e31d36c569b468097fe01033525686b59bbb263fb3ab82e9553dae50029")
 
-var ts *httptest.Server
+var (
+	ts    *httptest.Server
+	logDB *logdb.LogDB
+	txIDs []thor.Bytes32
+)
 
 func TestAccount(t *testing.T) {
 	initAccountServer(t)
 	defer ts.Close()
 	getAccount(t)
+	batchGetAccounts(t)
+	getBalanceHistory(t)
+	projectEnergy(t)
 	deployContractWithCall(t)
 	callContract(t)
+	callWithGasProfile(t)
+	batchCall(t)
+	callWithStateOverrides(t)
+	getTransactions(t)
 }
 
 func getAccount(t *testing.T) {
@@ -135,6 +149,105 @@ func getAccount(t *testing.T) {
 
 }
 
+func batchGetAccounts(t *testing.T) {
+	body := &accounts.BatchAccountQuery{Addresses: []thor.Address{addr, contractAddr}}
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := httpPost(t, ts.URL+"/accounts/batch?revision=best", reqBody)
+	var accs []*accounts.BatchAccount
+	if err := json.Unmarshal(res, &accs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(accs))
+	assert.Equal(t, addr, accs[0].Address)
+	assert.Equal(t, math.HexOrDecimal256(*value), accs[0].Balance, "balance should be equal")
+	assert.False(t, accs[0].HasCode)
+	assert.True(t, accs[0].CodeHash.IsZero())
+
+	assert.Equal(t, contractAddr, accs[1].Address)
+	assert.True(t, accs[1].HasCode)
+	assert.Equal(t, thor.Bytes32(crypto.Keccak256Hash(runtimeBytecode)), accs[1].CodeHash)
+}
+
+func getBalanceHistory(t *testing.T) {
+	res := httpGet(t, ts.URL+"/accounts/"+addr.String()+"/history?from=0&to=2")
+	var samples []*accounts.BalanceSample
+	if err := json.Unmarshal(res, &samples); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(samples))
+	for i, sample := range samples {
+		assert.Equal(t, uint32(i), sample.Block.Number)
+	}
+	assert.Equal(t, 0, (*big.Int)(&samples[0].Balance).Sign())
+	assert.Equal(t, math.HexOrDecimal256(*value), samples[1].Balance)
+	assert.Equal(t, math.HexOrDecimal256(*value), samples[2].Balance)
+
+	res = httpGet(t, ts.URL+"/accounts/"+addr.String()+"/history?revisions=0,best")
+	samples = nil
+	if err := json.Unmarshal(res, &samples); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(samples))
+	assert.Equal(t, uint32(0), samples[0].Block.Number)
+	assert.Equal(t, uint32(2), samples[1].Block.Number)
+
+	for _, query := range []string{"step=0", "from=2&to=1", "from=bad", "revisions=bad"} {
+		r, err := http.Get(ts.URL + "/accounts/" + addr.String() + "/history?" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, query)
+	}
+}
+
+func projectEnergy(t *testing.T) {
+	// dev account 1 is not involved in txs
+	holder := genesis.DevAccounts()[1].Address
+	res := httpGet(t, ts.URL+"/accounts/"+holder.String())
+	var acc accounts.Account
+	if err := json.Unmarshal(res, &acc); err != nil {
+		t.Fatal(err)
+	}
+
+	res = httpGet(t, ts.URL+"/accounts/"+holder.String()+"/history")
+	var samples []*accounts.BalanceSample
+	if err := json.Unmarshal(res, &samples); err != nil {
+		t.Fatal(err)
+	}
+	bestBlock := samples[0].Block
+
+	checkProjection := func(query string, elapsed uint64) {
+		res := httpGet(t, ts.URL+"/accounts/"+holder.String()+"/energy-projection?"+query)
+		var projection accounts.EnergyProjection
+		if err := json.Unmarshal(res, &projection); err != nil {
+			t.Fatal(err)
+		}
+		growthRate := new(big.Int).Mul((*big.Int)(&acc.Balance), thor.EnergyGrowthRate)
+		growthRate.Div(growthRate, big.NewInt(1e18))
+		assert.Equal(t, 0, growthRate.Cmp((*big.Int)(&projection.GrowthRate)))
+
+		expected := new(big.Int).Mul(growthRate, new(big.Int).SetUint64(elapsed))
+		expected.Add(expected, (*big.Int)(&acc.Energy))
+		assert.Equal(t, 0, expected.Cmp((*big.Int)(&projection.Energy)), query)
+		assert.Equal(t, bestBlock.Timestamp+elapsed, projection.Timestamp)
+	}
+	checkProjection("timestamp="+strconv.FormatUint(bestBlock.Timestamp+1000, 10), 1000)
+	checkProjection("blockNumber="+strconv.FormatUint(uint64(bestBlock.Number)+6, 10), 6*thor.BlockInterval)
+
+	for _, query := range []string{"", "timestamp=0", "blockNumber=0", "blockNumber=bad"} {
+		r, err := http.Get(ts.URL + "/accounts/" + holder.String() + "/energy-projection?" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, query)
+	}
+}
+
 func initAccountServer(t *testing.T) {
 	db, _ := lvldb.NewMem()
 	stateC := state.NewCreator(db)
@@ -147,6 +260,7 @@ func initAccountServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	chain, _ := chain.New(db, b)
+	logDB, _ = logdb.NewMem()
 	claTransfer := tx.NewClause(&addr).WithValue(value)
 	claDeploy := tx.NewClause(nil).WithData(bytecode)
 	transaction := buildTxWithClauses(t, chain.Tag(), claTransfer, claDeploy)
@@ -165,7 +279,7 @@ func initAccountServer(t *testing.T) {
 	packTx(chain, stateC, transactionCall, t)
 
 	router := mux.NewRouter()
-	accounts.New(chain, stateC).Mount(router, "/accounts")
+	accounts.New(chain, stateC, logDB, thor.NoFork).Mount(router, "/accounts")
 	ts = httptest.NewServer(router)
 }
 
@@ -188,7 +302,7 @@ func buildTxWithClauses(t *testing.T, chaiTag byte, clauses ...*tx.Clause) *tx.T
 
 func packTx(chain *chain.Chain, stateC *state.Creator, transaction *tx.Transaction, t *testing.T) {
 	b := chain.BestBlock()
-	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	err = flow.Adopt(transaction)
 	if err != nil {
@@ -204,6 +318,43 @@ func packTx(chain *chain.Chain, stateC *state.Creator, transaction *tx.Transacti
 	if _, err := chain.AddBlock(b, receipts); err != nil {
 		t.Fatal(err)
 	}
+	batch := logDB.Prepare(b.Header())
+	for i, trx := range b.Transactions() {
+		origin, _ := trx.Signer()
+		txBatch := batch.ForTransaction(trx.ID(), origin)
+		for _, output := range receipts[i].Outputs {
+			txBatch.Insert(output.Events, output.Transfers)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	txIDs = append(txIDs, transaction.ID())
+}
+
+func getTransactions(t *testing.T) {
+	origin := genesis.DevAccounts()[0].Address
+	var txs []*accounts.AccountTx
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/accounts/"+origin.String()+"/transactions"), &txs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(txIDs), len(txs))
+	// latest first
+	assert.Equal(t, txIDs[len(txIDs)-1], txs[0].ID)
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/accounts/"+origin.String()+"/transactions?offset=1&limit=1&order=asc"), &txs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, txIDs[1], txs[0].ID)
+	assert.Equal(t, uint32(2), txs[0].Block.Number)
+
+	res, err := http.Get(ts.URL + "/accounts/" + origin.String() + "/transactions?limit=1000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 }
 
 func deployContractWithCall(t *testing.T) {
@@ -262,6 +413,129 @@ func callContract(t *testing.T) {
 	assert.Equal(t, a+b, ret, "should be equal")
 }
 
+func callWithGasProfile(t *testing.T) {
+	abi, _ := ABI.New([]byte(abiJSON))
+	m, _ := abi.MethodByName("add")
+	input, err := m.EncodeInput(uint8(1), uint8(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqBody := &accounts.ContractCall{
+		Data:       hexutil.Encode(input),
+		ProfileGas: true,
+	}
+	reqBodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := httpPost(t, ts.URL+"/accounts/"+contractAddr.String(), reqBodyBytes)
+	var output *accounts.VMOutput
+	if err = json.Unmarshal(response, &output); err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, output.GasProfile) {
+		assert.Equal(t, 1, len(output.GasProfile.Frames))
+		assert.Equal(t, common.Address(contractAddr), output.GasProfile.Frames[0].Address)
+		assert.Equal(t, output.GasUsed, output.GasProfile.Frames[0].Gas, "gas of frame should cover all ops")
+		assert.NotEmpty(t, output.GasProfile.Opcodes)
+	}
+}
+
+func batchCall(t *testing.T) {
+	abi, _ := ABI.New([]byte(abiJSON))
+	m, _ := abi.MethodByName("add")
+	input, err := m.EncodeInput(uint8(1), uint8(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := thor.BytesToAddress([]byte("to"))
+	reqBody := &accounts.BatchCallData{
+		Clauses: transactions.Clauses{
+			{To: &to, Value: math.HexOrDecimal256(*big.NewInt(1))},
+			{To: &contractAddr, Data: hexutil.Encode(input)},
+			{To: &contractAddr, Data: "0x12345678"},
+			{To: &contractAddr, Data: hexutil.Encode(input)},
+		},
+		Caller: genesis.DevAccounts()[0].Address,
+	}
+	reqBodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := httpPost(t, ts.URL+"/accounts/*", reqBodyBytes)
+	var outputs []*accounts.VMOutput
+	if err = json.Unmarshal(response, &outputs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(outputs), "execution should stop at the reverted clause")
+	assert.Equal(t, 1, len(outputs[0].Transfers))
+	assert.False(t, outputs[1].Reverted)
+	data, err := hexutil.Decode(outputs[1].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret uint8
+	if err := m.DecodeOutput(data, &ret); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(3), ret)
+	assert.True(t, outputs[2].Reverted)
+}
+
+func callWithStateOverrides(t *testing.T) {
+	abi, _ := ABI.New([]byte(abiJSON))
+	m, _ := abi.MethodByName("add")
+	input, err := m.EncodeInput(uint8(1), uint8(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := hexutil.Encode(runtimeBytecode)
+	caller := thor.BytesToAddress([]byte("poor caller"))
+	balance := math.HexOrDecimal256(*big.NewInt(100))
+	notDeployed := thor.BytesToAddress([]byte("not deployed"))
+	to := thor.BytesToAddress([]byte("to"))
+	reqBody := &accounts.BatchCallData{
+		Clauses: transactions.Clauses{
+			{To: &notDeployed, Data: hexutil.Encode(input)},
+			{To: &to, Value: math.HexOrDecimal256(*big.NewInt(10))},
+		},
+		Caller: caller,
+		StateOverrides: accounts.StateOverrides{
+			caller.String():      {Balance: &balance},
+			notDeployed.String(): {Code: &code},
+		},
+	}
+	reqBodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := httpPost(t, ts.URL+"/accounts/*", reqBodyBytes)
+	var outputs []*accounts.VMOutput
+	if err = json.Unmarshal(response, &outputs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(outputs))
+	assert.False(t, outputs[0].Reverted)
+	assert.Equal(t, 1, len(outputs[1].Transfers), "caller should afford the transfer")
+	data, err := hexutil.Decode(outputs[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret uint8
+	if err := m.DecodeOutput(data, &ret); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(3), ret)
+
+	// overrides are ephemeral
+	res := httpGet(t, ts.URL+"/accounts/"+notDeployed.String()+"/code")
+	var c map[string]string
+	if err := json.Unmarshal(res, &c); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x", c["code"])
+}
+
 func httpPost(t *testing.T, url string, data []byte) []byte {
 	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
 	if err != nil {
@@ -6,11 +6,19 @@
 package accounts
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/abi"
 	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/runtime"
+	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/vm"
 )
 
 //Account for marshal account
@@ -20,34 +28,130 @@ type Account struct {
 	HasCode bool                 `json:"hasCode"`
 }
 
+//BatchAccountQuery body of querying accounts in batch
+type BatchAccountQuery struct {
+	Addresses []thor.Address `json:"addresses"`
+}
+
+//BatchAccount account in result of batch query
+type BatchAccount struct {
+	Address  thor.Address         `json:"address"`
+	Balance  math.HexOrDecimal256 `json:"balance,string"`
+	Energy   math.HexOrDecimal256 `json:"energy,string"`
+	HasCode  bool                 `json:"hasCode"`
+	CodeHash thor.Bytes32         `json:"codeHash"`
+}
+
+//BalanceSample balance of an account at a block
+type BalanceSample struct {
+	Block   transactions.BlockContext `json:"block"`
+	Balance math.HexOrDecimal256      `json:"balance,string"`
+	Energy  math.HexOrDecimal256      `json:"energy,string"`
+}
+
+//EnergyProjection energy of an account projected at a future time, assuming balance unchanged
+type EnergyProjection struct {
+	Timestamp  uint64               `json:"timestamp"`
+	Balance    math.HexOrDecimal256 `json:"balance,string"`
+	Energy     math.HexOrDecimal256 `json:"energy,string"`
+	GrowthRate math.HexOrDecimal256 `json:"growthRate,string"` // energy generated per second
+}
+
 //ContractCall represents contract-call body
 type ContractCall struct {
-	Value    *math.HexOrDecimal256 `json:"value,string"`
-	Data     string                `json:"data"`
-	Gas      uint64                `json:"gas"`
-	GasPrice *math.HexOrDecimal256 `json:"gasPrice,string"`
-	Caller   thor.Address          `json:"caller"`
+	Value          *math.HexOrDecimal256 `json:"value,string"`
+	Data           string                `json:"data"`
+	Gas            uint64                `json:"gas"`
+	GasPrice       *math.HexOrDecimal256 `json:"gasPrice,string"`
+	Caller         thor.Address          `json:"caller"`
+	StateOverrides StateOverrides        `json:"stateOverrides,omitempty"`
+	ProfileGas     bool                  `json:"profileGas,omitempty"`
+}
+
+//AccountOverride ephemeral changes of an account, nil fields are left unchanged
+type AccountOverride struct {
+	Balance *math.HexOrDecimal256 `json:"balance,omitempty"`
+	Energy  *math.HexOrDecimal256 `json:"energy,omitempty"`
+	Code    *string               `json:"code,omitempty"`
+	Storage map[string]string     `json:"storage,omitempty"`
+}
+
+//StateOverrides maps account address to its overrides
+type StateOverrides map[string]*AccountOverride
+
+// apply applies overrides to the state, which is never committed.
+func (so StateOverrides) apply(state *state.State, blockTime uint64) error {
+	for addrStr, ao := range so {
+		addr, err := thor.ParseAddress(addrStr)
+		if err != nil {
+			return utils.BadRequest(err, "stateOverrides")
+		}
+		if ao == nil {
+			continue
+		}
+		if ao.Balance != nil {
+			state.SetBalance(addr, (*big.Int)(ao.Balance))
+		}
+		if ao.Energy != nil {
+			state.SetEnergy(addr, (*big.Int)(ao.Energy), blockTime)
+		}
+		if ao.Code != nil {
+			code, err := hexutil.Decode(*ao.Code)
+			if err != nil {
+				return utils.BadRequest(err, fmt.Sprintf("stateOverrides[%v].code", addr))
+			}
+			state.SetCode(addr, code)
+		}
+		for k, v := range ao.Storage {
+			key, err := thor.ParseBytes32(k)
+			if err != nil {
+				return utils.BadRequest(err, fmt.Sprintf("stateOverrides[%v].storage", addr))
+			}
+			value, err := thor.ParseBytes32(v)
+			if err != nil {
+				return utils.BadRequest(err, fmt.Sprintf("stateOverrides[%v].storage", addr))
+			}
+			state.SetStorage(addr, key, value)
+		}
+	}
+	return nil
+}
+
+//BatchCallData represents body of batch call, which executes
+//clauses sequentially in one state context as a transaction does.
+type BatchCallData struct {
+	Clauses        transactions.Clauses  `json:"clauses"`
+	Gas            uint64                `json:"gas"`
+	GasPrice       *math.HexOrDecimal256 `json:"gasPrice,string"`
+	Caller         thor.Address          `json:"caller"`
+	StateOverrides StateOverrides        `json:"stateOverrides,omitempty"`
+	ProfileGas     bool                  `json:"profileGas,omitempty"`
 }
 
 type VMOutput struct {
-	Data      string                   `json:"data"`
-	Events    []*transactions.Event    `json:"events"`
-	Transfers []*transactions.Transfer `json:"transfers"`
-	GasUsed   uint64                   `json:"gasUsed"`
-	Reverted  bool                     `json:"reverted"`
-	VMError   string                   `json:"vmError"`
+	Data            string                   `json:"data"`
+	Events          []*transactions.Event    `json:"events"`
+	Transfers       []*transactions.Transfer `json:"transfers"`
+	GasUsed         uint64                   `json:"gasUsed"`
+	Reverted        bool                     `json:"reverted"`
+	VMError         string                   `json:"vmError"`
+	VMErrorCategory vm.ErrorCategory         `json:"vmErrorCategory,omitempty"`
+	RevertReason    string                   `json:"revertReason,omitempty"`
+	GasProfile      *vm.GasProfile           `json:"gasProfile,omitempty"`
 }
 
 func convertVMOutputWithInputGas(vo *runtime.Output, inputGas uint64) *VMOutput {
 	gasUsed := inputGas - vo.LeftOverGas
 	var (
-		vmError  string
-		reverted bool
+		vmError      string
+		reverted     bool
+		revertReason string
 	)
 
 	if vo.VMErr != nil {
 		reverted = true
 		vmError = vo.VMErr.Error()
+		revertReason, _ = abi.DecodeRevertReason(vo.Data)
 	}
 
 	events := make([]*transactions.Event, len(vo.Events))
@@ -81,11 +185,33 @@ func convertVMOutputWithInputGas(vo *runtime.Output, inputGas uint64) *VMOutput
 	}
 
 	return &VMOutput{
-		Data:      data,
-		Events:    events,
-		Transfers: transfers,
-		GasUsed:   gasUsed,
-		Reverted:  reverted,
-		VMError:   vmError,
+		Data:            data,
+		Events:          events,
+		Transfers:       transfers,
+		GasUsed:         gasUsed,
+		Reverted:        reverted,
+		VMError:         vmError,
+		VMErrorCategory: vo.VMErrCategory,
+		RevertReason:    revertReason,
+		GasProfile:      vo.GasProfile,
+	}
+}
+
+// AccountTx a transaction sent by an account.
+type AccountTx struct {
+	ID    thor.Bytes32              `json:"id"`
+	Index uint32                    `json:"index"`
+	Block transactions.BlockContext `json:"block"`
+}
+
+func convertAccountTx(txn *logdb.Transaction) *AccountTx {
+	return &AccountTx{
+		ID:    txn.TxID,
+		Index: txn.Index,
+		Block: transactions.BlockContext{
+			ID:        txn.BlockID,
+			Number:    txn.BlockNumber,
+			Timestamp: txn.BlockTime,
+		},
 	}
 }
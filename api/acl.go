@@ -0,0 +1,37 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ParseAllowedIPs parses comma separated IPs or CIDRs of allowed clients, e.g. '127.0.0.1,10.0.0.0/8'.
+func ParseAllowedIPs(s string) ([]*net.IPNet, error) {
+	nets, err := parseIPNets(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed IP %v", err)
+	}
+	return nets, nil
+}
+
+// AllowIPs wraps h to reject requests from clients not in allowed.
+// It should be wrapped by TrustProxy, to check the real client behind trusted proxies.
+func AllowIPs(h http.Handler, allowed []*net.IPNet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if ip := net.ParseIP(ClientIP(req)); ip != nil {
+			for _, n := range allowed {
+				if n.Contains(ip) {
+					h.ServeHTTP(w, req)
+					return
+				}
+			}
+		}
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowIPs(t *testing.T) {
+	allowed, err := ParseAllowedIPs("192.168.1.1, 10.0.0.0/8")
+	assert.Nil(t, err)
+	_, err = ParseAllowedIPs("10.0.0.0/x")
+	assert.Error(t, err)
+
+	trusted, _ := ParseTrustedProxies("127.0.0.1")
+	h := TrustProxy(AllowIPs(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), allowed), trusted)
+
+	do := func(remoteAddr, xff string) int {
+		req := httptest.NewRequest("GET", "/blocks/best", nil)
+		req.RemoteAddr = remoteAddr
+		if xff != "" {
+			req.Header.Set("X-Forwarded-For", xff)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("192.168.1.1:5678", ""))
+	assert.Equal(t, http.StatusOK, do("10.1.2.3:5678", ""))
+	assert.Equal(t, http.StatusForbidden, do("192.168.1.2:5678", ""))
+	// judged by the real client behind trusted proxy
+	assert.Equal(t, http.StatusOK, do("127.0.0.1:5678", "10.1.2.3"))
+	assert.Equal(t, http.StatusForbidden, do("127.0.0.1:5678", "1.2.3.4"))
+	assert.Equal(t, http.StatusForbidden, do("127.0.0.1:5678", ""))
+}
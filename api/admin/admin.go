@@ -0,0 +1,369 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/thor"
+)
+
+var log = log15.New("pkg", "admin")
+
+const defaultBanDuration = time.Hour
+
+// Admin serves operations to control the running node.
+// It should never be exposed to public network.
+type Admin struct {
+	logLevel  LogLevel
+	moduleMu  sync.Mutex // serializes read-modify-write of module levels
+	compactor Compactor
+	peers     PeerManager
+	allowlist Allowlist
+	gasLimit  GasLimitTarget
+	abis      ABIRegistry
+	startTime time.Time
+}
+
+// New create an Admin instance. peers can be nil if p2p is not available, allowlist can be nil
+// if not in permissioned mode, and gasLimit can be nil if the node doesn't propose blocks.
+func New(logLevel LogLevel, compactor Compactor, peers PeerManager, allowlist Allowlist, gasLimit GasLimitTarget, abis ABIRegistry) *Admin {
+	return &Admin{
+		logLevel:  logLevel,
+		compactor: compactor,
+		peers:     peers,
+		allowlist: allowlist,
+		gasLimit:  gasLimit,
+		abis:      abis,
+		startTime: time.Now(),
+	}
+}
+
+// ParseLogLevel parses level in name (e.g. 'debug') or number (0-9).
+func ParseLogLevel(s string) (log15.Lvl, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return log15.Lvl(n), nil
+	}
+	return log15.LvlFromString(s)
+}
+
+func (a *Admin) handleGetLogLevel(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, &LogLevelBody{a.logLevel.Get().String()})
+}
+
+func (a *Admin) handleSetLogLevel(w http.ResponseWriter, req *http.Request) error {
+	var body LogLevelBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	lvl, err := ParseLogLevel(body.Level)
+	if err != nil {
+		return utils.BadRequest(err, "level")
+	}
+	a.logLevel.Set(lvl)
+	log.Info("log level changed", "level", lvl)
+	return utils.WriteJSON(w, &LogLevelBody{lvl.String()})
+}
+
+func (a *Admin) handleGetModuleLogLevels(w http.ResponseWriter, req *http.Request) error {
+	result := make(map[string]string)
+	for module, lvl := range a.logLevel.Modules() {
+		result[module] = lvl.String()
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (a *Admin) handleSetModuleLogLevel(w http.ResponseWriter, req *http.Request) error {
+	module := mux.Vars(req)["module"]
+	var body LogLevelBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	lvl, err := ParseLogLevel(body.Level)
+	if err != nil {
+		return utils.BadRequest(err, "level")
+	}
+	a.updateModuleLogLevels(func(modules map[string]log15.Lvl) {
+		modules[module] = lvl
+	})
+	log.Info("module log level changed", "module", module, "level", lvl)
+	return utils.WriteJSON(w, &LogLevelBody{lvl.String()})
+}
+
+func (a *Admin) handleResetModuleLogLevel(w http.ResponseWriter, req *http.Request) error {
+	module := mux.Vars(req)["module"]
+	a.updateModuleLogLevels(func(modules map[string]log15.Lvl) {
+		delete(modules, module)
+	})
+	log.Info("module log level reset", "module", module)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+// updateModuleLogLevels applies the update to a copy of module levels, since the current ones
+// may be in use by log handlers.
+func (a *Admin) updateModuleLogLevels(update func(modules map[string]log15.Lvl)) {
+	a.moduleMu.Lock()
+	defer a.moduleMu.Unlock()
+
+	modules := make(map[string]log15.Lvl)
+	for module, lvl := range a.logLevel.Modules() {
+		modules[module] = lvl
+	}
+	update(modules)
+	a.logLevel.SetModules(modules)
+}
+
+func (a *Admin) handleGetGasLimit(w http.ResponseWriter, req *http.Request) error {
+	if a.gasLimit == nil {
+		return utils.Forbidden(errors.New("block proposing not available"), "gaslimit")
+	}
+	return utils.WriteJSON(w, &GasLimitBody{a.gasLimit.TargetGasLimit()})
+}
+
+func (a *Admin) handleSetGasLimit(w http.ResponseWriter, req *http.Request) error {
+	if a.gasLimit == nil {
+		return utils.Forbidden(errors.New("block proposing not available"), "gaslimit")
+	}
+	var body GasLimitBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if body.Target != 0 && body.Target < thor.MinGasLimit {
+		return utils.BadRequest(errors.Errorf("below %v", thor.MinGasLimit), "target")
+	}
+	a.gasLimit.SetTargetGasLimit(body.Target)
+	log.Info("target gas limit changed", "target", body.Target)
+	return utils.WriteJSON(w, &body)
+}
+
+func (a *Admin) handleCompact(w http.ResponseWriter, req *http.Request) error {
+	start := time.Now()
+	if err := a.compactor.Compact(); err != nil {
+		return err
+	}
+	log.Info("database compacted", "elapsed", time.Since(start))
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) parsePeer(req *http.Request) (*discover.Node, error) {
+	if a.peers == nil {
+		return nil, utils.Forbidden(errors.New("p2p not available"), "peers")
+	}
+	var body PeerBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return nil, utils.BadRequest(err, "body")
+	}
+	node, err := discover.ParseNode(body.Enode)
+	if err != nil {
+		return nil, utils.BadRequest(err, "enode")
+	}
+	return node, nil
+}
+
+func (a *Admin) handleAddPeer(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parsePeer(req)
+	if err != nil {
+		return err
+	}
+	a.peers.AddStatic(node)
+	log.Info("peer added", "node", node)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleRemovePeer(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parsePeer(req)
+	if err != nil {
+		return err
+	}
+	a.peers.RemoveStatic(node)
+	log.Info("peer removed", "node", node)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleBanPeer(w http.ResponseWriter, req *http.Request) error {
+	if a.peers == nil {
+		return utils.Forbidden(errors.New("p2p not available"), "peers")
+	}
+	var body BanPeerBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	node, err := discover.ParseNode(body.Enode)
+	if err != nil {
+		return utils.BadRequest(err, "enode")
+	}
+	duration := defaultBanDuration
+	if body.Duration > 0 {
+		duration = time.Duration(body.Duration) * time.Second
+	}
+	a.peers.BanPeer(node.ID, duration)
+	log.Info("peer banned", "node", node, "duration", duration)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleGetAllowlist(w http.ResponseWriter, req *http.Request) error {
+	if a.allowlist == nil {
+		return utils.Forbidden(errors.New("not in permissioned mode"), "allowlist")
+	}
+	nodes, err := a.allowlist.AllowedNodes()
+	if err != nil {
+		return err
+	}
+	enodes := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		enodes = append(enodes, node.String())
+	}
+	return utils.WriteJSON(w, enodes)
+}
+
+func (a *Admin) parseAllowlistNode(req *http.Request) (*discover.Node, error) {
+	if a.allowlist == nil {
+		return nil, utils.Forbidden(errors.New("not in permissioned mode"), "allowlist")
+	}
+	var body PeerBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return nil, utils.BadRequest(err, "body")
+	}
+	node, err := discover.ParseNode(body.Enode)
+	if err != nil {
+		return nil, utils.BadRequest(err, "enode")
+	}
+	return node, nil
+}
+
+func (a *Admin) handleAllowNode(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parseAllowlistNode(req)
+	if err != nil {
+		return err
+	}
+	if err := a.allowlist.AllowNode(node); err != nil {
+		return err
+	}
+	log.Info("node allowed", "node", node)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleDisallowNode(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parseAllowlistNode(req)
+	if err != nil {
+		return err
+	}
+	removed, err := a.allowlist.DisallowNode(node.ID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return utils.HTTPError(errors.New("node not in allowlist"), http.StatusNotFound)
+	}
+	log.Info("node disallowed", "node", node)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleGetABIs(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, a.abis.Addresses())
+}
+
+func (a *Admin) handleGetABI(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	raw, ok := a.abis.Get(addr)
+	if !ok {
+		return utils.HTTPError(errors.New("abi not found"), http.StatusNotFound)
+	}
+	return utils.WriteJSON(w, json.RawMessage(raw))
+}
+
+func (a *Admin) handleRegisterABI(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	raw, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if _, err := abi.New(raw); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if err := a.abis.Register(addr, raw); err != nil {
+		return err
+	}
+	log.Info("abi registered", "address", addr)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleUnregisterABI(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	removed, err := a.abis.Unregister(addr)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return utils.HTTPError(errors.New("abi not found"), http.StatusNotFound)
+	}
+	log.Info("abi unregistered", "address", addr)
+	return utils.WriteJSON(w, map[string]interface{}{})
+}
+
+func (a *Admin) handleGetStats(w http.ResponseWriter, req *http.Request) error {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	return utils.WriteJSON(w, &RuntimeStats{
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		Goroutines: runtime.NumGoroutine(),
+		Uptime:     uint64(time.Since(a.startTime) / time.Second),
+		Memory: MemStats{
+			Alloc:       ms.Alloc,
+			TotalAlloc:  ms.TotalAlloc,
+			Sys:         ms.Sys,
+			HeapObjects: ms.HeapObjects,
+			NumGC:       ms.NumGC,
+		},
+	})
+}
+
+func (a *Admin) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/loglevel").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetLogLevel))
+	sub.Path("/loglevel").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetLogLevel))
+	sub.Path("/loglevel/modules").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetModuleLogLevels))
+	sub.Path("/loglevel/modules/{module}").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetModuleLogLevel))
+	sub.Path("/loglevel/modules/{module}").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(a.handleResetModuleLogLevel))
+	sub.Path("/gaslimit").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetGasLimit))
+	sub.Path("/gaslimit").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetGasLimit))
+	sub.Path("/compact").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCompact))
+	sub.Path("/peers").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleAddPeer))
+	sub.Path("/peers").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(a.handleRemovePeer))
+	sub.Path("/peers/ban").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBanPeer))
+	sub.Path("/allowlist").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetAllowlist))
+	sub.Path("/allowlist").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleAllowNode))
+	sub.Path("/allowlist").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(a.handleDisallowNode))
+	sub.Path("/abis").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetABIs))
+	sub.Path("/abis/{address}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetABI))
+	sub.Path("/abis/{address}").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleRegisterABI))
+	sub.Path("/abis/{address}").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(a.handleUnregisterABI))
+	sub.Path("/stats").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStats))
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/api/admin"
+	"github.com/vechain/thor/thor"
+)
+
+type logLevel struct {
+	lvl     log15.Lvl
+	modules map[string]log15.Lvl
+}
+
+func (l *logLevel) Get() log15.Lvl                          { return l.lvl }
+func (l *logLevel) Set(lvl log15.Lvl)                       { l.lvl = lvl }
+func (l *logLevel) Modules() map[string]log15.Lvl           { return l.modules }
+func (l *logLevel) SetModules(modules map[string]log15.Lvl) { l.modules = modules }
+
+type compactor struct {
+	compacted bool
+}
+
+func (c *compactor) Compact() error {
+	c.compacted = true
+	return nil
+}
+
+type peerManager struct {
+	nodes  map[discover.NodeID]*discover.Node
+	banned map[discover.NodeID]time.Duration
+}
+
+func (pm *peerManager) AddStatic(node *discover.Node)    { pm.nodes[node.ID] = node }
+func (pm *peerManager) RemoveStatic(node *discover.Node) { delete(pm.nodes, node.ID) }
+func (pm *peerManager) BanPeer(id discover.NodeID, duration time.Duration) {
+	pm.banned[id] = duration
+}
+
+type allowlist struct {
+	nodes map[discover.NodeID]*discover.Node
+}
+
+func (al *allowlist) AllowedNodes() ([]*discover.Node, error) {
+	var nodes []*discover.Node
+	for _, node := range al.nodes {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (al *allowlist) AllowNode(node *discover.Node) error {
+	al.nodes[node.ID] = node
+	return nil
+}
+
+func (al *allowlist) DisallowNode(id discover.NodeID) (bool, error) {
+	if al.nodes[id] == nil {
+		return false, nil
+	}
+	delete(al.nodes, id)
+	return true, nil
+}
+
+type gasLimitTarget struct {
+	target uint64
+}
+
+func (g *gasLimitTarget) TargetGasLimit() uint64      { return g.target }
+func (g *gasLimitTarget) SetTargetGasLimit(gl uint64) { g.target = gl }
+
+var (
+	ts       *httptest.Server
+	level    *logLevel
+	comp     *compactor
+	peers    *peerManager
+	allowed  *allowlist
+	gasLimit *gasLimitTarget
+	registry *abis.Registry
+)
+
+func TestLogLevel(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	var body admin.LogLevelBody
+	res, statusCode := httpDo(t, "GET", ts.URL+"/admin/loglevel", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &body); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, log15.LvlInfo.String(), body.Level)
+
+	res, statusCode = httpDo(t, "PUT", ts.URL+"/admin/loglevel", &admin.LogLevelBody{"debug"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, log15.LvlDebug, level.lvl)
+
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/loglevel", &admin.LogLevelBody{"unknown"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, log15.LvlDebug, level.lvl)
+}
+
+func TestModuleLogLevel(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	_, statusCode := httpDo(t, "PUT", ts.URL+"/admin/loglevel/modules/comm", &admin.LogLevelBody{"debug"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	// level in number as --vmodule accepts
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/loglevel/modules/txpool", &admin.LogLevelBody{"2"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, map[string]log15.Lvl{"comm": log15.LvlDebug, "txpool": log15.LvlWarn}, level.modules)
+	assert.Equal(t, log15.LvlInfo, level.lvl)
+
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/loglevel/modules/comm", &admin.LogLevelBody{"unknown"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	var body map[string]string
+	res, statusCode := httpDo(t, "GET", ts.URL+"/admin/loglevel/modules", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &body); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"comm": log15.LvlDebug.String(), "txpool": log15.LvlWarn.String()}, body)
+
+	_, statusCode = httpDo(t, "DELETE", ts.URL+"/admin/loglevel/modules/comm", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, map[string]log15.Lvl{"txpool": log15.LvlWarn}, level.modules)
+}
+
+func TestGasLimit(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	_, statusCode := httpDo(t, "PUT", ts.URL+"/admin/gaslimit", &admin.GasLimitBody{20000000})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, uint64(20000000), gasLimit.target)
+
+	var body admin.GasLimitBody
+	res, statusCode := httpDo(t, "GET", ts.URL+"/admin/gaslimit", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &body); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(20000000), body.Target)
+
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/gaslimit", &admin.GasLimitBody{1000})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/gaslimit", &admin.GasLimitBody{0})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, uint64(0), gasLimit.target)
+}
+
+func TestCompact(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	_, statusCode := httpDo(t, "POST", ts.URL+"/admin/compact", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.True(t, comp.compacted)
+}
+
+func TestPeers(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	key, _ := crypto.GenerateKey()
+	enode := discover.NewNode(discover.PubkeyID(&key.PublicKey), net.ParseIP("127.0.0.1"), 11235, 11235).String()
+
+	_, statusCode := httpDo(t, "POST", ts.URL+"/admin/peers", &admin.PeerBody{enode})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 1, len(peers.nodes))
+
+	_, statusCode = httpDo(t, "DELETE", ts.URL+"/admin/peers", &admin.PeerBody{enode})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 0, len(peers.nodes))
+
+	_, statusCode = httpDo(t, "POST", ts.URL+"/admin/peers", &admin.PeerBody{"bad enode"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestBanPeer(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	key, _ := crypto.GenerateKey()
+	node := discover.NewNode(discover.PubkeyID(&key.PublicKey), net.ParseIP("127.0.0.1"), 11235, 11235)
+
+	_, statusCode := httpDo(t, "POST", ts.URL+"/admin/peers/ban", &admin.BanPeerBody{node.String(), 60})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, time.Minute, peers.banned[node.ID])
+
+	_, statusCode = httpDo(t, "POST", ts.URL+"/admin/peers/ban", &admin.BanPeerBody{Enode: node.String()})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, time.Hour, peers.banned[node.ID])
+
+	_, statusCode = httpDo(t, "POST", ts.URL+"/admin/peers/ban", &admin.BanPeerBody{Enode: "bad enode"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestAllowlist(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	key, _ := crypto.GenerateKey()
+	node := discover.NewNode(discover.PubkeyID(&key.PublicKey), nil, 0, 0)
+
+	_, statusCode := httpDo(t, "POST", ts.URL+"/admin/allowlist", &admin.PeerBody{node.String()})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Contains(t, allowed.nodes, node.ID)
+
+	res, statusCode := httpDo(t, "GET", ts.URL+"/admin/allowlist", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	var enodes []string
+	if err := json.Unmarshal(res, &enodes); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{node.String()}, enodes)
+
+	_, statusCode = httpDo(t, "DELETE", ts.URL+"/admin/allowlist", &admin.PeerBody{node.String()})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, allowed.nodes)
+	_, statusCode = httpDo(t, "DELETE", ts.URL+"/admin/allowlist", &admin.PeerBody{node.String()})
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	_, statusCode = httpDo(t, "POST", ts.URL+"/admin/allowlist", &admin.PeerBody{"bad enode"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	router := mux.NewRouter()
+	admin.New(level, comp, peers, nil, gasLimit, registry).Mount(router, "/admin")
+	ts2 := httptest.NewServer(router)
+	defer ts2.Close()
+	_, statusCode = httpDo(t, "GET", ts2.URL+"/admin/allowlist", nil)
+	assert.Equal(t, http.StatusForbidden, statusCode, "not in permissioned mode")
+}
+
+func TestABIs(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	abiJSON := `[{"anonymous":false,"inputs":[{"indexed":false,"name":"value","type":"uint256"}],"name":"Set","type":"event"}]`
+	addr := thor.BytesToAddress([]byte("contract"))
+	url := ts.URL + "/admin/abis/" + addr.String()
+
+	_, statusCode := httpDo(t, "PUT", url, json.RawMessage(abiJSON))
+	assert.Equal(t, http.StatusOK, statusCode)
+	_, statusCode = httpDo(t, "PUT", url, "not abi")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	_, statusCode = httpDo(t, "PUT", ts.URL+"/admin/abis/bad", json.RawMessage(abiJSON))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	res, statusCode := httpDo(t, "GET", url, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, abiJSON, string(res))
+
+	res, statusCode = httpDo(t, "GET", ts.URL+"/admin/abis", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	var addrs []thor.Address
+	if err := json.Unmarshal(res, &addrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []thor.Address{addr}, addrs)
+
+	_, statusCode = httpDo(t, "DELETE", url, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	_, statusCode = httpDo(t, "DELETE", url, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	_, statusCode = httpDo(t, "GET", url, nil)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Empty(t, registry.Addresses())
+}
+
+func TestStats(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	res, statusCode := httpDo(t, "GET", ts.URL+"/admin/stats", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	var stats admin.RuntimeStats
+	if err := json.Unmarshal(res, &stats); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotZero(t, stats.Goroutines)
+	assert.NotZero(t, stats.Memory.Sys)
+}
+
+func initAdminServer(t *testing.T) {
+	level = &logLevel{lvl: log15.LvlInfo}
+	comp = &compactor{}
+	peers = &peerManager{
+		make(map[discover.NodeID]*discover.Node),
+		make(map[discover.NodeID]time.Duration),
+	}
+	allowed = &allowlist{make(map[discover.NodeID]*discover.Node)}
+	gasLimit = &gasLimitTarget{}
+	r, err := abis.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry = r
+
+	router := mux.NewRouter()
+	admin.New(level, comp, peers, allowed, gasLimit, registry).Mount(router, "/admin")
+	ts = httptest.NewServer(router)
+}
+
+func httpDo(t *testing.T, method, url string, obj interface{}) ([]byte, int) {
+	var body []byte
+	if obj != nil {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = data
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	r, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/thor"
+)
+
+// LogLevel gets and sets log verbosity at runtime.
+// Levels of modules override the global one for loggers of those modules.
+type LogLevel interface {
+	Get() log15.Lvl
+	Set(lvl log15.Lvl)
+	Modules() map[string]log15.Lvl
+	SetModules(modules map[string]log15.Lvl)
+}
+
+// Compactor compacts the underlying database.
+type Compactor interface {
+	Compact() error
+}
+
+// PeerManager connects to, disconnects from or bans p2p nodes.
+type PeerManager interface {
+	AddStatic(node *discover.Node)
+	RemoveStatic(node *discover.Node)
+	BanPeer(id discover.NodeID, duration time.Duration)
+}
+
+// Allowlist edits nodes allowed to connect in permissioned mode.
+type Allowlist interface {
+	AllowedNodes() ([]*discover.Node, error)
+	AllowNode(node *discover.Node) error
+	DisallowNode(id discover.NodeID) (bool, error)
+}
+
+// GasLimitTarget gets and sets the target gas limit of blocks proposed by the node.
+type GasLimitTarget interface {
+	TargetGasLimit() uint64
+	SetTargetGasLimit(gl uint64)
+}
+
+// ABIRegistry keeps contract ABIs to decode events.
+type ABIRegistry interface {
+	Register(addr thor.Address, raw []byte) error
+	Unregister(addr thor.Address) (bool, error)
+	Get(addr thor.Address) ([]byte, bool)
+	Addresses() []thor.Address
+}
+
+// LogLevelBody body of log level requests and responses.
+type LogLevelBody struct {
+	Level string `json:"level"`
+}
+
+// GasLimitBody body of gas limit requests and responses.
+// Target 0 means following the adaptive gas limit.
+type GasLimitBody struct {
+	Target uint64 `json:"target"`
+}
+
+// PeerBody body of peer requests.
+type PeerBody struct {
+	Enode string `json:"enode"`
+}
+
+// BanPeerBody body of ban peer requests.
+// Duration is in seconds, defaults to 1 hour if omitted.
+type BanPeerBody struct {
+	Enode    string `json:"enode"`
+	Duration uint64 `json:"duration"`
+}
+
+// MemStats memory statistics of the process.
+type MemStats struct {
+	Alloc       uint64 `json:"alloc"`
+	TotalAlloc  uint64 `json:"totalAlloc"`
+	Sys         uint64 `json:"sys"`
+	HeapObjects uint64 `json:"heapObjects"`
+	NumGC       uint32 `json:"numGC"`
+}
+
+// RuntimeStats runtime statistics of the process.
+type RuntimeStats struct {
+	GoVersion  string   `json:"goVersion"`
+	NumCPU     int      `json:"numCPU"`
+	Goroutines int      `json:"goroutines"`
+	Uptime     uint64   `json:"uptime"`
+	Memory     MemStats `json:"memory"`
+}
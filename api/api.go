@@ -10,21 +10,34 @@ import (
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/gorilla/mux"
+	"github.com/vechain/thor/api/abis"
 	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/admin"
+	"github.com/vechain/thor/api/authority"
 	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/debug"
+	"github.com/vechain/thor/api/dev"
 	"github.com/vechain/thor/api/doc"
+	"github.com/vechain/thor/api/ethrpc"
 	"github.com/vechain/thor/api/events"
+	"github.com/vechain/thor/api/graphql"
+	"github.com/vechain/thor/api/light"
 	"github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/api/stats"
+	"github.com/vechain/thor/api/subscriptions"
 	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/api/transfers"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/txpool"
 )
 
-//New return api router
-func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, nw node.Network) http.HandlerFunc {
+//New return api router, debug endpoints (expensive to serve) are mounted at '/debug' if enableDebug is true,
+//GraphQL endpoint is mounted at '/graphql' if enableGraphQL is true,
+//and eth JSON-RPC endpoint is mounted at '/eth' if enableEthRPC is true
+func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, forkConfig thor.ForkConfig, nw node.Network, producer node.Producer, nat node.NAT, blockFeed subscriptions.BlockFeed, abiRegistry *abis.Registry, version string, enableDebug bool, enableGraphQL bool, enableEthRPC bool) http.HandlerFunc {
 	router := mux.NewRouter()
 
 	// to serve api doc and swagger-ui
@@ -41,18 +54,61 @@ func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool,
 			http.Redirect(w, req, "doc/swagger-ui/", http.StatusTemporaryRedirect)
 		})
 
-	accounts.New(chain, stateCreator).
+	accounts.New(chain, stateCreator, logDB, forkConfig).
 		Mount(router, "/accounts")
-	events.New(logDB).
+	events.New(logDB, abiRegistry).
 		Mount(router, "/events")
 	transfers.New(logDB).
 		Mount(router, "/transfers")
+	stats.New(logDB).
+		Mount(router, "/stats")
 	blocks.New(chain).
 		Mount(router, "/blocks")
 	transactions.New(chain, txPool).
 		Mount(router, "/transactions")
-	node.New(nw).
+	node.New(chain, stateCreator, nw, txPool, producer, nat, version).
 		Mount(router, "/node")
+	subscriptions.New(chain, blockFeed, txPool, abiRegistry).
+		Mount(router, "/subscriptions")
+	authority.New(chain, stateCreator).
+		Mount(router, "/authority")
+	if enableDebug {
+		debug.New(chain, stateCreator, forkConfig).
+			Mount(router, "/debug")
+	}
+	if enableGraphQL {
+		graphql.New(chain, stateCreator, logDB).
+			Mount(router, "/graphql")
+	}
+	if enableEthRPC {
+		ethrpc.New(chain, stateCreator, logDB, txPool, forkConfig).
+			Mount(router, "/eth")
+	}
 
+	return compress(instrument(router))
+}
+
+//WithDev wraps api handler to serve development endpoints of solo mode at '/dev'
+func WithDev(handler http.Handler, chain *chain.Chain, solo dev.Solo) http.HandlerFunc {
+	router := mux.NewRouter()
+	dev.New(chain, solo).
+		Mount(router, "/dev")
+	router.PathPrefix("/").Handler(handler)
+	return router.ServeHTTP
+}
+
+//NewLight return api router of light client, which serves blocks (headers only) and accounts
+func NewLight(client light.Client) http.HandlerFunc {
+	router := mux.NewRouter()
+	light.New(client).
+		Mount(router, "")
+	return compress(instrument(router))
+}
+
+//NewAdmin return admin api router
+func NewAdmin(logLevel admin.LogLevel, compactor admin.Compactor, peers admin.PeerManager, allowlist admin.Allowlist, gasLimit admin.GasLimitTarget, abiRegistry admin.ABIRegistry) http.HandlerFunc {
+	router := mux.NewRouter()
+	admin.New(logLevel, compactor, peers, allowlist, gasLimit, abiRegistry).
+		Mount(router, "/admin")
 	return router.ServeHTTP
 }
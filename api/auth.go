@@ -0,0 +1,67 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Credentials accepted by the API server. Empty fields are not accepted.
+type Credentials struct {
+	Token    string // bearer token
+	Username string // basic auth
+	Password string
+}
+
+// ParseBasicAuth parses basic auth credentials in form of 'username:password'.
+func ParseBasicAuth(s string) (username, password string, ok bool) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func (c *Credentials) match(req *http.Request) bool {
+	if c.Token != "" {
+		if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			if secureEqual(strings.TrimPrefix(auth, "Bearer "), c.Token) {
+				return true
+			}
+		}
+	}
+	if c.Username != "" {
+		if username, password, ok := req.BasicAuth(); ok {
+			// evaluate both to not leak which one mismatched
+			userOK := secureEqual(username, c.Username)
+			passOK := secureEqual(password, c.Password)
+			if userOK && passOK {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authenticate wraps h to reject requests without valid credentials.
+func Authenticate(h http.Handler, credentials Credentials) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !credentials.match(req) {
+			if credentials.Username != "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="thor"`)
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	h := Authenticate(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}),
+		Credentials{Token: "secret", Username: "user", Password: "pass"})
+
+	do := func(setup func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/blocks/best", nil)
+		setup(req)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(func(req *http.Request) {})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Basic realm="thor"`, w.Header().Get("WWW-Authenticate"))
+
+	assert.Equal(t, http.StatusOK, do(func(req *http.Request) { req.Header.Set("Authorization", "Bearer secret") }).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(func(req *http.Request) { req.Header.Set("Authorization", "Bearer wrong") }).Code)
+	assert.Equal(t, http.StatusOK, do(func(req *http.Request) { req.SetBasicAuth("user", "pass") }).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(func(req *http.Request) { req.SetBasicAuth("user", "wrong") }).Code)
+}
+
+func TestParseBasicAuth(t *testing.T) {
+	username, password, ok := ParseBasicAuth("user:p:ss")
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "p:ss", password)
+
+	_, _, ok = ParseBasicAuth("user")
+	assert.False(t, ok)
+	_, _, ok = ParseBasicAuth(":pass")
+	assert.False(t, ok)
+}
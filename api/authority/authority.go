@@ -0,0 +1,192 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package authority
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/poa"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+const defaultScheduleSlots = 10
+
+// Authority serves state of the builtin Authority contract, which manages block proposers.
+type Authority struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator) *Authority {
+	return &Authority{
+		chain,
+		stateCreator,
+	}
+}
+
+// Candidates returns all listed candidates in the order of registration.
+func (a *Authority) Candidates(header *block.Header) ([]*Candidate, error) {
+	st, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	endorsement := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	native := builtin.Authority.Native(st)
+
+	candidates := []*Candidate{}
+	for signer := native.First(); signer != nil; signer = native.Next(*signer) {
+		c, ok := native.Get(*signer)
+		if !ok {
+			break
+		}
+		candidates = append(candidates, &Candidate{
+			Signer:   c.Signer,
+			Endorsor: c.Endorsor,
+			Identity: c.Identity,
+			Active:   c.Active,
+			Endorsed: st.GetBalance(c.Endorsor).Cmp(endorsement) >= 0,
+		})
+	}
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// Candidate returns the candidate of the signer, nil if not listed.
+func (a *Authority) Candidate(signer thor.Address, header *block.Header) (*Candidate, error) {
+	st, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	endorsement := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	c, ok := builtin.Authority.Native(st).Get(signer)
+	if !ok {
+		return nil, st.Err()
+	}
+	endorsed := st.GetBalance(c.Endorsor).Cmp(endorsement) >= 0
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return &Candidate{
+		Signer:   c.Signer,
+		Endorsor: c.Endorsor,
+		Identity: c.Identity,
+		Active:   c.Active,
+		Endorsed: endorsed,
+	}, nil
+}
+
+// Schedule returns upcoming slots to produce the block upon the parent, starting from nowTime.
+func (a *Authority) Schedule(parent *block.Header, nowTime uint64, n int) (*Schedule, error) {
+	st, err := a.stateCreator.NewState(parent.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	endorsement := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	candidates := builtin.Authority.Native(st).Candidates(endorsement, thor.MaxBlockProposers)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+
+	proposers := make([]poa.Proposer, 0, len(candidates))
+	actives := 0
+	for _, c := range candidates {
+		proposers = append(proposers, poa.Proposer{
+			Address: c.Signer,
+			Active:  c.Active,
+		})
+		if c.Active {
+			actives++
+		}
+	}
+
+	schedule := &Schedule{
+		ParentID:     parent.ID(),
+		ParentNumber: parent.Number(),
+		Proposers:    actives,
+		Slots:        []*Slot{},
+	}
+	for _, slot := range poa.Upcoming(proposers, parent.Number(), parent.Timestamp(), nowTime, n) {
+		schedule.Slots = append(schedule.Slots, &Slot{slot.Time, slot.Proposer})
+	}
+	return schedule, nil
+}
+
+func (a *Authority) handleGetCandidates(w http.ResponseWriter, req *http.Request) error {
+	header, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	candidates, err := a.Candidates(header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, candidates)
+}
+
+func (a *Authority) handleGetCandidate(w http.ResponseWriter, req *http.Request) error {
+	signer, err := thor.ParseAddress(mux.Vars(req)["signer"])
+	if err != nil {
+		return utils.BadRequest(err, "signer")
+	}
+	header, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	candidate, err := a.Candidate(signer, header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, candidate)
+}
+
+func (a *Authority) handleGetSchedule(w http.ResponseWriter, req *http.Request) error {
+	n := defaultScheduleSlots
+	if s := req.URL.Query().Get("slots"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return utils.BadRequest(err, "slots")
+		}
+		if v == 0 || v > thor.MaxBlockProposers {
+			return utils.BadRequest(errors.Errorf("should be in range [1, %v]", thor.MaxBlockProposers), "slots")
+		}
+		n = int(v)
+	}
+	schedule, err := a.Schedule(a.chain.BestBlock().Header(), uint64(time.Now().Unix()), n)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, schedule)
+}
+
+func (a *Authority) getBlockHeader(revision string) (*block.Header, error) {
+	header, err := utils.GetBlockHeader(a.chain, revision)
+	if err != nil {
+		if a.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+func (a *Authority) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/candidates").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetCandidates))
+	sub.Path("/candidates/{signer}").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetCandidate))
+	sub.Path("/schedule").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetSchedule))
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package authority_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/authority"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+func TestAuthority(t *testing.T) {
+	ts := initAuthorityServer(t)
+	defer ts.Close()
+
+	devs := genesis.DevAccounts()
+
+	var candidates []*authority.Candidate
+	httpGet(t, ts.URL+"/authority/candidates", http.StatusOK, &candidates)
+	if assert.Equal(t, len(devs), len(candidates)) {
+		for i, c := range candidates {
+			assert.Equal(t, &authority.Candidate{
+				Signer:   devs[i].Address,
+				Endorsor: devs[i].Address,
+				Identity: thor.BytesToBytes32([]byte(fmt.Sprintf("a%v", i))),
+				Active:   true,
+				Endorsed: true,
+			}, c)
+		}
+	}
+
+	var candidate *authority.Candidate
+	httpGet(t, ts.URL+"/authority/candidates/"+devs[1].Address.String()+"?revision=0", http.StatusOK, &candidate)
+	assert.Equal(t, candidates[1], candidate)
+
+	candidate = nil
+	httpGet(t, ts.URL+"/authority/candidates/"+thor.BytesToAddress([]byte("none")).String(), http.StatusOK, &candidate)
+	assert.Nil(t, candidate)
+
+	httpGet(t, ts.URL+"/authority/candidates/bad", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/authority/candidates?revision=100", http.StatusBadRequest, nil)
+
+	var schedule authority.Schedule
+	httpGet(t, ts.URL+"/authority/schedule?slots=5", http.StatusOK, &schedule)
+	assert.Equal(t, len(devs), schedule.Proposers)
+	if assert.Equal(t, 5, len(schedule.Slots)) {
+		for i := 1; i < len(schedule.Slots); i++ {
+			assert.Equal(t, schedule.Slots[i-1].Timestamp+thor.BlockInterval, schedule.Slots[i].Timestamp)
+		}
+	}
+
+	httpGet(t, ts.URL+"/authority/schedule?slots=0", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/authority/schedule?slots=102", http.StatusBadRequest, nil)
+}
+
+func initAuthorityServer(t *testing.T) *httptest.Server {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := chain.New(db, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	authority.New(c, stateC).Mount(router, "/authority")
+	return httptest.NewServer(router)
+}
+
+func httpGet(t *testing.T, url string, status int, v interface{}) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, status, res.StatusCode, string(data))
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
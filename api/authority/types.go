@@ -0,0 +1,36 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package authority
+
+import (
+	"github.com/vechain/thor/thor"
+)
+
+// Candidate a block proposer candidate listed in the builtin Authority contract.
+// Endorsed tells whether the endorsor holds enough balance for the candidate to propose blocks.
+type Candidate struct {
+	Signer   thor.Address `json:"signer"`
+	Endorsor thor.Address `json:"endorsor"`
+	Identity thor.Bytes32 `json:"identity"`
+	Active   bool         `json:"active"`
+	Endorsed bool         `json:"endorsed"`
+}
+
+// Slot a time slot to produce the next block, and the proposer scheduled in it.
+type Slot struct {
+	Timestamp uint64       `json:"timestamp"`
+	Proposer  thor.Address `json:"proposer"`
+}
+
+// Schedule upcoming slots to produce the block upon the parent block.
+// Slots are in order, and the proposer of a slot takes over if previous ones are missed.
+// The schedule changes once a new block produced.
+type Schedule struct {
+	ParentID     thor.Bytes32 `json:"parentID"`
+	ParentNumber uint32       `json:"parentNumber"`
+	Proposers    int          `json:"proposers"` // count of active proposers
+	Slots        []*Slot      `json:"slots"`
+}
@@ -6,6 +6,8 @@
 package blocks
 
 import (
+	"fmt"
+	"math/big"
 	"net/http"
 	"strconv"
 
@@ -16,8 +18,12 @@ import (
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 )
 
+// maxBlocksRange limits blocks fetched in one range query.
+const maxBlocksRange = 100
+
 type Blocks struct {
 	chain *chain.Chain
 }
@@ -28,8 +34,24 @@ func New(chain *chain.Chain) *Blocks {
 	}
 }
 
+func parseExpanded(req *http.Request) (bool, error) {
+	s := req.URL.Query().Get("expanded")
+	if s == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, utils.BadRequest(err, "expanded")
+	}
+	return v, nil
+}
+
 func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error {
 	revision := mux.Vars(req)["revision"]
+	expanded, err := parseExpanded(req)
+	if err != nil {
+		return err
+	}
 	block, err := b.getBlock(revision)
 	if err != nil {
 		if b.chain.IsNotFound(err) {
@@ -41,13 +63,157 @@ func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error
 	if err != nil {
 		return err
 	}
+	finality := newFinality(block.Header().Number(), isTrunk, b.chain.BestBlock().Header().Number())
+	if expanded {
+		receipts, err := b.getReceipts(block)
+		if err != nil {
+			return err
+		}
+		blk, err := ConvertExpandedBlock(block, receipts, isTrunk)
+		if err != nil {
+			return err
+		}
+		blk.Finality = finality
+		return utils.WriteJSON(w, blk)
+	}
 	blk, err := ConvertBlock(block, isTrunk)
 	if err != nil {
 		return err
 	}
+	blk.Finality = finality
 	return utils.WriteJSON(w, blk)
 }
 
+// Range returns trunk blocks numbered in range [from, to], which stops at the best block.
+func (b *Blocks) Range(from, to uint32, expanded bool) ([]interface{}, error) {
+	best := b.chain.BestBlock().Header().Number()
+	if to > best {
+		to = best
+	}
+	blks := []interface{}{}
+	for n := uint64(from); n <= uint64(to); n++ {
+		block, err := b.chain.GetTrunkBlock(uint32(n))
+		if err != nil {
+			return nil, err
+		}
+		if expanded {
+			receipts, err := b.getReceipts(block)
+			if err != nil {
+				return nil, err
+			}
+			blk, err := ConvertExpandedBlock(block, receipts, true)
+			if err != nil {
+				return nil, err
+			}
+			blk.Finality = newFinality(uint32(n), true, best)
+			blks = append(blks, blk)
+		} else {
+			blk, err := ConvertBlock(block, true)
+			if err != nil {
+				return nil, err
+			}
+			blk.Finality = newFinality(uint32(n), true, best)
+			blks = append(blks, blk)
+		}
+	}
+	return blks, nil
+}
+
+// handleGetBlocks serves trunk blocks in range ['from', 'to']. At most maxBlocksRange blocks in one query,
+// and 'to' defaults to the last one allowed.
+func (b *Blocks) handleGetBlocks(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	if query.Get("from") == "" {
+		return utils.BadRequest(errors.New("required"), "from")
+	}
+	from, err := strconv.ParseUint(query.Get("from"), 10, 32)
+	if err != nil {
+		return utils.BadRequest(err, "from")
+	}
+	to := from + maxBlocksRange - 1
+	if s := query.Get("to"); s != "" {
+		if to, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return utils.BadRequest(err, "to")
+		}
+		if to < from {
+			return utils.BadRequest(errors.New("should not be less than 'from'"), "to")
+		}
+		if to-from >= maxBlocksRange {
+			return utils.BadRequest(fmt.Errorf("range exceeds %v blocks", maxBlocksRange), "to")
+		}
+	}
+	if to > math.MaxUint32 {
+		to = math.MaxUint32
+	}
+	expanded, err := parseExpanded(req)
+	if err != nil {
+		return err
+	}
+	blks, err := b.Range(uint32(from), uint32(to), expanded)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, blks)
+}
+
+// Reward sums up fees paid by txs in the block, and the share rewarded to the beneficiary,
+// as recorded in receipts.
+func (b *Blocks) Reward(blk *block.Block) (*BlockReward, error) {
+	header := blk.Header()
+	receipts, err := b.getReceipts(blk)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		paid   = new(big.Int)
+		reward = new(big.Int)
+		txs    = make([]*TxReward, 0, len(receipts))
+	)
+	for i, trx := range blk.Transactions() {
+		r := receipts[i]
+		paid.Add(paid, r.Paid)
+		reward.Add(reward, r.Reward)
+		txs = append(txs, &TxReward{
+			ID:     trx.ID(),
+			Paid:   (*math.HexOrDecimal256)(r.Paid),
+			Reward: (*math.HexOrDecimal256)(r.Reward),
+		})
+	}
+	return &BlockReward{
+		ID:          header.ID(),
+		Number:      header.Number(),
+		Beneficiary: header.Beneficiary(),
+		Paid:        (*math.HexOrDecimal256)(paid),
+		Reward:      (*math.HexOrDecimal256)(reward),
+		Burned:      (*math.HexOrDecimal256)(new(big.Int).Sub(paid, reward)),
+		Txs:         txs,
+	}, nil
+}
+
+// getReceipts returns receipts of txs in the block.
+func (b *Blocks) getReceipts(blk *block.Block) (tx.Receipts, error) {
+	// receipts of genesis block are not saved
+	if len(blk.Transactions()) == 0 {
+		return tx.Receipts{}, nil
+	}
+	return b.chain.GetBlockReceipts(blk.Header().ID())
+}
+
+func (b *Blocks) handleGetBlockReward(w http.ResponseWriter, req *http.Request) error {
+	blk, err := b.getBlock(mux.Vars(req)["revision"])
+	if err != nil {
+		if b.chain.IsNotFound(err) {
+			return utils.WriteJSON(w, nil)
+		}
+		return err
+	}
+	reward, err := b.Reward(blk)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, reward)
+}
+
 func (b *Blocks) getBlock(revision string) (*block.Block, error) {
 	if revision == "" || revision == "best" {
 		return b.chain.BestBlock(), nil
@@ -77,6 +243,8 @@ func (b *Blocks) isTrunk(blkID thor.Bytes32, blkNum uint32) (bool, error) {
 
 func (b *Blocks) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlocks))
 	sub.Path("/{revision}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlock))
+	sub.Path("/{revision}/reward").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlockReward))
 
 }
@@ -6,6 +6,7 @@
 package blocks_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"math/big"
@@ -60,7 +61,115 @@ func TestBlock(t *testing.T) {
 		t.Fatal(err)
 	}
 	checkBlock(t, raw, rb)
+	assert.Equal(t, &blocks.Finality{Confirmations: 0, IsFinalized: false}, rb.Finality)
 
+	res = httpGet(t, ts.URL+"/blocks/0")
+	if err := json.Unmarshal(res, &rb); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &blocks.Finality{Confirmations: 1, IsFinalized: false}, rb.Finality)
+}
+
+func TestExpandedBlock(t *testing.T) {
+	initBlockServer(t)
+	defer ts.Close()
+
+	var eb *blocks.ExpandedBlock
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks/1?expanded=true"), &eb); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := blocks.ConvertBlock(blk, true)
+	// tx IDs are superseded by expanded txs
+	raw.Transactions = nil
+	checkBlock(t, raw, eb.Block)
+	trx := blk.Transactions()[0]
+	if assert.Equal(t, 1, len(eb.Transactions)) {
+		assert.Equal(t, trx.ID(), eb.Transactions[0].ID)
+		assert.Equal(t, genesis.DevAccounts()[0].Address, eb.Transactions[0].Origin)
+		assert.Equal(t, blk.Header().ID(), eb.Transactions[0].Block.ID)
+		if assert.NotNil(t, eb.Transactions[0].Receipt) {
+			assert.Equal(t, uint64(21000), eb.Transactions[0].Receipt.GasUsed)
+			assert.Equal(t, trx.ID(), eb.Transactions[0].Receipt.Tx.ID)
+		}
+	}
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks/0?expanded=true"), &eb); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(0), eb.Number)
+	assert.NotNil(t, eb.Transactions)
+	assert.Empty(t, eb.Transactions)
+
+	res, err := http.Get(ts.URL + "/blocks/1?expanded=bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestBlockRange(t *testing.T) {
+	initBlockServer(t)
+	defer ts.Close()
+
+	var blks []*blocks.Block
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks?from=0"), &blks); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(blks)) {
+		assert.Equal(t, uint32(0), blks[0].Number)
+		raw, _ := blocks.ConvertBlock(blk, true)
+		checkBlock(t, raw, blks[1])
+	}
+
+	var ebs []*blocks.ExpandedBlock
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks?from=1&to=1&expanded=true"), &ebs); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(ebs)) && assert.Equal(t, 1, len(ebs[0].Transactions)) {
+		assert.NotNil(t, ebs[0].Transactions[0].Receipt)
+	}
+
+	assert.Equal(t, "[]", string(bytes.TrimSpace(httpGet(t, ts.URL+"/blocks?from=5"))))
+
+	for _, query := range []string{"", "?from=bad", "?from=2&to=1", "?from=0&to=100", "?from=0&expanded=bad"} {
+		res, err := http.Get(ts.URL + "/blocks" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+	}
+}
+
+func TestBlockReward(t *testing.T) {
+	initBlockServer(t)
+	defer ts.Close()
+
+	var reward *blocks.BlockReward
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks/1/reward"), &reward); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, blk.Header().ID(), reward.ID)
+	assert.Equal(t, blk.Header().Beneficiary(), reward.Beneficiary)
+	paid := (*big.Int)(reward.Paid)
+	assert.NotZero(t, paid.Sign())
+	// 30% rewarded
+	assert.Equal(t, new(big.Int).Mul(paid, big.NewInt(3)), new(big.Int).Mul((*big.Int)(reward.Reward), big.NewInt(10)))
+	assert.Equal(t, paid, new(big.Int).Add((*big.Int)(reward.Reward), (*big.Int)(reward.Burned)))
+	if assert.Equal(t, 1, len(reward.Txs)) {
+		assert.Equal(t, blk.Transactions()[0].ID(), reward.Txs[0].ID)
+		assert.Equal(t, reward.Paid, reward.Txs[0].Paid)
+		assert.Equal(t, reward.Reward, reward.Txs[0].Reward)
+	}
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/blocks/0/reward"), &reward); err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, (*big.Int)(reward.Paid).Sign())
+	assert.Empty(t, reward.Txs)
+
+	assert.Equal(t, "null", string(bytes.TrimSpace(httpGet(t, ts.URL+"/blocks/100/reward"))))
 }
 
 func initBlockServer(t *testing.T) {
@@ -92,7 +201,7 @@ func initBlockServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	tx = tx.WithSignature(sig)
-	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	if err != nil {
 		t.Fatal(err)
@@ -6,8 +6,12 @@
 package blocks
 
 import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 )
 
 //Block block
@@ -26,9 +30,29 @@ type Block struct {
 	ReceiptsRoot thor.Bytes32   `json:"receiptsRoot"`
 	Signer       thor.Address   `json:"signer"`
 	IsTrunk      bool           `json:"isTrunk"`
+	Finality     *Finality      `json:"finality,omitempty"`
 	Transactions []thor.Bytes32 `json:"transactions,string"`
 }
 
+// FinalityDepth count of trunk blocks on top of a block, to regard the block as finalized.
+// PoA has no explicit finality, so it's a heuristic that deeper reorgs hardly happen.
+const FinalityDepth = 12
+
+//Finality finality info of a block, judged by trunk blocks on top of it
+type Finality struct {
+	Confirmations uint32 `json:"confirmations"` // 0 if not in trunk
+	IsFinalized   bool   `json:"isFinalized"`
+}
+
+func newFinality(blkNum uint32, isTrunk bool, bestNum uint32) *Finality {
+	var f Finality
+	if isTrunk && bestNum >= blkNum {
+		f.Confirmations = bestNum - blkNum
+		f.IsFinalized = f.Confirmations >= FinalityDepth
+	}
+	return &f
+}
+
 //ConvertBlock convert a raw block into a json format block
 func ConvertBlock(b *block.Block, isTrunk bool) (*Block, error) {
 	if b == nil {
@@ -63,3 +87,88 @@ func ConvertBlock(b *block.Block, isTrunk bool) (*Block, error) {
 		Transactions: txIds,
 	}, nil
 }
+
+//ExpandedBlock block with full txs and their receipts inlined
+type ExpandedBlock struct {
+	*Block
+	Transactions []*ExpandedTx `json:"transactions"`
+}
+
+//ExpandedTx tx in expanded block, along with its receipt
+type ExpandedTx struct {
+	*transactions.Transaction
+	Receipt *transactions.Receipt `json:"receipt"`
+}
+
+//ConvertExpandedBlock convert a raw block and its receipts into a json format expanded block
+func ConvertExpandedBlock(b *block.Block, receipts tx.Receipts, isTrunk bool) (*ExpandedBlock, error) {
+	blk, err := ConvertBlock(b, isTrunk)
+	if err != nil {
+		return nil, err
+	}
+	header := b.Header()
+	blockCtx := transactions.BlockContext{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+	}
+	txs := make([]*ExpandedTx, 0, len(receipts))
+	for i, trx := range b.Transactions() {
+		t, err := transactions.ConvertTransaction(trx)
+		if err != nil {
+			return nil, err
+		}
+		t.Block = blockCtx
+		r, err := transactions.ConvertReceipt(receipts[i], header, trx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &ExpandedTx{t, r})
+	}
+	return &ExpandedBlock{blk, txs}, nil
+}
+
+//BlockReward fees paid by txs in a block, and the share rewarded to the beneficiary. The rest of fees is burned.
+type BlockReward struct {
+	ID          thor.Bytes32          `json:"id"`
+	Number      uint32                `json:"number"`
+	Beneficiary thor.Address          `json:"beneficiary"`
+	Paid        *math.HexOrDecimal256 `json:"paid"`
+	Reward      *math.HexOrDecimal256 `json:"reward"`
+	Burned      *math.HexOrDecimal256 `json:"burned"`
+	Txs         []*TxReward           `json:"txs"`
+}
+
+//TxReward fee paid by a tx, and the share rewarded to the beneficiary
+type TxReward struct {
+	ID     thor.Bytes32          `json:"id"`
+	Paid   *math.HexOrDecimal256 `json:"paid"`
+	Reward *math.HexOrDecimal256 `json:"reward"`
+}
+
+//Reorg re-organization of trunk, blocks of old branch are replaced by those of new branch
+type Reorg struct {
+	Seq       uint64         `json:"seq"`
+	Timestamp uint64         `json:"timestamp"`
+	Ancestor  thor.Bytes32   `json:"ancestor"`
+	OldBranch []thor.Bytes32 `json:"oldBranch"`
+	NewBranch []thor.Bytes32 `json:"newBranch"`
+}
+
+//ConvertReorg convert a chain reorg into json format
+func ConvertReorg(r *chain.Reorg) *Reorg {
+	ids := func(headers []*block.Header) []thor.Bytes32 {
+		ids := make([]thor.Bytes32, len(headers))
+		for i, h := range headers {
+			ids[i] = h.ID()
+		}
+		return ids
+	}
+	return &Reorg{
+		Seq:       r.Seq,
+		Timestamp: r.Timestamp,
+		Ancestor:  r.Ancestor.ID(),
+		OldBranch: ids(r.Branch),
+		NewBranch: ids(r.Trunk),
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/websocket"
+)
+
+// compress wraps h to compress responses with gzip or deflate, negotiated via Accept-Encoding.
+// Websocket upgrades are left untouched, since the connection will be hijacked.
+func compress(h http.Handler) http.HandlerFunc {
+	compressed := handlers.CompressHandler(h)
+	return func(w http.ResponseWriter, req *http.Request) {
+		if websocket.IsWebSocketUpgrade(req) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		compressed.ServeHTTP(w, req)
+	}
+}
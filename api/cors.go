@@ -0,0 +1,187 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CORSRule allows cross origin requests to paths with the prefix.
+type CORSRule struct {
+	Path    string   `json:"path" yaml:"path"`
+	Origins []string `json:"origins" yaml:"origins"` // full origin (e.g. 'https://foo.com') or host, '*' for any
+	Methods []string `json:"methods" yaml:"methods"` // GET and HEAD if empty, mutations must be listed explicitly
+	Headers []string `json:"headers" yaml:"headers"` // extra request headers besides 'content-type' and 'authorization'
+}
+
+// CORSPolicy cross origin policy of API and admin services, e.g. in YAML
+//
+//	api:
+//	  - path: /
+//	    origins: ['*']
+//	  - path: /transactions
+//	    origins: [wallet.example.org]
+//	    methods: [GET, HEAD, POST]
+//	admin:
+//	  origins: [ops.example.org]
+//	  methods: [GET, PUT, POST, DELETE]
+type CORSPolicy struct {
+	API []CORSRule `json:"api" yaml:"api"`
+	// Admin applies to admin service and '/debug' of API, regardless of API rules.
+	// Cross origin requests to them are refused if nil.
+	Admin *CORSRule `json:"admin" yaml:"admin"`
+}
+
+const debugPath = "/debug"
+
+// Validate checks that no API rule covers '/debug' only, which is governed by admin rule.
+func (p *CORSPolicy) Validate() error {
+	for _, rule := range p.API {
+		if strings.HasPrefix(rule.Path, debugPath) {
+			return fmt.Errorf("api rule of path %q: %v is governed by admin rule", rule.Path, debugPath)
+		}
+	}
+	return nil
+}
+
+// APIRules returns rules for API service. API rules under '/debug' are dropped.
+func (p *CORSPolicy) APIRules() []CORSRule {
+	debug := CORSRule{Path: debugPath}
+	if p.Admin != nil {
+		debug = *p.Admin
+		debug.Path = debugPath
+	}
+	rules := make([]CORSRule, 0, len(p.API)+1)
+	for _, rule := range p.API {
+		if !strings.HasPrefix(rule.Path, debugPath) {
+			rules = append(rules, rule)
+		}
+	}
+	return append(rules, debug)
+}
+
+// AdminRules returns rules for admin service. p can be nil, which refuses all cross origin requests.
+func (p *CORSPolicy) AdminRules() []CORSRule {
+	if p == nil || p.Admin == nil {
+		return nil
+	}
+	rule := *p.Admin
+	rule.Path = "/"
+	return []CORSRule{rule}
+}
+
+const corsMaxAge = "600"
+
+// CORS wraps h to handle cross origin requests by rules. The rule of the longest matched path prefix applies.
+// Cross origin requests not allowed are served without CORS headers, so browsers keep responses from scripts,
+// except that mutations and preflights are refused, since browsers send some mutations without preflight.
+func CORS(h http.Handler, rules []CORSRule) http.Handler {
+	rules = append([]CORSRule(nil), rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].Path) > len(rules[j].Path)
+	})
+	match := func(path string) *CORSRule {
+		for i := range rules {
+			if strings.HasPrefix(path, rules[i].Path) {
+				return &rules[i]
+			}
+		}
+		return nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get("Origin")
+		if origin == "" || isSameOrigin(origin, req) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+
+		rule := match(req.URL.Path)
+		allowed := rule != nil && rule.allowsOrigin(origin)
+
+		// preflight
+		if req.Method == "OPTIONS" && req.Header.Get("Access-Control-Request-Method") != "" {
+			method := req.Header.Get("Access-Control-Request-Method")
+			headers := req.Header.Get("Access-Control-Request-Headers")
+			if !allowed || !rule.allowsMethod(method) || !rule.allowsHeaders(headers) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", method)
+			if headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if allowed && rule.allowsMethod(req.Method) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		} else if req.Method != "GET" && req.Method != "HEAD" {
+			http.Error(w, "cross origin request not allowed", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+func (r *CORSRule) allowsOrigin(origin string) bool {
+	var host string
+	if u, err := url.Parse(origin); err == nil {
+		host = u.Host
+	}
+	for _, o := range r.Origins {
+		if o == "*" || strings.EqualFold(o, origin) || (host != "" && strings.EqualFold(o, host)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CORSRule) allowsMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return method == "GET" || method == "HEAD"
+	}
+	for _, m := range r.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders checks comma separated headers of preflight request.
+func (r *CORSRule) allowsHeaders(headers string) bool {
+	for _, header := range strings.Split(headers, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" || strings.EqualFold(header, "content-type") || strings.EqualFold(header, "authorization") {
+			continue
+		}
+		found := false
+		for _, h := range r.Headers {
+			if strings.EqualFold(h, header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isSameOrigin(origin string, req *http.Request) bool {
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, req.Host)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	policy := &CORSPolicy{
+		API: []CORSRule{
+			{Path: "/", Origins: []string{"*"}},
+			{Path: "/transactions", Origins: []string{"wallet.org"}, Methods: []string{"GET", "POST"}, Headers: []string{"x-foo"}},
+		},
+	}
+	h := CORS(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), policy.APIRules())
+
+	do := func(method, path, origin string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "http://node.org"+path, nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// same origin or no origin
+	assert.Equal(t, http.StatusOK, do("POST", "/transactions", "").Code)
+	assert.Equal(t, http.StatusOK, do("POST", "/transactions", "http://node.org").Code)
+
+	// read-only by default
+	w := do("GET", "/blocks/best", "https://any.org")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://any.org", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusForbidden, do("POST", "/accounts/0x0", "https://any.org").Code)
+
+	// per-path origins and methods
+	w = do("POST", "/transactions", "https://wallet.org")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://wallet.org", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusForbidden, do("POST", "/transactions", "https://any.org").Code)
+	w = do("GET", "/transactions/0x0", "https://any.org")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// preflight
+	w = do("OPTIONS", "/transactions", "https://wallet.org", "Access-Control-Request-Method", "POST", "Access-Control-Request-Headers", "Content-Type, X-Foo")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, http.StatusForbidden, do("OPTIONS", "/transactions", "https://wallet.org", "Access-Control-Request-Method", "DELETE").Code)
+	assert.Equal(t, http.StatusForbidden, do("OPTIONS", "/transactions", "https://wallet.org", "Access-Control-Request-Method", "POST", "Access-Control-Request-Headers", "x-bar").Code)
+
+	// debug follows admin rule, which refuses all if absent
+	assert.Empty(t, do("GET", "/debug/tracers", "https://any.org").Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusForbidden, do("POST", "/debug/tracers", "https://any.org").Code)
+
+	policy.Admin = &CORSRule{Origins: []string{"https://ops.org"}, Methods: []string{"POST"}}
+	h = CORS(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), policy.APIRules())
+	assert.Equal(t, http.StatusOK, do("POST", "/debug/tracers", "https://ops.org").Code)
+	assert.Nil(t, (*CORSPolicy)(nil).AdminRules())
+	assert.Equal(t, "/", policy.AdminRules()[0].Path)
+}
+
+func TestCORSDebugRules(t *testing.T) {
+	policy := &CORSPolicy{
+		API: []CORSRule{
+			{Path: "/", Origins: []string{"*"}},
+			{Path: "/debug/tracers", Origins: []string{"*"}, Methods: []string{"POST"}},
+			{Path: "/debug", Origins: []string{"*"}, Methods: []string{"POST"}},
+		},
+	}
+	assert.Error(t, policy.Validate())
+
+	rules := policy.APIRules()
+	assert.Equal(t, []CORSRule{{Path: "/", Origins: []string{"*"}}, {Path: "/debug"}}, rules)
+
+	h := CORS(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), rules)
+	req := httptest.NewRequest("POST", "http://node.org/debug/tracers", nil)
+	req.Header.Set("Origin", "https://any.org")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	policy.API = policy.API[:1]
+	assert.Nil(t, policy.Validate())
+}
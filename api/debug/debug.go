@@ -0,0 +1,254 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/runtime"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+	"github.com/vechain/thor/xenv"
+)
+
+// Debug re-executes transactions or clauses with VM tracer attached.
+type Debug struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	forkConfig   thor.ForkConfig
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Debug {
+	return &Debug{
+		chain,
+		stateCreator,
+		forkConfig,
+	}
+}
+
+// newRuntime creates a runtime on the given state, with context of the block.
+func (d *Debug) newRuntime(header *block.Header, stateRoot thor.Bytes32) (*runtime.Runtime, error) {
+	ctx, err := runtime.NewBlockContext(header)
+	if err != nil {
+		return nil, err
+	}
+	state, err := d.stateCreator.NewState(stateRoot)
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	return runtime.New(d.chain.NewSeeker(header.ParentID()), state, ctx, d.forkConfig), nil
+}
+
+// TraceTransaction traces clauses of a transaction in block.
+// If clauseIndex < 0, all executed clauses are traced and a slice of results returned.
+func (d *Debug) TraceTransaction(name string, blockID thor.Bytes32, txIndex uint64, clauseIndex int) (interface{}, error) {
+	if _, err := newTracer(name); err != nil {
+		return nil, err
+	}
+	blk, err := d.chain.GetBlock(blockID)
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.New("block not found"), "target")
+		}
+		return nil, err
+	}
+	txs := blk.Transactions()
+	if txIndex >= uint64(len(txs)) {
+		return nil, utils.BadRequest(errors.New("tx index out of range"), "target")
+	}
+	if clauseIndex >= len(txs[txIndex].Clauses()) {
+		return nil, utils.BadRequest(errors.New("clause index out of range"), "target")
+	}
+
+	rt, err := runtime.NewForBlock(d.chain, d.stateCreator, blk.Header(), d.forkConfig)
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	for _, tx := range txs[:txIndex] {
+		if _, err := rt.ExecuteTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	var tracers []vm.JSONTracer
+	if _, err := rt.TraceTransaction(txs[txIndex], func(i uint32) vm.Tracer {
+		if clauseIndex >= 0 && int(i) != clauseIndex {
+			return nil
+		}
+		t, _ := newTracer(name)
+		tracers = append(tracers, t)
+		return t
+	}); err != nil {
+		return nil, err
+	}
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, err
+	}
+	if err := rt.State().Err(); err != nil {
+		return nil, err
+	}
+
+	if clauseIndex >= 0 {
+		// the clause is not executed if any previous clause reverted
+		if len(tracers) == 0 {
+			return nil, nil
+		}
+		return tracers[0].GetResult()
+	}
+	results := make([]json.RawMessage, 0, len(tracers))
+	for _, t := range tracers {
+		res, err := t.GetResult()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// TraceCall traces a clause call on the block with the given header.
+func (d *Debug) TraceCall(opt *TraceCallOption, header *block.Header) (interface{}, error) {
+	tracer, err := newTracer(opt.Name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := hexutil.Decode(opt.Data)
+	if err != nil {
+		return nil, utils.BadRequest(err, "data")
+	}
+	var (
+		value    = new(big.Int)
+		gasPrice = new(big.Int)
+		gas      = opt.Gas
+	)
+	if opt.Value != nil {
+		value = (*big.Int)(opt.Value)
+	}
+	if opt.GasPrice != nil {
+		gasPrice = (*big.Int)(opt.GasPrice)
+	}
+	if gas == 0 {
+		gas = math.MaxUint64
+	}
+
+	rt, err := d.newRuntime(header, header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+
+	rt.ExecuteClause(tx.NewClause(opt.To).WithData(data).WithValue(value), 0, gas, &xenv.TransactionContext{
+		Origin:     opt.Caller,
+		GasPrice:   gasPrice,
+		ProvedWork: &big.Int{},
+	})
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, err
+	}
+	if err := rt.State().Err(); err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
+// parseTarget parses target in form of 'blockID/txIndex[/clauseIndex]'.
+func parseTarget(target string) (blockID thor.Bytes32, txIndex uint64, clauseIndex int, err error) {
+	parts := strings.Split(target, "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return thor.Bytes32{}, 0, 0, utils.BadRequest(errors.New("invalid format"), "target")
+	}
+	if blockID, err = thor.ParseBytes32(parts[0]); err != nil {
+		return thor.Bytes32{}, 0, 0, utils.BadRequest(err, "target[0]")
+	}
+	if txIndex, err = strconv.ParseUint(parts[1], 0, 0); err != nil {
+		return thor.Bytes32{}, 0, 0, utils.BadRequest(err, "target[1]")
+	}
+	clauseIndex = -1
+	if len(parts) == 3 {
+		n, err := strconv.ParseUint(parts[2], 0, 0)
+		if err != nil {
+			return thor.Bytes32{}, 0, 0, utils.BadRequest(err, "target[2]")
+		}
+		if n > math.MaxInt32 {
+			return thor.Bytes32{}, 0, 0, utils.BadRequest(errors.New("clause index out of range"), "target[2]")
+		}
+		clauseIndex = int(n)
+	}
+	return
+}
+
+func (d *Debug) getBlockHeader(revision string) (*block.Header, error) {
+	return utils.GetBlockHeader(d.chain, revision)
+}
+
+func (d *Debug) handleTraceTransaction(w http.ResponseWriter, req *http.Request) error {
+	var opt TracerOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	blockID, txIndex, clauseIndex, err := parseTarget(opt.Target)
+	if err != nil {
+		return err
+	}
+	res, err := d.TraceTransaction(opt.Name, blockID, txIndex, clauseIndex)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error {
+	var opt TraceCallOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	header, err := d.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	res, err := d.TraceCall(&opt, header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (d *Debug) handleReplayBlock(w http.ResponseWriter, req *http.Request) error {
+	header, err := d.getBlockHeader(mux.Vars(req)["revision"])
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return err
+	}
+	res, err := d.ReplayBlock(header.ID())
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (d *Debug) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/tracers").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleTraceTransaction))
+	sub.Path("/tracers/call").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleTraceCall))
+	sub.Path("/blocks/{revision}/replay").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(d.handleReplayBlock))
+	sub.Path("/storage/{address}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(d.handleStorageRange))
+}
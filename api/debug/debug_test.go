@@ -0,0 +1,316 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/debug"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+var (
+	ts  *httptest.Server
+	c   *chain.Chain
+	blk *block.Block
+)
+
+func TestTraceTransaction(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	target := blk.Header().ID().String() + "/0"
+
+	var frames []*vm.CallFrame
+	res, statusCode := httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Name: "callTracer", Target: target})
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &frames); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(frames), "all clauses should be traced")
+	assert.Equal(t, common.Address(builtin.Params.Address), frames[1].To)
+
+	var result debug.StructLoggerResult
+	res, statusCode = httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Target: target + "/1"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, result.Failed)
+	assert.NotEmpty(t, result.StructLogs)
+
+	_, statusCode = httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Target: target + "/2"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Name: "unknown", Target: target})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Target: "bad target"})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	var accounts map[common.Address]*vm.PrestateAccount
+	res, statusCode := httpPost(t, ts.URL+"/debug/tracers", &debug.TracerOption{Name: "prestateTracer", Target: blk.Header().ID().String() + "/0/0"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &accounts); err != nil {
+		t.Fatal(err)
+	}
+	to := common.Address(thor.BytesToAddress([]byte("to")))
+	from := common.Address(genesis.DevAccounts()[0].Address)
+	if assert.Contains(t, accounts, to) {
+		assert.Equal(t, 0, (*big.Int)(accounts[to].Balance).Sign(), "recipient had no balance before")
+	}
+	if assert.Contains(t, accounts, from) {
+		assert.True(t, (*big.Int)(accounts[from].Balance).Cmp(big.NewInt(10)) >= 0)
+	}
+}
+
+func TestTraceCall(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	method, _ := builtin.Params.ABI.MethodByName("executor")
+	data, err := method.EncodeInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var frame vm.CallFrame
+	res, statusCode := httpPost(t, ts.URL+"/debug/tracers/call", &debug.TraceCallOption{
+		Name: "callTracer",
+		To:   &builtin.Params.Address,
+		Data: hexutil.Encode(data),
+	})
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &frame); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, common.Address(builtin.Params.Address), frame.To)
+	assert.Empty(t, frame.Error)
+
+	var addr common.Address
+	if err := method.DecodeOutput(frame.Output, &addr); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, genesis.DevAccounts()[0].Address, thor.Address(addr))
+}
+
+func TestReplayBlock(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	var result debug.ReplayedBlock
+	res, statusCode := httpGet(t, ts.URL+"/debug/blocks/"+blk.Header().ID().String()+"/replay")
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, blk.Header().ID(), result.ID)
+	assert.True(t, result.Consistent, "replay should reproduce committed receipts")
+	assert.Equal(t, 1, len(result.Txs))
+	assert.Equal(t, blk.Transactions()[0].ID(), result.Txs[0].ID)
+	assert.False(t, result.Txs[0].Reverted)
+	assert.NotZero(t, result.Txs[0].GasUsed)
+	assert.Equal(t, 2, len(result.Txs[0].Clauses))
+	assert.Equal(t, common.Address(builtin.Params.Address), result.Txs[0].Clauses[1].Call.To)
+
+	// by number
+	_, statusCode = httpGet(t, ts.URL+"/debug/blocks/1/replay")
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	_, statusCode = httpGet(t, ts.URL+"/debug/blocks/0/replay")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpGet(t, ts.URL+"/debug/blocks/bad/replay")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestStorageRange(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	url := ts.URL + "/debug/storage/" + builtin.Params.Address.String() + "?limit=2&preimage=true"
+	var (
+		entries []*debug.StorageEntry
+		start   string
+	)
+	for pages := 0; ; pages++ {
+		assert.True(t, pages < 100, "too many pages")
+		res, statusCode := httpGet(t, url+start)
+		assert.Equal(t, http.StatusOK, statusCode)
+		var result debug.StorageRangeResult
+		if err := json.Unmarshal(res, &result); err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, len(result.Storage) <= 2)
+		entries = append(entries, result.Storage...)
+		if result.NextKeyHash == nil {
+			break
+		}
+		start = "&start=" + result.NextKeyHash.String()
+	}
+	assert.True(t, len(entries) > 2, "should span pages")
+
+	found := false
+	for i, entry := range entries {
+		if i > 0 {
+			assert.True(t, bytes.Compare(entries[i-1].KeyHash[:], entry.KeyHash[:]) < 0, "should be ordered by key hash")
+		}
+		if assert.NotNil(t, entry.Key) {
+			assert.Equal(t, thor.Blake2b(entry.Key[:]), entry.KeyHash)
+			if *entry.Key == thor.KeyExecutorAddress {
+				found = true
+				assert.Equal(t, genesis.DevAccounts()[0].Address, thor.BytesToAddress(hexutil.MustDecode(entry.Value)))
+			}
+		}
+	}
+	assert.True(t, found, "executor param expected")
+
+	// preimage not requested
+	res, statusCode := httpGet(t, ts.URL+"/debug/storage/"+builtin.Params.Address.String()+"?revision=0")
+	assert.Equal(t, http.StatusOK, statusCode)
+	var result debug.StorageRangeResult
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(entries), len(result.Storage))
+	assert.Nil(t, result.Storage[0].Key)
+	assert.Nil(t, result.NextKeyHash)
+
+	// structured storage longer than 32 bytes
+	res, statusCode = httpGet(t, ts.URL+"/debug/storage/"+builtin.Authority.Address.String()+"?limit=1000")
+	assert.Equal(t, http.StatusOK, statusCode)
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	long := false
+	for _, entry := range result.Storage {
+		if len(hexutil.MustDecode(entry.Value)) > 32 {
+			long = true
+		}
+	}
+	assert.True(t, long, "authority entries expected")
+
+	_, statusCode = httpGet(t, ts.URL+"/debug/storage/"+builtin.Params.Address.String()+"?limit=0")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	_, statusCode = httpGet(t, ts.URL+"/debug/storage/bad")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func initDebugServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ = chain.New(db, b)
+
+	method, _ := builtin.Params.ABI.MethodByName("executor")
+	data, err := method.EncodeInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := thor.BytesToAddress([]byte("to"))
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+		Clause(tx.NewClause(&builtin.Params.Address).WithData(data)).
+		Gas(300000).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	p := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := p.Mock(b.Header(), b.Header().Timestamp()+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		stage    *state.Stage
+		receipts tx.Receipts
+	)
+	blk, stage, receipts, err = flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddBlock(blk, receipts); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	debug.New(c, stateC, thor.NoFork).Mount(router, "/debug")
+	ts = httptest.NewServer(router)
+}
+
+func httpPost(t *testing.T, url string, obj interface{}) ([]byte, int) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	r, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
+
+func httpGet(t *testing.T, url string) ([]byte, int) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	r, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
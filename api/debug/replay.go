@@ -0,0 +1,140 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/runtime"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+// ReplayedBlock result of re-executing a block.
+// Consistent tells whether receipts of the replay match the ones committed in the block.
+type ReplayedBlock struct {
+	ID         thor.Bytes32  `json:"id"`
+	Number     uint32        `json:"number"`
+	Consistent bool          `json:"consistent"`
+	Txs        []*ReplayedTx `json:"txs"`
+}
+
+// ReplayedTx result of re-executing a transaction.
+// Clauses after the reverted one are not executed, so not present.
+type ReplayedTx struct {
+	ID       thor.Bytes32      `json:"id"`
+	GasUsed  uint64            `json:"gasUsed"`
+	Reverted bool              `json:"reverted"`
+	Clauses  []*ReplayedClause `json:"clauses"`
+}
+
+// ReplayedClause result of re-executing a clause.
+type ReplayedClause struct {
+	Storage []*StorageAccess `json:"storage"`
+	Call    *vm.CallFrame    `json:"call"`
+}
+
+// StorageAccess a storage slot touched by a clause, in order of first touched.
+type StorageAccess struct {
+	Address thor.Address `json:"address"`
+	Key     thor.Bytes32 `json:"key"`
+	Written bool         `json:"written"`
+}
+
+// replayTracer builds call tree and collects touched storage slots.
+type replayTracer struct {
+	*vm.CallTracer
+	storage []*StorageAccess
+	index   map[StorageAccess]*StorageAccess // keyed by address and key only
+}
+
+func newReplayTracer() *replayTracer {
+	return &replayTracer{
+		CallTracer: vm.NewCallTracer(),
+		index:      make(map[StorageAccess]*StorageAccess),
+	}
+}
+
+func (t *replayTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if err == nil && (op == vm.SLOAD || op == vm.SSTORE) {
+		key := StorageAccess{
+			Address: thor.Address(contract.Address()),
+			Key:     thor.BytesToBytes32(stack.Back(0).Bytes()),
+		}
+		access, ok := t.index[key]
+		if !ok {
+			access = &StorageAccess{Address: key.Address, Key: key.Key}
+			t.index[key] = access
+			t.storage = append(t.storage, access)
+		}
+		if op == vm.SSTORE {
+			access.Written = true
+		}
+	}
+	return t.CallTracer.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+}
+
+func (t *replayTracer) result() *ReplayedClause {
+	storage := t.storage
+	if storage == nil {
+		storage = []*StorageAccess{}
+	}
+	return &ReplayedClause{storage, t.Result()}
+}
+
+// ReplayBlock re-executes all transactions of the block clause by clause, with tracer attached.
+func (d *Debug) ReplayBlock(blockID thor.Bytes32) (*ReplayedBlock, error) {
+	blk, err := d.chain.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	header := blk.Header()
+	if header.Number() == 0 {
+		return nil, utils.BadRequest(errors.New("genesis block can't be replayed"), "revision")
+	}
+	rt, err := runtime.NewForBlock(d.chain, d.stateCreator, header, d.forkConfig)
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+
+	result := &ReplayedBlock{
+		ID:     header.ID(),
+		Number: header.Number(),
+		Txs:    make([]*ReplayedTx, 0, len(blk.Transactions())),
+	}
+	receipts := make(tx.Receipts, 0, len(blk.Transactions()))
+	for _, trx := range blk.Transactions() {
+		var tracers []*replayTracer
+		receipt, err := rt.TraceTransaction(trx, func(uint32) vm.Tracer {
+			t := newReplayTracer()
+			tracers = append(tracers, t)
+			return t
+		})
+		if err != nil {
+			return nil, err
+		}
+		replayed := &ReplayedTx{
+			ID:       trx.ID(),
+			GasUsed:  receipt.GasUsed,
+			Reverted: receipt.Reverted,
+			Clauses:  make([]*ReplayedClause, 0, len(tracers)),
+		}
+		for _, t := range tracers {
+			replayed.Clauses = append(replayed.Clauses, t.result())
+		}
+		result.Txs = append(result.Txs, replayed)
+		receipts = append(receipts, receipt)
+	}
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, err
+	}
+	if err := rt.State().Err(); err != nil {
+		return nil, err
+	}
+	result.Consistent = receipts.RootHash() == header.ReceiptsRoot()
+	return result, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	defaultStorageRangeLimit = 100
+	maxStorageRangeLimit     = 1000
+)
+
+// StorageEntry a storage slot. Slots are ordered by hash of key.
+// Key is present only if preimage requested and recorded.
+// Value is hex encoded raw bytes, which may be longer than 32 bytes, e.g. structured storage of builtins.
+type StorageEntry struct {
+	KeyHash thor.Bytes32  `json:"keyHash"`
+	Key     *thor.Bytes32 `json:"key,omitempty"`
+	Value   string        `json:"value"`
+}
+
+// StorageRangeResult a page of storage slots.
+// NextKeyHash is the start of the next page, nil if no more slots.
+type StorageRangeResult struct {
+	Storage     []*StorageEntry `json:"storage"`
+	NextKeyHash *thor.Bytes32   `json:"nextKeyHash"`
+}
+
+// StorageRange returns at most limit storage slots of the account, starting from the key hash.
+func (d *Debug) StorageRange(stateRoot thor.Bytes32, addr thor.Address, start thor.Bytes32, limit int, preimage bool) (*StorageRangeResult, error) {
+	st, err := d.stateCreator.NewState(stateRoot)
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	result := &StorageRangeResult{Storage: []*StorageEntry{}}
+	var decodeErr error
+	if err := st.ForEachStorage(addr, start, func(keyHash thor.Bytes32, key *thor.Bytes32, value []byte) bool {
+		if len(result.Storage) >= limit {
+			result.NextKeyHash = &keyHash
+			return false
+		}
+		// storage value as seen by vm
+		_, content, _, err := rlp.Split(value)
+		if err != nil {
+			decodeErr = err
+			return false
+		}
+		entry := &StorageEntry{KeyHash: keyHash, Value: hexutil.Encode(content)}
+		if preimage {
+			entry.Key = key
+		}
+		result.Storage = append(result.Storage, entry)
+		return true
+	}); err != nil {
+		return nil, utils.StateError(err)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return result, nil
+}
+
+func (d *Debug) handleStorageRange(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	query := req.URL.Query()
+	var start thor.Bytes32
+	if s := query.Get("start"); s != "" {
+		if start, err = thor.ParseBytes32(s); err != nil {
+			return utils.BadRequest(err, "start")
+		}
+	}
+	limit := defaultStorageRangeLimit
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return utils.BadRequest(err, "limit")
+		}
+		if n == 0 || n > maxStorageRangeLimit {
+			return utils.BadRequest(errors.New("should be in range [1, "+strconv.Itoa(maxStorageRangeLimit)+"]"), "limit")
+		}
+		limit = int(n)
+	}
+	preimage := false
+	if s := query.Get("preimage"); s != "" {
+		if preimage, err = strconv.ParseBool(s); err != nil {
+			return utils.BadRequest(err, "preimage")
+		}
+	}
+	header, err := d.getBlockHeader(query.Get("revision"))
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return err
+	}
+	res, err := d.StorageRange(header.StateRoot(), addr, start, limit, preimage)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
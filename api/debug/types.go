@@ -0,0 +1,44 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/vm"
+)
+
+// TracerOption body of tracing a clause of a transaction in block.
+// Target is in form of 'blockID/txIndex[/clauseIndex]'. All clauses of
+// the transaction are traced if clauseIndex omitted.
+type TracerOption struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// TraceCallOption body of tracing a clause call.
+type TraceCallOption struct {
+	Name     string                `json:"name"`
+	To       *thor.Address         `json:"to"`
+	Value    *math.HexOrDecimal256 `json:"value,string"`
+	Data     string                `json:"data"`
+	Gas      uint64                `json:"gas"`
+	GasPrice *math.HexOrDecimal256 `json:"gasPrice,string"`
+	Caller   thor.Address          `json:"caller"`
+}
+
+// StructLoggerResult result of 'structLogger' tracer.
+type StructLoggerResult = vm.StructLoggerResult
+
+// newTracer creates tracer by name. Empty name means 'structLogger'.
+func newTracer(name string) (vm.JSONTracer, error) {
+	tracer, err := vm.NewTracer(name)
+	if err != nil {
+		return nil, utils.BadRequest(err, "name")
+	}
+	return tracer, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dev
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/chain"
+)
+
+const maxMineBlocks = 1000
+
+// Dev serves development only endpoints of solo mode, to snapshot/revert chain,
+// mine blocks on demand and advance block timestamps.
+type Dev struct {
+	chain *chain.Chain
+	solo  Solo
+}
+
+func New(chain *chain.Chain, solo Solo) *Dev {
+	return &Dev{
+		chain,
+		solo,
+	}
+}
+
+func (d *Dev) handleSnapshot(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, &Snapshot{d.solo.Snapshot()})
+}
+
+func (d *Dev) handleRevert(w http.ResponseWriter, req *http.Request) error {
+	id, err := strconv.Atoi(mux.Vars(req)["id"])
+	if err != nil {
+		return utils.BadRequest(err, "id")
+	}
+	if err := d.solo.Revert(id); err != nil {
+		return utils.BadRequest(err, "id")
+	}
+	return utils.WriteJSON(w, convertBestBlock(d.chain.BestBlock().Header()))
+}
+
+func (d *Dev) handleMine(w http.ResponseWriter, req *http.Request) error {
+	body := MineBody{Blocks: 1}
+	if req.ContentLength != 0 {
+		if err := utils.ParseJSON(req.Body, &body); err != nil {
+			return utils.BadRequest(err, "body")
+		}
+	}
+	if body.Blocks < 1 || body.Blocks > maxMineBlocks {
+		return utils.BadRequest(errors.Errorf("should be in range [1, %v]", maxMineBlocks), "blocks")
+	}
+	if err := d.solo.Mine(body.Blocks); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertBestBlock(d.chain.BestBlock().Header()))
+}
+
+func (d *Dev) handleIncreaseTime(w http.ResponseWriter, req *http.Request) error {
+	var body IncreaseTimeBody
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	d.solo.IncreaseTime(body.Seconds)
+	return utils.WriteJSON(w, convertBestBlock(d.chain.BestBlock().Header()))
+}
+
+func (d *Dev) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/snapshot").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleSnapshot))
+	sub.Path("/revert/{id}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleRevert))
+	sub.Path("/mine").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleMine))
+	sub.Path("/increase-time").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(d.handleIncreaseTime))
+}
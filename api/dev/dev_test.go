@@ -0,0 +1,121 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dev_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/dev"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+)
+
+type fakeSolo struct {
+	snapshots int
+	reverted  []int
+	mined     int
+	shift     uint64
+}
+
+func (s *fakeSolo) Snapshot() int {
+	s.snapshots++
+	return s.snapshots - 1
+}
+
+func (s *fakeSolo) Revert(id int) error {
+	if id >= s.snapshots {
+		return errors.New("snapshot not found")
+	}
+	s.reverted = append(s.reverted, id)
+	return nil
+}
+
+func (s *fakeSolo) Mine(n int) error {
+	s.mined += n
+	return nil
+}
+
+func (s *fakeSolo) IncreaseTime(seconds uint64) {
+	s.shift += seconds
+}
+
+var (
+	ts   *httptest.Server
+	solo *fakeSolo
+)
+
+func TestDev(t *testing.T) {
+	initDevServer(t)
+	defer ts.Close()
+
+	var snapshot dev.Snapshot
+	res := post(t, "/dev/snapshot", "", &snapshot)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 0, snapshot.ID)
+
+	var best dev.BestBlock
+	res = post(t, "/dev/revert/0", "", &best)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []int{0}, solo.reverted)
+	assert.Equal(t, uint32(0), best.Number)
+
+	res = post(t, "/dev/revert/1", "", nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = post(t, "/dev/mine", "", &best)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res = post(t, "/dev/mine", `{"blocks": 5}`, &best)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 6, solo.mined)
+
+	res = post(t, "/dev/mine", `{"blocks": 0}`, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = post(t, "/dev/increase-time", `{"seconds": 3600}`, &best)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, uint64(3600), solo.shift)
+}
+
+func post(t *testing.T, path string, body string, v interface{}) *http.Response {
+	res, err := http.Post(ts.URL+path, "application/json", bytes.NewReader([]byte(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if v != nil && res.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res
+}
+
+func initDevServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := chain.New(db, b)
+
+	solo = &fakeSolo{}
+	router := mux.NewRouter()
+	dev.New(c, solo).Mount(router, "/dev")
+	ts = httptest.NewServer(router)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dev
+
+import (
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// Solo chain operations for development.
+type Solo interface {
+	Snapshot() int
+	Revert(id int) error
+	Mine(n int) error
+	IncreaseTime(seconds uint64)
+}
+
+// Snapshot id of snapshot.
+type Snapshot struct {
+	ID int `json:"id"`
+}
+
+// MineBody body of mine request.
+type MineBody struct {
+	Blocks int `json:"blocks"`
+}
+
+// IncreaseTimeBody body of increase time request.
+type IncreaseTimeBody struct {
+	Seconds uint64 `json:"seconds"`
+}
+
+// BestBlock summary of best block after an operation.
+type BestBlock struct {
+	ID        thor.Bytes32 `json:"id"`
+	Number    uint32       `json:"number"`
+	Timestamp uint64       `json:"timestamp"`
+}
+
+func convertBestBlock(header *block.Header) *BestBlock {
+	return &BestBlock{
+		header.ID(),
+		header.Number(),
+		header.Timestamp(),
+	}
+}
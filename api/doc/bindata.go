@@ -215,7 +215,7 @@ func swaggerUiSwaggerUiCss() (*asset, error) {
 	return a, nil
 }
 
-var _thorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7c\x5b\x8f\xdc\xb8\x72\xff\xfb\x7c\x0a\x02\xff\x3f\xa0\x5d\xc0\x33\x4d\x52\xd4\x6d\x1e\x02\xf8\xd8\x9b\xc0\x38\x8b\xd8\xb1\x9d\xbc\x04\x79\xa0\xc8\x62\xb7\x8e\xd5\x92\x0e\xc5\x9e\xe9\xc9\x22\xdf\x3d\xa0\x6e\xad\x5b\xdf\xdb\xf6\x2c\x72\x66\x5f\xd6\x6a\xb2\x58\x55\xfc\xd5\x95\x94\xf2\x02\x32\x5e\x24\x8f\xc8\x7d\xc0\x0f\xe4\x2e\xc9\x54\xfe\x78\x87\xd0\x13\xe8\x32\xc9\xb3\x47\x84\x1f\xc8\x03\xbe\x43\xc8\x24\x26\x85\x47\xf4\x1f\xf0\x6e\xc5\x93\x0c\x7d\x5d\xe5\x1a\xbd\xfd\xf4\xe1\x0e\xa1\x34\x11\x90\x95\x60\x67\x21\x94\xf1\x35\x3c\xa2\xdf\xff\xe5\xd3\xef\x96\x60\xf5\x68\xa3\xd3\x47\xe4\xac\x8c\x29\xca\xc7\xc5\xe2\xf9\xf9\xf9\x61\x99\x6d\x1e\x72\xbd\x5c\x34\x33\xcb\x45\xba\x2c\xd2\x7b\xcb\x00\x64\x0f\x2b\xb3\x4e\x9d\x3b\x84\x24\x94\x42\x27\x85\xa9\xb8\xf8\xfc\xdb\x97\xaf\x6a\x93\xda\x15\x91\xc9\x11\x17\x02\xca\x72\xc0\xcc\x5d\x09\xda\x32\x6d\xd9\xb8\x6f\xd6\x5c\x38\x15\x03\x03\x4a\x69\x2e\x78\x8a\x8c\x65\x3f\xcb\x25\xdc\x19\xbe\x6c\xe6\xd4\xac\xbf\x15\x22\xdf\x64\xa6\x9c\xce\x7c\x5b\x2f\x5a\x2f\x6f\xc7\xa0\x3c\xfe\x1b\x88\x6a\x68\x3b\xfb\xab\xe6\x59\xc9\x85\x9d\x70\x90\x82\x19\x8e\x6b\xa7\xff\x25\xcd\xc5\xb7\x83\x13\xe3\x76\x44\x3b\xe5\xb7\x27\x38\xc2\x2d\xd8\x11\x28\xcd\x97\x13\x46\x15\xe8\xe3\x5c\x2a\xd0\xe3\xc9\xff\x6a\x15\x77\x60\x9e\x55\x2c\xb2\x48\xba\x2b\xb8\x59\x55\xea\x75\x16\x8d\xd2\xca\xc5\x1f\x5c\x4a\x0d\x65\xf9\x3f\x4e\x0d\x99\x82\x6b\xbe\x06\xd3\xec\x9d\xfd\xbb\x47\xff\x5f\x83\x7a\x44\xce\xff\x5b\x88\x7c\x5d\xe4\x99\x15\x71\xb1\x1b\xb7\x78\x5b\x53\xf8\x90\x7d\xe2\x66\xe5\x9c\x3a\xeb\x33\x3c\x25\x16\xd4\x1f\xb2\x7f\xdb\x80\x7e\xa9\xe7\x2d\xc1\xb4\xcb\xb6\x50\x68\xc9\x0d\xa0\x80\x50\xb9\x59\xaf\xb9\x7e\x79\xb4\x53\x46\x10\x40\x12\x0c\x4f\xd2\x66\xa0\x86\xb2\xc8\x2d\xae\x77\xc4\x1c\x8a\xb1\xb3\xfb\xe7\x48\x73\x1f\xff\xda\xfb\x45\xe4\x99\x81\xcc\xf4\x07\x23\xc4\x8b\x22\x4d\x04\xb7\xc3\x17\x7f\x2b\xf3\x6c\xf8\x2b\x42\xa5\x58\xc1\x9a\x8f\x9f\xa2\x59\x8d\xd4\x63\xcb\x45\x23\x5e\xad\x86\x22\x2f\xcf\xd6\x43\x01\x5a\xe5\x7a\x5d\x71\xac\xb9\x30\x48\xf0\x34\x45\x79\x36\x52\x4e\xa7\x95\xbf\x6f\xa0\x34\x7f\xc9\xe5\xcb\x8e\xf8\x40\x0d\x5c\x2f\x37\x6b\xcb\x22\xe2\x99\x44\x90\x3d\x25\x3a\xcf\xec\x83\x6e\xb8\xa5\x91\x68\x90\x8f\xc8\xe8\x0d\xdc\x1d\x50\xd9\x61\x85\xcd\xab\xeb\x90\xb2\xde\x35\x32\xbe\xe3\x69\xea\xfc\xb9\xf6\xb9\xcf\xfa\x67\x28\x37\x69\xb5\xe5\x3b\x83\x6c\xcd\xb0\x87\x80\xa9\x49\x5e\x6a\x5e\x57\xa3\x49\x21\x09\x45\x9a\xbf\x24\xd9\x12\xf1\xee\xc7\x7f\x60\xea\x75\x63\x6a\xe7\xe4\x17\x22\x97\xf0\x67\xf5\xf4\x1a\x8c\x4e\xe0\x09\x90\x15\xc2\x62\x71\x8f\x67\x7b\x35\x7b\x56\xe8\xbc\x00\x6d\x92\x3e\x2f\xfd\xa5\x24\xcc\x3d\x47\xc8\xbc\x14\xf0\x88\x4a\xa3\x93\x6c\x39\x19\x00\x5b\xbe\x2e\xd2\xd9\x99\x15\x45\xf4\x4f\xf7\xb3\x44\xf1\xd6\xc7\xf6\x3f\x86\x3d\xea\x63\x8c\x43\xac\x24\xc6\x9c\xf8\x9e\x4f\x03\x1e\xf0\x80\xba\xd8\x0b\x29\x16\xd4\x95\x2e\x07\x2a\x45\xe8\x73\x49\x5c\xec\xf9\x84\xd3\x90\x46\x32\x0c\x44\x20\xe2\x90\xb9\x9e\xeb\x7b\x2c\xa2\xb1\x24\x1e\x0b\x21\x0e\x20\x50\x02\x2b\xd7\x77\x69\x0c\x11\xc6\x34\xda\x87\xbe\xd2\xe4\x9a\x2f\x61\xf1\xc7\x37\x78\xf9\xe1\x09\xc7\x97\x7a\xf1\xbf\xc2\xcb\xcf\xc6\x6f\xa3\x06\xf4\xc4\xd3\xcd\x0c\x90\x91\xca\x35\x5a\x26\x4f\x90\xa1\x6f\xf0\xf2\x67\x83\x75\x25\xd4\x6d\x71\x5d\x93\xdc\x0f\x6c\x7c\xdd\x1f\xb9\x43\x68\x51\xe5\xe5\xe5\x34\xf8\x8e\x37\xb7\x97\xe1\xf7\xb6\x56\x25\xa9\x01\x3d\x4c\xee\xd1\xa5\xa1\xfb\x9f\x2b\x62\x1f\xb5\x04\x3d\x8a\xde\x27\x4f\xee\x2c\x64\x30\xfd\x78\x80\xae\x05\x68\xa4\x11\x3a\x31\xa0\x13\xfe\x0a\x82\x73\xa5\xf5\x5a\xb4\x57\x18\x9b\x6b\x5c\x73\xad\xf9\xcb\xe4\xb7\xc4\xc0\x7a\xd6\x4e\x0e\x89\x5b\x4b\x0a\xb2\x12\xdb\x0a\xbc\x68\x8b\xbf\x13\x10\x3a\x2c\x26\xa7\x20\x1d\xd7\x91\xe8\xf6\x38\x3d\x0e\xb4\x3e\x13\xaf\x10\x6f\xad\x0e\xff\xef\x41\xae\x95\xbc\xce\x20\xeb\x06\xc7\xe2\x0f\xdd\x84\xc0\x2b\x82\xf6\x2e\x8a\xee\x82\xef\x81\x20\xda\x6b\xbe\xf4\x20\xec\x74\x31\xb4\xe2\x0c\xc5\x2f\xe8\xc3\xfb\x37\x28\xdb\xac\x63\xd0\x6f\x50\xae\x91\xe3\xc4\x50\x1a\xc7\xa9\x82\xa8\x59\x01\x4a\xb9\x81\xd2\xa0\x3c\x83\x57\xb8\x8d\x87\x76\xa4\xd2\x40\xbd\x0d\xfd\x06\xd5\xe2\x8f\x44\x5e\xb1\x0d\x5f\xb7\x1f\xde\x9f\x9b\xff\xf0\xe7\x91\x7d\xdf\x3c\x65\x9a\x74\xea\x7a\x7b\xde\x0b\xfb\xdd\xee\xf7\x14\x62\x31\x90\x98\x12\x25\x12\xfd\x92\x28\xa4\xf9\x73\xe5\x2d\xd0\x9b\xdd\x68\x6e\x9f\x76\x44\x7a\x73\x7f\x7d\x7d\x88\xe0\x69\xfa\x51\xcd\x19\xef\xbc\xce\x07\x0e\xab\x16\xca\x39\x7b\xf2\x67\xfe\xfc\x75\xbb\x07\x69\x0b\x0d\x02\x92\xc2\xfc\x58\xc4\xdd\x10\x3e\xb3\x98\x69\x84\xb2\xd8\xe9\x3f\xfe\xf0\xfe\xf5\x01\xe2\xe0\xc6\x35\x7b\xd3\x65\x08\x8d\x0e\x4e\x4c\x12\xf6\x68\xac\x84\x4c\x36\x76\xd4\x0d\x3a\x14\xd8\x7f\x5e\x98\xee\x80\xfb\xca\xf6\xec\x70\x85\x94\xc8\xdb\x96\x47\x89\x3c\x50\x1b\x31\x09\x01\x51\x54\x7a\x61\xc8\x79\xc8\x09\x70\x8c\x15\x84\x2e\xa1\x32\xa2\x91\xef\x4b\xce\x28\x93\x51\xe4\x46\xdc\x23\x44\x09\x1c\x43\x48\xc0\xf7\x14\x97\x1e\xe5\x2a\xb4\xd0\xca\x72\x09\x8b\x0c\xcc\x73\xae\xbf\x2d\x0a\xe8\x8c\xff\x80\x45\x76\x87\x12\x73\x96\xd8\x90\x42\xa5\xe1\x66\x53\xbe\xbe\xed\xbb\x28\x81\xfa\x04\xa0\xbf\x18\x6e\x4a\xe7\x6e\xf7\xab\x25\xd2\x0c\xa8\xe9\x35\xbd\x81\xae\xb5\x3b\x03\x94\x98\xa7\x3c\x13\x83\x9d\xde\x83\x8c\x81\x3a\x56\xb0\x45\x55\xcb\x36\x57\xc8\xe4\xdf\x20\x6b\x09\x75\x13\x20\x03\xbd\x7c\xb9\x86\xae\x86\x35\x4f\x32\x90\x88\xaf\xeb\x7e\x85\x6a\x88\x76\x93\x57\xbc\x7c\x37\xea\x6b\xd5\x8b\xc4\x79\x9e\x02\x6f\xfd\xc8\x04\xcd\xad\xd0\xc8\xc1\x5b\x09\x38\xf6\x63\x97\x07\x3e\xb3\xe5\xb9\x33\x16\xe0\xe0\x98\x96\x01\xa4\x78\x5a\xd6\xb2\x57\x89\xd4\x3b\x0b\x90\xed\x41\xc5\x0f\xed\xf2\x14\xdd\x24\x12\x32\x93\xa8\x04\x74\xa5\xf5\x55\x9b\x96\xfe\x12\xbf\x18\x28\x5d\xfa\x6b\x37\xb1\xce\x50\xa7\xf4\x93\xcc\xc0\x12\x74\xef\xb9\xd5\x35\x37\x8f\x68\x93\x64\xc6\xa5\xfb\x56\xae\xe9\xfd\xb2\x82\x64\xb9\x32\xbf\x0e\x56\xdf\x25\x3a\xc9\x1a\x4a\xc3\xd7\xc5\xb9\xcb\xfa\x6c\xdf\xb2\x9b\x2c\xd9\xee\xe8\x4e\x97\xfd\xba\xfd\x41\x7a\x9e\x86\x26\x84\x72\x9d\x2c\x93\xec\x5c\xda\x96\x5a\x9e\x01\x7a\x5e\xe5\xa8\x4c\x96\x16\xdd\x73\x0b\x54\x20\x3a\x24\xd5\xcf\xd8\xe1\xef\x89\xd8\x32\xf9\xef\x19\x33\xbe\x54\x1a\x4b\xbe\x22\x39\x5c\xd6\xac\xb8\x41\x49\x89\x3e\xff\xfe\x09\x41\x26\x72\x09\xb2\xa3\x50\x70\x0d\x99\xf9\xf0\xfe\x5c\x11\x3f\xbc\xb7\x6b\xd4\xb3\xf7\x4a\xf7\x13\x6c\xc3\xfe\x2d\x79\xf9\x7b\xb2\x4e\xcc\xed\x56\x5d\xf2\x12\xa5\x96\xe4\xfc\x82\x31\x64\xa0\x12\x91\xd8\x00\x7c\xa6\x1e\x9b\xae\x7d\xbf\x3f\x6d\xf2\x3a\x73\xee\xea\x6f\x0d\xcf\x5c\xcb\xbe\x78\xff\x5e\xc2\x0c\x28\x4f\x96\xce\xe4\x86\xa7\x5f\x44\xae\xcf\xc6\x5e\x9f\xc8\xb6\xfc\x9c\xe7\x33\x4a\x3e\x2c\xb0\xce\x73\x63\xe3\xc7\xaa\x52\x65\x2f\x41\x46\x49\x76\xd8\x54\x0c\x37\x70\xf5\x8a\xed\x79\x49\x4d\x6e\x66\x99\xa6\x68\xb9\xa9\x6c\x1d\xd1\x59\x0f\xb0\xcc\xe6\x3c\xda\x05\xfe\x34\x29\x07\xca\xa3\x78\xb7\x4a\x52\x7e\xd5\x9b\xec\xdb\xb1\x8c\x61\xb2\xce\xf3\x0a\xcc\x0a\x74\x43\x37\x29\x6d\xf1\x91\xf5\x82\xdf\xa4\x1c\xea\xd3\x1e\x37\xce\x46\x0e\xa4\x1c\x23\xa0\x37\x74\x26\x39\xdc\x9b\xb8\xcf\xf8\xa5\xbe\xee\xc7\x2a\x9f\x64\x45\x4d\x4c\x41\xa4\xef\xf1\x6d\xfa\xd3\x9e\x5c\x08\xe6\x85\x11\x8b\xa2\xd0\xe3\xbe\x0c\xfd\x38\x20\x6e\xe4\x47\x38\x0e\x43\x42\xa4\x74\x63\xe6\xb3\x40\x60\x2a\x99\x62\x44\x48\x50\x71\x20\x5d\xea\xd2\xc0\x19\xba\x79\x44\xdd\x70\xea\x77\x7b\x0b\x51\x8e\x45\x10\x50\x12\x44\x9c\x33\x57\xc4\x7e\x1c\x7b\x9e\xc4\xb1\x4b\x5c\x3f\x52\x11\x44\x14\x13\x26\xc2\x90\x7b\x38\xa6\x22\x8e\x22\x15\xc5\x40\x84\x27\x9d\x19\x8f\x8b\x88\x47\x5d\xe2\xf9\x34\x20\x53\xc7\x88\x48\xb3\xe4\xac\x0b\xb3\x2c\x05\x9e\x1f\xc8\xd0\x8d\x83\x38\x94\x21\xe6\x52\x8a\x98\x86\x84\x07\x44\x7a\x4c\x89\x20\x76\x5d\x9f\x29\x05\xbd\xa5\x5b\xb7\x84\xf0\x9c\x9f\x41\x04\x93\x89\xeb\xb0\x0b\x11\x29\x04\x93\x10\x4a\x10\x81\x27\x03\xce\xe3\xd0\x8b\x3d\x3f\x88\x7d\x21\x24\x23\x5c\xba\x84\x32\x8f\xc4\x11\x0b\x79\xc0\x88\xab\x30\x27\x8c\x2a\xc9\xb0\x64\x91\xcb\xfa\x4a\xee\x1c\xc4\x6d\xe9\x0e\x3c\xc2\x8d\x59\xae\x8d\xff\x32\x85\xb7\x36\x3d\x6c\x08\xec\x33\xc9\x7b\xbb\xc8\xb5\x75\x6a\xbd\x78\xd5\x10\x38\x94\xa5\x69\xfe\x7c\x4d\x01\xd4\xe4\x28\x33\xe9\xe7\xc4\x76\xed\x4a\xc3\xb2\x1c\x6f\x55\xe8\x47\x21\x89\x79\x88\x31\x97\x5c\x46\x11\x3b\xe5\x7c\x32\x60\xbe\x0a\x29\x0d\x08\x0e\x31\x26\x21\xf5\x28\x0e\xed\xff\x09\x1c\x87\x8c\xb0\x20\xa2\x22\x62\x6e\xe4\x45\x0c\x47\xa1\x4b\xdd\x08\x63\xf0\x59\x80\x03\x46\x85\x0c\x83\x00\x44\xa4\xa2\x08\xfb\xb1\xe0\xd8\xf3\x08\x06\x46\x89\x72\x63\x4c\x5c\x90\x94\x12\x97\x32\x08\x02\xc1\x09\x96\x2e\xf3\xfd\xd8\xa5\x31\x09\x31\x16\x01\x05\x42\x03\x12\xc5\x94\xb8\x8a\x48\x26\xdc\x00\xbb\xd8\x73\xa3\x48\x4a\x1a\x70\x15\xf9\xd4\xa7\xb6\xfc\xaa\x44\x7c\x97\xf2\x4d\x09\x87\x54\x6f\xf2\x73\x35\xef\x68\x10\x49\x91\x40\x5d\x69\x8a\x6a\x85\x37\x28\xdb\xa4\x69\xd5\xdd\xef\xee\x26\xd5\x77\x92\xd6\x76\x5c\xe7\x52\x77\x60\x9c\x1c\x48\x5f\x56\x4d\x9b\x1c\xc5\xd0\x9d\x5b\xe9\x5e\xa2\x2a\xb9\xe1\x67\xe7\xe1\x59\xb1\x31\xd5\xcc\x86\xe5\xbd\x31\xc0\xe4\x17\x1a\x61\x73\x6a\x6e\xbd\x42\xaf\x3e\xae\x98\xad\x74\x58\x17\x6c\x3b\x20\xff\x8c\x92\xed\x3b\x17\x19\xfd\x60\x7b\xa8\xd4\x10\x2b\x9e\x64\x5f\xf9\xf2\x5c\x56\xc2\x7d\x9c\xa4\xbc\x34\x35\x3b\xb9\x42\x4b\xc8\xa0\xec\x32\xa0\xae\xc7\x8c\xea\x07\x9f\x41\x9d\xab\xdb\xb0\x22\x5d\xa2\x42\x83\x4a\xb6\x76\x89\x32\x5f\xc3\x94\x3e\x6c\x8b\x44\xf3\xfe\xde\x5e\xaf\x63\x67\x47\x14\x69\x48\xb9\xb1\xc5\x40\x7b\x15\xfa\x33\xa8\x37\x36\x59\xde\x64\x49\x53\x7a\xed\x80\x57\x9b\xef\x09\xb9\xd8\x4c\x82\x75\xf0\xf2\x5b\x45\x77\x10\xec\x3f\xe9\x44\xc0\xbb\x7c\x4e\xb1\x17\xee\xa7\xc8\x41\xd9\x1c\xc4\xba\x98\x4d\x69\x03\x40\x8e\x04\x4f\xc5\x26\xb5\xa9\xba\x85\x9a\x4a\x32\x9e\x56\xd5\x58\x61\x57\xef\xb3\x73\xbb\x62\x6f\xcd\xb7\xbd\xd6\x9b\x5d\x4c\xf0\xcc\xba\x25\x91\x67\xe5\x66\x5d\xf3\x05\x5b\x10\x9b\x8a\xab\x2a\x29\x9e\x1a\x9d\x84\x02\x32\x59\x7e\x3c\xbb\x55\x32\x6a\x32\x37\x09\xed\xc8\xce\xf2\x0c\x3d\xaf\x12\xb1\xaa\x7e\x10\x1b\x5d\x95\xe1\xfd\x01\xcd\xf2\x03\x52\x33\x0d\xb3\xfc\x94\x1e\xe8\x77\x6d\xf9\xa0\xd6\x44\xfb\xf4\x8f\x9e\xd8\x36\x0d\x30\x67\x9f\x3f\x6f\x32\xf8\xdb\xe4\x3b\xa8\xcb\xe0\x49\x88\xa7\xee\xac\x57\x38\x74\xbe\xa6\x5f\x3e\xb4\x94\x9d\x39\x97\x81\x5c\x3c\x31\x5e\xf4\x9f\xff\x35\x6f\x68\x88\xd0\x70\x80\x79\x44\x49\x3f\x89\xdf\x61\x0e\x39\x36\xf8\x38\xa3\x8d\xae\x7a\xba\x23\xc1\x9d\xf1\x36\x5f\x16\x07\x27\x5b\x78\xf3\x1a\x6a\xae\x50\x3b\x54\xf0\x54\xd7\x7a\x0e\x85\xdb\xa6\xf5\x72\x09\xae\x7b\x5d\x9b\x2e\x3f\xaa\xed\xb1\xd0\xb9\xdc\x08\x28\xab\x61\xf5\x45\xaf\x69\x35\x6e\xf2\x22\x11\x97\x39\xe9\x59\x0e\x4f\xc8\x8d\x26\x16\xd2\x4a\x7f\xd9\x76\x4f\x25\xb8\x61\x7d\xd1\x89\x54\xe1\x55\x2a\xe5\xec\xb2\x28\xb5\xeb\x95\xcc\xed\x69\x09\x99\x3c\xbf\x9b\xd2\x6e\x67\x95\xbd\x58\x12\x65\x9d\x8e\x96\xfd\x1a\xb0\xce\x91\xaf\x22\xdd\xb4\xf5\x26\xd4\xeb\x68\x73\x36\xe9\x2e\x46\x0d\xc8\x4d\x76\xba\xd1\xc9\x65\x1b\xbd\x13\xbc\x9a\xef\xba\x71\x40\xfd\x88\x31\x57\x04\x58\x02\xf1\xe3\x58\x45\x31\xf6\x89\xe7\xe2\x20\x0c\x59\x2c\x84\xe7\xbb\xbe\x33\x16\x6d\xef\x69\x52\x73\xb8\x7e\x68\x4f\xaf\xef\x77\x5a\x27\xca\x5f\x2e\xc7\x45\xaf\x39\x6b\xa3\x59\xc1\x13\x59\x27\x28\x4b\xbe\xdb\x44\xfb\xf4\x9a\x02\x68\xb7\x9d\x15\xfd\xd1\x91\x5f\xdd\x03\xbe\x0d\xfd\x51\x3f\x59\xc3\x13\x68\x33\xa7\xe0\x23\xcd\xc1\xea\x06\xd0\x1a\x78\x56\x4e\xf2\x93\x67\x5e\x76\x74\x6f\x17\xe6\x11\x32\xdb\x53\xe7\x77\x87\x64\xbd\x00\xb7\x31\xc5\xc6\x5c\xe6\x77\xf7\x5f\x36\x68\x03\xc0\xdb\x69\x38\x39\xb8\x51\x33\x0a\x9d\xbd\x5f\x50\xd7\xdd\x20\x77\x91\xa6\x81\xe5\x1b\x94\xd4\x89\xa1\xc8\x75\x7d\xae\x2f\x93\x6c\xd9\x64\x11\xb6\x08\xe3\x33\xd4\xe6\xca\xf9\x7a\xc6\x68\x70\xff\xaa\xf6\x54\x9a\x1b\xde\x89\xec\xae\xdf\x0e\x56\x19\xde\xc4\xfd\xae\x0c\xf4\x2f\x63\xa2\x39\x07\xda\x75\x36\x87\xd9\x56\xe7\x55\x2e\xf3\xac\x95\xbf\xa8\xa6\x52\x57\x72\x45\x9d\xb1\xad\xef\xf9\xad\x31\xd6\xde\xd1\x3b\x7a\x95\xf9\x17\x9a\x98\xeb\xcd\x93\xf2\x2b\x73\xd6\x19\x7f\x70\x8f\x9c\xb1\x3d\x3b\xe7\xd0\x76\x9c\x5e\xdb\xa7\xfd\x9b\x37\xa5\xfb\x2b\x53\xb0\x4e\xc7\x75\x2a\x36\xef\x3c\x6e\x72\x35\x69\xf8\x57\x67\x66\x3f\x62\xb5\xbd\x4e\xe0\xfe\xba\x9c\xa6\xfd\x1b\xe5\x36\x17\xd3\xe9\xe5\x38\x84\xba\x4d\xb6\xda\x7f\x5d\xf0\x50\x76\x73\x51\xe3\x74\x94\xfa\x7d\xbf\xb6\xe9\xa0\x03\x2c\x78\x0f\xd8\x37\x6d\xb9\x38\x79\xf5\x3f\x3c\x7d\x63\x45\x29\x0b\x10\x89\x7a\xa9\x1a\x31\x4b\x5e\x56\x4c\xd4\xfd\x96\xc1\xc5\xdb\xb6\x34\x3e\xbb\xe1\xbd\x5b\x8c\xc7\x65\x9e\x6e\x0c\xec\x5a\x4a\xbd\x56\x1a\x4f\xd3\xf3\x53\xc6\x79\x49\xaa\x28\x5d\xd1\xdb\x1b\x64\x76\x8d\xe4\x49\x1f\xd9\xc1\x5b\xcf\xf7\x3d\xe6\xfa\xa1\x4f\xfc\xc8\x07\x8a\x3d\xe6\x87\xbe\x0a\xe8\x14\x6b\xf5\xab\xa9\x87\x10\x77\x09\x24\xaa\x66\x4e\xe5\x2e\xab\xe9\xdd\xb0\xa9\x6b\xbb\x49\xbb\x71\x94\x13\xcc\x3a\x82\x9b\x2c\x34\x8e\xfd\xb7\xa8\x36\x66\xee\x8e\x54\xc5\x82\xdc\x58\x0d\xef\x90\x7c\x41\x02\xfe\xb4\xfe\x4d\xeb\xfc\x18\x28\x27\xd8\xea\x60\x44\xb0\xeb\x79\x3e\x0f\x5c\x41\x30\xb8\xa1\x52\x40\x95\x60\x9c\x7b\x58\x89\x48\x32\x9f\x4b\x4c\x58\xa8\x70\x00\xd4\x67\x24\x00\x42\x82\x58\x12\x10\x10\xc9\x88\x85\xb1\xe7\x8c\x37\xbe\xdf\xaa\xda\xed\xd2\xa8\x81\x35\x97\x3c\xed\xcb\x63\x5a\x09\x91\x53\xaf\xf5\xb1\x18\x9c\x64\xce\xe1\x39\x57\xaa\x84\x13\x2e\xfb\xa4\xc7\xef\x04\x7d\xe6\xd9\xf2\xe0\xf1\xda\x26\x9b\x23\x31\xb1\x1d\xc8\x36\xeb\x21\x08\xef\x47\x57\x86\xea\x67\x36\x7b\xea\x1e\x29\x9d\xaf\xaf\xba\xd4\x73\xf1\xe4\x09\x60\x2a\x31\x47\x1c\x57\xec\x21\x82\xfb\xc7\xfb\xf5\x45\x82\xea\xc1\x57\x9b\x86\x7c\x81\x83\x9e\xa7\x4a\x55\xf0\x51\xfd\x55\xc3\xc8\x69\xc3\xe8\x69\xc3\xdc\xd3\x86\xb1\x73\x2d\xab\x91\xe8\x76\xb6\xd5\x7b\x07\xf3\xf0\x09\x7b\x0f\xa8\xe8\xe8\x05\xfe\x6c\xd9\x0b\x6c\x79\x31\xb9\x1c\x70\x68\x76\x63\x81\xa3\xde\xdf\x17\xf8\x0e\x6e\xbf\xc5\x50\xbd\xd6\xe0\xfd\xcc\xa3\xb0\xfa\xb1\xed\xd4\x9f\xdd\xcc\x98\x07\xe2\xb4\x21\x7b\x3b\x87\xdf\xc5\x90\xdb\x95\x6f\xff\xa8\x59\xcf\xab\x39\x9a\x8a\xf4\x98\x93\xdd\x7e\x3c\xed\xbc\xee\xc4\x5e\xf9\xa9\xad\xef\x29\x24\x5b\x46\x2e\xab\xae\x6e\xd9\xb6\x3e\x6b\xfe\xf0\xb5\xe4\xd7\xea\x85\x77\x60\xb8\xbd\x1f\xde\xd1\x1e\x7a\xe2\x1b\x9e\xc0\x9c\x7e\xa0\x72\x5a\x81\xfc\xca\xdc\xf1\x4f\x03\x2f\x1a\x94\x92\x91\xe2\xaf\xfa\x8c\x76\xaa\xf6\xd7\xe4\x6f\xbb\x97\xcd\x0e\xe1\xbd\xfa\x3c\xe0\x51\x74\x42\x69\x2a\xd0\x9d\xf0\xa2\xc5\x39\x97\xf3\x0b\x00\x7d\x02\xc9\x0c\xaa\x6e\xe6\xd1\x71\x49\x16\xe7\x9b\xec\x84\x3a\x54\x6e\x4e\xbb\xf1\xd4\xda\x05\x1a\xaa\x0b\x39\x66\x95\xeb\xc5\x13\x79\xc0\x0f\xf8\xde\xf7\x43\x1c\x47\xe1\xbd\x84\xa7\x45\x9a\x64\x9b\xed\x62\x99\x93\x07\x82\x1f\x5c\x67\x56\x81\x2d\x64\xc3\x20\x76\x39\x93\x4c\x48\x45\x84\xf0\xa8\xf4\xfc\x38\x0a\x30\x53\x4c\x90\x50\x61\x8a\x81\xc4\x2c\x94\x71\xac\x18\xa7\xae\x24\x00\x4c\x11\xc5\x3d\xa5\x22\xe6\xcc\xa9\x1b\xf9\x21\x8b\x82\xb1\x72\x91\xe3\x61\x20\x94\x72\x0f\x7b\x00\x9e\x17\x87\xcc\x75\x09\xf6\x43\x2e\x94\x0c\xbd\x00\xdc\x80\x4b\x2f\x54\xcc\x77\x39\x56\x3c\x8e\x38\x57\x8a\x0a\x02\x2c\xa6\x40\x25\xa5\x1c\x02\x22\x05\x61\x4a\x72\xe5\x03\x70\x19\xb0\x58\xba\xca\xc7\x5e\xc4\x7c\xc6\x38\x77\x3d\xe1\x85\xa1\x8a\x04\xf7\x63\x70\x5d\x46\x80\x0a\x20\xa1\x94\x82\x11\xd7\xa5\xc4\x99\x6c\x24\x72\x08\x0d\x1f\xc8\x83\x1b\x3d\x10\x8a\x1f\x09\xa1\x6e\x2f\x55\x6b\xb7\x71\x54\x5a\x77\x9b\x86\xaa\x5b\x24\xe3\x97\xcc\x07\xdf\x7b\x6a\x37\xb6\xde\xae\xa6\x51\x7d\xd7\x92\x7f\x44\x05\x37\xab\xe6\x9f\x47\x4e\x4c\x9b\x51\xb3\xaf\x2f\x8f\x5f\x0d\x3d\x90\x55\x9c\x6f\xbd\xbb\x0f\x2a\x0c\x85\xd9\x7d\xa5\xc0\x0a\xf2\x77\x3b\x60\x4e\x92\xf6\xcd\x87\xe1\xf7\x0d\xfa\x87\x9c\x0f\x13\xd1\xfa\xfa\x9e\x97\xad\x6f\x48\xa3\xf7\xef\x47\x5c\x36\x3f\x9e\xc2\x6a\x73\x3d\xad\xbe\x99\x58\x3b\x60\x94\x6b\xf4\xe1\xfd\x43\x65\x37\xbb\x97\x37\x78\x59\xdf\x5f\x4b\x14\xca\xd7\x89\x31\x20\x1f\x4e\xdd\x89\xe1\x87\x45\x8e\xf2\xba\x0f\x1f\xce\x0c\xaf\x6f\xaa\x3b\x6e\xa3\x8f\x8a\xa4\xbc\x34\x1d\xef\xce\xad\x40\x64\x17\xa8\x9e\x8d\x3f\x53\xf6\x78\x02\xef\x66\x05\xe8\x1b\xbc\xfc\x52\xe4\x65\x52\x7d\xd7\xa2\xf7\x59\xde\xf6\x7a\x40\xf3\xd1\xb1\x43\xfc\xd6\x3a\xdb\x7d\x73\xec\x4c\x23\xb8\xee\x2b\x5c\x98\xf4\x33\xba\xe1\x07\xac\x8e\xd9\xfc\x5e\xfc\x9d\x60\xf4\xc7\x2d\xe3\x46\x56\x3f\xfd\x5c\xd2\x50\xac\xdc\xfe\x72\x8a\x50\xd5\x40\x2b\x52\xfd\xdd\xa4\xf2\x5a\x91\xa6\x4d\xc1\x7b\xc4\x4b\x31\xf8\xb7\x65\x60\xac\x81\x76\xcc\xee\x03\x1f\xa7\x60\x75\xf2\x1a\xd4\x71\x44\x26\xf2\xb2\xfd\x89\x62\x21\x7c\x8f\xfa\x3c\xf0\x39\x78\x3e\xa6\x8c\x29\x3f\x0a\x43\xec\x09\x81\x31\x89\x82\x80\x32\x5f\xc4\x11\x15\x34\x66\x8a\x00\x8d\x03\x4e\x31\x03\xc6\x3c\x86\x23\xe0\xce\xdd\xff\x06\x00\x00\xff\xff\xa8\x71\x4c\x7e\x90\x5b\x00\x00")
+var _thorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x7d\x59\x93\xdb\x46\xd2\xe0\x7b\xff\x0a\x44\xec\x46\x40\xde\x65\xb3\x71\x11\x04\xf5\xb0\xb1\xba\xec\xe9\x18\xcf\xb8\x3f\xb5\xec\x97\x89\x89\x2f\x0a\x40\x81\xc4\x08\x04\x68\x00\xec\xc3\x33\xfb\xdf\x37\xb3\x0e\xa0\x70\x90\x04\x41\xb6\xd4\x2d\xd9\x8e\xb0\x25\x10\xa8\xca\xaa\xca\xab\xf2\xcc\x36\x34\x25\x9b\xf8\xb5\x66\x4f\x8d\xa9\x79\x11\xa7\x51\xf6\xfa\x42\xd3\xee\x68\x5e\xc4\x59\xfa\x5a\x83\x87\x53\x03\x1e\x94\x71\x99\xd0\xd7\xda\x6f\xf4\xdd\x8a\xc4\xa9\xf6\x69\x95\xe5\xda\x9b\x9b\x6b\xf8\x25\x89\x03\x9a\x16\x14\xbf\xd2\xb4\x94\xac\xe1\xad\x9f\x7f\xba\xf9\x19\x07\x64\x8f\xb6\x79\xf2\x5a\xd3\x57\x65\xb9\x29\x5e\x5f\x5d\xdd\xdf\xdf\x4f\x97\xe9\x76\x9a\xe5\xcb\x2b\xf1\x65\x71\x95\x2c\x37\xc9\x25\x02\x40\xd3\xe9\xaa\x5c\x27\x3a\x7c\x18\xd2\x22\xc8\xe3\x4d\xc9\xa0\xf8\xf8\xe1\xf6\x53\xb4\x4d\x70\x46\xad\xcc\x34\x12\x04\xb4\x28\x1a\xc0\x5c\x14\x34\x47\xa0\x11\x8c\x4b\x31\xe7\x95\xce\x00\x68\x8c\x94\x64\x01\x49\xb4\x12\xc1\x4f\xb3\x90\x5e\x94\x64\x29\xbe\xe1\xa0\xbf\x09\x82\x6c\x9b\x96\x45\xf7\xcb\x37\x7c\x52\x3e\x3d\xbe\xa3\x65\xfe\xbf\x68\xc0\x5e\x95\x5f\x7f\xca\x49\x5a\x90\x00\x3f\xd8\x3b\x42\xd9\x7c\x4f\x7e\xfe\x16\xa0\xfb\xbc\xf7\x43\x5f\xbe\x21\x3f\xf9\x70\x47\x0f\x40\x4b\xf1\x0d\x58\xf7\xb2\x03\x68\x04\xfb\x75\x10\x4a\x78\xa9\xfd\xf1\x6d\x49\x7a\xa7\x5c\x2e\x73\xba\x24\x25\xd5\x0a\x78\x21\x2e\xca\x38\x28\xb4\x2c\x6a\x7f\xfd\x77\xdc\xf6\x3d\xb3\xe2\xb1\x68\x88\x87\xea\x8c\x5b\xbf\x7a\xb7\x67\x66\xf1\xb3\x4f\xf1\xfb\x80\xe1\x44\x48\x4a\xa2\xdd\xc5\x44\xbb\xa7\x7e\x01\x7b\x46\x4b\x65\xb8\xf7\xd4\xdf\x2e\xbb\xc3\xc0\xa6\x04\x54\xfb\xed\x6f\x1a\x7d\xa0\xc1\x16\x9f\xa9\x88\xb1\x45\xa4\x89\xcb\xc7\x83\xc7\xa3\x6d\xf2\x6c\x93\x01\x3e\x6a\x01\x49\xc3\x18\x20\xa1\xc5\xc5\x86\x94\x2b\x86\x68\xfa\x95\x40\x9f\xe2\xea\xdf\x24\x0c\x73\xf8\xf2\xff\xe9\x9c\x78\x36\x24\x87\xa9\x4a\x81\xc5\xf8\xcf\xa5\xf6\x3f\x73\x1a\x01\x2a\xff\x8f\xab\x20\x5b\x6f\xb2\x14\x0f\xfb\xaa\x7e\xef\xea\x0d\x1f\xe1\x3a\xbd\x81\xf1\xf5\xa1\x5f\x7d\xa4\x77\x31\x92\xf7\x75\xfa\x5f\x5b\x9a\x3f\xf2\xef\x96\xb4\x94\xd3\x4a\xa2\x90\xc3\x35\x88\x42\xd3\x8a\xed\x7a\x4d\xf2\xc7\xd7\xf8\x49\x8b\x18\x60\x63\x4a\x12\x27\xe2\x45\x00\x0d\x66\x07\x0a\xaf\x07\xd3\x2d\xc3\xd0\xeb\xbf\xb6\x76\xf2\x97\xbf\x2a\xbf\x04\x59\x5a\x02\xe4\xea\xcb\x9a\x46\x36\x1b\x60\x1b\x04\x5f\xbf\xfa\x57\x01\xdf\x34\x7e\x05\xd8\x82\x15\x5d\x93\xf6\x53\xad\x77\x47\xf8\xbb\xb0\x89\x7c\x09\x7c\x1b\xe0\xe4\x8e\xde\x87\x0d\xcd\xa3\x2c\x5f\x33\x88\x01\x87\x4a\x38\xf8\x24\xd1\xb2\xb4\xb5\x39\xd5\xae\xfc\xbe\xa5\x45\xf9\x36\x0b\x1f\xeb\xc1\x1b\xdb\x40\xf2\xe5\x76\x8d\x20\x6a\x80\x40\x1a\x4d\xef\xe2\x3c\x4b\xf1\x41\xf5\x3a\x8e\x11\xe7\x34\x7c\x0d\x44\xba\xa5\x17\x7b\xb6\x6c\xff\x86\xf5\x6f\xd7\xbe\xcd\x7a\x27\xd6\xf8\x0e\x96\xa8\xbf\xac\x73\x56\x41\xff\x48\x8b\x6d\xc2\x8e\xbc\x26\x48\x49\x86\x0a\x06\x74\x49\x72\x2c\x79\x9d\x8c\x4d\x11\x6c\xe1\x26\xc9\x1e\xe3\x74\xa9\x91\xea\xc7\x3f\x71\xea\x79\xe3\xd4\xd5\xff\x7a\x26\x58\x55\xc4\xeb\x6d\x82\xc2\xb9\x12\x6e\x88\x52\x44\xf3\x49\x19\xac\xf0\x8f\x41\x42\xb6\xb0\xdd\x17\x3d\x5b\xfb\x7f\x2e\xab\x09\xde\xf1\xb7\x00\x9d\xe4\x48\x34\xd4\x0a\xc4\xbe\xb4\x8c\x61\x0f\x1e\x41\x74\x03\xe7\xe3\x3a\x00\xe5\xe7\xf0\x50\x4e\x34\x02\x9f\xa8\x6a\x8f\x16\x66\xb4\x98\x56\xc3\x7e\xa8\x80\x2a\xca\x6c\x03\xef\x96\xa0\xa3\x51\x2d\x8a\xf3\xa2\x04\x54\x00\xcd\x0e\xe7\xe1\x20\x4e\x07\xe3\x7c\x20\x81\x7d\x76\x18\xff\x16\x77\x1d\x71\xe6\x3d\xe8\x29\xcf\x10\xe5\xcb\xc7\x0d\x45\x9e\x91\x93\xc7\xce\x6f\x71\x49\xd7\x45\xf7\x93\x13\xe9\x84\xe1\xe1\x33\xa1\x15\x45\xaf\x29\x10\x9f\x19\x6c\x7d\x84\xc1\x46\xaf\x5f\x05\xf4\x45\xac\x2d\x00\x0c\x8e\xff\x13\x44\xe4\x35\xac\x46\x33\x0d\xc3\xd0\x84\xbe\x07\x18\x09\x3c\x5e\xe2\xef\x5e\x74\x7e\x5a\x0c\x45\x45\x15\x28\x2b\xa6\x3d\xc7\x59\xc1\xda\x77\xd2\xfb\xd0\x63\x0f\x82\xc8\x0f\x8b\x32\x07\x29\x36\x1e\xeb\x27\x78\x28\xd5\x4e\x67\x79\x08\xbb\x89\xcc\x4c\x82\xfc\x62\xa8\x82\xb1\x01\x45\xfd\xec\xbb\x1c\xc0\x77\x21\x7d\xa9\x37\x84\x9c\xc2\x51\x03\xfb\xd6\x70\x11\xec\x8c\xfa\x35\xe2\x67\xc3\xf8\xf6\x91\x84\xc6\x56\x31\x18\xb1\xeb\x7f\xe8\x03\x59\x6f\x12\xba\x73\x44\x55\xc0\xaa\xff\x18\x0f\xae\x81\xff\x3a\xc6\xcc\x72\x81\x81\x78\x46\x14\x1a\x06\x31\xdd\x99\x6b\xcd\x09\xfc\x6b\xd9\xc6\xcc\xb3\x8c\xc0\xb2\x43\x9b\x50\x2b\x0c\x3c\x97\x84\x26\x3c\x74\x4d\x62\x79\xd6\x22\xf4\xe6\xc1\x3c\xf0\x3d\xc7\x9e\xd9\xee\xcc\x59\x58\x7e\x68\xce\x1c\x8f\xfa\x73\x3a\x8f\x02\x23\xb2\x5d\xdb\xf2\xe9\xc2\x30\xac\xc5\x2e\xec\x53\x4d\x15\x67\xc5\xc2\x53\xb0\x49\x05\x0a\xb4\x0f\xc0\x27\xff\x91\x31\x04\xb1\x80\x03\x4a\x8c\x6a\xa6\x61\x9a\x4c\x9c\x86\xa0\xcc\x84\xc8\x56\x92\x6c\xc9\x8c\x07\x3e\x29\x80\x7d\xc3\x9d\xbf\xa0\x4c\x04\xd4\xa6\x19\x81\x26\x78\xe7\x87\x4f\x60\x62\xb4\x58\x00\x4b\xcf\xe3\x2c\x67\x66\x93\x55\x5c\x68\x11\x25\xe5\x16\x46\xc6\xd1\xd3\xac\x84\x21\x82\x64\x1b\xd2\x70\xba\x57\xac\x71\x53\x43\x16\x45\x05\x2d\x15\x8c\x88\x01\xfc\xdf\x91\x0e\x95\x67\xb5\x64\x88\x48\x52\xd0\x8b\xfd\xa8\xcd\xd1\x33\x06\x42\x59\xd2\xbc\xf1\x4b\x48\x23\x02\xd2\xf8\xb5\x66\x74\xe0\x48\xe2\x75\xfc\xc5\xc1\x30\x8d\xc6\xf3\x35\x79\x00\xc5\x75\x8d\xcf\xbb\x00\x32\xce\xff\x04\x00\xf6\x90\x31\x4d\x01\x88\x16\x91\x5e\x82\x56\x1b\x74\x9e\x21\xd2\xf5\x2f\x4d\xf9\xe5\x5b\x56\xf5\x04\xf5\x7e\x7a\xd0\xeb\xb5\x39\xfb\xd6\xf6\x96\x84\x52\xfb\x39\xb4\x48\xbc\x4c\x5c\x6d\x12\x12\x1f\xb9\xbc\xea\x44\x7b\x79\x1c\x10\x6c\x99\x81\x94\x7b\x2e\xec\xcd\x27\x09\x49\x81\xbf\xa0\xc0\x54\xb8\x1a\x2a\x93\x04\xd8\x1d\xbc\xc4\x7e\x6a\xf0\xa4\x5d\xbc\x8e\xdb\x94\x19\x1f\x5a\xc6\x77\x34\xd5\x68\x0c\x43\xe6\xc8\xb7\xf4\x5c\x48\xf9\x42\x9f\x00\x2d\xe1\x23\x60\x8c\x4b\x5a\x8d\xad\x01\xd2\xfb\xb0\x3e\xa6\xd8\xe6\xdb\xf4\x73\x7d\x61\x7b\x53\xeb\xb5\xa8\x85\x81\x74\x6b\x2a\xb5\xcc\x48\xcc\xc1\xe4\x3f\x87\x02\x5c\x6d\xbd\x85\xcf\x90\x25\xfa\x14\x78\xe6\x36\x1d\xc6\x13\x2b\x50\x47\x93\x7b\x63\x83\x5a\xcb\xcb\xb5\xeb\xf7\x28\x48\x10\x82\x92\x33\x75\x38\xe8\x35\x19\xc3\x2d\x24\xc4\x51\x9e\xad\xcf\x03\x2c\x5c\x25\xf2\xb2\x01\xf2\x04\x36\xaf\x68\x3e\xd2\xe2\x48\xcb\x80\x5f\x03\xf8\xa3\x98\xb0\x04\xbb\xcc\xce\x03\x34\x4d\xc3\x26\x7c\xaf\x98\x08\x2c\x00\x07\x7f\x78\x42\xf0\x8b\x92\x6e\xbe\xb8\xc8\xfa\x0e\x98\xfa\x5b\xce\x92\x6e\x19\x2d\xef\xbc\xaa\xd0\x94\xe6\xcb\xc7\x4b\xd0\x8e\x50\xbb\x07\xa0\xbf\x36\x4b\x15\x90\x68\x1c\xb0\x5e\x7e\x1a\x6d\x99\xa2\x56\xc6\x6b\x7a\x80\x95\x7e\xe0\x83\x80\x76\x87\x20\x33\xcb\x17\x12\x39\xbf\x89\x32\x73\x17\x32\xce\x0a\xb3\xd1\xe8\x05\x80\xa0\xbd\x16\xdf\x10\x4c\x5d\xdb\xa6\xc1\x0a\xb9\x6c\xa8\x58\xbf\x38\x4b\xd6\x11\x06\x18\x68\xbd\xd1\x91\x25\xe9\x6c\x94\xbf\x33\xf2\xd0\x71\x56\x89\xb8\x53\xce\xd4\x19\xc8\xf8\x1c\xbe\x89\xd7\x44\xa5\x1c\x06\x56\x83\xbc\x2a\x50\xd2\x4c\x2b\x12\xe0\xbe\xeb\x18\xd5\xd7\x21\xac\xb7\x82\xea\x3c\x8c\x61\x9b\xc6\x0f\xf5\x98\x13\x26\x0a\x28\xc9\x93\x18\xa0\x2c\x61\x67\x94\x1d\x3c\x89\x13\x28\xbb\x77\x7e\x99\xc1\xc1\x4e\x98\xdb\xaf\x09\xb3\x78\x61\x04\xe8\x2f\xc4\xe2\xcd\xa9\xe0\xa6\x26\xf1\x5d\xcc\x00\x75\x2a\xb2\xa4\x57\xff\xfe\x4c\x1f\xbf\xb8\x8b\xf3\x96\x4f\xfe\x57\xfa\xf8\xb5\x2d\x1f\x62\x1b\xb4\x3b\x92\x6c\x7b\x4c\x20\x5a\x04\xa4\xce\x35\x33\xd8\xa7\x97\x66\x10\x61\x8b\x3a\xaf\x45\x84\x0f\xb9\xdb\x24\x62\x9c\xf6\x0f\x0a\xeb\x2b\x16\x13\x51\xbc\x3e\xe8\xf0\x55\xa2\x2b\x94\xa3\x8d\xe2\x04\x50\xa5\x19\x58\x31\xda\x54\xfd\x23\x1b\xec\x17\xbc\xc9\xb6\xac\xd5\x83\x3f\xae\x28\xa4\xf1\xf9\x61\xf7\x08\x5f\x80\x58\x0d\x3c\x86\xff\xc5\xe4\x19\x38\x47\xd8\xae\xf3\xa5\x7d\x0f\xae\x11\xbe\x52\x1a\xb2\x65\xe3\x82\xaf\x64\xe0\xcd\x00\x0c\x6d\x06\xf2\x74\x91\xb4\x1d\xc3\xf3\x04\x78\x7a\x18\xd1\x54\x20\x9e\x21\xbe\xc9\x3d\xfc\xfe\x50\x4e\xae\x9c\x61\x1d\xaa\xb0\x45\x83\x35\xee\x11\x7b\x75\x0c\x98\x82\x73\x5c\xae\xf1\x11\x98\x35\xa0\x0a\x61\x10\xfe\x1a\x66\x5e\x60\xde\x1b\xdc\x39\xb8\x22\xa2\x46\xca\xfd\x37\xec\xca\x5d\x9b\x6e\x47\xe1\x28\x03\xea\xd7\x34\x2e\x8f\xe7\xa4\xec\xd3\x1f\x41\x6d\x1e\xf9\xe9\xa7\xac\xe7\xc3\xe1\x66\xd4\x06\x22\xad\xc9\x83\x54\xdb\xd1\x2f\x2f\xf6\x10\xf5\x7f\xb8\xa9\xa4\x34\x9c\xc8\xab\x27\x8b\x39\x33\x0d\xa3\xe9\x66\x3c\xeb\x55\xf7\x7b\xf0\x49\x73\x29\xff\x1c\xad\x95\x82\x26\x5b\xf2\xe0\x58\xb2\x24\x55\x60\xe6\x6f\x1f\x3e\x55\xcc\xb8\x68\x10\x25\xd2\xdf\xaf\x9f\xde\x69\x61\xb5\xb9\x2f\x9e\x02\xbf\x65\xd4\x7d\x4f\xe2\xe4\xb1\x92\xfd\xcf\x1d\x75\x85\xab\xed\x14\xa1\xd2\xf0\xf8\xfd\x89\xb8\xdf\x00\xe2\x4a\x9f\xf2\x73\xc4\x5d\xee\xaa\x38\x88\xaf\x6f\x55\x07\x4c\x9f\x97\x7a\x9b\x7e\x96\x6e\x0f\xc0\x59\x52\xbb\x57\x84\xe7\xa1\xcf\xe0\xa8\x88\x72\xe5\x5b\x0c\xa9\x63\x4a\xc3\x84\x45\xb3\x89\x1f\x48\xc4\x74\x7c\xb4\x2e\xa2\x01\x0a\x5f\x42\x47\x4f\xd3\x90\xbe\xcf\xb6\x37\xc0\x49\xd1\x00\xae\x56\x4b\xea\xf0\xbc\xb6\xa9\x6e\x87\x22\xff\x24\xce\x88\x3d\xc0\x25\xa4\x32\xc9\x35\x5c\x0f\xaa\xee\xc4\xec\xa4\xff\x5b\x5b\x2c\x4e\x82\x92\x3e\x6c\xe0\x4c\x1a\x8e\x8b\x83\xb0\xde\xaf\x28\xb3\xf9\x02\x10\x71\x9a\xc4\x70\x70\xd1\x36\x49\xb4\xf2\x01\x0e\x35\xc9\x40\x2b\xbe\x8f\xcb\x15\xae\x23\x46\x9f\x5a\x40\xe1\xbb\x62\x02\xf8\xc3\x3f\x42\x93\x63\xf9\x80\x4e\xab\x41\x80\xfb\x59\x96\x50\x92\x7e\x23\xec\x05\xb0\xfc\x97\xa8\xdf\xe6\x74\xb9\xdf\x87\x81\xc8\xa0\x8f\xf8\xf0\x83\x38\xe0\x6a\x00\x5d\x70\x88\xab\x7f\x4b\xbf\xe4\x09\x06\xce\xda\xe2\x38\xc8\xd5\xd1\xcf\x74\xf4\xda\x79\xcc\x50\x1e\xa4\xe2\xf5\xfb\x49\x65\xad\x46\x77\x82\x8e\x3c\x42\xd7\x99\xc1\x91\x13\x48\x29\x98\x86\x3e\x80\x53\xfc\x89\xe4\x63\x91\x7c\x27\xbe\x8e\xc4\xd6\xd3\x71\xf5\x2a\xa7\xf7\x24\x0f\xbf\x32\xca\x56\x18\x1b\x51\x0c\x1e\x20\x31\xf3\xbb\x23\x72\x08\xfd\x4e\x7a\xd1\x40\xde\x31\x17\xdb\x0a\x65\x1b\x07\x9d\x86\x3c\xd2\x0a\x05\x5f\x4a\xa3\x38\x88\x49\x85\x86\x8d\x63\x65\x63\xa3\xaf\xa6\xfa\x0e\x07\xf1\xd9\x3d\x7a\x0a\xe4\x01\xe8\x28\xaf\xd5\xe8\x82\x16\x2e\x9c\x0c\xcd\xf2\xdb\x34\x7c\x59\x9e\x19\xb6\xcd\x1f\xf9\xd1\xb2\x83\x57\x95\xe6\xab\x7f\xc7\xe1\x09\x4c\xea\xd3\xc3\xf5\xfb\x63\x3d\x29\xe4\xbe\xa5\xd8\x9e\xdd\xf9\xd2\xc9\xb7\x54\xd0\x4b\x71\x20\xf4\xc5\x0d\x22\xae\xc5\x18\xde\x1d\x82\x7a\x10\x01\xd3\xb9\x67\xea\x8a\x36\xa9\xdf\x46\x7d\xed\xbe\x1a\x44\xf9\xf6\x87\xe7\x87\x17\x24\x49\xc6\x30\x19\x65\x03\x8f\x67\x35\x70\xc0\x3c\xc8\xab\x07\xd3\xae\x04\x3f\xff\xb2\x18\x77\x46\xf4\xe9\xc5\x19\xb1\x28\xc6\xa7\x94\xc7\xd7\xef\x5f\x16\xa3\xf8\x28\xce\xa6\xf2\x35\x34\x2e\xe8\x07\xdd\x0d\x3b\x76\xac\xc0\x90\x1f\x4e\x47\xd5\x4b\x5f\x2f\xb7\x61\x10\xe2\xbe\x28\x5f\x6b\x1c\x9e\xd7\xd1\x0a\xe3\xed\xf6\xb2\x3a\x21\x9d\x9b\x91\x15\xce\x3c\x8f\x10\x8f\x98\x94\x18\x46\x44\x3d\xdb\xb4\xc2\x85\xb5\x70\xdd\x90\x38\x96\x13\x2e\x16\xf6\x82\xcc\x4c\x33\x0a\x0c\x9f\x7a\x26\x75\x67\x11\x09\x67\x16\x89\xbc\x36\x6a\xf1\xfc\x9e\xf3\x23\xd8\xfe\xfc\x9c\xff\xec\x0e\xf9\x26\x61\xc8\x02\xbe\x41\x8d\xd8\x80\xe6\xc8\xee\xce\x40\xd6\xf0\xbf\x5a\xe3\xc8\x59\xa2\x12\x5e\x28\x29\xc1\x24\xb9\x94\xf2\x30\x1c\xa9\x2f\xb4\x93\x50\xba\xe1\x91\x33\xb8\xc4\x37\xa0\x05\x3e\x9d\xdd\xf3\x6f\x45\xee\xdd\xf4\x79\xd2\x08\x4b\x4d\x79\xae\x84\xf2\x34\x89\x38\xb7\x80\x5f\x75\x6e\xda\xb3\x33\x4a\x35\xc8\x29\xa4\x09\x9a\xf4\x71\xe3\x56\xa4\x58\xd1\x13\x79\xb7\x08\x68\xd3\x8a\x78\x99\xa2\x4f\x8e\x8f\xc9\x33\x44\xc5\x54\xa8\x72\x77\x18\xfb\x2e\x6a\x5b\x35\xe5\x26\x8b\xf9\x5d\x11\x94\xa6\xf0\x4b\x0d\x7b\x95\x26\xf1\xea\xb7\xeb\x9b\x4b\x73\x61\xfe\x00\x44\x5e\x72\x02\x44\xe5\x0c\xc1\xe1\x2f\x00\xdd\xc1\x9f\xb3\x5c\x0d\x9b\xc3\x59\xb2\x3c\x5e\x02\x2d\xe1\x8b\x85\xa6\x0b\xf0\xff\x02\xd0\xeb\x35\x19\x8b\xf9\x32\xb8\x07\xdf\xaf\x80\xdc\xc9\x63\xa1\x2d\x09\xdc\x34\xc5\x57\xd5\xef\xb7\xca\xe7\xcf\x94\x2c\xdf\x57\x7b\x87\x50\x7e\xe4\xc0\xbd\xb0\x9c\xe9\xe6\x1a\x68\xf1\xa2\xa8\x0d\xd6\xe3\xc7\x29\x3d\x99\xdc\x70\x90\x1a\xbf\x19\xa9\x09\x5c\x46\xb4\xad\x50\x92\x4b\xa8\x51\x44\x88\xd8\x8c\x6e\x3e\x7e\x6b\x0e\xb2\x3b\xf4\xf8\x2b\x11\xab\xf5\xdc\x38\x63\x25\xd5\xd0\x3c\x04\xaf\xc6\x91\x38\xf6\x29\xa3\x33\x21\x0a\x63\x7e\x99\x66\x29\x57\x42\x72\x3e\x7b\x42\xb9\xad\x16\xfa\x32\xe9\x84\x86\xcf\x33\x95\xe6\x0a\xf3\xde\xae\x52\x5a\xde\x67\xf9\xe7\xab\x0d\x1d\xe2\x9f\xae\x8a\xff\xf4\xdd\xb4\xc4\x50\x2c\x96\x7a\x5b\x3c\xbf\xb3\x1a\xa5\x59\xdc\xc0\xbe\x30\x37\x9f\x5e\x6d\xd9\x19\xb6\x0a\xd6\x95\xd2\x00\xd9\x01\x1b\xec\x3b\xd0\xd0\x70\x1f\xeb\x2d\x2c\x1f\x90\xf5\x9c\xb6\x87\xed\x5b\x04\x8e\x38\xc0\x10\xde\xc0\xce\x41\x66\x70\x11\xf1\x06\xb7\x0b\xfe\xed\x04\x6f\x01\xcd\xe9\x55\x1b\xe4\x31\x69\x30\x83\x33\x15\x37\x3c\xd8\xaa\xf3\x1c\x00\xdf\x36\xe6\xe2\x8f\x71\xc6\x70\x9b\xd0\xf0\x7b\xc0\x2c\x38\xf7\xe7\xcc\x61\x39\xae\x5f\x71\xdc\x39\x95\x6d\xf0\x3a\x15\xd1\x3e\xe4\x7f\x21\xd2\x11\x8f\xed\x96\xed\x49\xcd\x16\xce\xb1\x47\xa8\x27\x21\x7d\xf2\xb1\xa4\x37\x39\xad\x3f\x79\x21\xfb\xd3\xd9\x9b\xc7\x34\xd8\xe4\xd9\x12\x43\xc5\x4f\xdb\x21\x39\x4a\x9d\x27\x8a\x63\xaf\xf2\x2c\x8d\xff\x20\xbb\xf4\x52\xee\x84\xba\x01\x61\x08\xaa\x28\xe8\x9b\xac\x26\x4f\x49\x98\x76\xba\xa6\xa4\xd8\xa2\x72\x5a\xc4\x98\x21\xd5\x1a\x8d\xe7\x3f\x62\xd8\x23\x7e\xf3\x07\xcd\x33\xe4\x92\x4c\x0d\x85\x17\x4f\x2a\x24\xf2\x55\xce\x05\x80\xbe\x11\x3b\x58\x9f\x4e\x4e\xb3\x7c\x39\xee\x5c\x92\x98\xd5\x48\x0a\x50\x27\xe7\xc3\xec\x0b\x2b\x51\x3c\xbf\xa6\xe5\x89\x0f\xc4\xc6\x4b\x44\x97\x3b\xce\x0e\xe7\x33\xdd\x94\x78\x71\x8e\x13\x2a\x0a\x3d\xc1\x9b\x32\x73\x76\x83\x75\x42\x0b\xac\x96\x93\x67\x05\x8a\x31\xf6\x65\x71\x5a\xed\x1e\x80\xe8\x96\xfe\xfe\x1d\x05\x45\xb1\x25\xd7\xb8\xb0\xa2\x24\x29\x57\x23\x71\xe1\x8e\xa6\x48\x9a\x40\xa3\x7e\x6f\x3e\x63\x44\xe2\x04\x13\xba\xb1\x52\x17\x67\x6d\xb2\xda\x05\x5e\xee\xfc\x3c\xfb\x4c\xd3\x97\x45\x50\x7f\x61\xdb\xa5\xc8\xef\x99\x61\xef\x86\xf1\xd7\x94\xdc\xc1\x16\x10\x3f\xa1\x5f\x17\x58\x49\xf7\x44\xde\x97\x8f\x66\xc7\x04\x34\xba\xbd\x67\x5d\x6c\x83\x80\xd2\xb0\x90\x27\xcd\x4b\xab\x16\x8c\x6f\x22\x3f\x5d\x91\x02\xd4\xc5\x6c\xbb\x5c\xf1\x6b\x44\x65\x31\x53\xd2\x19\xb1\x96\x09\x20\xc2\x6a\x80\x66\xbc\x26\x0f\xcc\x05\xfd\x66\x49\x8f\x0d\x77\x2f\x98\x50\x50\xf9\x90\x9a\x47\xab\x86\x6c\xb9\xc6\x99\x53\xb9\x2b\xe8\xe3\xf4\x46\xb9\x4b\x0d\x03\x1d\x34\xa7\x46\xa4\xbe\x7a\x29\x6b\x85\xe9\x7f\xab\x61\xf9\xdf\x2c\x69\x32\x54\x3f\x51\x55\x5a\xa2\x36\x99\xb2\xbc\x6f\x3e\x1c\xda\xb7\x99\xcb\x6a\x0b\x97\x42\xf8\xff\x0d\x7f\xda\x2a\xe7\x79\xce\xa2\x77\x2f\x45\x9d\x67\x1b\xc1\x76\x3f\xc4\xf2\xcc\x68\x80\x0d\x06\xa5\xc0\xd5\xd5\x9c\x95\x13\x60\x5f\x57\x05\x20\x99\x1f\x43\x75\x48\xc8\x8a\x4e\x07\x32\xfe\x3f\xd2\x4b\x51\xe4\xb2\x60\x4c\x49\x1d\x42\x16\xfb\x93\x89\xff\xe8\xaf\x80\xd3\x60\xc5\xa8\x70\xe8\xda\x4f\x71\x2d\x8b\x6b\xf2\x3a\x53\xf2\x82\xcf\xfc\x87\x24\x5f\x72\x87\x07\xd7\x27\x70\x20\x6e\x6a\x2d\x98\x21\xb6\x2a\xb1\x29\x57\xa2\xf8\x1f\x9f\xa9\xe1\x95\x55\xd1\xce\x7f\xd9\xa8\x31\x25\x2f\x3f\x1a\xef\x16\xb6\x31\x28\x7f\xce\x96\xc0\x83\xdb\x3e\xc2\xa1\x63\x60\xed\xcb\x1f\x91\x5c\x8f\xff\xf4\x86\x69\xd6\x25\xed\xd2\xc7\x15\x56\x07\x3e\x89\x48\x88\xc4\x4e\x1c\xe9\x49\x18\xd0\xf3\xc3\x4f\x3c\x8a\x3f\x51\xf4\xa9\x51\xb4\x2f\xf0\x74\x93\x90\xc7\x2f\x15\x77\xda\x8b\xf4\x1c\x04\x8c\xbe\xd8\x25\x00\xfe\xd3\xc3\xff\xbb\x26\x5b\x61\x18\xe2\x5a\xb2\xa0\x20\x4c\x62\xe5\x7f\xe2\x91\xcd\x8c\x44\xe1\xea\x5d\x12\xb4\xa7\x4e\xf6\xc8\x8c\xa6\x57\xbb\x7a\x01\xdf\x56\x85\xca\x9e\xea\x59\x2f\x26\xf6\x0c\xb7\x5f\x89\x4f\x16\xb8\x52\x55\x0b\x91\xf5\x43\xbe\x50\xe5\xa0\x1d\x38\xa2\x84\x80\x8a\x94\x1d\x59\xc7\x03\xab\xe7\x14\x07\x3c\xaf\xea\xab\x9d\xa2\x43\xb9\x88\x16\xe2\x75\xc6\x30\xd2\x02\x5f\xf9\x4c\x1f\xa7\xa0\x0d\xc2\x75\x4e\x4f\xe9\x43\xf9\x57\xfa\xc8\xc2\x10\xe4\xd7\xc2\xff\x4a\x30\xc8\x01\x4d\x2c\x3a\xde\x29\xb0\x4e\x31\xbb\xd7\xc1\x07\xb0\x53\x4b\x5a\x63\x11\x7c\x2f\x3d\xbf\x45\x96\xdc\xc1\x5c\xec\xca\x8f\x3a\x05\x87\xea\x3e\x47\x25\x24\xad\xeb\x57\xa2\x8b\x38\x67\x09\xd9\x00\x0a\xe0\x16\x8d\xd7\x30\x62\x31\x7d\x02\x89\xd0\x70\xa6\xe4\x47\xe5\x46\x23\x6c\x6c\xcb\x60\xf9\xbc\x2e\x1a\x73\x68\x2b\x19\x3e\xa7\xd4\x6c\x3b\x31\x55\x9b\xef\x6c\x9d\xa6\x0d\x0a\x1e\x47\x9f\x7f\x98\x13\x96\x9a\xfd\xcf\x69\x3b\x75\xfb\xa4\x2b\xab\x3c\xa4\xd1\x39\x19\xac\x26\x29\xee\xe9\x0b\x4f\xb1\xd8\x2f\x16\x19\x31\x7e\xc4\x83\x60\xfc\x86\xc8\x4e\x2e\x57\x75\x7f\x96\x83\xb7\xbc\x66\xfb\x97\x5e\x56\x01\x02\xa2\x1e\x90\x59\x65\xb9\x8e\x2f\xaf\x7a\xd5\x10\xdf\xc9\x6d\xef\xfc\xf9\xfa\x72\x77\x45\xc9\xa9\x9e\x73\xbc\xfa\x77\xc1\xa2\x5e\x64\xa6\xc3\x49\x27\x8a\xac\xb5\x1a\x5a\x32\x62\x3e\xfe\x45\x6f\xfa\x61\x2b\x99\xa4\x7e\x9d\x57\x0b\x63\x18\x31\xc4\xc3\xac\x4e\x21\x89\x1a\x1b\x08\xed\x3a\x43\x21\x32\x7b\x41\x1c\x99\x91\xd9\x61\x90\xdf\xb4\xf1\xa1\x81\x59\x0a\x62\x49\x37\xf8\x19\x90\x69\xbb\x81\x79\x51\xba\x72\x21\x81\xc1\x53\x79\x16\x6e\xa5\xd7\x05\x25\xf8\x00\x85\xf4\x96\x7d\xac\x08\xbe\x34\xbb\xe7\x7e\x31\x16\xa1\xcc\x2a\xfb\xc5\xc2\x56\x01\x78\xc8\x0c\x1f\xd8\x86\xa8\x8c\xb9\xdf\x8e\xf5\xa5\x9a\xa2\x45\x82\x69\x96\xb2\x51\x15\x2b\x06\x58\xf0\x90\x4b\x18\x02\xeb\x5e\x53\xb5\xd6\x35\x7f\x4b\xba\x42\x11\x56\x8c\x85\x2e\xc9\x67\xb4\xad\xdc\xe1\x88\x4c\x6b\x15\xbb\xa5\xf1\x02\x87\xe8\x65\x60\xd7\xcb\x94\xde\xd7\x9d\xb1\x70\xc9\x83\xca\x0e\xaa\x7a\xd6\x91\xe9\xbf\xec\xd3\x8e\xf8\x35\x3b\xd2\xf7\xdb\x35\xbc\xde\x8a\xa3\xe0\x85\x7d\xd4\xee\x69\xfc\x52\x76\xb8\x14\x43\xa7\xe3\x9a\x82\xd4\xaf\xaa\xa6\x6a\x3f\x68\x45\xd5\x7b\xad\x3a\xe6\x93\xdc\x7f\x37\x59\x11\x97\xc3\x18\x09\x1c\xe9\xee\x7d\xbf\x85\x1b\x58\xb0\x42\x82\x03\xa4\x2b\xb3\x20\x4b\x00\x23\xc4\x1d\x0a\x78\x25\xaa\xb6\xda\x66\x5b\xac\x1a\xc1\x2f\x5f\x36\x55\xef\x6f\x1c\x8e\x9e\x33\x62\x25\x94\x9e\xe2\x8c\xaa\x82\x4c\x54\xad\x6c\x77\xce\x83\xaa\x09\x18\xa5\xd2\x31\xf4\xab\x48\xb1\x0a\xcc\xfb\x55\x0c\x6c\x8d\xae\x91\x33\x35\x40\x1e\xeb\x46\xd9\xa1\xf8\x97\xc6\x31\x90\x96\xd9\x26\x0e\x0c\x96\x17\xf2\x94\x30\x99\x47\xc3\x64\x3e\x39\x4c\xd6\xd1\x30\x59\x4f\x0e\x93\x7d\x34\x4c\xf6\x93\xc3\xe4\x1c\x0d\x93\xf3\x34\x30\x9d\x87\x71\xf2\x52\x91\xcf\x80\x71\xb2\x5a\x5d\xbb\x19\xa7\x2c\x6e\xf5\x14\xbc\xb3\x51\x3c\xeb\x49\x39\x67\xf9\xf0\x0b\x4b\x05\x18\xc9\x3d\xa5\xa5\x09\x93\x5d\xd8\x5d\x20\x6c\x3b\xaf\x9e\x06\xe9\x31\x3f\x8f\xe6\x67\x00\x5a\xee\x32\x9a\xc8\x60\xd7\x9f\x06\xda\x9c\x06\xf1\x26\x56\xdb\xc1\x8d\x07\x98\xe5\x05\xdf\x9d\x1f\xda\xf3\x10\x6f\x55\x7e\xf3\x19\xd0\xaf\xac\x59\xb6\x9b\x84\x7d\x4a\x9e\x48\xf5\x59\x6f\x50\xa5\xe0\x76\x4e\x76\x84\x1d\x8d\x75\xc7\xad\xeb\x0d\xdc\xdd\x97\xab\xf2\x9e\xe2\x7f\xf1\x84\x28\x59\xb3\xfa\x13\x14\x6e\xfc\xd2\xa0\x46\xea\x76\xaf\x6b\xf6\x1e\xcc\x49\xa2\x88\x07\x84\xa0\x95\xb5\x9a\x6c\x52\x0d\xec\xd3\x28\xcb\xb1\x00\x86\x38\x34\x56\x1e\x05\xe3\xb1\xa6\xcf\x57\x85\xa6\xe4\x59\x08\x82\xb7\x00\xc7\x6e\x24\x62\x61\x8d\x4f\x81\x45\x8d\x00\xcb\xa7\x8e\x6f\x3c\xfe\x74\x18\x78\xcf\xe1\x78\xea\x90\xc6\x96\x80\x1e\x96\xb8\x31\xe2\x64\x9a\x69\xd6\x41\x40\x37\xa5\x4c\xf0\x2e\x1f\x86\x26\x77\x20\x01\x8e\xb4\xa6\xe3\x5e\x8b\xfa\x46\x6a\x95\x91\x2c\x8c\x29\x1c\x4c\x86\xaf\xdd\xc7\x05\xe5\x7e\x98\x66\x51\xa3\x31\x72\xe2\xb0\x2d\xfe\x78\xec\x11\x49\x22\xcd\xd4\xdd\xaf\x8f\x4b\x37\x1c\xac\x4f\x0f\x15\xbd\xd7\x2f\xe1\x48\xe2\x3d\x3e\xa8\xa8\xc7\x5f\xb5\x0f\xed\x29\xa9\x20\x3a\x71\xa8\x40\xec\xc8\xa6\x69\x6c\xd9\x8a\x3e\x68\xac\x31\x33\xda\xc1\x30\x4c\x56\x0e\x74\x51\xa7\xde\x60\x6b\x84\x53\xc6\xcd\x61\x21\x31\x2a\x6c\x64\xcd\x7b\x04\x44\x62\xd0\xea\xe3\x15\x29\xde\xb5\xba\x10\xf6\x21\x44\xa7\xee\x83\x5c\xb4\xa6\x1b\x0f\x21\x35\x7c\xd7\xb7\xc9\xdc\x75\xb0\x24\xbe\xde\x5e\xc0\xde\x77\x24\x00\x0a\xae\xaa\x6d\x2c\xf7\x6d\xbc\xd0\x9e\x0e\x6e\xd0\xf7\x70\x40\xbc\xf5\x23\xfa\x78\x8f\x05\xa7\xce\x80\x60\x43\xf0\x13\x40\xc5\xe2\x1d\x6f\xb6\xbc\xef\x04\x9a\x35\x44\x86\xcc\x16\x87\xd8\xd9\x39\x8a\x6b\xfb\xaf\xa8\xa9\xe8\x3f\x96\xb4\xb0\xad\xda\xdf\xca\xed\xaf\xdd\xf1\xbb\xbd\x93\x70\x33\x41\xc9\xd3\xb6\xf0\x93\x6d\xed\xb7\xe7\xbe\x5a\x31\xad\xeb\x87\xc6\xec\x75\x51\x26\xd9\x47\xe6\xd8\x69\x5d\x67\x58\x7f\x9a\xee\xb4\x55\x7b\xbb\xa7\xde\xe7\xbe\xfb\x1a\x0b\x20\x1c\xb2\xd6\xe6\xd8\x3c\xec\xb0\x33\x6c\x3b\x0c\x52\xd3\x14\xe3\xf0\x40\x23\xa6\x40\x3a\x5d\x30\x02\xa5\x4b\xd4\x5e\x16\x7c\xda\x3c\xcf\x9c\x49\xb4\x79\x83\x6c\x67\xee\x57\x6d\x9b\xd8\x28\xed\x4e\x3a\xfb\x36\xec\x28\x44\x6f\x1a\x97\xb0\x4b\x94\xe8\x83\x85\x7c\xab\x7c\x09\x3b\xa8\xc0\xbb\x8b\xcf\x2e\xf3\xec\xbe\x5c\x7d\x24\xe5\x49\x0b\x10\x07\xb4\xc4\xff\x13\x1e\xba\x9f\x8b\x6c\x04\xf6\xf9\xa7\x87\x2f\xc4\x55\xfb\xa8\x9d\x17\x84\x38\x76\x6c\x1c\x0d\xdd\x73\x07\xcc\x3f\x6f\x55\x12\xec\x5b\xd5\xd7\xe0\xe7\x4f\x29\x9f\x8a\xf8\x0f\x7a\xbe\xd5\xe0\xf0\x6c\xc8\xe6\xb4\xe5\x8a\x30\x0f\xec\xc7\x9f\x6f\x00\xb7\x50\x3e\xd7\x2a\x33\x0f\xe4\xbb\x7e\x7f\xec\x12\xaf\xdf\x33\x92\x50\xc3\x00\xbb\xab\xfb\x0a\x92\x90\x51\x21\x29\x7e\xc6\xa0\xa9\xf3\xcd\x0a\x23\xf2\x38\xac\xfe\x09\x95\x7a\x9f\xc7\xee\x63\x8f\xf1\xae\xac\x6c\x77\x62\x63\x79\x99\x50\x75\x79\xbf\x16\x34\x3c\x61\x75\x65\x56\x92\xe4\x36\xc8\x72\x7a\xca\x20\x0f\xc5\xc7\x2c\x2b\x8f\x5d\x70\x0e\xdf\x54\x01\x86\x7d\x15\xf4\x77\x92\x0a\xc6\x9f\x9e\x3c\xa3\xec\x09\x27\xc2\x59\xbb\xd3\xc8\x9a\xbf\xe7\x5c\x5b\x35\x68\x2f\x07\xc0\xc0\x98\xb3\xf0\x53\xcc\x95\x54\x36\xcf\x32\xea\x59\xe2\xe2\x13\x16\x7e\x3f\x7c\x01\xd8\x61\x4b\xa8\xf2\xee\x58\xfd\xf8\xba\xa7\x63\x9c\x92\x24\x2e\x7b\xb0\x9e\x37\x92\xdb\x35\xec\x7f\x1a\x77\xed\x0d\x50\x00\xb2\x11\x8c\x8d\x90\x36\x83\xba\x97\xb0\xf6\xe6\xe6\x7a\xaa\xdd\x64\x6f\x58\x6a\x20\x5c\x30\xe8\x03\x5e\xe7\xe3\xb2\x9a\x7d\xa2\x15\x99\x8c\x9d\xe6\xc9\x28\x4b\x51\x56\xb7\x10\xef\xfc\xd1\x2a\x27\xb1\xa2\xdb\x3c\x2e\xca\x18\xb3\x0b\x1e\x71\x91\xa9\x66\x5a\xcd\xda\xf8\x2c\x24\x36\x45\x37\x18\x0f\x8a\xbe\x50\xe1\xed\x2f\x89\x08\x02\x3a\x8a\x91\x56\xea\xba\x95\x87\xa9\xab\xb3\x37\x81\xd4\x2d\x9a\xe0\xd4\x55\xf5\x79\xfe\xa1\x21\x13\xce\xe3\xb4\x75\x28\xfc\xbc\x7f\x94\x0b\xef\x07\xa4\x7d\xee\xdd\x92\x9b\xfb\x02\xe6\x5a\xa2\xa0\x53\xbd\xe1\x62\x6f\x54\xdd\xce\x32\x21\x3d\x12\x46\xa5\xa2\x36\xf1\x74\xec\x09\x42\x3b\x50\xf2\x1a\xb1\x9e\xa4\x5e\xf5\xe7\x33\x03\x67\xe6\x2d\x9c\xc5\xc2\x9b\x11\x37\xf4\x5c\x7f\x6e\xda\x0b\x77\x61\xf8\x9e\x67\x9a\x61\x68\xfb\x8e\xeb\xcc\x03\xc3\x0a\x9d\xc8\x31\x83\x90\x46\xfe\x3c\xb4\x2d\xdb\x9a\xeb\x4d\x81\xad\x59\xb6\xd7\x95\xa0\xca\x44\x16\x31\x82\xf9\xdc\x32\xe7\x0b\x42\x1c\x3b\xf0\x5d\xdf\x9f\xcd\x42\xc3\xb7\x4d\xdb\x5d\x44\x0b\xba\xb0\x0c\xd3\x09\x3c\x8f\xcc\x0c\xdf\x0a\xfc\x05\x3c\xf3\xa9\x19\xcc\x42\xbd\x47\x76\x6a\xe6\xcc\xb2\xcd\x99\x6b\xcd\xcd\xae\x88\x63\x21\xbc\x86\xda\x61\x49\x15\x46\x08\xd2\x7c\xe6\xce\x43\xcf\xf6\xe7\xbe\x17\x7a\x06\xc8\x9b\xc0\xb7\x3c\x93\xcc\xcd\x70\xe6\x44\xc1\xdc\xb7\x6d\xd7\x89\x22\xaa\x4c\x2d\x05\x8c\x66\xf4\x49\x0c\x8c\x5a\xea\x08\x01\x9c\xc8\x0c\x83\xc0\x09\xa9\x17\xd2\x60\x3e\x0b\xe7\x84\xf8\xde\xcc\x87\xc9\x7d\x37\x08\x42\xc7\x24\xa1\x6d\x5a\xce\xcc\xf4\x17\x8e\x47\xe6\x8e\x69\x47\x06\x31\x1d\x2b\x0a\x1d\x23\x74\x16\xb6\xa3\x6e\x72\xc5\xea\xcf\x3b\x6e\x83\xb7\x9f\x19\x64\xce\xc6\xc7\x6d\xb8\xe4\xce\xcd\x50\xc8\x5d\x24\x79\x89\x93\x9c\x5a\x0b\x95\x4f\xce\x6a\x69\xee\xd3\xb7\x73\x72\x7f\xda\x4d\x86\x69\x9b\x3d\x17\x89\x0e\xed\xe2\x4c\xcd\xd2\xaf\xc6\x43\xe4\xb9\x0b\xcf\xf4\x89\x67\xc0\x36\x12\x58\x8d\x33\xa4\x9b\xe6\xdc\x71\x23\xcf\x02\x6a\x31\xe0\x3b\xd3\xb3\x66\x96\xe1\xe1\x9f\x60\x0f\x3c\xc7\x74\xe6\x0b\x2b\x58\x38\xf6\x62\x06\xa3\x2d\x3c\x20\xef\x85\x61\x50\xa0\x7b\xf8\xce\x0a\x42\x6f\x3e\xa7\x01\x90\xe3\xc2\x70\xfd\x80\x18\xb3\x99\x69\x50\xc7\x32\x23\xdb\x37\x4c\x9b\x86\x96\x65\xda\x96\x43\xe7\xf3\x80\x98\x46\x68\x3b\xae\xeb\xdb\x96\x6f\xc2\xf0\xc1\xdc\xa2\x26\x4c\xba\xf0\xe1\x95\xc8\x0c\x9d\xc0\x9e\x1b\xb6\x31\xb3\x17\x8b\x30\xb4\xe6\x24\x5a\xb8\x16\xfc\xeb\x08\x4a\xed\xad\x99\xf8\x65\x4f\x62\x22\x35\x87\x8c\xc5\x02\x9f\x78\xc3\x6b\xc5\xfb\x8a\xba\x81\x4d\x4d\xa4\x5d\x64\x71\xdf\x7a\x95\xb2\x99\x47\xaf\x1b\x35\x30\xcc\x2a\x11\x40\x94\x5c\x47\xea\x51\xbe\xfa\xaa\x6c\x8e\x9e\xad\xae\x8f\xd8\x3f\x61\x5f\xf5\xbf\xe7\x74\xe2\x15\x54\xc7\xce\x5a\x57\x44\xad\x0b\x46\xbe\x72\x67\xec\x3a\x5a\xec\xd8\xec\xd3\x27\xaa\xb7\xbb\x35\x97\x52\xa2\xf0\xcb\x6e\x2f\x4f\xb0\xf3\x33\xf8\x4f\x5d\xc8\xf2\x65\x1b\x64\x1a\xe7\x76\xca\x1c\xb2\xc0\x6d\xed\x9b\x19\x22\x7b\x06\xa8\xa2\xc3\xf5\xcb\x63\x8e\x52\x81\xb3\xae\xc0\x7c\x5e\x73\x1b\x4b\xcd\x88\xbb\xf6\xef\x80\xa4\x3a\x0b\xd4\x00\xa5\xa4\x61\xaa\xa1\x79\x7e\xfc\x19\xe4\x94\x14\x68\x54\xef\xce\x83\x77\x07\xe9\x92\x9e\x68\xc4\x67\x57\xb0\xca\x25\xbc\x4b\x4c\x0b\x85\xfa\x3c\xea\xc7\x3b\x96\x97\xba\xd7\xc4\x9c\x1d\xbb\x60\xbd\x8a\xa1\x62\x41\xbb\x6c\x86\x09\xdf\x6c\x64\xd2\x55\x14\x6f\x48\x37\x49\xf6\xb8\xc6\xf7\x2a\x36\x5d\x6b\x64\x9d\x1e\xe2\xe3\x8c\xd0\x20\x08\x64\x14\x00\x0f\x1d\xab\x89\x8a\x94\xe4\x68\x7e\x90\x6e\xb6\x25\xfb\x52\x80\xbc\xf3\x22\x04\xdb\x36\x4e\x13\x15\x8d\xce\x51\x35\x56\xdc\xab\x0c\x58\xb6\x87\xdc\xfe\x5c\x63\xd1\xd7\xb0\x40\x3f\xb1\xcd\x54\xa5\x91\x7d\x96\xd3\x60\x45\xe2\xf4\x13\x59\x1e\x0b\x8a\xb7\x0b\x12\xde\x1c\xee\x91\xa7\x75\xa1\xf1\xbf\xa8\x0c\x3a\x55\x33\x0f\xe1\xa5\xfa\x48\xa3\x63\xf7\xd6\xe3\x22\x12\x8d\x2d\x51\xcc\x1c\x6f\x45\xb6\xa6\xdd\xf1\xe9\xc3\x26\xce\x89\x7a\xb6\xa7\xef\xb1\x5e\x0f\x0a\x0c\x29\x21\x2c\xf3\x07\x69\x43\xac\x85\xa5\xc5\x6c\xd3\x58\x58\x92\x6b\xc4\x13\x15\x46\x46\x49\x81\xbd\xb9\x55\x6c\xdc\xc6\x8d\xf7\x26\x8f\x03\xfa\x2e\xeb\xdb\xd8\x91\xe7\x19\xc0\x60\x78\x11\x47\x16\x03\xb3\xb1\xee\x50\x01\x49\x82\x2d\x96\xba\x13\xbd\x0a\x53\x92\x30\xe3\xf2\x06\x67\x57\xc1\x39\x9f\xed\x1a\xd3\x81\x6b\x87\x15\x4e\x06\x12\x46\xe3\x79\x17\xc5\x76\xcd\xe1\x92\x15\x05\x98\x11\xb1\x5f\x09\xc0\x40\x9c\xe2\x97\xa3\x15\x8d\x56\x37\x0f\x61\xd5\xe9\xd6\xad\xe1\x59\x15\x2c\xbd\x71\x9b\x33\xaf\x82\xfa\x82\x98\xbe\x31\x54\x8f\xb7\x3f\x1b\xe2\x3a\x7c\x59\x0a\x53\xd5\x1a\xaf\xb7\xda\xba\x90\x6c\x6d\xb1\x7d\x06\x67\xf6\xd3\x4a\xfe\xda\x94\x06\x77\xe7\x2e\x4b\x55\x2c\x78\x15\xbf\x53\xed\x78\x72\x64\xbd\x8f\x6d\x69\xb6\xd1\x61\x20\xda\x3f\xfe\xd9\x4f\xec\x58\x70\xb2\x41\x77\x9a\xd5\xe8\x57\x5e\xe3\xbd\xa6\xe3\x56\xeb\x2d\x64\x63\x61\x49\xad\x85\xeb\x6d\x54\x1b\x27\x8b\x3b\x47\x78\x76\x63\x66\x9f\xc5\x74\x9f\xe5\xf1\xc3\x1d\x3d\x4f\x30\x55\x0f\xde\xef\xce\xb4\x12\x09\x9a\x3c\x83\x94\x27\x7d\x74\x1d\x1c\x2c\x5d\xe5\x8c\xd7\x85\x01\xfa\x59\x87\x42\xe4\xea\xc7\x1d\x77\x77\x05\x97\xe7\xa5\x37\xae\xc5\x21\xbe\x86\x51\xa4\xd7\x9a\x5c\x54\xbb\x9f\x7a\x0d\x31\x2c\x83\x62\xac\x39\x88\x69\x50\x38\x44\xc1\x55\xe2\x42\x35\xc6\x72\x3d\xfd\xa4\xa1\x85\xa7\xb4\x33\x3a\x97\x78\x47\x0f\x5d\xc9\xc9\xc6\x70\x9d\x93\x16\x7b\x32\xee\xa0\xeb\x85\xb3\xef\x6d\xf8\xd6\x72\x17\x8e\x63\x07\x73\x23\xa4\xa6\xeb\xfb\xd1\xc2\x37\x5c\x73\x66\x1b\x73\xcf\x73\xfc\x20\x98\xb9\xb6\xab\xb7\x97\xb6\x33\x20\x52\x74\x52\xdb\x77\xa6\xa7\xbb\x90\x91\x89\x92\xc7\xf1\x78\xd1\x4a\x56\x61\x9d\x36\x99\x92\x24\x6d\x04\xdc\xb5\x72\xfc\x1d\xa2\x3f\xe6\x89\x8d\xdf\x0a\xd6\xe1\x6e\xf5\xf3\x8c\xdf\x72\xd1\xe7\xc0\xa6\xb0\x58\xf1\xd1\xfe\x56\xd6\xee\x71\x0d\x2f\x74\x6b\xfb\xdd\x93\xa2\x1a\xb7\xbe\xaf\xad\x3f\xa0\x55\xe0\x1d\xe8\x05\xcb\x6c\x50\xf4\x01\x2b\xc5\xaf\xfd\x83\x8f\x34\xd1\xb2\x6d\x79\x99\x45\x97\xac\xf5\x50\x9c\xc2\xf5\x2f\x0e\x2f\xb3\x0d\xde\x74\x26\xe8\x86\x09\x3e\x5f\x6e\x11\xd5\xa3\x04\x73\xfa\xb1\x3e\x0f\xbd\xc4\xe0\x6c\x2a\xb4\x0f\xa6\x78\xfc\x73\xa7\x06\x2c\xc0\x92\x2a\xdf\xdd\x9a\x1b\x31\xd0\x1c\x21\x97\x32\xd5\xde\x70\xd3\x03\xea\x39\x95\x9f\xbc\xf6\xfe\x8a\x74\x94\xb8\xd4\x65\x39\x20\xda\xde\xe7\x8f\xcc\xc6\x31\xd2\x32\xc2\x5f\x92\xc6\x16\x5e\xc0\x40\x67\x9b\xfa\x8a\xff\xf4\x83\xce\x38\xe7\x44\x05\x9a\x5b\xfa\xf8\x08\x53\x24\x38\x06\x16\x77\x24\x77\xe2\x19\x8b\xaa\x62\x62\x65\x66\x19\xb3\xd6\x33\x84\x27\x96\x0f\x43\xbf\xaf\x42\xcc\x14\x5d\x66\x5b\x6e\xb6\xe5\x38\x11\xbb\xbb\x89\xa0\x94\xf5\x6f\xba\x9a\xc3\x01\x67\xf1\xa1\x9b\x46\xa5\xbf\x25\xd9\x23\x16\xab\x94\x4a\x85\xe0\x40\x13\x69\x13\x83\x6d\xe6\x51\x07\x2c\xcd\x40\x16\xc5\x2c\x34\xd2\x33\x5a\x9f\xf5\x88\x7f\xd1\x7a\x99\xe7\xcc\x7e\x81\xfa\x33\x4c\x25\x6b\x17\xc6\xab\x32\x42\xbf\x00\x00\x52\x85\xd8\x79\x6f\xa8\xbc\xc9\x4d\xc5\xba\x12\x20\xe3\x84\x28\x13\x0d\xec\x53\xcb\x0e\x49\x64\xe9\x6d\xb6\xbe\xe3\x37\xc1\x97\x5b\x49\x2d\xcf\x4f\xd5\xee\x92\xeb\xd9\xef\x5f\x27\x5e\x4f\x7a\xf8\x01\x28\xac\x6d\x7a\xd6\x8f\x19\x5b\xd7\x15\x2b\xe3\x7e\x52\xba\x3c\x51\xdb\x6e\x69\xdd\xfd\xcc\xe3\x2c\x2d\x47\x5b\xfc\x88\x29\xe1\x5f\x62\xb6\x9d\x4c\xe0\xf2\x34\xf5\x75\x87\x1a\x3b\x7a\x1c\x45\x9d\x35\x2d\x5b\x5c\x4c\xde\x09\x34\x7a\x57\x15\x92\xed\x17\x22\xa3\xec\xf4\x2d\x2d\xff\xe9\xac\xf4\x0d\x87\x83\x52\xc9\xf6\xcc\x16\x3e\x3d\x63\x7f\x20\xc9\x84\x79\x9e\x37\x70\x30\xd1\x23\xb3\xfb\x49\xfb\x11\x57\x40\x1a\x0d\xb5\xa5\x15\xe4\x68\xff\x4a\x3d\x19\x28\x33\x59\x82\x56\xc3\xca\x82\xa9\x58\x6e\x61\xb5\xc7\xdf\x0e\xfa\x57\xc2\x6b\x9e\xe1\x78\xad\x10\xa1\x5f\x80\x9b\xe7\x71\xd8\xd4\x2a\x0e\xf5\x9f\xa9\xbf\xd2\xd5\x72\x52\x51\x9c\xd0\x9f\xfa\x4e\xe5\x80\xc6\xde\x04\x99\x97\x5a\xe3\x56\x56\x69\x5e\xc5\xe8\x7d\xae\x52\xb3\xa2\x58\xf8\x57\x5c\x0d\xa8\x9a\x4a\x4d\xdb\x8e\xd8\xac\x3d\x31\x46\xcf\x15\x7e\xe6\xba\x33\xc7\x76\x3d\xd7\x74\x17\x2e\xb5\x8c\x99\x03\x7f\x8e\xe6\x96\x5e\xfb\x2d\x91\x74\xde\x2b\xf8\xdb\x47\x3e\x5f\xd0\xbe\x7e\x66\x83\xb6\x68\x59\xdc\x41\x70\x76\x2d\xc3\xaa\x87\x7c\x65\xc7\xa3\xfb\x40\xc4\xfd\x56\xf0\xaf\x6a\x24\x1d\x28\x47\x76\xdb\xbb\xb8\x9e\xc0\xde\x5d\xfa\x77\x0d\x13\xdd\xc0\xca\xb1\x79\x55\x75\xdd\x97\x85\xd8\x58\x56\x2e\xf7\x3e\xf0\x58\x6c\x71\xfb\xa9\x8e\x72\x82\x45\x40\x79\x9d\x02\x21\xeb\x2f\x2a\x3b\x5b\xcc\xc7\xbf\xe9\xc1\xe9\xfe\xbb\x46\x4f\x5e\xd1\x9e\x2b\x72\x3b\x55\x68\xe7\xab\x41\x2b\xab\x72\xe7\x8b\xa2\x52\x6f\xdf\xbb\x9d\x50\xe9\x76\xd4\x8b\xa8\xdc\x8b\x65\x66\x61\xb3\x18\x63\x68\xe6\x37\xef\xdd\x8f\xe1\xf6\xcb\x63\xc4\x78\xdf\xde\xee\x4d\xd1\xdd\xb1\x05\xb5\x92\x3d\xf6\x1f\x53\x7f\x7d\x86\x51\xac\xae\xde\x71\x38\xe8\x63\x8c\x7a\xc0\x7c\x3c\x4c\x75\x66\x9f\x5f\xec\x56\x73\xcf\xc2\x89\x5b\xf7\xc3\x5e\xa5\xf0\x2c\x13\xb5\xef\x81\xe7\x30\x32\xf6\x64\xe1\x30\x1b\x61\xb8\x65\x36\x9b\x8a\x53\x8c\xb0\xbb\x09\xc3\xd9\xc1\xd3\x7b\x39\x06\x36\x01\x69\x65\x63\x12\xe9\x01\x1d\x93\xe1\x73\xb0\x99\x35\xc5\x32\x93\x7d\x43\x45\xe8\x4f\xd5\x17\x3b\x55\xa7\x4a\x4b\x32\x0d\x7b\x36\x73\xc9\xdc\x0e\x4c\x83\xda\x1e\x30\x2e\x2b\x0a\x1c\x42\x66\x46\x14\x2c\x42\xc7\x25\xa1\x61\x3a\x5e\x64\xcc\xa9\xe5\x3a\xe6\x9c\x9a\xe6\xdc\x0f\x4d\x1a\xd0\x45\xb8\x70\x3c\x7f\xa6\xb7\xa9\x53\x75\x23\xd6\xa4\xd4\x72\x2e\xf6\x59\x3b\x76\x19\x1e\x24\x1a\x6a\x3a\x9f\xeb\xa7\xce\x7e\x34\xf6\xbf\xca\x94\x89\x14\x95\x41\x36\xb3\x40\x5b\x2a\xfe\x75\x43\x8a\x3a\xda\x20\xa1\xbc\x41\x0b\xa2\x02\x93\xbf\xf5\x2f\x20\xa5\x8b\x3d\xcc\x8d\x23\x69\x71\x6c\x6a\x4f\x25\xb3\x85\xca\x81\x35\x4c\x2e\x8e\x91\x55\xfb\x6c\x85\xdb\x76\x69\x8f\x43\x99\x35\x2d\xc5\x73\xdf\x07\x4c\x1d\x3a\x36\xf7\xa5\x56\xa4\x58\xfe\x1a\xab\xa1\xcf\x43\x0a\x4b\x2c\xa6\x3f\x61\x3c\x8b\x3e\xb0\x9a\xe6\x05\x16\x86\x61\x5f\x14\x63\xad\xa5\x21\xdd\x94\xab\xe3\x76\x80\x8c\x30\xac\x0e\xdc\x35\xde\xac\x64\x6f\x0c\x73\x16\x45\x05\x2d\x8f\x2f\x0e\xb0\x4c\xb3\x9c\x17\xac\x0e\xb6\x79\x81\x1e\x03\xd6\xa5\xaa\x7a\x3f\x19\x9a\xdf\xd9\x24\x1f\xd6\x00\x21\xfe\x83\x36\xdb\xa0\xa1\x56\x2c\x5b\x51\x36\xa8\x96\xcf\x7d\x2c\x93\x14\x10\x0b\x9f\x07\x8b\xea\x4a\xb2\x25\xcf\x20\xa7\x77\x71\xb6\x2d\x18\x20\x4c\x5f\x67\x85\x7c\x9a\xfd\x12\x44\x62\x46\xba\xdc\x1b\x18\x89\xd1\x52\x43\x85\xd1\x45\xd3\xfa\xd3\xcc\x5d\xe5\xcf\xaa\xfc\x7f\x4e\x09\xd9\xfa\xa4\xec\xd2\xd1\x1f\x77\x58\x39\x5b\x66\x0b\x62\x06\x5e\xa3\x4f\x01\x86\x3b\x56\x07\xf7\x09\x2d\x7a\xb7\xb4\xdc\x1f\x56\x8a\xc5\x52\x0f\xee\x1f\xaf\x5f\x3a\xec\x35\x6b\xd8\x6b\xf6\xb0\xd7\x9c\x63\x63\x0f\xc4\x8a\xce\x27\xf5\x98\xe2\xf8\x23\xeb\x12\xbe\x3f\x48\x3b\x5d\x0e\x96\xdd\x55\xbf\x03\xf5\x96\x38\xf8\xf2\x2c\xd8\x4d\x2b\x62\x02\x4e\xfa\x09\x94\x59\x31\xb2\x62\xcf\x42\xcd\x2c\x8f\xc9\x6d\x1f\x37\xdb\x2b\x22\xb8\xea\xa0\xad\x89\xa8\x6d\x45\xd2\xca\x1f\x2a\x07\x9d\x6a\x6f\x04\xfb\x71\x1d\xbe\x2a\x64\x76\xbc\xf5\xa5\x7c\x87\xe5\x79\x62\x16\xde\x84\x2b\x52\x92\x61\x59\x33\x57\xf2\x78\x5a\x9c\x78\x4f\x78\x27\xe6\x52\x30\x40\x3e\xea\x55\x47\xd8\x9a\xa8\xac\x82\xcc\x4a\x22\x8b\xc2\x82\xca\x22\x2b\xe0\x04\xe0\x4b\x6c\x07\x2b\xec\xee\x53\xed\xc3\x7a\x53\x3e\xd6\xef\xb0\xcc\x14\x56\xaf\x8c\xed\x82\x9c\x00\x86\x93\x77\xff\x24\x51\xfb\x50\x5d\x1e\x79\x8c\x97\x3b\x85\x6b\x05\x42\xff\xcd\xb9\xcf\x63\xb6\xc3\x5f\x76\x44\xa8\x10\xed\xc6\xfb\xec\xbb\xa4\x3a\x33\x97\xba\xb3\xb9\xe5\xce\xe7\x0b\xbd\xfd\xe1\xc8\x88\x23\x43\x86\x04\x59\x33\x8b\x84\xa6\x4f\xad\xc0\x5b\xf8\xee\x22\xb0\x7c\xc3\xf5\xa2\xc0\x9e\x7b\x21\x21\x8b\x99\xe5\x93\x79\x64\xba\x36\x70\x12\xd3\x74\x2d\x2f\x9a\xcd\x88\x13\x46\x33\xcb\xf6\x6d\x2a\xac\xf6\x9c\x5d\xd0\xf0\x60\x9c\xd8\x57\x88\xd6\xd2\xe4\x75\x65\x28\xbb\x79\xcf\x5f\x6f\x5d\xa0\xbf\xb6\x17\x7e\x9c\x4a\x92\x6d\x08\x68\x1a\x52\x33\xa9\xf4\x0e\x50\x4b\xea\xcc\xf2\x18\x7b\x23\xd0\xbd\xf2\xa5\x8b\xad\xe7\xbb\x61\x55\x97\xb6\xf3\x39\x38\xff\xf4\xea\x1e\xc7\x13\x3e\x3c\x6c\x40\xe2\x88\x36\x68\xaf\xc7\x30\xdc\xb7\xcd\xf0\xfd\xdd\xdc\x76\x57\x6e\xf3\x28\x86\xdb\x02\x51\x45\xd1\x83\x16\x2b\x0e\x43\x7f\x67\xc1\xdd\xf7\xb0\x56\x42\xf9\xae\x9f\x0f\x95\x23\x65\x1f\xeb\x75\xe5\xa3\x8f\x8d\x88\xb4\x91\x39\x35\x43\x4b\x24\x1d\x53\xb4\xe6\xd4\x40\x3c\xa6\xb8\xc8\xba\x56\x2c\x16\x0f\x74\x84\xf2\xa1\x38\x63\x2c\x9e\x18\x9c\x0f\xc4\x8d\x1c\xbc\x65\x77\xb5\xca\x7a\xe5\xac\x1b\xd2\x19\x26\xe3\x03\xa9\xc5\x10\xce\x1c\x1d\x15\x87\x47\xdd\xdb\xdb\xc7\x74\xf0\x83\xee\xb6\xef\xf8\xe4\x46\xe9\x3b\xbd\xab\x80\x69\x41\x7f\x1a\xe9\x53\x56\xb7\x16\xc7\xa9\x1d\xca\xa8\xf5\xde\xd3\xb8\x85\x27\x1f\x31\xd0\xff\x24\x7c\xc4\xce\x40\x2b\xc2\x13\x89\x4b\x78\x4e\x59\xb3\xc1\x1a\x75\xaa\xd6\x40\xac\xcb\xd0\x44\x2b\x02\x92\x70\xcd\xd6\xa4\xa6\xd7\x69\x43\xf4\x21\x0d\xb3\xbc\xa0\xeb\x11\xd1\xcc\x2a\x58\x58\xcd\x9f\xa4\x80\x5d\x38\x1a\x76\x45\x5c\x65\xdb\x24\xd4\x56\x19\xfc\x07\xbd\x9c\xa4\x05\xd7\xee\xba\xaa\xca\x59\xa0\x1c\xb0\xbd\x70\x4e\x89\x13\xb8\x5e\xc3\x27\xa3\xee\x26\x93\x42\xd6\x22\x34\xdc\x85\xe9\x2d\x68\xd3\x79\xd3\xb7\x4e\x26\xfe\x1d\x12\x46\x8e\x3f\xb7\x2d\xc3\xb6\x1d\x7f\xc1\x05\xab\x70\xa5\xc8\xfe\x55\x87\xb2\xfc\x4f\x0a\x22\x66\xa6\x13\xb4\x33\x02\x47\x45\x3d\x86\xa7\x0c\xe0\xb0\x45\xb3\x8c\xba\x56\x6d\xeb\xc1\xd9\x78\x1a\x62\x79\x98\x2d\xf2\x1e\x56\xa3\x8b\x2a\x35\x7b\xb9\x31\xfd\x2b\x89\x53\x3a\xc1\xba\x45\x05\xe5\x7d\x30\xeb\x8a\x58\xb2\x93\x55\x6b\x3d\x23\x82\x8c\xd5\xf9\x2b\x5c\x43\x24\x83\x2b\x5c\x9a\x6d\x97\x2b\x86\x88\x32\xef\xa8\x86\x90\xf7\x07\x43\x44\x68\x6e\x6d\x37\x3c\xfe\x94\x62\x28\xd5\x31\x8d\xac\xa5\x22\x0f\xef\x54\xa7\xa0\x6b\xda\x0a\x05\x88\xa3\x6e\x56\x68\xa9\x4e\xa0\x7e\x7c\xdb\xe8\xce\xd6\x8f\xf4\x83\x6b\xee\xf1\x17\xff\x3e\x50\xa0\x4b\x22\x2d\x8e\xb6\x8b\x56\x25\xa7\x5a\x4d\xd9\x6a\xda\x61\xdd\xcb\xce\x2c\xdc\x7a\xab\x03\x1e\x36\x68\x4b\xe0\x06\x48\x2d\xf5\x0a\xf7\x7a\x7f\x3b\x05\x9e\x57\x24\x3d\x5a\xcc\x50\xf1\xe6\xed\x35\x96\x12\xc3\x0e\x89\x68\x8b\xbe\x8b\x09\x30\x9e\x35\x36\xcd\xbc\xb9\x6e\x7a\xd9\xda\xaf\x56\xa4\x23\xbc\xc9\x13\x25\x21\x4c\xc9\x62\x0a\x33\x5a\x60\xae\x3f\xb3\x72\xd4\x6d\x72\xb9\xac\x65\x05\xc8\xea\x00\x88\x7c\xb9\x65\xd1\xc6\xe8\x4e\x99\xe0\x30\x1b\xd1\x50\x01\x21\xd8\xa6\xf8\x38\x9c\x6a\xd7\x7c\xc7\xf8\xc7\x31\xa6\x4d\x06\xf1\x1a\x34\x2f\xbe\x27\x13\x91\x02\x0c\x3f\x80\xd4\xa9\x81\x42\x33\x13\x2b\xc7\x8b\xc1\x22\x7c\x72\xac\xaf\xf1\x08\x83\xc6\x01\xdb\x55\x09\xcd\x86\x75\xfb\x65\x77\xc1\x7d\x45\x3a\xb1\x6c\xfd\x00\xdc\x26\xeb\x43\xde\xa5\x6e\x85\x2f\x56\x11\x5f\xfa\x9a\xf7\x0c\xf6\xdf\xdc\x4a\x3c\x32\x32\xf1\xbf\x45\x02\x7d\x68\x13\x3a\xf7\x2c\xcb\xf2\x29\x09\x7d\xc3\xf6\x40\xce\xf9\xd4\x32\x69\x38\x0b\xe8\x3c\x58\xf8\xa6\x1f\x45\xae\x61\x35\xbe\x95\x91\x5b\x66\x97\xa7\xf0\xf7\x44\x6c\xec\x21\x1b\xb5\x68\xb8\x73\x38\x14\x69\x58\x82\xd6\xd0\x7c\xab\xee\xd5\x5f\x02\x32\x6e\x37\xcf\x99\x2b\x75\xd4\xf7\x12\x4b\x9e\xb7\x11\xbb\x46\x86\x63\x7d\x8f\xa4\xb6\x19\x4b\x85\x08\xad\xc6\xe3\x6d\xc0\x35\x28\x4d\x73\xde\x19\xb3\x04\x87\x27\xfd\x0d\x8b\xec\xfd\x5e\xcd\x71\x5f\x8d\xa8\x9a\xa1\xa9\x8b\x88\x84\x7f\xda\xdb\xc6\x72\xc7\x1b\x4a\x73\x0c\xb5\xdc\x7b\xaf\x1e\x24\x4c\x7d\x58\x3f\xc3\xee\x01\x4a\xe5\x31\xc5\x7f\x37\x00\xe1\x80\x21\x53\xca\xf2\x3d\x0e\x5f\xac\x52\x1f\x34\xcd\x01\x17\x96\x70\x3b\xb4\x04\x49\x31\x64\x21\x92\x7c\xb4\x96\x1e\xa1\x63\x83\xe4\xab\x3b\x73\x6a\x4c\x8d\x4b\x17\x2e\xc7\xfe\xc2\xbb\x0c\xe9\xdd\x15\x5c\xc3\xb6\x0f\x57\xcb\xcc\x9c\x9a\xc6\xd4\xd6\x7b\xf7\x59\x62\xb6\x07\xc7\x4a\x9c\xd0\x09\xc2\xc8\x0c\x82\x19\xe0\x94\xeb\x2f\xe6\x06\x20\x71\x60\x7a\x91\x61\x19\xd4\xf4\x1d\x2f\xf4\xfd\xc8\x21\x96\x1d\x9a\x94\x3a\x91\x19\x91\x59\x14\x2d\x1c\xbd\xb7\xc0\xa6\xeb\x39\x8b\x79\xfb\x0c\x34\x7d\x06\x23\x59\x16\x99\x19\x33\x4a\x67\x33\xdf\x73\x6c\xdb\x34\x5c\x8f\x04\x51\xe8\xcd\xe6\xd4\x9e\x03\x6e\x7a\x91\xe3\xda\xc4\x88\x88\xbf\x20\x24\x8a\xac\xc0\xa4\x8e\x6f\x51\x2b\x84\x0f\x01\xe3\xc3\xc0\x74\xa2\x90\x44\x2e\x05\x7d\x66\xee\xf8\xa1\x0d\xda\xcb\x6c\x01\x84\xe7\x10\x62\xcf\x02\x20\x87\x68\x11\x10\xd7\xa7\x70\x9d\x37\xa9\x15\x50\xd3\x03\x24\x76\x4c\xdb\xb6\x4c\xbd\x73\xde\xa0\xe3\x58\xde\xd4\x9c\xda\x8b\xa9\x69\x19\xaf\x4d\xd3\xb2\x15\x8b\xbe\x3c\xed\x56\xc8\x53\x75\xb6\x9a\x52\x79\xa1\x90\xa5\x45\x8d\x8a\x32\xf6\x11\x05\x4d\x7b\xbb\x99\xec\xe7\xba\xec\x23\x6d\x9b\x27\x9a\xbf\x05\xc9\xc6\xc3\xd6\x72\xba\xce\x4a\xda\x0a\x30\x1e\x48\x75\x61\x9c\x37\x9b\x24\x1c\x19\x88\x21\x36\xa8\xf5\x34\xdb\x96\xcd\xc7\xc3\x89\xa1\x73\xad\x4b\x53\x2a\xea\xa7\x88\x31\x50\xf7\xe7\x9d\x04\x8a\xe3\x48\xa8\x27\x14\x70\xb3\x2d\xf9\x98\x6c\x80\x89\x86\x11\xf9\x78\xfb\x61\xb3\xb0\x40\x47\x61\x26\xb8\x2a\x1f\xb0\xe3\x1f\x70\x69\x58\x1b\xf7\x6a\x6f\x0b\x9a\xa0\xc2\x52\xd5\x7b\xe6\xdd\xba\x11\xd7\xd1\x10\xe2\x93\x94\x95\x29\xc4\x5e\xdd\x31\x5c\x94\x00\x05\x58\xa4\x0e\xb6\x99\xa9\x9b\xda\x00\x0e\xbf\x1e\x50\x96\x39\x0e\x07\x04\x43\xef\xb2\x9d\xef\xbf\x8d\xf6\xf3\xd2\x43\x6c\xa8\x85\xc6\x9a\xce\xff\x7f\x75\xf5\xb5\x29\xfc\xff\xee\x23\xe7\x91\x2c\xb3\x26\x92\x3d\x98\xbd\x87\x15\xf4\x9d\xb4\xa2\x57\x9c\x87\xfb\xd6\x7a\x85\xed\xcc\xed\xc5\x45\xef\x09\x2b\x7c\xf9\x06\xe4\xd5\xc9\xcd\x76\x06\xd6\x21\x3a\xae\x36\xd5\xa0\x4c\x1b\xcc\xb7\xd8\x16\x23\xb9\x96\x68\xc8\xd6\x7a\x0a\x1a\xec\x96\xb6\x59\x99\x34\x59\xd6\xcf\x5b\xf1\xff\x83\x38\x8d\xe0\x57\x5a\x11\x23\x3b\x68\xa7\xd7\x03\xe7\xe6\x26\x7b\xa5\x9b\xde\xd3\xd7\x2e\x3a\x29\x73\xf6\xa8\x02\x44\xe2\xac\x3a\xdb\x8e\x3b\x09\xdf\x0a\x71\xd9\x6c\x46\x77\x22\x62\x36\x35\xe6\xde\x00\x64\xd6\x30\x30\x8e\x78\x6f\x41\x3f\x0b\x1f\xeb\x20\xe4\x8b\xbd\x8e\xd9\xa3\x5d\xb2\x4f\x7b\x96\x48\xc8\xb7\x0d\x6a\xe8\x35\xd8\xf2\xfd\x3d\x8c\xb9\x9c\x0a\x86\x68\xad\x82\x30\x8e\xef\x9c\xa4\x76\xcc\x58\xd1\x04\x44\x69\x5a\xc6\x09\x92\x45\x9c\x57\xfd\x42\x30\xea\x9e\x04\x6a\x6b\x44\xc6\xc7\x86\xea\xc9\x9d\x85\x4b\x44\x53\xd6\xa8\xd9\x3d\xab\x51\xae\x65\x7c\x42\xcd\x74\x1b\x79\x35\x1f\x1a\x59\x2e\xa7\x94\x90\x0a\xfa\xeb\xfb\x1c\x58\x90\x9a\x34\x7e\x7c\xa4\x15\x9f\x13\x44\x93\xc5\x03\x56\xdf\x93\x38\x79\xfc\xd4\x4e\xa9\xe9\xcf\x14\x7a\x1c\xd5\x24\xab\xd9\xe5\x86\x02\xcf\xc1\x32\xd5\xf2\x41\xa8\x18\x87\x06\xed\xc7\xc0\xba\x48\x3d\x19\x15\x8f\x78\xb7\xb6\x0d\x63\x36\x77\xd5\x00\x69\xbe\x21\x76\x5f\x6d\xa2\xda\x36\x50\x6f\x53\x2b\xe2\xe3\x19\xef\xd4\xb1\x5b\x20\xb9\xf8\xed\x63\x1a\xdc\xe4\xd9\x52\xc5\xe1\x5e\x7b\x19\xbc\x37\xc4\x75\x27\x8a\x21\x1e\xbd\x25\x5c\x9f\xa9\xf7\xa3\x28\x5b\x51\xd4\xab\x78\xb9\x52\x4a\xce\x8f\x1c\x58\x8c\x22\x18\xcf\xe7\x34\xbb\x4f\xf9\xbd\x0a\x35\xf9\xa2\x69\x19\x2a\x6e\x68\x7e\xcb\x64\x79\x77\x52\x3e\xea\xce\x1a\xad\xbc\x3c\x4e\x0c\xf2\x22\x6f\xf6\x43\x13\x4a\x01\xee\xe6\x2a\xcf\xd2\xf8\x0f\x71\x23\x29\x49\x23\xe7\x89\xf6\x85\x07\x1e\x58\x28\x2c\x2b\x5e\xb3\x7a\x8b\x52\x01\x61\xf9\xb5\x44\x54\xa9\x6c\xac\x5c\xb4\x63\xd9\xa6\x7c\x07\x58\x75\x9f\x9a\xff\x1e\x16\x30\x77\xb0\x59\x43\xee\x91\xa2\x2a\xec\x00\x93\xcc\xf0\xea\xb4\x95\x4d\xe3\x6b\xdf\xa5\x76\xb9\x03\x77\x88\x50\x38\xf2\xc1\x69\xd7\x0a\x51\xea\x0d\xc3\xca\xbb\x61\x5c\xb3\x7c\x60\x8a\xc2\xa0\xc2\xc3\xdb\x0d\xae\xe4\x0c\x5a\x2e\x33\x57\xb4\x31\x99\x17\x43\x6c\x9b\x03\xba\xd4\xa2\xbc\x28\x69\x55\x44\x48\xf4\x4f\xa0\x3a\x34\xab\xdf\xa4\x83\x52\x54\x60\x6c\x07\x54\xec\x46\x13\x3e\xd5\x60\x54\xe9\x55\x87\x0e\xf9\x81\x77\x70\x25\xe6\xa9\xae\x47\xac\x7c\xb1\x1c\xa4\xb6\x6b\x1a\x97\x75\x8e\x59\x05\x87\x92\x23\x8a\x16\xdf\x9d\xb6\x4c\xeb\xb8\x28\xce\xb3\xca\x6a\x7d\x7c\xbd\xe8\xb0\x86\xeb\x75\xe3\xec\x5b\xd7\x31\x4c\x44\xfa\xdb\x69\xf3\x77\xe4\x2c\x4b\x6e\xe2\x8b\x62\x80\x54\x6d\xa9\x52\xaa\xd8\x0d\xca\x9d\xa8\x4a\x1f\xe0\x4c\xb0\x06\xf2\x8d\x85\x05\xb6\xc3\x4d\x16\x33\x2f\x7c\xc9\xfb\x6c\xa2\xcb\xfd\xef\x6f\x3e\x69\x6b\x8a\x45\x03\xe2\x62\xad\x62\x29\xfe\x50\x25\x36\xa6\x51\xbc\xdc\xe6\x8d\x15\xef\xc4\x4d\x39\xd8\x60\xf4\x6c\x03\x3d\x5d\x4e\xb5\x5f\x6f\xd2\x9b\x09\xc2\x70\x79\xf3\x37\xf8\xc3\x87\x87\xf2\xfa\xe6\x95\x39\xb5\xa6\xf6\xd4\xf9\xa1\x59\xbf\x48\xac\xf1\xfa\x66\xf4\x84\x2c\x47\x42\xe4\xf3\x56\x9b\xf3\x48\x9b\x35\x03\x50\x36\x0e\x3f\x59\x26\x9f\x88\x9f\xd0\x61\xed\xc1\x0e\x47\x11\xf1\xa3\x83\x03\x01\x59\x86\x25\xdc\xc3\x7a\x0a\x8c\x3b\xd0\xc2\x98\x24\xa8\x90\x61\x0f\xc5\x5c\x9a\x3e\x15\x13\x65\x21\xf4\x86\xad\x9f\xc4\x01\x5a\x98\xef\xb3\xfc\xf3\xce\xe2\x28\x42\x5e\x6a\x68\x87\x32\x2f\xa9\xe9\xcf\xc2\x79\x70\x99\x53\x00\x5a\x31\x35\xd7\xe2\x52\xd5\xf7\xbd\x99\x19\x90\xc8\x0e\xa2\xd0\x77\xa9\xb7\x58\x04\xd1\x6c\x31\xf3\xfc\xc8\x37\x49\x60\x3b\xa6\x8d\x6d\x7d\x42\xc7\x9e\xd9\x0b\xd7\x9a\x53\xd7\xa7\x73\x1a\x98\xbe\x43\xf4\x9e\x3a\xc5\x73\x67\xbf\x1c\x7d\x16\x1e\xb0\xb6\xa8\x14\xba\x67\x33\x96\xa9\x56\x35\x1b\x23\x4b\x35\x51\x79\x58\xcb\x4d\xcd\x9a\xf5\x89\x48\xf5\xb6\x28\xa4\xa1\x66\xab\x3a\x73\xbf\x10\x13\x42\x63\x6c\xcc\x86\x72\x0b\x55\x0a\x40\x2b\x5c\x5e\xb3\x54\xc3\x9e\x60\xc5\x8d\xc5\x2a\x2c\xb2\xda\x47\x97\xbf\xf0\x96\x92\x72\x80\x75\xe5\xe4\xfe\xb4\x03\xcc\x33\x83\xc3\xc7\x8e\x68\xbf\x0a\x72\xa3\x2f\xe7\x73\xbf\x43\xe5\x3f\xad\x9e\x8a\x75\x20\xab\x65\x38\xde\xa5\xcf\xeb\xf9\x67\xbc\x54\x6a\x95\xe1\x55\x66\x5b\x3c\xa9\x46\x70\x23\x96\x46\xc0\x1c\x69\x64\x10\x4a\xc4\xb6\xe8\x57\x94\x4f\x9a\x8a\xe2\x83\x30\xbf\x15\x13\x59\xae\xb1\xf2\x68\x17\x3c\xe3\x7a\x83\x95\x05\xe1\xcf\x3c\xce\x8a\xe7\xa5\xe1\xdf\xd1\xf5\x20\xf3\xf6\x85\x0f\x9d\xfb\x23\xea\x01\xa6\x8d\xb9\xde\xc2\x1a\x64\xa4\x15\x2f\x5a\x9b\x56\xc1\x16\x18\x12\x05\x03\xc4\x77\xb2\x04\x42\x5c\x62\xcc\x29\xf9\x4c\x2d\xff\x12\xd3\xf2\xb0\x33\xd4\x84\x57\xd7\x61\xbf\x3b\x22\xfe\xea\x95\x1f\x2f\x91\x65\xc6\x24\xfd\x41\x5b\x67\x21\xdb\xae\x7a\xde\xcf\x27\xdc\xc9\xfc\x06\xbc\x05\x2d\xd9\x5d\xa9\xed\xdd\xca\xb0\x70\x17\x2d\x0f\x46\xae\x7e\x8d\x06\x8b\x7d\xed\x14\xcf\xc0\xb2\xf7\x73\x48\x8e\xfe\x72\xc6\xe9\x74\xaa\x2b\xa7\xa1\x79\xdd\x8d\x53\x7c\x9a\x1f\x69\x96\x2f\xf7\x87\xc6\xfc\x3e\xe2\x36\xf0\xfb\x96\xa2\x9e\xce\x77\x9c\xd1\x07\x96\xce\x10\x31\xe8\xec\x54\x73\x9c\xf8\xd0\x7d\x61\x7c\x8f\xf6\xaa\x98\x2d\x9f\x67\x45\x36\x1b\xaa\x66\x3b\x60\xc9\x9f\x62\x44\x93\x83\x38\xac\xd2\x5f\xb3\xf5\x1a\x2d\xf8\x62\xa0\x96\x81\x22\x4b\xc2\xb7\x40\xaa\xc1\xea\xc8\xb0\xa8\x38\x54\x4b\xea\x26\x34\x2a\xb9\x22\xce\x9a\x8c\x90\x22\xe0\x26\x4d\x5e\xaa\x61\x44\xaa\x61\x4a\xef\xcf\x00\xd6\xbf\x40\x5b\x62\x9d\x1c\xce\x05\x58\x4f\x84\xd0\xef\x0d\x73\x6c\x17\xff\x3d\xb3\x7b\x96\xe7\xa5\xe5\xde\x23\x54\xb3\x5c\x2d\xea\x90\x79\x38\xf7\x0d\xcb\x37\x43\x20\xef\x60\x46\x3c\xdf\xa2\x76\xe4\xd1\xc8\x25\x26\x9d\x07\x26\x31\x22\x37\x9c\x91\x59\xe8\xf8\x76\x60\x51\x33\x32\xc8\xc2\xf7\xf4\xfd\xe7\xd1\x98\xc3\x72\x89\x41\x4c\xf8\xda\x84\x91\xe6\xd4\x8b\x16\xc4\xf0\xcd\xc0\x0a\x6d\xea\x44\xb0\x36\x7f\x1e\x78\xe1\x82\x1a\x91\x49\x2c\x78\xcb\x09\x67\xd4\x8d\xe6\x44\xcc\xf1\x17\x4a\x92\xba\xe6\x46\x1f\x7d\xaf\xd8\x1b\x8f\x87\x6d\x79\x43\x6d\x7e\x47\x18\x26\x2a\xa5\xf3\xcd\x59\x1c\x6b\x3d\x76\xc2\xd0\xff\x30\xa6\xef\x18\xaf\xf3\xcd\x2a\xa3\x13\x86\xd6\x98\xdf\x89\x79\x29\x13\x2d\x13\x69\xe2\x3c\x74\x9a\xbd\xb8\x0b\x89\xe5\xd6\x36\x55\xd5\x5e\xfd\xb5\x5f\x2b\x6d\xec\x8f\xb0\x53\x7f\xca\x49\x40\x73\x1e\x88\x79\x72\xe4\xd5\x5e\x95\x28\x15\x05\xf6\x4a\x36\xe3\x44\xd3\xe1\x63\xd0\x7b\x7f\xce\x96\x70\x2a\x3a\x6e\x80\xd8\x8b\x96\xd2\x81\xe1\x29\xac\xad\x33\x7e\xc6\x15\x8d\xe6\xa7\x30\x14\xd6\x91\xe1\x2b\xd1\x99\x06\xa3\xa3\x6b\x0e\xeb\xe8\x89\x87\xa2\x74\x54\x35\x88\x12\x9e\x2e\x34\x2f\x26\x2f\x70\x6c\x10\x65\x59\xd5\x0a\xae\xe6\x18\x24\x5f\xd2\xa3\xd3\x99\x74\x80\xbb\x4a\xe5\xe2\x91\x52\x57\xe5\xc3\x35\x46\x97\xff\xe3\x8a\x6b\x6b\xec\x2f\xff\xd4\xf7\x87\x78\xd7\xcb\x6b\x03\x74\x0e\xcf\xff\x95\x71\x65\xe8\x35\x32\x60\xb5\xb7\x26\x3e\x74\xb2\x5e\x77\x59\x13\xda\x48\x72\xcc\xbd\xbe\x8d\x1e\x05\xa5\x0d\xe4\x6c\x85\x9a\x8c\x9d\xa6\xaf\xb3\x8a\x28\x01\x55\x13\x63\xb3\x54\x2d\x10\x6d\x03\x80\xfd\x5e\x5b\xb5\x68\x9e\x2c\x20\x59\x23\xeb\xe1\x32\x7a\x83\x62\x16\x22\x12\x27\x43\x98\x27\xaf\x80\xf9\xdb\xa0\xe8\xe1\x8a\xa6\x4e\x29\x45\xa0\x24\x2c\x7c\xa4\x9b\x04\x6e\x1e\xcd\x04\xea\x27\x4e\xe3\xdd\x79\x93\xc4\xfa\x9f\x48\xf1\x7d\x62\x64\x60\x82\x98\xda\xa2\x80\x6b\x82\xb8\x3e\x9e\xaf\x22\x9b\x55\x15\x4c\x8d\x13\x1c\x3d\xad\xaf\x74\xcf\x27\x2b\xb6\xa7\x0e\xe1\x61\xbb\x67\x5f\x25\xc1\xc3\x06\xb2\x9e\x7a\xba\x87\x72\xda\x3b\x16\x51\xde\x2a\x42\x0e\x35\xa9\xf7\x59\x46\x86\xd3\xba\xa4\x1e\x36\x0b\x63\x1c\x9c\x59\x45\x87\x17\xf4\x3f\x94\xd9\xde\x5b\xaa\x73\xd8\x6a\xfa\xf4\x0c\x51\xc0\x94\x9b\xaa\xc5\xfd\x7f\xd2\xa8\x97\x16\xc5\x79\x51\xca\x9f\x76\x8c\xb9\x73\x35\xc3\xd6\xb4\x33\xbc\x60\xd7\xfa\x76\xf4\x9c\xa8\xff\xf9\x4c\x1f\xcf\x32\x0e\x56\x7d\x04\x3a\x1d\x32\x56\x3f\xda\x09\xe4\x53\x6a\xa5\x1f\x53\x87\x00\xd9\xf6\x8f\x75\x2d\xea\x5b\x7e\x5a\x07\x2b\x90\xf5\xe0\xc8\x19\x68\x1b\xf6\xb4\xdd\x84\xfb\xe0\x5e\xf6\x9e\xc3\xf0\xbe\x21\x8d\xa2\x8a\x34\x5e\x23\xaa\x56\xd1\x4c\x4c\xb7\x62\x5e\xdd\xd6\x20\x9d\xfc\x94\x63\xa7\x45\x7b\x99\xec\x3c\x9c\x93\x7b\x61\x1b\x12\x1e\x2c\x3e\xfc\x44\xa4\x33\xaf\x81\xe9\xfa\x54\x4b\x32\x38\x12\xe4\x00\x24\xd5\x6c\x8b\x7f\xa0\xdc\x83\x1e\xca\xbf\x76\x37\x6f\x50\x5b\x6d\xb4\x19\x54\xc9\xd4\xb2\x0e\xdd\xa4\xea\x50\x0c\x5a\xfa\x1a\x2b\x33\x33\xfa\x15\xb9\xdd\x02\x65\xf6\x26\xb0\xe1\xd4\x87\x40\xe9\xaf\x35\xd7\x49\x59\x38\x53\xba\xd0\x20\x4d\x63\x70\xdd\x5a\x56\xe9\xff\x70\x98\x27\x2b\xf6\x7b\xf0\xb5\x61\xdd\x9d\x59\xf5\xc8\xf3\x28\x2b\x37\xe2\xbe\x30\xb4\xbc\x37\x7b\x99\xab\x02\xc2\x82\x2b\x2a\x7a\x57\x7d\x8b\x98\x27\xe8\xab\x57\xef\xfe\x12\x25\xb9\xe5\x0e\x34\x24\x9b\xb2\x62\xa5\x64\xf7\xe9\x95\xba\x99\x36\xd9\xf2\x3d\x3c\x4d\x55\x9e\x3e\xbb\xf0\x68\x9f\x20\x77\x45\x63\xd7\x32\x39\x2c\xdb\x1a\xe6\x3b\x03\xfe\x7a\x99\xe5\xcb\xba\xcc\xdc\x00\xd7\xca\xc8\xde\x92\xbb\xfb\x4a\xa2\x5b\x40\x69\x2a\xf9\x67\x71\xb2\x93\xb3\x21\x87\xfa\x15\xf6\xfb\x92\x99\xcf\xe6\x30\xde\xc8\xb8\xcb\x01\xa8\x73\xf6\xe4\xd4\x71\xdd\x25\xfb\x5b\x07\xfe\xf6\xe1\xd3\xb7\x75\x80\x95\x83\xed\xd0\x19\xb2\xac\x7d\x5a\x56\xa1\xb3\xcc\x93\x72\x4b\x7f\xbf\x4e\xff\x0b\xf3\x61\x25\x10\xdc\x20\xc4\x6e\x3f\x17\x52\xf0\xbe\xe6\x29\xb3\x17\x87\x5d\x27\xdc\x06\x09\x03\x4f\x78\xd0\x3a\xfb\xb3\xbc\x4c\xc5\x25\xbb\x3e\x71\x9b\x01\x16\x50\xf8\x11\xab\xee\x6c\xfd\x6a\xb8\x66\xa1\x60\x1e\xf2\x52\xa2\x91\x14\xf6\x12\x1b\xff\xfd\x8b\xc7\x62\xc4\x91\x1c\x37\x8f\x97\xab\xb2\x39\x3a\xa8\x4f\x42\x73\x83\x79\x48\x5c\xfb\x50\x50\xc9\x8c\xf3\x76\x51\x70\x7e\x3c\x2d\x35\xaa\xa1\x7b\x88\x3c\xed\xeb\xf4\x86\xd4\x96\x69\xb1\x4b\x0d\x51\x1b\xb3\x9a\xc7\xe5\xea\x62\x3f\x5f\x14\x72\xbc\x03\x95\x62\x5f\xed\x07\xaa\xd7\x03\x71\xbc\xff\xfe\x23\xb9\xef\x3d\x72\xd0\x8a\x87\x1c\x78\x6d\xad\x00\x70\x80\x7b\x68\x84\xe9\xd3\x4a\xa4\xfc\x74\xc4\x86\xab\x08\xff\x91\xde\xc5\x18\x6f\xd2\x0f\xa5\xf8\x71\x08\xa8\xa2\xf3\x3a\x17\x8d\x12\x3f\x73\xed\xfa\xfd\x54\x31\xbd\xb3\xe6\x83\x05\xef\xdd\xd2\x35\x11\x1f\x3c\x89\x1a\xd8\x2e\x7a\xf4\xc0\xba\x0b\x3f\xf4\x1e\x58\x27\xac\x7d\x7b\xae\xe9\x3a\x42\xab\xeb\xcc\x6a\x88\x41\x13\x15\xec\xfa\xb9\x90\x08\x27\x50\xaf\xa3\x70\xb5\x69\x2e\x68\x1f\xec\x48\xa7\xa0\x7a\xbd\x92\x9e\xf0\x1f\x58\x95\xef\x20\x60\x5e\x7b\xd1\x86\x46\xa8\x68\xfb\xe0\xe5\x7b\x56\xeb\x70\x47\x12\xc1\xc9\x7d\x4d\x94\x22\x0c\x15\xc9\xf7\x71\xc6\x0e\xcd\xef\xc4\xbf\x01\x44\x7f\x98\x32\xce\x44\xf5\x7c\x61\xbf\xa0\x05\xa8\x77\x59\xaa\x1f\x74\xef\xa2\x14\x23\x12\x8e\x58\x9c\xba\xa4\x6e\xda\xdb\x25\xba\x67\x1b\x7f\x47\x00\xda\x3b\x20\xdf\x61\x29\xfd\xbf\xa6\x71\xd9\xbb\x2c\xac\x68\x3e\x64\x55\xf8\x1e\x93\x5d\x68\x87\x69\x8a\x21\xd5\xbe\x7a\xd6\x55\xb6\x2b\xc3\x2b\x75\xe1\xd9\xa2\x7e\x84\xcb\x7a\xef\xa2\xf0\x16\x3f\x48\x36\x4b\x4b\x83\x58\x15\x8b\xfa\x29\xe2\x3b\x7a\xa2\x44\x64\xd0\x7d\xca\x7a\x61\x2b\xb3\x21\x90\x81\x86\xd8\x07\x17\x4f\x4b\x66\x4f\xb5\x62\x83\x6d\x99\x65\xd5\x15\xdb\xc4\x94\x90\x42\x7b\x65\xb9\x73\xcf\x31\x84\x63\xff\x07\x69\xb2\x89\xf9\xcd\x42\x1c\x9b\xc2\xc7\x4f\x5c\xe9\xa7\x87\xeb\xf7\xc3\x19\x21\xb2\xeb\x48\x95\x84\x87\xd9\x5d\x1c\x8e\x23\xfe\x85\x1f\x04\xee\xcc\x72\xc9\xdc\x25\x74\xe6\x1a\x96\xe3\x44\xee\xc2\xf3\x8c\x59\x10\x00\x33\x5b\xcc\xe7\x96\xe3\x06\xfe\xc2\x0a\x2c\xdf\x89\x4c\x6a\xf9\x73\x62\x19\x0e\x75\x9c\x99\x63\x2c\x28\x91\xf9\x78\x9c\x63\xf7\x9e\x24\xb0\xf3\x21\x47\x29\x16\x5d\x5d\x41\x79\x83\xb1\x1c\x59\x7e\x4e\xc9\x1a\x9d\xd1\x88\xaf\x93\x46\xcf\x87\xba\x05\xf3\x2a\x06\x54\x40\xf1\x33\x5c\x26\x8f\x20\xc2\xff\x0f\x43\x3c\x83\xbd\xe4\x28\x01\x00")
 
 func thorYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -0,0 +1,266 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package ethrpc
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/runtime"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+	"github.com/vechain/thor/xenv"
+)
+
+const (
+	maxTopicCombinations = 64
+	maxLogs              = 10000
+)
+
+var errReverted = errors.New("execution reverted")
+
+// EthRPC serves a subset of Ethereum JSON-RPC methods, so that existing eth tooling can read the chain.
+// Transactions must be in thor format, since eth transactions can not be mapped to thor ones.
+type EthRPC struct {
+	server *rpc.Server
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB, pool *txpool.TxPool, forkConfig thor.ForkConfig) *EthRPC {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &EthAPI{chain, stateCreator, logDB, pool, forkConfig}); err != nil {
+		panic(err)
+	}
+	if err := server.RegisterName("net", &NetAPI{chain}); err != nil {
+		panic(err)
+	}
+	return &EthRPC{server}
+}
+
+func (e *EthRPC) Mount(root *mux.Router, pathPrefix string) {
+	root.Path(pathPrefix).Methods("POST").Handler(e.server)
+}
+
+// NetAPI implements 'net' namespace.
+type NetAPI struct {
+	chain *chain.Chain
+}
+
+// Version returns chain tag as network id.
+func (n *NetAPI) Version() string {
+	return strconv.Itoa(int(n.chain.Tag()))
+}
+
+// EthAPI implements 'eth' namespace.
+type EthAPI struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+	pool         *txpool.TxPool
+	forkConfig   thor.ForkConfig
+}
+
+// ChainId returns chain tag as chain id.
+func (e *EthAPI) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(e.chain.Tag())
+}
+
+// BlockNumber returns number of the best block.
+func (e *EthAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(e.chain.BestBlock().Header().Number())
+}
+
+// GetBalance returns VET balance of the account.
+func (e *EthAPI) GetBalance(address common.Address, blockNr rpc.BlockNumber) (*hexutil.Big, error) {
+	header, err := e.getBlockHeader(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	balance := st.GetBalance(thor.Address(address))
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// Call executes a message call on the state of given block, without creating a transaction.
+func (e *EthAPI) Call(args CallArgs, blockNr rpc.BlockNumber) (hexutil.Bytes, error) {
+	header, err := e.getBlockHeader(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+
+	gas := uint64(args.Gas)
+	if gas == 0 {
+		gas = math.MaxUint64
+	}
+	gasPrice := new(big.Int)
+	if args.GasPrice != nil {
+		gasPrice = (*big.Int)(args.GasPrice)
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = (*big.Int)(args.Value)
+	}
+	var to *thor.Address
+	if args.To != nil {
+		addr := thor.Address(*args.To)
+		to = &addr
+	}
+
+	blockCtx, err := runtime.NewBlockContext(header)
+	if err != nil {
+		return nil, err
+	}
+	rt := runtime.New(e.chain.NewSeeker(header.ParentID()), st, blockCtx, e.forkConfig)
+
+	output := rt.ExecuteClause(tx.NewClause(to).WithData(args.Data).WithValue(value), 0, gas, &xenv.TransactionContext{
+		Origin:     thor.Address(args.From),
+		GasPrice:   gasPrice,
+		ProvedWork: &big.Int{}})
+
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, err
+	}
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	if output.VMErr != nil {
+		return nil, errReverted
+	}
+	return output.Data, nil
+}
+
+// SendRawTransaction adds the RLP encoded thor transaction into tx pool.
+func (e *EthAPI) SendRawTransaction(encoded hexutil.Bytes) (common.Hash, error) {
+	var trx *tx.Transaction
+	if err := rlp.DecodeBytes(encoded, &trx); err != nil {
+		return common.Hash{}, errors.Wrap(err, "decode thor transaction")
+	}
+	if err := e.pool.AddLocal(trx); err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(trx.ID()), nil
+}
+
+// GetLogs returns event logs on trunk matching the query.
+func (e *EthAPI) GetLogs(ctx context.Context, query FilterQuery) ([]*Log, error) {
+	filter := &logdb.EventFilter{
+		Order: logdb.ASC,
+		// one more to detect results exceeded
+		Options: &logdb.Options{Limit: maxLogs + 1},
+	}
+	if query.BlockHash != nil {
+		if query.FromBlock != nil || query.ToBlock != nil {
+			return nil, errors.New("blockHash: can not be used with fromBlock or toBlock")
+		}
+		header, err := e.chain.GetBlockHeader(thor.Bytes32(*query.BlockHash))
+		if err != nil {
+			return nil, err
+		}
+		// log db only indexes trunk blocks
+		trunkID, err := e.chain.GetTrunkBlockID(header.Number())
+		if err != nil {
+			return nil, err
+		}
+		if trunkID != header.ID() {
+			return nil, errors.New("blockHash: not on trunk")
+		}
+		filter.Range = &logdb.Range{Unit: logdb.Block, From: uint64(header.Number()), To: uint64(header.Number())}
+	} else {
+		filter.Range = &logdb.Range{
+			Unit: logdb.Block,
+			From: e.resolveNumber(query.FromBlock),
+			To:   e.resolveNumber(query.ToBlock),
+		}
+		if filter.Range.From > filter.Range.To {
+			return nil, errors.New("fromBlock: greater than toBlock")
+		}
+	}
+	topicSet, err := query.topicSet()
+	if err != nil {
+		return nil, err
+	}
+	filter.TopicSet = topicSet
+
+	var events []*logdb.Event
+	if len(query.Addresses) == 0 {
+		if events, err = e.logDB.FilterEvents(ctx, filter); err != nil {
+			return nil, err
+		}
+	} else {
+		// logdb filters a single address, so query each one and merge
+		for _, addr := range query.Addresses {
+			address := thor.Address(addr)
+			filter.Address = &address
+			found, err := e.logDB.FilterEvents(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, found...)
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			if events[i].BlockNumber != events[j].BlockNumber {
+				return events[i].BlockNumber < events[j].BlockNumber
+			}
+			return events[i].Index < events[j].Index
+		})
+	}
+	if len(events) > maxLogs {
+		return nil, errors.Errorf("query returned more than %v results", maxLogs)
+	}
+
+	logs := make([]*Log, 0, len(events))
+	for _, event := range events {
+		meta, err := e.chain.GetTransactionMeta(event.TxID, event.BlockID)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, convertEvent(event, meta.Index))
+	}
+	return logs, nil
+}
+
+func (e *EthAPI) getBlockHeader(blockNr rpc.BlockNumber) (*block.Header, error) {
+	if blockNr < 0 {
+		// latest and pending
+		return e.chain.BestBlock().Header(), nil
+	}
+	if int64(blockNr) > math.MaxUint32 {
+		return nil, errors.New("block number exceeded")
+	}
+	return e.chain.GetTrunkBlockHeader(uint32(blockNr))
+}
+
+// resolveNumber converts block number tag, nil means latest.
+func (e *EthAPI) resolveNumber(blockNr *rpc.BlockNumber) uint64 {
+	if blockNr == nil || *blockNr < 0 {
+		return uint64(e.chain.BestBlock().Header().Number())
+	}
+	return uint64(*blockNr)
+}
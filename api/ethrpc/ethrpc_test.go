@@ -0,0 +1,263 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package ethrpc_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/ethrpc"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var (
+	ts    *httptest.Server
+	c     *chain.Chain
+	pool  *txpool.TxPool
+	blk   *block.Block
+	trx   *tx.Transaction
+	nonce uint64
+)
+
+func TestBlockNumber(t *testing.T) {
+	initEthRPCServer(t)
+	defer ts.Close()
+
+	var num hexutil.Uint64
+	call(t, "eth_blockNumber", &num)
+	assert.Equal(t, hexutil.Uint64(1), num)
+
+	var chainID hexutil.Uint64
+	call(t, "eth_chainId", &chainID)
+	assert.Equal(t, hexutil.Uint64(c.Tag()), chainID)
+}
+
+func TestGetBalance(t *testing.T) {
+	initEthRPCServer(t)
+	defer ts.Close()
+
+	recipient := genesis.DevAccounts()[1].Address
+	var balance hexutil.Big
+	call(t, "eth_getBalance", &balance, recipient.String(), "latest")
+	assert.NotEqual(t, 0, (*big.Int)(&balance).Sign())
+
+	call(t, "eth_getBalance", &balance, thor.BytesToAddress([]byte("nobody")).String(), "0x0")
+	assert.Equal(t, 0, (*big.Int)(&balance).Sign())
+}
+
+func TestCall(t *testing.T) {
+	initEthRPCServer(t)
+	defer ts.Close()
+
+	method, _ := builtin.Energy.ABI.MethodByName("symbol")
+	data, err := method.EncodeInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output hexutil.Bytes
+	call(t, "eth_call", &output, map[string]interface{}{
+		"to":   builtin.Energy.Address.String(),
+		"data": hexutil.Bytes(data),
+	}, "latest")
+
+	var symbol string
+	if err := method.DecodeOutput(output, &symbol); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "VTHO", symbol)
+}
+
+func TestGetLogs(t *testing.T) {
+	initEthRPCServer(t)
+	defer ts.Close()
+
+	transferEvent, _ := builtin.Energy.ABI.EventByName("Transfer")
+	var logs []*ethrpc.Log
+	call(t, "eth_getLogs", &logs, map[string]interface{}{
+		"fromBlock": "0x0",
+		"address":   builtin.Energy.Address.String(),
+		"topics":    []interface{}{transferEvent.ID().String()},
+	})
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, trx.ID().Bytes(), logs[0].TransactionHash.Bytes())
+	assert.Equal(t, blk.Header().ID().Bytes(), logs[0].BlockHash.Bytes())
+	assert.Equal(t, hexutil.Uint64(1), logs[0].BlockNumber)
+
+	call(t, "eth_getLogs", &logs, map[string]interface{}{
+		"blockHash": blk.Header().ID().String(),
+		"topics":    []interface{}{nil, []interface{}{thor.Bytes32{}.String()}},
+	})
+	assert.Equal(t, 0, len(logs))
+
+	assert.Contains(t, callError(t, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x1",
+		"toBlock":   "0x0",
+	}), "greater than toBlock")
+
+	// a block not on trunk
+	branch := new(block.Builder).ParentID(c.GenesisBlock().Header().ID()).Timestamp(blk.Header().Timestamp() + 1).Build()
+	if _, err := c.AddBlock(branch, nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, callError(t, "eth_getLogs", map[string]interface{}{
+		"blockHash": branch.Header().ID().String(),
+	}), "not on trunk")
+}
+
+func TestSendRawTransaction(t *testing.T) {
+	initEthRPCServer(t)
+	defer ts.Close()
+	defer pool.Close()
+
+	raw, err := rlp.EncodeToBytes(newEnergyTransferTx(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hash thor.Bytes32
+	call(t, "eth_sendRawTransaction", &hash, hexutil.Bytes(raw))
+	assert.Equal(t, 1, len(pool.Dump()))
+	assert.Equal(t, pool.Dump()[0].Tx.ID(), hash)
+}
+
+func call(t *testing.T, method string, result interface{}, params ...interface{}) {
+	resp := post(t, method, params...)
+	if resp.Error != nil {
+		t.Fatal(resp.Error.Message)
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// callError calls the method expecting an error, and returns the error message.
+func callError(t *testing.T, method string, params ...interface{}) string {
+	resp := post(t, method, params...)
+	if resp.Error == nil {
+		t.Fatal("error expected")
+	}
+	return resp.Error.Message
+}
+
+type response struct {
+	Result json.RawMessage
+	Error  *struct{ Message string }
+}
+
+func post(t *testing.T, method string, params ...interface{}) *response {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	res, err := http.Post(ts.URL+"/eth", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var resp response
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return &resp
+}
+
+func newEnergyTransferTx(t *testing.T) *tx.Transaction {
+	method, _ := builtin.Energy.ABI.MethodByName("transfer")
+	data, err := method.EncodeInput(genesis.DevAccounts()[1].Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce++
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Clause(tx.NewClause(&builtin.Energy.Address).WithData(data)).
+		Gas(300000).
+		Nonce(nonce).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trx.WithSignature(sig)
+}
+
+func initEthRPCServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ = chain.New(db, b)
+	logDB, _ := logdb.NewMem()
+
+	trx = newEnergyTransferTx(t)
+	p := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := p.Mock(b.Header(), b.Header().Timestamp()+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	newBlock, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddBlock(newBlock, receipts); err != nil {
+		t.Fatal(err)
+	}
+	batch := logDB.Prepare(newBlock.Header())
+	for i, txn := range newBlock.Transactions() {
+		origin, _ := txn.Signer()
+		txBatch := batch.ForTransaction(txn.ID(), origin)
+		for _, output := range receipts[i].Outputs {
+			txBatch.Insert(output.Events, output.Transfers)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	blk = newBlock
+
+	pool = txpool.New(c, stateC, thor.NoFork, txpool.DefaultPoolConfig)
+	router := mux.NewRouter()
+	ethrpc.New(c, stateC, logDB, pool, thor.NoFork).Mount(router, "/eth")
+	ts = httptest.NewServer(router)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+)
+
+// CallArgs arguments of eth_call.
+type CallArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// FilterQuery arguments of eth_getLogs.
+type FilterQuery struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses addressList      `json:"address"`
+	Topics    []topicAlt       `json:"topics"`
+}
+
+// addressList accepts either a single address or an array of addresses.
+type addressList []common.Address
+
+func (a *addressList) UnmarshalJSON(data []byte) error {
+	var single common.Address
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = addressList{single}
+		return nil
+	}
+	var list []common.Address
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("address: should be an address or array of addresses")
+	}
+	*a = list
+	return nil
+}
+
+// topicAlt alternatives of a topic position. Empty matches any.
+type topicAlt []common.Hash
+
+func (t *topicAlt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = nil
+		return nil
+	}
+	var single common.Hash
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = topicAlt{single}
+		return nil
+	}
+	var list []common.Hash
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("topics: should be null, a topic or array of topics")
+	}
+	*t = list
+	return nil
+}
+
+// topicSet expands topic alternatives into logdb topic set, which is OR of AND conditions.
+func (q *FilterQuery) topicSet() ([][5]*thor.Bytes32, error) {
+	if len(q.Topics) > 5 {
+		return nil, errors.New("topics: too many topics")
+	}
+	sets := [][5]*thor.Bytes32{{}}
+	for i, alt := range q.Topics {
+		if len(alt) == 0 {
+			continue
+		}
+		if len(sets)*len(alt) > maxTopicCombinations {
+			return nil, errors.New("topics: too many combinations")
+		}
+		expanded := make([][5]*thor.Bytes32, 0, len(sets)*len(alt))
+		for _, set := range sets {
+			for _, topic := range alt {
+				t := thor.Bytes32(topic)
+				set[i] = &t
+				expanded = append(expanded, set)
+			}
+		}
+		sets = expanded
+	}
+	if len(sets) == 1 && sets[0] == [5]*thor.Bytes32{} {
+		return nil, nil
+	}
+	return sets, nil
+}
+
+// Log eth style log.
+type Log struct {
+	Address          common.Address `json:"address"`
+	Topics           []common.Hash  `json:"topics"`
+	Data             hexutil.Bytes  `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	LogIndex         hexutil.Uint64 `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+func convertEvent(event *logdb.Event, txIndex uint64) *Log {
+	l := &Log{
+		Address:          common.Address(event.Address),
+		Topics:           make([]common.Hash, 0, len(event.Topics)),
+		Data:             event.Data,
+		BlockNumber:      hexutil.Uint64(event.BlockNumber),
+		BlockHash:        common.Hash(event.BlockID),
+		TransactionHash:  common.Hash(event.TxID),
+		TransactionIndex: hexutil.Uint64(txIndex),
+		LogIndex:         hexutil.Uint64(event.Index),
+	}
+	for _, topic := range event.Topics {
+		if topic != nil {
+			l.Topics = append(l.Topics, common.Hash(*topic))
+		}
+	}
+	return l
+}
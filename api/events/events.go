@@ -7,21 +7,28 @@ package events
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/vechain/thor/api/abis"
 	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/thor"
 )
 
+// maxCriteriaAddresses limits addresses of all criteria in one query.
+const maxCriteriaAddresses = 256
+
 type Events struct {
-	db *logdb.LogDB
+	db   *logdb.LogDB
+	abis *abis.Registry
 }
 
-func New(db *logdb.LogDB) *Events {
+func New(db *logdb.LogDB, registry *abis.Registry) *Events {
 	return &Events{
 		db,
+		registry,
 	}
 }
 
@@ -33,8 +40,8 @@ func (e *Events) filter(ctx context.Context, filter *Filter) ([]*FilteredEvent,
 		return nil, err
 	}
 	fes := make([]*FilteredEvent, len(events))
-	for i, e := range events {
-		fes[i] = convertEvent(e)
+	for i, ev := range events {
+		fes[i] = convertEvent(ev, e.abis)
 	}
 	return fes, nil
 }
@@ -58,6 +65,23 @@ func (e *Events) handleFilter(w http.ResponseWriter, req *http.Request) error {
 	} else {
 		filter.Order = logdb.DESC
 	}
+	if len(filter.TopicSets)+len(filter.CriteriaSet) > logdb.MaxCriteria {
+		return utils.BadRequest(fmt.Errorf("topic sets and criteria exceeds %v", logdb.MaxCriteria), "criteriaSet")
+	}
+	addrCount := 0
+	for _, criteria := range filter.CriteriaSet {
+		if criteria != nil {
+			addrCount += len(criteria.Addresses)
+		}
+	}
+	if addrCount > maxCriteriaAddresses {
+		return utils.BadRequest(fmt.Errorf("addresses exceeds %v", maxCriteriaAddresses), "criteriaSet")
+	}
+	options, err := utils.PageOptions(filter.Options)
+	if err != nil {
+		return err
+	}
+	filter.Options = options
 	fes, err := e.filter(req.Context(), &filter)
 	if err != nil {
 		return err
@@ -15,6 +15,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/api/abis"
 	"github.com/vechain/thor/api/events"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/logdb"
@@ -22,8 +24,15 @@ import (
 	"github.com/vechain/thor/tx"
 )
 
+const eventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"key","type":"uint8"},{"indexed":false,"name":"value","type":"string"}],"name":"Set","type":"event"}]`
+
 var contractAddr = thor.BytesToAddress([]byte("contract"))
 var ts *httptest.Server
+var decodableEvent = func() *abi.Event {
+	parsed, _ := abi.New([]byte(eventABI))
+	ev, _ := parsed.EventByName("Set")
+	return ev
+}()
 
 func TestEvents(t *testing.T) {
 	initEventServer(t)
@@ -66,6 +75,34 @@ func getEvents(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, limit, len(logs), "should be `limit` logs")
+	for _, log := range logs {
+		assert.Nil(t, log.Decoded, "topic0 doesn't match event in abi")
+	}
+}
+
+func TestDecodedEvents(t *testing.T) {
+	initEventServer(t)
+	defer ts.Close()
+
+	topic0 := decodableEvent.ID()
+	f, err := json.Marshal(&events.Filter{
+		TopicSets: []*events.TopicSet{{Topic0: &topic0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := httpPost(t, ts.URL+"/events", f)
+	var logs []*events.FilteredEvent
+	if err := json.Unmarshal(res, &logs); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(logs)) && assert.NotNil(t, logs[0].Decoded) {
+		assert.Equal(t, "Set", logs[0].Decoded.Name)
+		assert.Equal(t, map[string]interface{}{
+			"key":   "1",
+			"value": "hello",
+		}, logs[0].Decoded.Params)
+	}
 }
 
 func initEventServer(t *testing.T) {
@@ -89,9 +126,29 @@ func initEventServer(t *testing.T) {
 		}
 		header = new(block.Builder).ParentID(header.ID()).Build().Header()
 	}
+	data, err := decodableEvent.Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Prepare(header).ForTransaction(thor.BytesToBytes32([]byte("txID")), thor.BytesToAddress([]byte("txOrigin"))).
+		Insert(tx.Events{{
+			Address: contractAddr,
+			Topics:  []thor.Bytes32{decodableEvent.ID(), thor.BytesToBytes32([]byte{1})},
+			Data:    data,
+		}}, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := abis.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(contractAddr, []byte(eventABI)); err != nil {
+		t.Fatal(err)
+	}
 
 	router := mux.NewRouter()
-	events.New(db).Mount(router, "/events")
+	events.New(db, registry).Mount(router, "/events")
 	ts = httptest.NewServer(router)
 }
 
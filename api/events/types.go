@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/api/abis"
 	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/thor"
@@ -22,12 +23,24 @@ type TopicSet struct {
 	Topic4 *thor.Bytes32 `json:"topic4"`
 }
 
+func (ts *TopicSet) topics() [5]*thor.Bytes32 {
+	return [5]*thor.Bytes32{ts.Topic0, ts.Topic1, ts.Topic2, ts.Topic3, ts.Topic4}
+}
+
+// EventCriteria matches events emitted by any of the addresses, with topics of the topic set.
+// Empty addresses or null topic matches any.
+type EventCriteria struct {
+	Addresses []thor.Address `json:"addresses"`
+	TopicSet
+}
+
 type Filter struct {
-	Address   *thor.Address
-	TopicSets []*TopicSet
-	Range     *logdb.Range
-	Options   *logdb.Options
-	Order     logdb.Order
+	Address     *thor.Address
+	TopicSets   []*TopicSet
+	CriteriaSet []*EventCriteria
+	Range       *logdb.Range
+	Options     *logdb.Options
+	Order       logdb.Order
 }
 
 func convertFilter(filter *Filter) *logdb.EventFilter {
@@ -40,29 +53,35 @@ func convertFilter(filter *Filter) *logdb.EventFilter {
 	if len(filter.TopicSets) > 0 {
 		var topicSets [][5]*thor.Bytes32
 		for _, topicSet := range filter.TopicSets {
-			var topics [5]*thor.Bytes32
-			topics[0] = topicSet.Topic0
-			topics[1] = topicSet.Topic1
-			topics[2] = topicSet.Topic2
-			topics[3] = topicSet.Topic3
-			topics[4] = topicSet.Topic4
-			topicSets = append(topicSets, topics)
+			topicSets = append(topicSets, topicSet.topics())
 		}
 		f.TopicSet = topicSets
 	}
+	for _, criteria := range filter.CriteriaSet {
+		if criteria == nil {
+			continue
+		}
+		f.CriteriaSet = append(f.CriteriaSet, &logdb.EventCriteria{
+			Addresses: criteria.Addresses,
+			Topics:    criteria.TopicSet.topics(),
+		})
+	}
 	return f
 }
 
-// FilteredEvent only comes from one contract
+// FilteredEvent only comes from one contract.
+// Decoded is present if ABI of the contract registered and the event matched.
 type FilteredEvent struct {
-	Topics []*thor.Bytes32           `json:"topics"`
-	Data   string                    `json:"data"`
-	Block  transactions.BlockContext `json:"block"`
-	Tx     transactions.TxContext    `json:"tx"`
+	Topics  []*thor.Bytes32           `json:"topics"`
+	Data    string                    `json:"data"`
+	Decoded *abis.DecodedEvent        `json:"decoded,omitempty"`
+	Block   transactions.BlockContext `json:"block"`
+	Tx      transactions.TxContext    `json:"tx"`
+	Cursor  *logdb.Cursor             `json:"cursor"` // to query the next page after this event
 }
 
-//convert a logdb.Event into a json format Event
-func convertEvent(event *logdb.Event) *FilteredEvent {
+//convert a logdb.Event into a json format Event, decoded with registered ABIs
+func convertEvent(event *logdb.Event, registry *abis.Registry) *FilteredEvent {
 	fe := FilteredEvent{
 		Data: hexutil.Encode(event.Data),
 		Block: transactions.BlockContext{
@@ -74,13 +93,17 @@ func convertEvent(event *logdb.Event) *FilteredEvent {
 			ID:     event.TxID,
 			Origin: event.TxOrigin,
 		},
+		Cursor: event.Cursor(),
 	}
 	fe.Topics = make([]*thor.Bytes32, 0)
+	topics := make([]thor.Bytes32, 0, 5)
 	for i := 0; i < 5; i++ {
 		if event.Topics[i] != nil {
 			fe.Topics = append(fe.Topics, event.Topics[i])
+			topics = append(topics, *event.Topics[i])
 		}
 	}
+	fe.Decoded = registry.DecodeEvent(event.Address, topics, event.Data)
 	return &fe
 }
 
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+import (
+	"github.com/gorilla/mux"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	maxQueryDepth  = 10 // max field nesting depth, to refuse queries walking through cyclic references endlessly
+	maxParallelism = 10 // max resolvers run in parallel per query
+)
+
+// GraphQL serves GraphQL queries on blocks, transactions, receipts, accounts and logs.
+type GraphQL struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+	schema       *graphql.Schema
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) *GraphQL {
+	g := &GraphQL{
+		chain:        chain,
+		stateCreator: stateCreator,
+		logDB:        logDB,
+	}
+	g.schema = graphql.MustParseSchema(schema, &resolver{g},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxParallelism(maxParallelism))
+	return g
+}
+
+func (g *GraphQL) getBlockHeader(revision string) (*block.Header, error) {
+	return utils.GetBlockHeader(g.chain, revision)
+}
+
+func (g *GraphQL) getBlock(id thor.Bytes32) (*blockResolver, error) {
+	header, err := g.chain.GetBlockHeader(id)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{g, header}, nil
+}
+
+func (g *GraphQL) isTrunk(header *block.Header) (bool, error) {
+	ancestorID, err := g.chain.GetAncestorBlockID(g.chain.BestBlock().Header().ID(), header.Number())
+	if err != nil {
+		return false, err
+	}
+	return ancestorID == header.ID(), nil
+}
+
+// getTransaction gets tx on trunk.
+func (g *GraphQL) getTransaction(txID thor.Bytes32) (*txResolver, error) {
+	meta, err := g.chain.GetTrunkTransactionMeta(txID)
+	if err != nil {
+		return nil, err
+	}
+	return g.getTransactionAt(meta.BlockID, meta.Index)
+}
+
+// getTransactionInBlock gets tx in the given block.
+func (g *GraphQL) getTransactionInBlock(txID thor.Bytes32, blockID thor.Bytes32) (*txResolver, error) {
+	meta, err := g.chain.GetTransactionMeta(txID, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return g.getTransactionAt(meta.BlockID, meta.Index)
+}
+
+func (g *GraphQL) getTransactionAt(blockID thor.Bytes32, index uint64) (*txResolver, error) {
+	header, err := g.chain.GetBlockHeader(blockID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := g.chain.GetTransaction(blockID, index)
+	if err != nil {
+		return nil, err
+	}
+	return &txResolver{g, tx, header, index}, nil
+}
+
+func (g *GraphQL) Mount(root *mux.Router, pathPrefix string) {
+	root.Path(pathPrefix).Methods("POST").Handler(&relay.Handler{Schema: g.schema})
+}
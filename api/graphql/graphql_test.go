@@ -0,0 +1,248 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/graphql"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var (
+	ts  *httptest.Server
+	blk *block.Block
+	trx *tx.Transaction
+	to  = thor.BytesToAddress([]byte("to"))
+)
+
+func TestBlockQuery(t *testing.T) {
+	initGraphQLServer(t)
+	defer ts.Close()
+
+	var res struct {
+		Block struct {
+			ID           string
+			Number       int32
+			IsTrunk      bool
+			Parent       struct{ Number int32 }
+			Transactions []struct {
+				ID      string
+				Origin  string
+				Clauses []struct{ To, Value string }
+				Receipt struct {
+					Reverted bool
+					Outputs  []struct {
+						Transfers []struct{ Recipient, Amount string }
+					}
+				}
+			}
+		}
+	}
+	query(t, `{ block(revision: "best") {
+		id number isTrunk parent { number }
+		transactions { id origin clauses { to value } receipt { reverted outputs { transfers { recipient amount } } } }
+	} }`, &res)
+
+	assert.Equal(t, blk.Header().ID().String(), res.Block.ID)
+	assert.Equal(t, int32(1), res.Block.Number)
+	assert.True(t, res.Block.IsTrunk)
+	assert.Equal(t, int32(0), res.Block.Parent.Number)
+	assert.Equal(t, 1, len(res.Block.Transactions))
+
+	txRes := res.Block.Transactions[0]
+	assert.Equal(t, trx.ID().String(), txRes.ID)
+	assert.Equal(t, genesis.DevAccounts()[0].Address.String(), txRes.Origin)
+	assert.Equal(t, to.String(), txRes.Clauses[0].To)
+	assert.False(t, txRes.Receipt.Reverted)
+	assert.Equal(t, to.String(), txRes.Receipt.Outputs[0].Transfers[0].Recipient)
+	assert.Equal(t, "0x2710", txRes.Receipt.Outputs[0].Transfers[0].Amount)
+}
+
+func TestTransferConnection(t *testing.T) {
+	initGraphQLServer(t)
+	defer ts.Close()
+
+	type connection struct {
+		Transfers struct {
+			Edges []struct {
+				Cursor string
+				Node   struct {
+					Recipient   string
+					Transaction struct{ ID string }
+				}
+			}
+			PageInfo struct {
+				EndCursor   *string
+				HasNextPage bool
+			}
+		}
+	}
+	var res connection
+	query(t, `{ transfers(filter: { recipient: "`+to.String()+`" }, first: 1) {
+		edges { cursor node { recipient transaction { id } } }
+		pageInfo { endCursor hasNextPage }
+	} }`, &res)
+
+	assert.Equal(t, 1, len(res.Transfers.Edges))
+	assert.Equal(t, trx.ID().String(), res.Transfers.Edges[0].Node.Transaction.ID)
+	assert.False(t, res.Transfers.PageInfo.HasNextPage)
+
+	var next connection
+	query(t, `{ transfers(filter: { recipient: "`+to.String()+`" }, after: "`+*res.Transfers.PageInfo.EndCursor+`") {
+		edges { cursor }
+		pageInfo { endCursor hasNextPage }
+	} }`, &next)
+	assert.Equal(t, 0, len(next.Transfers.Edges))
+	assert.Nil(t, next.Transfers.PageInfo.EndCursor)
+}
+
+func TestAccountQuery(t *testing.T) {
+	initGraphQLServer(t)
+	defer ts.Close()
+
+	var res struct {
+		Account struct {
+			Balance      string
+			Transactions struct {
+				Edges []struct {
+					Node struct{ ID string }
+				}
+			}
+		}
+	}
+	query(t, `{ account(address: "`+genesis.DevAccounts()[0].Address.String()+`") {
+		balance transactions(first: 10) { edges { node { id } } }
+	} }`, &res)
+
+	assert.NotEmpty(t, res.Account.Balance)
+	assert.Equal(t, 1, len(res.Account.Transactions.Edges))
+	assert.Equal(t, trx.ID().String(), res.Account.Transactions.Edges[0].Node.ID)
+}
+
+func TestMaxDepth(t *testing.T) {
+	initGraphQLServer(t)
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]string{
+		"query": `{ block { parent { parent { parent { parent { parent { parent { parent { parent { parent { parent { number } } } } } } } } } } } }`,
+	})
+	res, err := http.Post(ts.URL+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		Errors []struct{ Message string }
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, result.Errors)
+}
+
+func query(t *testing.T, q string, v interface{}) {
+	body, _ := json.Marshal(map[string]string{"query": q})
+	res, err := http.Post(ts.URL+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage
+		Errors []struct{ Message string }
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors[0].Message)
+	}
+	if err := json.Unmarshal(result.Data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func initGraphQLServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := chain.New(db, b)
+	logDB, _ := logdb.NewMem()
+
+	trx = new(tx.Builder).
+		ChainTag(c.Tag()).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(10000))).
+		Gas(21000).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	p := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := p.Mock(b.Header(), b.Header().Timestamp()+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	newBlock, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddBlock(newBlock, receipts); err != nil {
+		t.Fatal(err)
+	}
+	batch := logDB.Prepare(newBlock.Header())
+	for i, txn := range newBlock.Transactions() {
+		origin, _ := txn.Signer()
+		txBatch := batch.ForTransaction(txn.ID(), origin)
+		for _, output := range receipts[i].Outputs {
+			txBatch.Insert(output.Events, output.Transfers)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	blk = newBlock
+
+	router := mux.NewRouter()
+	graphql.New(c, stateC, logDB).Mount(router, "/graphql")
+	ts = httptest.NewServer(router)
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// resolver resolves the root 'Query' type.
+type resolver struct {
+	g *GraphQL
+}
+
+func (r *resolver) Block(args struct{ Revision *string }) (*blockResolver, error) {
+	var revision string
+	if args.Revision != nil {
+		revision = *args.Revision
+	}
+	header, err := r.g.getBlockHeader(revision)
+	if err != nil {
+		if r.g.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &blockResolver{r.g, header}, nil
+}
+
+func (r *resolver) Transaction(args struct{ ID string }) (*txResolver, error) {
+	txID, err := thor.ParseBytes32(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.g.getTransaction(txID)
+	if err != nil {
+		if r.g.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *resolver) Account(args struct {
+	Address  string
+	Revision *string
+}) (*accountResolver, error) {
+	addr, err := thor.ParseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	var revision string
+	if args.Revision != nil {
+		revision = *args.Revision
+	}
+	header, err := r.g.getBlockHeader(revision)
+	if err != nil {
+		return nil, err
+	}
+	return &accountResolver{r.g, addr, header}, nil
+}
+
+func (r *resolver) Events(ctx context.Context, args struct {
+	Filter *EventFilter
+	First  *int32
+	After  *string
+}) (*eventConnectionResolver, error) {
+	filter, err := args.Filter.convert()
+	if err != nil {
+		return nil, err
+	}
+	p, err := newPage(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	filter.Options = p.options()
+	events, err := r.g.logDB.FilterEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	cursors, info := p.cursors(len(events))
+	edges := make([]*eventEdgeResolver, len(cursors))
+	for i, cursor := range cursors {
+		ev := events[i]
+		topics := make([]thor.Bytes32, 0, len(ev.Topics))
+		for _, topic := range ev.Topics {
+			if topic != nil {
+				topics = append(topics, *topic)
+			}
+		}
+		edges[i] = &eventEdgeResolver{cursor, &eventResolver{
+			g:       r.g,
+			event:   &tx.Event{Address: ev.Address, Topics: topics, Data: ev.Data},
+			blockID: ev.BlockID,
+			txID:    ev.TxID,
+		}}
+	}
+	return &eventConnectionResolver{edges, info}, nil
+}
+
+func (r *resolver) Transfers(ctx context.Context, args struct {
+	Filter *TransferFilter
+	First  *int32
+	After  *string
+}) (*transferConnectionResolver, error) {
+	filter, err := args.Filter.convert()
+	if err != nil {
+		return nil, err
+	}
+	p, err := newPage(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	filter.Options = p.options()
+	transfers, err := r.g.logDB.FilterTransfers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	cursors, info := p.cursors(len(transfers))
+	edges := make([]*transferEdgeResolver, len(cursors))
+	for i, cursor := range cursors {
+		tr := transfers[i]
+		edges[i] = &transferEdgeResolver{cursor, &transferResolver{
+			g:        r.g,
+			transfer: &tx.Transfer{Sender: tr.Sender, Recipient: tr.Recipient, Amount: tr.Amount},
+			blockID:  tr.BlockID,
+			txID:     tr.TxID,
+		}}
+	}
+	return &transferConnectionResolver{edges, info}, nil
+}
+
+type blockResolver struct {
+	g      *GraphQL
+	header *block.Header
+}
+
+func (b *blockResolver) ID() string          { return b.header.ID().String() }
+func (b *blockResolver) Number() int32       { return int32(b.header.Number()) }
+func (b *blockResolver) ParentID() string    { return b.header.ParentID().String() }
+func (b *blockResolver) Timestamp() Long     { return Long(b.header.Timestamp()) }
+func (b *blockResolver) GasLimit() Long      { return Long(b.header.GasLimit()) }
+func (b *blockResolver) GasUsed() Long       { return Long(b.header.GasUsed()) }
+func (b *blockResolver) TotalScore() Long    { return Long(b.header.TotalScore()) }
+func (b *blockResolver) Beneficiary() string { return b.header.Beneficiary().String() }
+func (b *blockResolver) TxsRoot() string     { return b.header.TxsRoot().String() }
+func (b *blockResolver) StateRoot() string   { return b.header.StateRoot().String() }
+func (b *blockResolver) ReceiptsRoot() string {
+	return b.header.ReceiptsRoot().String()
+}
+
+func (b *blockResolver) Signer() (string, error) {
+	signer, err := b.header.Signer()
+	if err != nil {
+		return "", err
+	}
+	return signer.String(), nil
+}
+
+func (b *blockResolver) Parent() (*blockResolver, error) {
+	if b.header.Number() == 0 {
+		return nil, nil
+	}
+	parent, err := b.g.chain.GetBlockHeader(b.header.ParentID())
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{b.g, parent}, nil
+}
+
+func (b *blockResolver) IsTrunk() (bool, error) {
+	return b.g.isTrunk(b.header)
+}
+
+func (b *blockResolver) Transactions() ([]*txResolver, error) {
+	body, err := b.g.chain.GetBlockBody(b.header.ID())
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*txResolver, len(body.Txs))
+	for i, tx := range body.Txs {
+		txs[i] = &txResolver{b.g, tx, b.header, uint64(i)}
+	}
+	return txs, nil
+}
+
+type txResolver struct {
+	g      *GraphQL
+	tx     *tx.Transaction
+	header *block.Header
+	index  uint64
+}
+
+func (t *txResolver) ID() string          { return t.tx.ID().String() }
+func (t *txResolver) ChainTag() int32     { return int32(t.tx.ChainTag()) }
+func (t *txResolver) Expiration() int32   { return int32(t.tx.Expiration()) }
+func (t *txResolver) GasPriceCoef() int32 { return int32(t.tx.GasPriceCoef()) }
+func (t *txResolver) Gas() Long           { return Long(t.tx.Gas()) }
+func (t *txResolver) Nonce() Long         { return Long(t.tx.Nonce()) }
+func (t *txResolver) Index() int32        { return int32(t.index) }
+func (t *txResolver) Block() *blockResolver {
+	return &blockResolver{t.g, t.header}
+}
+
+func (t *txResolver) BlockRef() string {
+	br := t.tx.BlockRef()
+	return hexutil.Encode(br[:])
+}
+
+func (t *txResolver) DependsOn() *string {
+	if dep := t.tx.DependsOn(); dep != nil {
+		s := dep.String()
+		return &s
+	}
+	return nil
+}
+
+func (t *txResolver) Origin() (string, error) {
+	origin, err := t.tx.Signer()
+	if err != nil {
+		return "", err
+	}
+	return origin.String(), nil
+}
+
+func (t *txResolver) Clauses() []*clauseResolver {
+	clauses := t.tx.Clauses()
+	res := make([]*clauseResolver, len(clauses))
+	for i, c := range clauses {
+		res[i] = &clauseResolver{c}
+	}
+	return res
+}
+
+func (t *txResolver) Receipt() (*receiptResolver, error) {
+	receipt, err := t.g.chain.GetTransactionReceipt(t.header.ID(), t.index)
+	if err != nil {
+		return nil, err
+	}
+	return &receiptResolver{t, receipt}, nil
+}
+
+type clauseResolver struct {
+	clause *tx.Clause
+}
+
+func (c *clauseResolver) To() *string {
+	if to := c.clause.To(); to != nil {
+		s := to.String()
+		return &s
+	}
+	return nil
+}
+
+func (c *clauseResolver) Value() string { return encodeBig(c.clause.Value()) }
+func (c *clauseResolver) Data() string  { return hexutil.Encode(c.clause.Data()) }
+
+type receiptResolver struct {
+	tx      *txResolver
+	receipt *tx.Receipt
+}
+
+func (r *receiptResolver) GasUsed() Long    { return Long(r.receipt.GasUsed) }
+func (r *receiptResolver) GasPayer() string { return r.receipt.GasPayer.String() }
+func (r *receiptResolver) Paid() string     { return encodeBig(r.receipt.Paid) }
+func (r *receiptResolver) Reward() string   { return encodeBig(r.receipt.Reward) }
+func (r *receiptResolver) Reverted() bool   { return r.receipt.Reverted }
+func (r *receiptResolver) Outputs() []*outputResolver {
+	outputs := make([]*outputResolver, len(r.receipt.Outputs))
+	for i, output := range r.receipt.Outputs {
+		outputs[i] = &outputResolver{r.tx, uint32(i), output}
+	}
+	return outputs
+}
+
+type outputResolver struct {
+	tx     *txResolver
+	index  uint32
+	output *tx.Output
+}
+
+func (o *outputResolver) ContractAddress() *string {
+	if o.tx.tx.Clauses()[o.index].To() != nil {
+		return nil
+	}
+	s := thor.CreateContractAddress(o.tx.tx.ID(), o.index, 0).String()
+	return &s
+}
+
+func (o *outputResolver) Events() []*eventResolver {
+	events := make([]*eventResolver, len(o.output.Events))
+	for i, ev := range o.output.Events {
+		events[i] = &eventResolver{o.tx.g, ev, o.tx.header.ID(), o.tx.tx.ID()}
+	}
+	return events
+}
+
+func (o *outputResolver) Transfers() []*transferResolver {
+	transfers := make([]*transferResolver, len(o.output.Transfers))
+	for i, tr := range o.output.Transfers {
+		transfers[i] = &transferResolver{o.tx.g, tr, o.tx.header.ID(), o.tx.tx.ID()}
+	}
+	return transfers
+}
+
+type eventResolver struct {
+	g       *GraphQL
+	event   *tx.Event
+	blockID thor.Bytes32
+	txID    thor.Bytes32
+}
+
+func (e *eventResolver) Address() string { return e.event.Address.String() }
+func (e *eventResolver) Data() string    { return hexutil.Encode(e.event.Data) }
+func (e *eventResolver) Topics() []string {
+	topics := make([]string, len(e.event.Topics))
+	for i, topic := range e.event.Topics {
+		topics[i] = topic.String()
+	}
+	return topics
+}
+
+func (e *eventResolver) Block() (*blockResolver, error) {
+	return e.g.getBlock(e.blockID)
+}
+
+func (e *eventResolver) Transaction() (*txResolver, error) {
+	return e.g.getTransactionInBlock(e.txID, e.blockID)
+}
+
+type transferResolver struct {
+	g        *GraphQL
+	transfer *tx.Transfer
+	blockID  thor.Bytes32
+	txID     thor.Bytes32
+}
+
+func (t *transferResolver) Sender() string    { return t.transfer.Sender.String() }
+func (t *transferResolver) Recipient() string { return t.transfer.Recipient.String() }
+func (t *transferResolver) Amount() string    { return encodeBig(t.transfer.Amount) }
+
+func (t *transferResolver) Block() (*blockResolver, error) {
+	return t.g.getBlock(t.blockID)
+}
+
+func (t *transferResolver) Transaction() (*txResolver, error) {
+	return t.g.getTransactionInBlock(t.txID, t.blockID)
+}
+
+type accountResolver struct {
+	g      *GraphQL
+	addr   thor.Address
+	header *block.Header
+}
+
+func (a *accountResolver) Address() string { return a.addr.String() }
+
+func (a *accountResolver) Balance() (string, error) {
+	state, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	balance := state.GetBalance(a.addr)
+	if err := state.Err(); err != nil {
+		return "", err
+	}
+	return encodeBig(balance), nil
+}
+
+func (a *accountResolver) Energy() (string, error) {
+	state, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	energy := state.GetEnergy(a.addr, a.header.Timestamp())
+	if err := state.Err(); err != nil {
+		return "", err
+	}
+	return encodeBig(energy), nil
+}
+
+func (a *accountResolver) Code() (string, error) {
+	state, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	code := state.GetCode(a.addr)
+	if err := state.Err(); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(code), nil
+}
+
+func (a *accountResolver) Transactions(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*txConnectionResolver, error) {
+	p, err := newPage(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := a.g.logDB.FilterTransactions(ctx, &logdb.TransactionFilter{
+		TxOrigin: a.addr,
+		Options:  p.options(),
+		Order:    logdb.DESC,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cursors, info := p.cursors(len(txs))
+	edges := make([]*txEdgeResolver, len(cursors))
+	for i, cursor := range cursors {
+		node, err := a.g.getTransactionInBlock(txs[i].TxID, txs[i].BlockID)
+		if err != nil {
+			return nil, err
+		}
+		edges[i] = &txEdgeResolver{cursor, node}
+	}
+	return &txConnectionResolver{edges, info}, nil
+}
+
+type eventEdgeResolver struct {
+	cursor string
+	node   *eventResolver
+}
+
+func (e *eventEdgeResolver) Cursor() string       { return e.cursor }
+func (e *eventEdgeResolver) Node() *eventResolver { return e.node }
+
+type eventConnectionResolver struct {
+	edges    []*eventEdgeResolver
+	pageInfo *PageInfo
+}
+
+func (c *eventConnectionResolver) Edges() []*eventEdgeResolver { return c.edges }
+func (c *eventConnectionResolver) PageInfo() *PageInfo         { return c.pageInfo }
+
+type transferEdgeResolver struct {
+	cursor string
+	node   *transferResolver
+}
+
+func (e *transferEdgeResolver) Cursor() string          { return e.cursor }
+func (e *transferEdgeResolver) Node() *transferResolver { return e.node }
+
+type transferConnectionResolver struct {
+	edges    []*transferEdgeResolver
+	pageInfo *PageInfo
+}
+
+func (c *transferConnectionResolver) Edges() []*transferEdgeResolver { return c.edges }
+func (c *transferConnectionResolver) PageInfo() *PageInfo            { return c.pageInfo }
+
+type txEdgeResolver struct {
+	cursor string
+	node   *txResolver
+}
+
+func (e *txEdgeResolver) Cursor() string    { return e.cursor }
+func (e *txEdgeResolver) Node() *txResolver { return e.node }
+
+type txConnectionResolver struct {
+	edges    []*txEdgeResolver
+	pageInfo *PageInfo
+}
+
+func (c *txConnectionResolver) Edges() []*txEdgeResolver { return c.edges }
+func (c *txConnectionResolver) PageInfo() *PageInfo      { return c.pageInfo }
+
+func encodeBig(v *big.Int) string {
+	return hexutil.EncodeBig(v)
+}
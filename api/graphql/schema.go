@@ -0,0 +1,161 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+// schema of the GraphQL API.
+// Bytes, hashes and addresses are hex strings, and big integers are hex strings too.
+const schema = `
+	schema {
+		query: Query
+	}
+
+	# Long is a 64 bit unsigned integer.
+	scalar Long
+
+	type Query {
+		# Block by revision, which can be block ID, number or 'best'. Best block if omitted.
+		block(revision: String): Block
+		# Transaction by ID, on trunk.
+		transaction(id: String!): Transaction
+		# Account at the given revision.
+		account(address: String!, revision: String): Account!
+		# Event logs with cursor pagination.
+		events(filter: EventFilter, first: Int, after: String): EventConnection!
+		# Transfer logs with cursor pagination.
+		transfers(filter: TransferFilter, first: Int, after: String): TransferConnection!
+	}
+
+	type Block {
+		id: String!
+		number: Int!
+		parentID: String!
+		parent: Block
+		timestamp: Long!
+		gasLimit: Long!
+		gasUsed: Long!
+		totalScore: Long!
+		beneficiary: String!
+		signer: String!
+		txsRoot: String!
+		stateRoot: String!
+		receiptsRoot: String!
+		isTrunk: Boolean!
+		transactions: [Transaction!]!
+	}
+
+	type Transaction {
+		id: String!
+		chainTag: Int!
+		blockRef: String!
+		expiration: Int!
+		gasPriceCoef: Int!
+		gas: Long!
+		nonce: Long!
+		dependsOn: String
+		origin: String!
+		clauses: [Clause!]!
+		block: Block!
+		index: Int!
+		receipt: Receipt!
+	}
+
+	type Clause {
+		to: String
+		value: String!
+		data: String!
+	}
+
+	type Receipt {
+		gasUsed: Long!
+		gasPayer: String!
+		paid: String!
+		reward: String!
+		reverted: Boolean!
+		outputs: [Output!]!
+	}
+
+	type Output {
+		contractAddress: String
+		events: [Event!]!
+		transfers: [Transfer!]!
+	}
+
+	type Event {
+		address: String!
+		topics: [String!]!
+		data: String!
+		block: Block!
+		transaction: Transaction!
+	}
+
+	type Transfer {
+		sender: String!
+		recipient: String!
+		amount: String!
+		block: Block!
+		transaction: Transaction!
+	}
+
+	type Account {
+		address: String!
+		balance: String!
+		energy: String!
+		code: String!
+		# Transactions sent by the account, latest first.
+		transactions(first: Int, after: String): TransactionConnection!
+	}
+
+	type PageInfo {
+		endCursor: String
+		hasNextPage: Boolean!
+	}
+
+	type EventEdge {
+		cursor: String!
+		node: Event!
+	}
+
+	type EventConnection {
+		edges: [EventEdge!]!
+		pageInfo: PageInfo!
+	}
+
+	type TransferEdge {
+		cursor: String!
+		node: Transfer!
+	}
+
+	type TransferConnection {
+		edges: [TransferEdge!]!
+		pageInfo: PageInfo!
+	}
+
+	type TransactionEdge {
+		cursor: String!
+		node: Transaction!
+	}
+
+	type TransactionConnection {
+		edges: [TransactionEdge!]!
+		pageInfo: PageInfo!
+	}
+
+	input EventFilter {
+		address: String
+		# topic0 to topic4, null matches any
+		topics: [String]
+		fromBlock: Int
+		toBlock: Int
+	}
+
+	input TransferFilter {
+		txOrigin: String
+		sender: String
+		recipient: String
+		fromBlock: Int
+		toBlock: Int
+	}
+`
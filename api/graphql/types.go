@@ -0,0 +1,229 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+	cursorPrefix    = "offset:"
+)
+
+// Long is the 'Long' scalar, a 64 bit unsigned integer.
+type Long uint64
+
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case string:
+		n, err := strconv.ParseUint(v, 0, 64)
+		if err != nil {
+			return err
+		}
+		*l = Long(n)
+	case int32:
+		if v < 0 {
+			return errors.New("negative value for Long")
+		}
+		*l = Long(v)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(l))
+}
+
+// EventFilter input of events query.
+type EventFilter struct {
+	Address   *string
+	Topics    *[]*string
+	FromBlock *int32
+	ToBlock   *int32
+}
+
+func (f *EventFilter) convert() (*logdb.EventFilter, error) {
+	filter := &logdb.EventFilter{Order: logdb.ASC}
+	if f == nil {
+		return filter, nil
+	}
+	if f.Address != nil {
+		addr, err := thor.ParseAddress(*f.Address)
+		if err != nil {
+			return nil, errors.Wrap(err, "filter.address")
+		}
+		filter.Address = &addr
+	}
+	if f.Topics != nil {
+		if len(*f.Topics) > 5 {
+			return nil, errors.New("filter.topics: too many topics")
+		}
+		var set [5]*thor.Bytes32
+		for i, topic := range *f.Topics {
+			if topic == nil {
+				continue
+			}
+			t, err := thor.ParseBytes32(*topic)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("filter.topics[%v]", i))
+			}
+			set[i] = &t
+		}
+		filter.TopicSet = [][5]*thor.Bytes32{set}
+	}
+	filter.Range = convertRange(f.FromBlock, f.ToBlock)
+	return filter, nil
+}
+
+// TransferFilter input of transfers query.
+type TransferFilter struct {
+	TxOrigin  *string
+	Sender    *string
+	Recipient *string
+	FromBlock *int32
+	ToBlock   *int32
+}
+
+func (f *TransferFilter) convert() (*logdb.TransferFilter, error) {
+	filter := &logdb.TransferFilter{Order: logdb.ASC}
+	if f == nil {
+		return filter, nil
+	}
+	var (
+		set logdb.AddressSet
+		err error
+	)
+	if set.TxOrigin, err = parseOptionalAddress(f.TxOrigin, "filter.txOrigin"); err != nil {
+		return nil, err
+	}
+	if set.Sender, err = parseOptionalAddress(f.Sender, "filter.sender"); err != nil {
+		return nil, err
+	}
+	if set.Recipient, err = parseOptionalAddress(f.Recipient, "filter.recipient"); err != nil {
+		return nil, err
+	}
+	if set.TxOrigin != nil || set.Sender != nil || set.Recipient != nil {
+		filter.AddressSets = []*logdb.AddressSet{&set}
+	}
+	filter.Range = convertRange(f.FromBlock, f.ToBlock)
+	return filter, nil
+}
+
+func parseOptionalAddress(s *string, name string) (*thor.Address, error) {
+	if s == nil {
+		return nil, nil
+	}
+	addr, err := thor.ParseAddress(*s)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+	return &addr, nil
+}
+
+func convertRange(from, to *int32) *logdb.Range {
+	if from == nil && to == nil {
+		return nil
+	}
+	r := &logdb.Range{Unit: logdb.Block, To: math.MaxUint32}
+	if from != nil && *from > 0 {
+		r.From = uint64(*from)
+	}
+	if to != nil {
+		if *to < 0 {
+			r.To = 0
+		} else {
+			r.To = uint64(*to)
+		}
+	}
+	return r
+}
+
+// PageInfo of a connection.
+type PageInfo struct {
+	endCursor   *string
+	hasNextPage bool
+}
+
+func (p *PageInfo) EndCursor() *string { return p.endCursor }
+func (p *PageInfo) HasNextPage() bool  { return p.hasNextPage }
+
+// page resolves 'first' and 'after' arguments into logdb options.
+// One more item is queried to determine whether there is next page.
+type page struct {
+	offset uint64
+	size   uint64
+}
+
+func newPage(first *int32, after *string) (*page, error) {
+	p := &page{size: defaultPageSize}
+	if first != nil {
+		if *first < 0 || *first > maxPageSize {
+			return nil, fmt.Errorf("first: should be in range [0, %v]", maxPageSize)
+		}
+		p.size = uint64(*first)
+	}
+	if after != nil {
+		offset, err := decodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		p.offset = offset + 1
+	}
+	return p, nil
+}
+
+func (p *page) options() *logdb.Options {
+	return &logdb.Options{Offset: p.offset, Limit: p.size + 1}
+}
+
+// cursors returns cursors of n items fetched, and the page info.
+// n may be one more than page size.
+func (p *page) cursors(n int) ([]string, *PageInfo) {
+	info := &PageInfo{}
+	if uint64(n) > p.size {
+		n = int(p.size)
+		info.hasNextPage = true
+	}
+	cursors := make([]string, n)
+	for i := range cursors {
+		cursors[i] = encodeCursor(p.offset + uint64(i))
+	}
+	if n > 0 {
+		info.endCursor = &cursors[n-1]
+	}
+	return cursors, info
+}
+
+func encodeCursor(offset uint64) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(offset, 10)))
+}
+
+func decodeCursor(cursor string) (uint64, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(data), cursorPrefix) {
+		return 0, errors.New("after: invalid cursor")
+	}
+	offset, err := strconv.ParseUint(strings.TrimPrefix(string(data), cursorPrefix), 10, 64)
+	if err != nil {
+		return 0, errors.New("after: invalid cursor")
+	}
+	return offset, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package light
+
+import (
+	"context"
+	"net/http"
+
+	ethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	lightchain "github.com/vechain/thor/light"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+// Client light client which stores headers only, and requests verified states from peers.
+type Client interface {
+	Chain() *lightchain.HeaderChain
+	GetAccount(ctx context.Context, header *block.Header, addr thor.Address) (*state.Account, error)
+}
+
+// Light serves the subset of api that a light client is able to, in the same form as a full node.
+type Light struct {
+	client Client
+}
+
+func New(client Client) *Light {
+	return &Light{
+		client,
+	}
+}
+
+func (l *Light) handleGetBlock(w http.ResponseWriter, req *http.Request) error {
+	header, err := l.getHeader(mux.Vars(req)["revision"])
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return utils.WriteJSON(w, nil)
+	}
+	h, err := ConvertHeader(header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, h)
+}
+
+func (l *Light) handleGetAccount(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	revision := req.URL.Query().Get("revision")
+	if revision == "" {
+		revision = "best"
+	}
+	header, err := l.getHeader(revision)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return utils.BadRequest(errors.New("block not found"), "revision")
+	}
+	acc, err := l.client.GetAccount(req.Context(), header, addr)
+	if err != nil {
+		return utils.HTTPError(errors.WithMessage(err, "request account"), http.StatusServiceUnavailable)
+	}
+	return utils.WriteJSON(w, &accounts.Account{
+		Balance: ethmath.HexOrDecimal256(*acc.Balance),
+		Energy:  ethmath.HexOrDecimal256(*acc.CalcEnergy(header.Timestamp())),
+		HasCode: len(acc.CodeHash) > 0,
+	})
+}
+
+// getHeader returns nil if not found.
+func (l *Light) getHeader(revision string) (*block.Header, error) {
+	rev, err := utils.ParseRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+	chain := l.client.Chain()
+	var header *block.Header
+	switch {
+	case rev.ID != nil:
+		header, err = chain.GetHeaderByID(*rev.ID)
+	case rev.Number != nil:
+		header, err = chain.GetHeader(*rev.Number)
+	default:
+		return chain.Best(), nil
+	}
+	if err != nil {
+		if chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+func (l *Light) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/blocks/{revision}").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(l.handleGetBlock))
+	sub.Path("/accounts/{address}").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(l.handleGetAccount))
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package light_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/light"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/kv"
+	lightchain "github.com/vechain/thor/light"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+// client proves accounts from local state, as a full node peer does.
+type client struct {
+	chain *lightchain.HeaderChain
+	kv    kv.GetPutter
+}
+
+func (c *client) Chain() *lightchain.HeaderChain { return c.chain }
+
+func (c *client) GetAccount(ctx context.Context, header *block.Header, addr thor.Address) (*state.Account, error) {
+	proof, err := state.ProveAccount(c.kv, header.StateRoot(), addr)
+	if err != nil {
+		return nil, err
+	}
+	return state.VerifyAccountProof(header.StateRoot(), addr, proof)
+}
+
+func TestLight(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc, err := lightchain.NewHeaderChain(db, b0.Header())
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	light.New(&client{hc, db}).Mount(router, "")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	var header *light.Header
+	httpGet(t, ts.URL+"/blocks/best", http.StatusOK, &header)
+	assert.Equal(t, b0.Header().ID(), header.ID)
+	assert.Equal(t, b0.Header().StateRoot(), header.StateRoot)
+
+	header = nil
+	httpGet(t, ts.URL+"/blocks/"+b0.Header().ID().String(), http.StatusOK, &header)
+	assert.Equal(t, uint32(0), header.Number)
+
+	header = nil
+	httpGet(t, ts.URL+"/blocks/1", http.StatusOK, &header)
+	assert.Nil(t, header)
+	httpGet(t, ts.URL+"/blocks/bad", http.StatusBadRequest, nil)
+
+	dev := genesis.DevAccounts()[0].Address
+	var acc accounts.Account
+	httpGet(t, ts.URL+"/accounts/"+dev.String(), http.StatusOK, &acc)
+	st, _ := state.New(b0.Header().StateRoot(), db)
+	assert.Equal(t, st.GetBalance(dev), (*big.Int)(&acc.Balance))
+	assert.False(t, acc.HasCode)
+
+	httpGet(t, ts.URL+"/accounts/"+dev.String()+"?revision=1", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/accounts/bad", http.StatusBadRequest, nil)
+}
+
+func httpGet(t *testing.T, url string, status int, v interface{}) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, status, res.StatusCode, string(data))
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
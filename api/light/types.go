@@ -0,0 +1,49 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package light
+
+import (
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// Header block header served by light client, in the same form of block with txs absent.
+type Header struct {
+	Number       uint32       `json:"number"`
+	ID           thor.Bytes32 `json:"id"`
+	ParentID     thor.Bytes32 `json:"parentID"`
+	Timestamp    uint64       `json:"timestamp"`
+	GasLimit     uint64       `json:"gasLimit"`
+	Beneficiary  thor.Address `json:"beneficiary"`
+	GasUsed      uint64       `json:"gasUsed"`
+	TotalScore   uint64       `json:"totalScore"`
+	TxsRoot      thor.Bytes32 `json:"txsRoot"`
+	StateRoot    thor.Bytes32 `json:"stateRoot"`
+	ReceiptsRoot thor.Bytes32 `json:"receiptsRoot"`
+	Signer       thor.Address `json:"signer"`
+}
+
+// ConvertHeader convert a raw header into a json format header.
+func ConvertHeader(h *block.Header) (*Header, error) {
+	signer, err := h.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return &Header{
+		Number:       h.Number(),
+		ID:           h.ID(),
+		ParentID:     h.ParentID(),
+		Timestamp:    h.Timestamp(),
+		GasLimit:     h.GasLimit(),
+		Beneficiary:  h.Beneficiary(),
+		GasUsed:      h.GasUsed(),
+		TotalScore:   h.TotalScore(),
+		TxsRoot:      h.TxsRoot(),
+		StateRoot:    h.StateRoot(),
+		ReceiptsRoot: h.ReceiptsRoot(),
+		Signer:       signer,
+	}, nil
+}
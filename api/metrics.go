@@ -0,0 +1,45 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/vechain/thor/metric"
+)
+
+var metricRequestDuration = metric.NewHistogramVec("api", "request_duration_seconds", "time spent to serve API requests", nil, "method", "path")
+
+// instrument wraps the router to trace requests and observe their durations, labeled by route path template.
+func instrument(router *mux.Router) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		// long-lived subscriptions would pollute spans and durations
+		if websocket.IsWebSocketUpgrade(req) {
+			router.ServeHTTP(w, req)
+			return
+		}
+
+		// the route is matched once for both
+		path := routePath(router, req)
+		start := time.Now()
+		serveTraced(w, req, path, router)
+		metricRequestDuration.WithLabelValues(req.Method, path).Observe(time.Since(start).Seconds())
+	}
+}
+
+// routePath returns the path template of the route matching req, to label requests with low cardinality.
+func routePath(router *mux.Router, req *http.Request) string {
+	var match mux.RouteMatch
+	if router.Match(req, &match) {
+		if tpl, err := match.Route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unknown"
+}
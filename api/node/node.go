@@ -6,19 +6,52 @@
 package node
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/blocks"
 	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	defaultMaxBlockAge = 60 // seconds, 6 blocks
+	defaultMinPeers    = 1
 )
 
 type Node struct {
-	nw Network
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	nw           Network
+	pool         TxPool
+	producer     Producer
+	nat          NAT
+	version      string
+	startTime    time.Time
 }
 
-func New(nw Network) *Node {
+// New create a Node instance. producer can be nil if the node doesn't produce blocks,
+// and nat can be nil if p2p is not available.
+func New(chain *chain.Chain, stateCreator *state.Creator, nw Network, pool TxPool, producer Producer, nat NAT, version string) *Node {
 	return &Node{
+		chain,
+		stateCreator,
 		nw,
+		pool,
+		producer,
+		nat,
+		version,
+		time.Now(),
 	}
 }
 
@@ -30,8 +63,239 @@ func (n *Node) handleNetwork(w http.ResponseWriter, req *http.Request) error {
 	return utils.WriteJSON(w, n.PeersStats())
 }
 
+func (n *Node) handlePeers(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, ConvertPeers(n.nw.PeersStats()))
+}
+
+// PoolTxs returns txs in pool, filtered by status if not empty.
+func (n *Node) PoolTxs(status string) []*PoolTx {
+	return ConvertPoolTxs(n.pool.Dump(), status, time.Now().Unix())
+}
+
+func (n *Node) handlePoolTxs(w http.ResponseWriter, req *http.Request) error {
+	status := req.URL.Query().Get("status")
+	switch status {
+	case "", PoolTxPending, PoolTxQueued, PoolTxScheduled:
+	default:
+		return utils.BadRequest(errors.New("should be one of 'pending', 'queued', 'scheduled'"), "status")
+	}
+	return utils.WriteJSON(w, n.PoolTxs(status))
+}
+
+func (n *Node) handlePoolStatus(w http.ResponseWriter, req *http.Request) error {
+	var status PoolStatus
+	for _, info := range n.pool.Dump() {
+		switch {
+		case info.Pending:
+			status.Pending++
+		case info.Scheduled:
+			status.Scheduled++
+		default:
+			status.Queued++
+		}
+	}
+	status.Total = status.Pending + status.Queued + status.Scheduled
+	return utils.WriteJSON(w, &status)
+}
+
+// Status returns overall status of the node.
+func (n *Node) Status() *Status {
+	best := n.chain.BestBlock().Header()
+	peersStats := n.nw.PeersStats()
+	status := &Status{
+		Version:   n.version,
+		GenesisID: n.chain.GenesisBlock().Header().ID(),
+		ChainTag:  n.chain.Tag(),
+		BestBlock: StatusBlock{
+			ID:        best.ID(),
+			Number:    best.Number(),
+			Timestamp: best.Timestamp(),
+		},
+		Sync:       *n.SyncProgress(),
+		PeerCount:  len(peersStats),
+		TxPoolSize: len(n.pool.Dump()),
+		Uptime:     uint64(time.Since(n.startTime).Seconds()),
+	}
+	if n.producer != nil {
+		status.Production = n.producer.Production()
+	}
+	if n.nat != nil {
+		status.NAT = ConvertNATStatus(n.nat.NATStatus())
+	}
+	return status
+}
+
+func (n *Node) handleStatus(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, n.Status())
+}
+
+// SyncProgress returns progress of block synchronization.
+func (n *Node) SyncProgress() *SyncProgress {
+	best := n.chain.BestBlock().Header().Number()
+	progress := &SyncProgress{
+		Current: best,
+		Highest: best,
+	}
+	if p := n.nw.SyncProgress(); p != nil {
+		progress.Current = p.Current
+		progress.Highest = p.Target
+		progress.BlocksPerSecond = p.Rate
+		progress.ETA = p.ETA
+	}
+	select {
+	case <-n.nw.Synced():
+		progress.Synced = true
+	default:
+	}
+	return progress
+}
+
+func (n *Node) handleSyncProgress(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, n.SyncProgress())
+}
+
+// handleReorgs lists recent reorgs happened after the sequence number given by query 'after'.
+func (n *Node) handleReorgs(w http.ResponseWriter, req *http.Request) error {
+	var after uint64
+	if s := req.URL.Query().Get("after"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return utils.BadRequest(err, "after")
+		}
+		if lowest := n.chain.LowestReorgSeq(); v+1 < lowest {
+			return utils.BadRequest(fmt.Errorf("reorgs before %v are not retained", lowest), "after")
+		}
+		after = v
+	}
+	reorgs := n.chain.RecentReorgs(after)
+	result := make([]*blocks.Reorg, 0, len(reorgs))
+	for _, r := range reorgs {
+		result = append(result, blocks.ConvertReorg(r))
+	}
+	return utils.WriteJSON(w, result)
+}
+
+// Params returns governance params of the builtin Params contract, at the given block.
+func (n *Node) Params(header *block.Header) (*Params, error) {
+	st, err := n.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, utils.StateError(err)
+	}
+	native := builtin.Params.Native(st)
+	params := &Params{
+		BaseGasPrice:        (*math.HexOrDecimal256)(native.Get(thor.KeyBaseGasPrice)),
+		RewardRatio:         (*math.HexOrDecimal256)(native.Get(thor.KeyRewardRatio)),
+		ProposerEndorsement: (*math.HexOrDecimal256)(native.Get(thor.KeyProposerEndorsement)),
+	}
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (n *Node) handleParams(w http.ResponseWriter, req *http.Request) error {
+	header, err := n.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	params, err := n.Params(header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, params)
+}
+
+func (n *Node) getBlockHeader(revision string) (*block.Header, error) {
+	header, err := utils.GetBlockHeader(n.chain, revision)
+	if err != nil {
+		if n.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+// Health returns health of the node.
+func (n *Node) Health() *Health {
+	var health Health
+	select {
+	case <-n.nw.Synced():
+		health.Synced = true
+	default:
+	}
+	health.PeerCount = len(n.nw.PeersStats())
+	if now, ts := uint64(time.Now().Unix()), n.chain.BestBlock().Header().Timestamp(); now > ts {
+		health.BestBlockAge = now - ts
+	}
+	if err := n.chain.Check(); err != nil {
+		health.DBError = err.Error()
+	}
+	return &health
+}
+
+// handleHealth serves the liveness probe, which fails only if the database is broken,
+// since a syncing node is still alive.
+func (n *Node) handleHealth(w http.ResponseWriter, req *http.Request) error {
+	health := n.Health()
+	health.Healthy = health.DBError == ""
+	return writeHealth(w, health)
+}
+
+// handleReady serves the readiness probe, which succeeds if the node is synced, has enough peers,
+// and the best block is fresh. Thresholds can be set by query 'maxBlockAge' and 'minPeers'.
+func (n *Node) handleReady(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	maxBlockAge := uint64(defaultMaxBlockAge)
+	if s := query.Get("maxBlockAge"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return utils.BadRequest(err, "maxBlockAge")
+		}
+		maxBlockAge = v
+	}
+	minPeers := defaultMinPeers
+	if s := query.Get("minPeers"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return utils.BadRequest(err, "minPeers")
+		}
+		minPeers = int(v)
+	}
+
+	health := n.Health()
+	health.Healthy = health.DBError == "" &&
+		health.Synced &&
+		health.PeerCount >= minPeers &&
+		health.BestBlockAge <= maxBlockAge
+	return writeHealth(w, health)
+}
+
+// writeHealth writes health in json, with status 503 if not healthy.
+func writeHealth(w http.ResponseWriter, health *Health) error {
+	data, err := json.Marshal(health)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", utils.JSONContentType)
+	if !health.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(data)
+	return nil
+}
+
 func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
 	sub.Path("/network/peers").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handleNetwork))
+	sub.Path("/peers").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handlePeers))
+	sub.Path("/txpool").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handlePoolTxs))
+	sub.Path("/txpool/status").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handlePoolStatus))
+	sub.Path("/reorgs").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleReorgs))
+	sub.Path("/status").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleStatus))
+	sub.Path("/syncprogress").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleSyncProgress))
+	sub.Path("/health").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleHealth))
+	sub.Path("/ready").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleReady))
+	sub.Path("/params").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(n.handleParams))
 }
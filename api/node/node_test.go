@@ -7,22 +7,47 @@ package node_test
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/blocks"
 	"github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/comm"
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/p2psrv"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 	"github.com/vechain/thor/txpool"
 )
 
-var ts *httptest.Server
+var (
+	ts   *httptest.Server
+	pool *txpool.TxPool
+	c    *chain.Chain
+)
+
+type producer struct{}
+
+type nat struct{}
+
+func (nat) NATStatus() *p2psrv.NATStatus {
+	return &p2psrv.NATStatus{Mechanism: "UPnP", ExternalIP: net.ParseIP("1.2.3.4"), Port: 11235, Reachable: true}
+}
+
+func (producer) Production() *node.Production {
+	return &node.Production{Scheduled: 3, Produced: 2, Missed: 1, LastMissed: 1000}
+}
 
 func TestNode(t *testing.T) {
 	initCommServer(t)
@@ -34,6 +59,91 @@ func TestNode(t *testing.T) {
 	assert.Equal(t, 0, len(peersStats), "count should be zero")
 }
 
+func TestPeers(t *testing.T) {
+	initCommServer(t)
+	res := httpGet(t, ts.URL+"/node/peers")
+	var peers []*node.Peer
+	if err := json.Unmarshal(res, &peers); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, peers, "should be empty array rather than null")
+	assert.Equal(t, 0, len(peers), "count should be zero")
+}
+
+func TestTxPool(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	trx := newTx(t)
+	if err := pool.Add(trx); err != nil {
+		t.Fatal(err)
+	}
+
+	var txs []*node.PoolTx
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/txpool"), &txs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, trx.ID(), txs[0].ID)
+	assert.Equal(t, genesis.DevAccounts()[0].Address, txs[0].Origin)
+	assert.Equal(t, trx.GasPriceCoef(), txs[0].GasPriceCoef)
+	assert.Equal(t, node.PoolTxPending, txs[0].Status)
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/txpool?status=queued"), &txs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(txs))
+
+	var status node.PoolStatus
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/txpool/status"), &status); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, node.PoolStatus{Pending: 1, Queued: 0, Total: 1}, status)
+
+	res, err := http.Get(ts.URL + "/node/txpool?status=bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestParams(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	var params node.Params
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/params?revision=0"), &params); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, thor.InitialBaseGasPrice, (*big.Int)(params.BaseGasPrice))
+	assert.Equal(t, thor.InitialRewardRatio, (*big.Int)(params.RewardRatio))
+	assert.Equal(t, thor.InitialProposerEndorsement, (*big.Int)(params.ProposerEndorsement))
+
+	res, err := http.Get(ts.URL + "/node/params?revision=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func newTx(t *testing.T) *tx.Transaction {
+	to := thor.BytesToAddress([]byte("to"))
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+		GasPriceCoef(1).
+		Gas(21000).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trx.WithSignature(sig)
+}
+
 func initCommServer(t *testing.T) {
 	db, _ := lvldb.NewMem()
 	stateC := state.NewCreator(db)
@@ -45,10 +155,11 @@ func initCommServer(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	chain, _ := chain.New(db, b)
-	comm := comm.New(chain, txpool.New(chain, stateC))
+	c, _ = chain.New(db, b)
+	pool = txpool.New(c, stateC, thor.NoFork, txpool.DefaultPoolConfig)
+	comm := comm.New(c, pool, db)
 	router := mux.NewRouter()
-	node.New(comm).Mount(router, "/node")
+	node.New(c, stateC, comm, pool, producer{}, nat{}, "1.0.0-test").Mount(router, "/node")
 	ts = httptest.NewServer(router)
 }
 
@@ -64,3 +175,108 @@ func httpGet(t *testing.T, url string) []byte {
 	}
 	return r
 }
+
+func TestHealth(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	get := func(url string) (int, *node.Health) {
+		res, err := http.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var health node.Health
+		if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, &health
+	}
+
+	status, health := get(ts.URL + "/node/health")
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, "", health.DBError)
+
+	// not synced, no peers and genesis is stale
+	status, health = get(ts.URL + "/node/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.False(t, health.Healthy)
+	assert.False(t, health.Synced)
+	assert.Equal(t, 0, health.PeerCount)
+	assert.True(t, health.BestBlockAge > 0)
+
+	res, err := http.Get(ts.URL + "/node/ready?minPeers=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestReorgs(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	newBlock := func(parent *block.Block, score uint64) *block.Block {
+		b := new(block.Builder).ParentID(parent.Header().ID()).TotalScore(parent.Header().TotalScore() + score).Build()
+		sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), key)
+		return b.WithSignature(sig)
+	}
+	b0 := c.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b1x := newBlock(b0, 2)
+	for _, b := range []*block.Block{b1, b1x} {
+		if _, err := c.AddBlock(b, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var reorgs []*blocks.Reorg
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/reorgs"), &reorgs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, uint64(1), reorgs[0].Seq)
+	assert.Equal(t, b0.Header().ID(), reorgs[0].Ancestor)
+	assert.Equal(t, []thor.Bytes32{b1.Header().ID()}, reorgs[0].OldBranch)
+	assert.Equal(t, []thor.Bytes32{b1x.Header().ID()}, reorgs[0].NewBranch)
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/reorgs?after=1"), &reorgs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(reorgs))
+}
+
+func TestStatus(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	var status node.Status
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/status"), &status); err != nil {
+		t.Fatal(err)
+	}
+	best := c.BestBlock().Header()
+	assert.Equal(t, "1.0.0-test", status.Version)
+	assert.Equal(t, c.GenesisBlock().Header().ID(), status.GenesisID)
+	assert.Equal(t, c.Tag(), status.ChainTag)
+	assert.Equal(t, node.StatusBlock{ID: best.ID(), Number: best.Number(), Timestamp: best.Timestamp()}, status.BestBlock)
+	assert.Equal(t, node.SyncProgress{Synced: false, Current: best.Number(), Highest: best.Number()}, status.Sync)
+	assert.Equal(t, 0, status.PeerCount)
+	assert.Equal(t, 0, status.TxPoolSize)
+	assert.Equal(t, producer{}.Production(), status.Production)
+	assert.Equal(t, &node.NATStatus{Mechanism: "UPnP", ExternalIP: "1.2.3.4", Port: 11235, Reachable: true}, status.NAT)
+}
+
+func TestSyncProgress(t *testing.T) {
+	initCommServer(t)
+	defer pool.Close()
+
+	var progress node.SyncProgress
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/node/syncprogress"), &progress); err != nil {
+		t.Fatal(err)
+	}
+	best := c.BestBlock().Header()
+	assert.Equal(t, node.SyncProgress{Synced: false, Current: best.Number(), Highest: best.Number()}, progress)
+}
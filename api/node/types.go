@@ -6,12 +6,32 @@
 package node
 
 import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/p2psrv"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
 )
 
 type Network interface {
 	PeersStats() []*comm.PeerStats
+	Synced() <-chan struct{}
+	SyncProgress() *comm.SyncProgress
+}
+
+type TxPool interface {
+	Dump() []*txpool.TxInfo
+}
+
+// NAT reports the external P2P endpoint detected via NAT mechanism.
+type NAT interface {
+	NATStatus() *p2psrv.NATStatus
+}
+
+// Producer reports block production of the master of the node.
+type Producer interface {
+	Production() *Production
 }
 
 type PeerStats struct {
@@ -22,6 +42,7 @@ type PeerStats struct {
 	NetAddr     string       `json:"netAddr"`
 	Inbound     bool         `json:"inbound"`
 	Duration    uint64       `json:"duration"`
+	Score       int          `json:"score"`
 }
 
 func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
@@ -38,7 +59,180 @@ func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
 			NetAddr:     peerStats.NetAddr,
 			Inbound:     peerStats.Inbound,
 			Duration:    peerStats.Duration,
+			Score:       peerStats.Score,
 		}
 	}
 	return peersStats
 }
+
+// PeerHead head block of a peer.
+type PeerHead struct {
+	ID         thor.Bytes32 `json:"id"`
+	Number     uint32       `json:"number"`
+	TotalScore uint64       `json:"totalScore"`
+}
+
+// Peer summary of a connected peer.
+type Peer struct {
+	Enode     string   `json:"enode"`
+	Name      string   `json:"name"`
+	Direction string   `json:"direction"`
+	Duration  uint64   `json:"duration"`
+	Score     int      `json:"score"` // reputation score, the peer is banned once it drops to zero
+	Head      PeerHead `json:"head"`
+}
+
+func ConvertPeers(ss []*comm.PeerStats) []*Peer {
+	peers := make([]*Peer, 0, len(ss))
+	for _, peerStats := range ss {
+		dir := "outbound"
+		if peerStats.Inbound {
+			dir = "inbound"
+		}
+		peers = append(peers, &Peer{
+			Enode:     peerStats.Enode,
+			Name:      peerStats.Name,
+			Direction: dir,
+			Duration:  peerStats.Duration,
+			Score:     peerStats.Score,
+			Head: PeerHead{
+				ID:         peerStats.BestBlockID,
+				Number:     block.Number(peerStats.BestBlockID),
+				TotalScore: peerStats.TotalScore,
+			},
+		})
+	}
+	return peers
+}
+
+const (
+	PoolTxPending   = "pending"
+	PoolTxQueued    = "queued"
+	PoolTxScheduled = "scheduled"
+)
+
+// PoolTx summary of a tx in pool.
+type PoolTx struct {
+	ID           thor.Bytes32 `json:"id"`
+	Origin       thor.Address `json:"origin"`
+	GasPriceCoef uint8        `json:"gasPriceCoef"`
+	Gas          uint64       `json:"gas"`
+	Status       string       `json:"status"`
+	Age          uint64       `json:"age"`
+}
+
+// PoolStatus counts of txs in pool.
+type PoolStatus struct {
+	Pending   int `json:"pending"`
+	Queued    int `json:"queued"`
+	Scheduled int `json:"scheduled"`
+	Total     int `json:"total"`
+}
+
+func ConvertPoolTxs(infos []*txpool.TxInfo, status string, now int64) []*PoolTx {
+	txs := make([]*PoolTx, 0, len(infos))
+	for _, info := range infos {
+		s := PoolTxQueued
+		if info.Pending {
+			s = PoolTxPending
+		} else if info.Scheduled {
+			s = PoolTxScheduled
+		}
+		if status != "" && status != s {
+			continue
+		}
+		var age uint64
+		if now > info.AddTime {
+			age = uint64(now - info.AddTime)
+		}
+		txs = append(txs, &PoolTx{
+			ID:           info.Tx.ID(),
+			Origin:       info.Origin,
+			GasPriceCoef: info.Tx.GasPriceCoef(),
+			Gas:          info.Tx.Gas(),
+			Status:       s,
+			Age:          age,
+		})
+	}
+	return txs
+}
+
+// Health reports health of the node.
+type Health struct {
+	Healthy      bool   `json:"healthy"`
+	Synced       bool   `json:"synced"`
+	PeerCount    int    `json:"peerCount"`
+	BestBlockAge uint64 `json:"bestBlockAge"` // seconds since the best block
+	DBError      string `json:"dbError,omitempty"`
+}
+
+// Params governance params set in the builtin Params contract.
+// RewardRatio is the share of tx fees rewarded to block proposer, scaled by 1e18.
+type Params struct {
+	BaseGasPrice        *math.HexOrDecimal256 `json:"baseGasPrice"`
+	RewardRatio         *math.HexOrDecimal256 `json:"rewardRatio"`
+	ProposerEndorsement *math.HexOrDecimal256 `json:"proposerEndorsement"`
+}
+
+// StatusBlock summary of the best block.
+type StatusBlock struct {
+	ID        thor.Bytes32 `json:"id"`
+	Number    uint32       `json:"number"`
+	Timestamp uint64       `json:"timestamp"`
+}
+
+// SyncProgress compares the best block with the highest block known from peers.
+// BlocksPerSecond and ETA are measured since synchronization started, and zero if not syncing.
+type SyncProgress struct {
+	Synced          bool    `json:"synced"`
+	Current         uint32  `json:"current"`
+	Highest         uint32  `json:"highest"`
+	BlocksPerSecond float64 `json:"blocksPerSecond"`
+	ETA             uint64  `json:"eta"` // estimated seconds to reach the highest block
+}
+
+// Status overall status of the node.
+type Status struct {
+	Version    string       `json:"version"`
+	GenesisID  thor.Bytes32 `json:"genesisID"`
+	ChainTag   byte         `json:"chainTag"`
+	BestBlock  StatusBlock  `json:"bestBlock"`
+	Sync       SyncProgress `json:"sync"`
+	PeerCount  int          `json:"peerCount"`
+	TxPoolSize int          `json:"txPoolSize"`
+	Uptime     uint64       `json:"uptime"` // seconds since started
+	Production *Production  `json:"production,omitempty"`
+	NAT        *NATStatus   `json:"nat,omitempty"`
+}
+
+// NATStatus the external P2P endpoint detected via NAT mechanism.
+type NATStatus struct {
+	Mechanism  string `json:"mechanism"`
+	ExternalIP string `json:"externalIP"` // empty if not detected yet
+	Port       int    `json:"port"`
+	Reachable  bool   `json:"reachable"`
+}
+
+func ConvertNATStatus(s *p2psrv.NATStatus) *NATStatus {
+	if s == nil {
+		return nil
+	}
+	status := &NATStatus{
+		Mechanism: s.Mechanism,
+		Port:      s.Port,
+		Reachable: s.Reachable,
+	}
+	if s.ExternalIP != nil {
+		status.ExternalIP = s.ExternalIP.String()
+	}
+	return status
+}
+
+// Production compares slots scheduled for the master with blocks actually produced, since started.
+type Production struct {
+	Master     thor.Address `json:"master"`
+	Scheduled  uint64       `json:"scheduled"`
+	Produced   uint64       `json:"produced"`
+	Missed     uint64       `json:"missed"`
+	LastMissed uint64       `json:"lastMissed"` // timestamp of the last missed slot, 0 if none
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ParseTrustedProxies parses comma separated IPs or CIDRs, e.g. '127.0.0.1,10.0.0.0/8'.
+func ParseTrustedProxies(s string) ([]*net.IPNet, error) {
+	nets, err := parseIPNets(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy %v", err)
+	}
+	return nets, nil
+}
+
+// parseIPNets parses comma separated IPs or CIDRs, a single IP is treated as a full-length CIDR.
+func parseIPNets(s string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("%q, should be IP or CIDR", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q, should be IP or CIDR", item)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// TrustProxy wraps h to replace remote address of requests from trusted proxies with the client IP, so that
+// rate limiting and logging apply to the real client.
+// The client IP is the rightmost untrusted one in X-Forwarded-For, or X-Real-IP if the former is absent.
+func TrustProxy(h http.Handler, trusted []*net.IPNet) http.Handler {
+	isTrusted := func(ip net.IP) bool {
+		for _, n := range trusted {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if ip := net.ParseIP(ClientIP(req)); ip != nil && isTrusted(ip) {
+			if client := forwardedClient(req, isTrusted); client != nil {
+				req.RemoteAddr = net.JoinHostPort(client.String(), "0")
+			}
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+// forwardedClient returns the client IP told by proxy headers, or nil if absent or malformed.
+func forwardedClient(req *http.Request, isTrusted func(net.IP) bool) net.IP {
+	// proxies may append a separate header line instead of extending the existing one
+	if xff := strings.Join(req.Header["X-Forwarded-For"], ","); xff != "" {
+		hops := strings.Split(xff, ",")
+		var client net.IP
+		// walk back from the nearest hop, since ones before the first untrusted hop may be forged
+		for i := len(hops) - 1; i >= 0; i-- {
+			if client = net.ParseIP(strings.TrimSpace(hops[i])); client == nil || !isTrusted(client) {
+				break
+			}
+		}
+		return client
+	}
+	return net.ParseIP(strings.TrimSpace(req.Header.Get("X-Real-IP")))
+}
+
+// ClientIP returns IP of the remote address of the request.
+func ClientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
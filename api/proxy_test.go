@@ -0,0 +1,54 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustProxy(t *testing.T) {
+	trusted, err := ParseTrustedProxies("127.0.0.1, 10.0.0.0/8")
+	assert.Nil(t, err)
+	assert.Len(t, trusted, 2)
+	_, err = ParseTrustedProxies("10.0.0.x")
+	assert.Error(t, err)
+
+	var client string
+	h := TrustProxy(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		client = ClientIP(req)
+	}), trusted)
+
+	do := func(remoteAddr string, headers ...string) string {
+		req := httptest.NewRequest("GET", "/blocks/best", nil)
+		req.RemoteAddr = remoteAddr
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		return client
+	}
+
+	assert.Equal(t, "1.2.3.4", do("1.2.3.4:5678"))
+	// untrusted peer
+	assert.Equal(t, "1.2.3.4", do("1.2.3.4:5678", "X-Forwarded-For", "5.6.7.8"))
+	// trusted proxies
+	assert.Equal(t, "5.6.7.8", do("127.0.0.1:5678", "X-Forwarded-For", "5.6.7.8"))
+	assert.Equal(t, "5.6.7.8", do("127.0.0.1:5678", "X-Forwarded-For", "9.9.9.9, 5.6.7.8, 10.0.0.1"))
+	assert.Equal(t, "5.6.7.8", do("127.0.0.1:5678", "X-Real-IP", "5.6.7.8"))
+	// multiple header lines
+	req := httptest.NewRequest("GET", "/blocks/best", nil)
+	req.RemoteAddr = "127.0.0.1:5678"
+	req.Header.Add("X-Forwarded-For", "9.9.9.9")
+	req.Header.Add("X-Forwarded-For", "5.6.7.8, 10.0.0.1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "5.6.7.8", client)
+	// malformed
+	assert.Equal(t, "127.0.0.1", do("127.0.0.1:5678", "X-Forwarded-For", "unknown"))
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// max count of clients tracked, least recently seen ones are forgotten
+const rateLimitClients = 16384
+
+// RateLimiter limits request rate per client IP, using token buckets.
+// Each request costs the weight of the longest matched path prefix, or 1 if none matched.
+type RateLimiter struct {
+	rate    float64 // tokens per second
+	burst   float64
+	weights []pathWeight
+	lock    sync.Mutex
+	buckets *lru.Cache
+	now     func() time.Time
+}
+
+type pathWeight struct {
+	prefix string
+	weight float64
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter. weights maps path prefix to request cost.
+func NewRateLimiter(rate float64, burst int, weights map[string]int) *RateLimiter {
+	buckets, _ := lru.New(rateLimitClients)
+	rl := &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: buckets,
+		now:     time.Now,
+	}
+	for prefix, weight := range weights {
+		rl.weights = append(rl.weights, pathWeight{prefix, float64(weight)})
+	}
+	sort.Slice(rl.weights, func(i, j int) bool {
+		return len(rl.weights[i].prefix) > len(rl.weights[j].prefix)
+	})
+	return rl
+}
+
+// ParseRateLimitWeights parses weights in form of 'prefix=weight,prefix=weight'.
+// A weight greater than burst is rejected, since such requests could never be served.
+func ParseRateLimitWeights(s string, burst int) (map[string]int, error) {
+	weights := make(map[string]int)
+	if s == "" {
+		return weights, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 || !strings.HasPrefix(kv[0], "/") {
+			return nil, fmt.Errorf("invalid rate limit weight %q, should be in form of '/path=weight'", item)
+		}
+		weight, err := strconv.Atoi(kv[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid rate limit weight %q, should be in form of '/path=weight'", item)
+		}
+		if weight > burst {
+			return nil, fmt.Errorf("invalid rate limit weight %q, exceeds burst %v", item, burst)
+		}
+		weights[kv[0]] = weight
+	}
+	return weights, nil
+}
+
+func (rl *RateLimiter) weightOf(path string) float64 {
+	for _, w := range rl.weights {
+		if strings.HasPrefix(path, w.prefix) {
+			return w.weight
+		}
+	}
+	return 1
+}
+
+// take takes cost tokens from bucket of the client.
+// It returns whether allowed, tokens remaining and time to wait if not allowed.
+func (rl *RateLimiter) take(client string, cost float64) (bool, float64, time.Duration) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := rl.now()
+	var b *bucket
+	if v, ok := rl.buckets.Get(client); ok {
+		b = v.(*bucket)
+		b.tokens = math.Min(rl.burst, b.tokens+now.Sub(b.last).Seconds()*rl.rate)
+	} else {
+		b = &bucket{tokens: rl.burst}
+		rl.buckets.Add(client, b)
+	}
+	b.last = now
+
+	if b.tokens < cost {
+		wait := time.Duration((cost - b.tokens) / rl.rate * float64(time.Second))
+		return false, b.tokens, wait
+	}
+	b.tokens -= cost
+	return true, b.tokens, 0
+}
+
+// Handler wraps h with rate limiting.
+func (rl *RateLimiter) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		allowed, remaining, wait := rl.take(ClientIP(req), rl.weightOf(req.URL.Path))
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(int(rl.burst)))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining)))
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
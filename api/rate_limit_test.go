@@ -0,0 +1,62 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	rl := NewRateLimiter(1, 3, map[string]int{"/logs": 2, "/logs/free": 0})
+	rl.now = func() time.Time { return now }
+
+	h := rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	do := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, do("/blocks/best", "1.1.1.1:1000").Code)
+	w := do("/logs/event", "1.1.1.1:1001")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = do("/blocks/best", "1.1.1.1:1002")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// zero weighted path and other clients are not affected
+	assert.Equal(t, http.StatusOK, do("/logs/free", "1.1.1.1:1003").Code)
+	assert.Equal(t, http.StatusOK, do("/blocks/best", "2.2.2.2:1000").Code)
+
+	// refilled over time
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, http.StatusOK, do("/logs/event", "1.1.1.1:1004").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do("/blocks/best", "1.1.1.1:1005").Code)
+}
+
+func TestParseRateLimitWeights(t *testing.T) {
+	weights, err := ParseRateLimitWeights("/logs=5, /accounts=2", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"/logs": 5, "/accounts": 2}, weights)
+
+	_, err = ParseRateLimitWeights("logs=5", 5)
+	assert.NotNil(t, err)
+	_, err = ParseRateLimitWeights("/logs=x", 5)
+	assert.NotNil(t, err)
+	_, err = ParseRateLimitWeights("/logs=6", 5)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package stats
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	defaultContractLimit = 100
+	maxContractLimit     = 1000
+
+	// ranges are capped, since stats are aggregated by scanning records in range
+	maxBlockSpan = 31 * 24 * 3600 / thor.BlockInterval
+	maxTimeSpan  = 31 * 24 * 3600
+)
+
+// Stats serves aggregate statistics computed from log db.
+type Stats struct {
+	db *logdb.LogDB
+}
+
+func New(db *logdb.LogDB) *Stats {
+	return &Stats{
+		db,
+	}
+}
+
+// parseRange parses range from query params 'unit', 'from' and 'to'.
+// The span is at most about a month, which is also the default if 'to' is absent.
+func parseRange(query url.Values) (*logdb.Range, error) {
+	rng := &logdb.Range{Unit: logdb.Block}
+	maxSpan := uint64(maxBlockSpan)
+	switch unit := query.Get("unit"); unit {
+	case "", string(logdb.Block):
+	case string(logdb.Time):
+		rng.Unit = logdb.Time
+		maxSpan = maxTimeSpan
+	default:
+		return nil, utils.BadRequest(errors.New("should be one of 'block', 'time'"), "unit")
+	}
+	var err error
+	if s := query.Get("from"); s != "" {
+		if rng.From, err = strconv.ParseUint(s, 0, 32); err != nil {
+			return nil, utils.BadRequest(err, "from")
+		}
+	}
+	rng.To = rng.From + maxSpan - 1
+	if s := query.Get("to"); s != "" {
+		if rng.To, err = strconv.ParseUint(s, 0, 63); err != nil {
+			return nil, utils.BadRequest(err, "to")
+		}
+	}
+	if rng.To < rng.From {
+		return nil, utils.BadRequest(errors.New("less than from"), "to")
+	}
+	if rng.To-rng.From >= maxSpan {
+		return nil, utils.BadRequest(fmt.Errorf("range exceeds %v", maxSpan), "to")
+	}
+	return rng, nil
+}
+
+func (s *Stats) handleGetContractEvents(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	rng, err := parseRange(query)
+	if err != nil {
+		return err
+	}
+	limit := uint64(defaultContractLimit)
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.ParseUint(v, 0, 0); err != nil {
+			return utils.BadRequest(err, "limit")
+		}
+		if limit > maxContractLimit {
+			return utils.BadRequest(fmt.Errorf("exceeds %v", maxContractLimit), "limit")
+		}
+	}
+	counts, err := s.db.CountEventsByContract(req.Context(), rng, limit)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertContractEvents(counts))
+}
+
+func (s *Stats) handleGetDailyTransfers(w http.ResponseWriter, req *http.Request) error {
+	rng, err := parseRange(req.URL.Query())
+	if err != nil {
+		return err
+	}
+	days, err := s.db.DailyTransfers(req.Context(), rng)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertDailyTransfers(days))
+}
+
+func (s *Stats) handleGetDailyTransactions(w http.ResponseWriter, req *http.Request) error {
+	rng, err := parseRange(req.URL.Query())
+	if err != nil {
+		return err
+	}
+	days, err := s.db.DailyTransactions(req.Context(), rng)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertDailyTransactions(days))
+}
+
+func (s *Stats) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/events").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleGetContractEvents))
+	sub.Path("/transfers").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleGetDailyTransfers))
+	sub.Path("/transactions").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleGetDailyTransactions))
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package stats_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/stats"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+const day = 24 * 3600
+
+var contractAddr = thor.BytesToAddress([]byte("contract"))
+
+func TestStats(t *testing.T) {
+	ts := initStatsServer(t)
+	defer ts.Close()
+
+	var events []*stats.ContractEvents
+	httpGet(t, ts.URL+"/stats/events?from=1", http.StatusOK, &events)
+	assert.Equal(t, []*stats.ContractEvents{{contractAddr, 3}}, events)
+
+	var transfers []*stats.DailyTransfers
+	httpGet(t, ts.URL+"/stats/transfers?unit=time&from=0&to=259199", http.StatusOK, &transfers)
+	if assert.Equal(t, 2, len(transfers)) {
+		assert.Equal(t, uint64(day), transfers[0].Day)
+		assert.Equal(t, uint64(2), transfers[0].Count)
+		assert.Equal(t, big.NewInt(20), (*big.Int)(transfers[0].Amount))
+	}
+
+	var txs []*stats.DailyTransactions
+	httpGet(t, ts.URL+"/stats/transactions?unit=time&from=172800", http.StatusOK, &txs)
+	assert.Equal(t, []*stats.DailyTransactions{{2 * day, 1}}, txs)
+
+	httpGet(t, ts.URL+"/stats/events?unit=none", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/stats/events?from=10&to=1", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/stats/events?limit=100000", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/stats/events?from=0&to=1000000", http.StatusBadRequest, nil)
+	httpGet(t, ts.URL+"/stats/transactions?unit=time&from=0&to=100000000", http.StatusBadRequest, nil)
+}
+
+func initStatsServer(t *testing.T) *httptest.Server {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origin := thor.BytesToAddress([]byte("origin"))
+	header := new(block.Builder).Build().Header()
+	for i, ts := range []uint64{day + 1, day + 2, 2*day + 1} {
+		header = new(block.Builder).ParentID(header.ID()).Timestamp(ts).Build().Header()
+		if err := db.Prepare(header).ForTransaction(thor.BytesToBytes32([]byte{byte(i)}), origin).
+			Insert(tx.Events{{Address: contractAddr}},
+				tx.Transfers{{Sender: origin, Recipient: contractAddr, Amount: big.NewInt(10)}}).
+			Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := mux.NewRouter()
+	stats.New(db).Mount(router, "/stats")
+	return httptest.NewServer(router)
+}
+
+func httpGet(t *testing.T, url string, status int, v interface{}) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, status, res.StatusCode, string(data))
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
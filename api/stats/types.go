@@ -0,0 +1,56 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package stats
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+)
+
+// ContractEvents is the number of events emitted by a contract.
+type ContractEvents struct {
+	Address thor.Address `json:"address"`
+	Count   uint64       `json:"count"`
+}
+
+// DailyTransfers aggregates VET transfers in a UTC day.
+type DailyTransfers struct {
+	Day    uint64                `json:"day"` // timestamp of the beginning of the day
+	Count  uint64                `json:"count"`
+	Amount *math.HexOrDecimal256 `json:"amount"`
+}
+
+// DailyTransactions is the number of transactions in a UTC day.
+type DailyTransactions struct {
+	Day   uint64 `json:"day"` // timestamp of the beginning of the day
+	Count uint64 `json:"count"`
+}
+
+func convertContractEvents(counts []*logdb.ContractEventCount) []*ContractEvents {
+	result := make([]*ContractEvents, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, &ContractEvents{c.Address, c.Count})
+	}
+	return result
+}
+
+func convertDailyTransfers(days []*logdb.DailyTransfers) []*DailyTransfers {
+	result := make([]*DailyTransfers, 0, len(days))
+	for _, d := range days {
+		amount := math.HexOrDecimal256(*d.Amount)
+		result = append(result, &DailyTransfers{d.Day, d.Count, &amount})
+	}
+	return result
+}
+
+func convertDailyTransactions(days []*logdb.DailyTransactions) []*DailyTransactions {
+	result := make([]*DailyTransactions, 0, len(days))
+	for _, d := range days {
+		result = append(result, &DailyTransactions{d.Day, d.Count})
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+)
+
+// msgReader reads messages converted from blocks changed since the last read position.
+type msgReader struct {
+	chain    *chain.Chain
+	position thor.Bytes32
+	convert  func(b *block.Block, obsolete bool) ([]interface{}, error)
+}
+
+func newMsgReader(chain *chain.Chain, position thor.Bytes32, convert func(*block.Block, bool) ([]interface{}, error)) *msgReader {
+	return &msgReader{
+		chain,
+		position,
+		convert,
+	}
+}
+
+// Read returns messages of blocks changed since last read.
+// Messages of blocks obsoleted by re-org come first, in descending order.
+func (r *msgReader) Read() ([]interface{}, error) {
+	best := r.chain.BestBlock().Header()
+	if best.ID() == r.position {
+		return nil, nil
+	}
+	position, err := r.chain.GetBlockHeader(r.position)
+	if err != nil {
+		return nil, err
+	}
+	fork, err := r.chain.BuildFork(best, position)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []interface{}
+	for i := len(fork.Branch) - 1; i >= 0; i-- {
+		blk, err := r.chain.GetBlock(fork.Branch[i].ID())
+		if err != nil {
+			return nil, err
+		}
+		m, err := r.convert(blk, true)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m...)
+	}
+	for _, header := range fork.Trunk {
+		blk, err := r.chain.GetBlock(header.ID())
+		if err != nil {
+			return nil, err
+		}
+		m, err := r.convert(blk, false)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m...)
+	}
+	r.position = best.ID()
+	return msgs, nil
+}
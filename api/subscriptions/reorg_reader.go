@@ -0,0 +1,27 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/chain"
+)
+
+// reorgReader reads reorgs happened since the last read.
+type reorgReader struct {
+	chain *chain.Chain
+	seq   uint64 // sequence number of the last read reorg
+}
+
+func (r *reorgReader) Read() ([]interface{}, error) {
+	reorgs := r.chain.RecentReorgs(r.seq)
+	msgs := make([]interface{}, 0, len(reorgs))
+	for _, reorg := range reorgs {
+		msgs = append(msgs, blocks.ConvertReorg(reorg))
+		r.seq = reorg.Seq
+	}
+	return msgs, nil
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var log = log15.New("pkg", "subscriptions")
+
+const (
+	backtraceLimit = 1000 // max number of blocks allowed to backtrace by 'pos'
+	txChanSize     = 100  // buffer size of new txs from tx pool
+
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 7 / 10
+)
+
+// reader reads messages to be pushed, and is called each time new blocks committed.
+type reader interface {
+	Read() ([]interface{}, error)
+}
+
+type Subscriptions struct {
+	chain    *chain.Chain
+	feed     BlockFeed
+	txFeed   TxFeed
+	abis     *abis.Registry
+	upgrader *websocket.Upgrader
+}
+
+func New(chain *chain.Chain, feed BlockFeed, txFeed TxFeed, registry *abis.Registry) *Subscriptions {
+	return &Subscriptions{
+		chain:  chain,
+		feed:   feed,
+		txFeed: txFeed,
+		abis:   registry,
+		upgrader: &websocket.Upgrader{
+			// subscriptions only push public chain data, so accept any origin
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// parsePosition parses the 'pos' query param, which is the ID of the block where to start streaming.
+// Best block assumed if omitted.
+func (s *Subscriptions) parsePosition(pos string) (thor.Bytes32, error) {
+	best := s.chain.BestBlock().Header()
+	if pos == "" {
+		return best.ID(), nil
+	}
+	id, err := thor.ParseBytes32(pos)
+	if err != nil {
+		return thor.Bytes32{}, utils.BadRequest(err, "pos")
+	}
+	header, err := s.chain.GetBlockHeader(id)
+	if err != nil {
+		if s.chain.IsNotFound(err) {
+			return thor.Bytes32{}, utils.BadRequest(errors.New("block not found"), "pos")
+		}
+		return thor.Bytes32{}, err
+	}
+	if best.Number() > header.Number() && best.Number()-header.Number() > backtraceLimit {
+		return thor.Bytes32{}, utils.Forbidden(errors.New("exceeds backtrace limit"), "pos")
+	}
+	return id, nil
+}
+
+func (s *Subscriptions) handleSubscribeBlock(w http.ResponseWriter, req *http.Request) error {
+	pos, err := s.parsePosition(req.URL.Query().Get("pos"))
+	if err != nil {
+		return err
+	}
+	return s.serve(w, req, newMsgReader(s.chain, pos, convertBlock))
+}
+
+func (s *Subscriptions) handleSubscribeBeat(w http.ResponseWriter, req *http.Request) error {
+	pos, err := s.parsePosition(req.URL.Query().Get("pos"))
+	if err != nil {
+		return err
+	}
+	return s.serve(w, req, newMsgReader(s.chain, pos, newBeatConverter(s.chain)))
+}
+
+func (s *Subscriptions) handleSubscribeEvent(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	pos, err := s.parsePosition(query.Get("pos"))
+	if err != nil {
+		return err
+	}
+	var filter EventFilter
+	if addr := query.Get("addr"); addr != "" {
+		address, err := thor.ParseAddress(addr)
+		if err != nil {
+			return utils.BadRequest(err, "addr")
+		}
+		filter.Address = &address
+	}
+	for i := range filter.Topics {
+		name := fmt.Sprintf("t%d", i)
+		if t := query.Get(name); t != "" {
+			topic, err := thor.ParseBytes32(t)
+			if err != nil {
+				return utils.BadRequest(err, name)
+			}
+			filter.Topics[i] = &topic
+		}
+	}
+	return s.serve(w, req, newMsgReader(s.chain, pos, newEventConverter(s.chain, &filter, s.abis)))
+}
+
+func (s *Subscriptions) handleSubscribeTransfer(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	pos, err := s.parsePosition(query.Get("pos"))
+	if err != nil {
+		return err
+	}
+	parseAddress := func(name string) (*thor.Address, error) {
+		str := query.Get(name)
+		if str == "" {
+			return nil, nil
+		}
+		addr, err := thor.ParseAddress(str)
+		if err != nil {
+			return nil, utils.BadRequest(err, name)
+		}
+		return &addr, nil
+	}
+	var filter TransferFilter
+	if filter.TxOrigin, err = parseAddress("txOrigin"); err != nil {
+		return err
+	}
+	if filter.Sender, err = parseAddress("sender"); err != nil {
+		return err
+	}
+	if filter.Recipient, err = parseAddress("recipient"); err != nil {
+		return err
+	}
+	return s.serve(w, req, newMsgReader(s.chain, pos, newTransferConverter(s.chain, &filter)))
+}
+
+// handleSubscribeReorg streams reorgs happened after the sequence number given by query 'after',
+// or after subscribed if omitted.
+func (s *Subscriptions) handleSubscribeReorg(w http.ResponseWriter, req *http.Request) error {
+	var after uint64
+	if str := req.URL.Query().Get("after"); str != "" {
+		v, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return utils.BadRequest(err, "after")
+		}
+		if lowest := s.chain.LowestReorgSeq(); v+1 < lowest {
+			return utils.BadRequest(fmt.Errorf("reorgs before %v are not retained", lowest), "after")
+		}
+		after = v
+	} else if reorgs := s.chain.RecentReorgs(0); len(reorgs) > 0 {
+		after = reorgs[len(reorgs)-1].Seq
+	}
+	return s.serve(w, req, &reorgReader{s.chain, after})
+}
+
+// handleSubscribeTxPool streams txs accepted into tx pool after subscribed.
+// Full tx bodies are pushed if query 'full' is true, otherwise only IDs.
+func (s *Subscriptions) handleSubscribeTxPool(w http.ResponseWriter, req *http.Request) error {
+	var full bool
+	if str := req.URL.Query().Get("full"); str != "" {
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return utils.BadRequest(err, "full")
+		}
+		full = v
+	}
+	return s.serveWith(w, req, func(conn *websocket.Conn) error {
+		return s.pipeTxs(conn, full)
+	})
+}
+
+// serve upgrades the http connection to websocket, and pipes messages from reader.
+func (s *Subscriptions) serve(w http.ResponseWriter, req *http.Request, reader reader) error {
+	return s.serveWith(w, req, func(conn *websocket.Conn) error {
+		return s.pipe(conn, reader)
+	})
+}
+
+// serveWith upgrades the http connection to websocket, and runs pipe on it.
+func (s *Subscriptions) serveWith(w http.ResponseWriter, req *http.Request, pipe func(conn *websocket.Conn) error) error {
+	conn, err := s.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// upgrader already responded the error
+		log.Debug("failed to upgrade", "err", err)
+		return nil
+	}
+	defer conn.Close()
+
+	if err := pipe(conn); err != nil {
+		log.Debug("subscription closed", "err", err)
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()),
+			time.Now().Add(writeWait))
+		return nil
+	}
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(writeWait))
+	return nil
+}
+
+func (s *Subscriptions) pipe(conn *websocket.Conn, reader reader) error {
+	newBlockCh := make(chan *chain.Fork, 1)
+	sub := s.feed.SubscribeBlock(newBlockCh)
+	defer sub.Unsubscribe()
+
+	closed := readLoop(conn)
+
+	pingTicker := time.NewTicker(pingPeriod)
+	defer pingTicker.Stop()
+
+	for {
+		msgs, err := reader.Read()
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(msg); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-newBlockCh:
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+func (s *Subscriptions) pipeTxs(conn *websocket.Conn, full bool) error {
+	txCh := make(chan *tx.Transaction, txChanSize)
+	sub := s.txFeed.SubscribeNewTransaction(txCh)
+	defer sub.Unsubscribe()
+
+	closed := readLoop(conn)
+
+	pingTicker := time.NewTicker(pingPeriod)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case trx := <-txCh:
+			msg, err := convertPendingTx(trx, full)
+			if err != nil {
+				return err
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(msg); err != nil {
+				return err
+			}
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+// readLoop processes control messages from peer, which is required by websocket.
+// The returned channel is closed once the connection is broken.
+func readLoop(conn *websocket.Conn) <-chan struct{} {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			conn.SetReadDeadline(time.Now().Add(pongWait))
+			return nil
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return closed
+}
+
+func (s *Subscriptions) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/block").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeBlock))
+	sub.Path("/event").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeEvent))
+	sub.Path("/transfer").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeTransfer))
+	sub.Path("/beat").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeBeat))
+	sub.Path("/reorg").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeReorg))
+	sub.Path("/txpool").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleSubscribeTxPool))
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions_test
+
+import (
+	"math"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/subscriptions"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/builtin/gen"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+type blockFeed struct {
+	feed event.Feed
+}
+
+func (f *blockFeed) SubscribeBlock(ch chan *chain.Fork) event.Subscription {
+	return f.feed.Subscribe(ch)
+}
+
+type txFeed struct {
+	feed event.Feed
+}
+
+func (f *txFeed) SubscribeNewTransaction(ch chan *tx.Transaction) event.Subscription {
+	return f.feed.Subscribe(ch)
+}
+
+var (
+	ts     *httptest.Server
+	feed   blockFeed
+	txs    txFeed
+	c      *chain.Chain
+	stateC *state.Creator
+)
+
+func TestSubscribeBlock(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	genesisID := c.GenesisBlock().Header().ID()
+	blk1 := packBlock(t, c.BestBlock())
+
+	conn := dial(t, "/subscriptions/block", "pos="+genesisID.String())
+	defer conn.Close()
+
+	// backtraced block
+	var msg subscriptions.BlockMessage
+	readMessage(t, conn, &msg)
+	assert.Equal(t, blk1.Header().ID(), msg.ID)
+	assert.False(t, msg.Obsolete)
+
+	// new block
+	blk2 := packBlock(t, blk1)
+	readMessage(t, conn, &msg)
+	assert.Equal(t, blk2.Header().ID(), msg.ID)
+	assert.Equal(t, blk2.Header().Number(), msg.Number)
+}
+
+func TestSubscribeEvent(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	genesisID := c.GenesisBlock().Header().ID()
+	trx := newEnergyTransferTx(t)
+	blk := packBlock(t, c.BestBlock(), trx)
+
+	transferEvent, _ := builtin.Energy.ABI.EventByName("Transfer")
+	conn := dial(t, "/subscriptions/event", "pos="+genesisID.String()+"&addr="+builtin.Energy.Address.String()+"&t0="+transferEvent.ID().String())
+	defer conn.Close()
+
+	var msg subscriptions.EventMessage
+	readMessage(t, conn, &msg)
+	assert.Equal(t, builtin.Energy.Address, msg.Address)
+	assert.Equal(t, transferEvent.ID(), msg.Topics[0])
+	if assert.NotNil(t, msg.Decoded) {
+		assert.Equal(t, "Transfer", msg.Decoded.Name)
+		assert.Equal(t, map[string]interface{}{
+			"_from":  genesis.DevAccounts()[0].Address.String(),
+			"_to":    genesis.DevAccounts()[1].Address.String(),
+			"_value": "1",
+		}, msg.Decoded.Params)
+	}
+	assert.Equal(t, blk.Header().ID(), msg.Block.ID)
+	assert.Equal(t, trx.ID(), msg.Tx.ID)
+	assert.Equal(t, genesis.DevAccounts()[0].Address, msg.Tx.Origin)
+	assert.False(t, msg.Obsolete)
+}
+
+func TestSubscribeTransfer(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	genesisID := c.GenesisBlock().Header().ID()
+	from := genesis.DevAccounts()[0].Address
+	to := thor.BytesToAddress([]byte("to"))
+	trx := newTx(t, tx.NewClause(&to).WithValue(big.NewInt(10)))
+	blk := packBlock(t, c.BestBlock(), trx)
+
+	conn := dial(t, "/subscriptions/transfer", "pos="+genesisID.String()+"&sender="+from.String()+"&recipient="+to.String())
+	defer conn.Close()
+
+	var msg subscriptions.TransferMessage
+	readMessage(t, conn, &msg)
+	assert.Equal(t, from, msg.Sender)
+	assert.Equal(t, to, msg.Recipient)
+	assert.Equal(t, big.NewInt(10), (*big.Int)(msg.Amount))
+	assert.Equal(t, blk.Header().ID(), msg.Block.ID)
+	assert.Equal(t, trx.ID(), msg.Tx.ID)
+}
+
+func TestSubscribeBeat(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	genesisID := c.GenesisBlock().Header().ID()
+	to := thor.BytesToAddress([]byte("to"))
+	blk := packBlock(t, c.BestBlock(), newTx(t, tx.NewClause(&to).WithValue(big.NewInt(10))))
+
+	conn := dial(t, "/subscriptions/beat", "pos="+genesisID.String())
+	defer conn.Close()
+
+	var msg subscriptions.BeatMessage
+	readMessage(t, conn, &msg)
+	assert.Equal(t, blk.Header().ID(), msg.ID)
+	assert.Equal(t, genesisID, msg.ParentID)
+	assert.Equal(t, blk.Header().Number(), msg.Number)
+	assert.False(t, msg.Obsolete)
+
+	bits, err := hexutil.Decode(msg.Bloom)
+	assert.Nil(t, err)
+	bloom := thor.Bloom{K: msg.K}
+	copy(bloom.Bits[:], bits)
+	assert.True(t, bloom.Test(to.Bytes()))
+	assert.True(t, bloom.Test(genesis.DevAccounts()[0].Address.Bytes()))
+	assert.False(t, bloom.Test(thor.BytesToAddress([]byte("untouched")).Bytes()))
+}
+
+func TestSubscribeReorg(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	b0 := c.GenesisBlock()
+	blk1 := packBlock(t, b0)
+
+	conn := dial(t, "/subscriptions/reorg", "")
+	defer conn.Close()
+
+	// blk1x may take over trunk by tie-breaking, while blk2x always does
+	blk1x := packBlock(t, b0, newEnergyTransferTx(t))
+	packBlock(t, blk1x)
+
+	var msg blocks.Reorg
+	readMessage(t, conn, &msg)
+	assert.Equal(t, uint64(1), msg.Seq)
+	assert.Equal(t, b0.Header().ID(), msg.Ancestor)
+	assert.Equal(t, []thor.Bytes32{blk1.Header().ID()}, msg.OldBranch)
+	assert.Equal(t, blk1x.Header().ID(), msg.NewBranch[0])
+}
+
+func TestSubscribeTxPool(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	send := func(trx *tx.Transaction) {
+		// wait for the subscriber
+		for i := 0; txs.feed.Send(trx) == 0; i++ {
+			if i >= 50 {
+				t.Fatal("no subscriber")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	conn := dial(t, "/subscriptions/txpool", "")
+	trx := newEnergyTransferTx(t)
+	send(trx)
+	var msg subscriptions.PendingTxMessage
+	readMessage(t, conn, &msg)
+	assert.Equal(t, trx.ID(), msg.ID)
+	assert.Nil(t, msg.Tx)
+	conn.Close()
+
+	conn = dial(t, "/subscriptions/txpool", "full=true")
+	defer conn.Close()
+	trx = newEnergyTransferTx(t)
+	send(trx)
+	msg = subscriptions.PendingTxMessage{}
+	readMessage(t, conn, &msg)
+	assert.Equal(t, trx.ID(), msg.ID)
+	if assert.NotNil(t, msg.Tx) {
+		assert.Equal(t, trx.ID(), msg.Tx.ID)
+		assert.Equal(t, len(trx.Clauses()), len(msg.Tx.Clauses))
+	}
+}
+
+func TestSubscribeBlockBadPos(t *testing.T) {
+	initSubscriptionsServer(t)
+	defer ts.Close()
+
+	u := url.URL{Scheme: "ws", Host: ts.Listener.Addr().String(), Path: "/subscriptions/block", RawQuery: "pos=bad"}
+	_, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func initSubscriptionsServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC = state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ = chain.New(db, b)
+
+	registry, err := abis.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(builtin.Energy.Address, gen.MustAsset("compiled/Energy.abi")); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	subscriptions.New(c, &feed, &txs, registry).Mount(router, "/subscriptions")
+	ts = httptest.NewServer(router)
+}
+
+func newEnergyTransferTx(t *testing.T) *tx.Transaction {
+	method, _ := builtin.Energy.ABI.MethodByName("transfer")
+	data, err := method.EncodeInput(genesis.DevAccounts()[1].Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTx(t, tx.NewClause(&builtin.Energy.Address).WithData(data))
+}
+
+func newTx(t *testing.T, clause *tx.Clause) *tx.Transaction {
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Clause(clause).
+		Gas(300000).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trx.WithSignature(sig)
+}
+
+func packBlock(t *testing.T, parent *block.Block, txs ...*tx.Transaction) *block.Block {
+	p := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := p.Mock(parent.Header(), parent.Header().Timestamp()+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, trx := range txs {
+		if err := flow.Adopt(trx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blk, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	fork, err := c.AddBlock(blk, receipts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed.feed.Send(fork)
+	return blk
+}
+
+func dial(t *testing.T, path string, query string) *websocket.Conn {
+	u := url.URL{Scheme: "ws", Host: ts.Listener.Addr().String(), Path: path, RawQuery: query}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn, v interface{}) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(v); err != nil {
+		t.Fatal(err)
+	}
+}
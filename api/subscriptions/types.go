@@ -0,0 +1,281 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// BlockFeed emits an event each time new blocks are committed into the chain.
+type BlockFeed interface {
+	SubscribeBlock(ch chan *chain.Fork) event.Subscription
+}
+
+// TxFeed emits txs accepted by tx pool.
+type TxFeed interface {
+	SubscribeNewTransaction(ch chan *tx.Transaction) event.Subscription
+}
+
+// BlockMessage block pushed to subscribers.
+type BlockMessage struct {
+	*blocks.Block
+	Obsolete bool `json:"obsolete"`
+}
+
+func convertBlock(b *block.Block, obsolete bool) ([]interface{}, error) {
+	blk, err := blocks.ConvertBlock(b, !obsolete)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{&BlockMessage{
+		blk,
+		obsolete,
+	}}, nil
+}
+
+// PendingTxMessage tx accepted by tx pool, pushed to subscribers.
+// Tx is present only if full body requested.
+type PendingTxMessage struct {
+	ID thor.Bytes32              `json:"id"`
+	Tx *transactions.Transaction `json:"tx,omitempty"`
+}
+
+func convertPendingTx(trx *tx.Transaction, full bool) (*PendingTxMessage, error) {
+	msg := &PendingTxMessage{ID: trx.ID()}
+	if full {
+		t, err := transactions.ConvertTransaction(trx)
+		if err != nil {
+			return nil, err
+		}
+		msg.Tx = t
+	}
+	return msg, nil
+}
+
+// EventFilter contract event criteria. Nil fields match any.
+type EventFilter struct {
+	Address *thor.Address
+	Topics  [5]*thor.Bytes32
+}
+
+// Match returns whether event matches filter
+func (ef *EventFilter) Match(event *tx.Event) bool {
+	if ef.Address != nil && *ef.Address != event.Address {
+		return false
+	}
+	for i, topic := range ef.Topics {
+		if topic == nil {
+			continue
+		}
+		if i >= len(event.Topics) || event.Topics[i] != *topic {
+			return false
+		}
+	}
+	return true
+}
+
+// EventMessage event pushed to subscribers.
+// Decoded is present if ABI of the contract registered and the event matched.
+type EventMessage struct {
+	Address  thor.Address              `json:"address"`
+	Topics   []thor.Bytes32            `json:"topics"`
+	Data     string                    `json:"data"`
+	Decoded  *abis.DecodedEvent        `json:"decoded,omitempty"`
+	Block    transactions.BlockContext `json:"block"`
+	Tx       transactions.TxContext    `json:"tx"`
+	Obsolete bool                      `json:"obsolete"`
+}
+
+func newBlockContext(header *block.Header) transactions.BlockContext {
+	return transactions.BlockContext{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+	}
+}
+
+// forEachOutput iterates outputs of all txs in the block, along with tx context.
+func forEachOutput(chain *chain.Chain, b *block.Block, cb func(txCtx transactions.TxContext, output *tx.Output)) error {
+	receipts, err := chain.GetBlockReceipts(b.Header().ID())
+	if err != nil {
+		return err
+	}
+	for i, tx := range b.Transactions() {
+		origin, err := tx.Signer()
+		if err != nil {
+			return err
+		}
+		txCtx := transactions.TxContext{
+			ID:     tx.ID(),
+			Origin: origin,
+		}
+		for _, output := range receipts[i].Outputs {
+			cb(txCtx, output)
+		}
+	}
+	return nil
+}
+
+func newEventConverter(chain *chain.Chain, filter *EventFilter, registry *abis.Registry) func(*block.Block, bool) ([]interface{}, error) {
+	return func(b *block.Block, obsolete bool) ([]interface{}, error) {
+		blockCtx := newBlockContext(b.Header())
+		var msgs []interface{}
+		err := forEachOutput(chain, b, func(txCtx transactions.TxContext, output *tx.Output) {
+			for _, event := range output.Events {
+				if !filter.Match(event) {
+					continue
+				}
+				msgs = append(msgs, &EventMessage{
+					Address:  event.Address,
+					Topics:   event.Topics,
+					Data:     hexutil.Encode(event.Data),
+					Decoded:  registry.DecodeEvent(event.Address, event.Topics, event.Data),
+					Block:    blockCtx,
+					Tx:       txCtx,
+					Obsolete: obsolete,
+				})
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return msgs, nil
+	}
+}
+
+// TransferFilter transfer criteria. Nil fields match any.
+type TransferFilter struct {
+	TxOrigin  *thor.Address
+	Sender    *thor.Address
+	Recipient *thor.Address
+}
+
+// Match returns whether transfer matches filter
+func (tf *TransferFilter) Match(transfer *tx.Transfer, origin thor.Address) bool {
+	if tf.TxOrigin != nil && *tf.TxOrigin != origin {
+		return false
+	}
+	if tf.Sender != nil && *tf.Sender != transfer.Sender {
+		return false
+	}
+	if tf.Recipient != nil && *tf.Recipient != transfer.Recipient {
+		return false
+	}
+	return true
+}
+
+// TransferMessage transfer pushed to subscribers.
+type TransferMessage struct {
+	Sender    thor.Address              `json:"sender"`
+	Recipient thor.Address              `json:"recipient"`
+	Amount    *math.HexOrDecimal256     `json:"amount"`
+	Block     transactions.BlockContext `json:"block"`
+	Tx        transactions.TxContext    `json:"tx"`
+	Obsolete  bool                      `json:"obsolete"`
+}
+
+func newTransferConverter(chain *chain.Chain, filter *TransferFilter) func(*block.Block, bool) ([]interface{}, error) {
+	return func(b *block.Block, obsolete bool) ([]interface{}, error) {
+		blockCtx := newBlockContext(b.Header())
+		var msgs []interface{}
+		err := forEachOutput(chain, b, func(txCtx transactions.TxContext, output *tx.Output) {
+			for _, transfer := range output.Transfers {
+				if !filter.Match(transfer, txCtx.Origin) {
+					continue
+				}
+				msgs = append(msgs, &TransferMessage{
+					Sender:    transfer.Sender,
+					Recipient: transfer.Recipient,
+					Amount:    (*math.HexOrDecimal256)(transfer.Amount),
+					Block:     blockCtx,
+					Tx:        txCtx,
+					Obsolete:  obsolete,
+				})
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return msgs, nil
+	}
+}
+
+// BeatMessage compact record of a block pushed to subscribers.
+// Bloom is made of addresses touched by the block, to cheaply determine whether an account might be affected.
+type BeatMessage struct {
+	Number    uint32       `json:"number"`
+	ID        thor.Bytes32 `json:"id"`
+	ParentID  thor.Bytes32 `json:"parentID"`
+	Timestamp uint64       `json:"timestamp"`
+	Bloom     string       `json:"bloom"`
+	K         uint32       `json:"k"`
+	Obsolete  bool         `json:"obsolete"`
+}
+
+func newBeatConverter(chain *chain.Chain) func(*block.Block, bool) ([]interface{}, error) {
+	return func(b *block.Block, obsolete bool) ([]interface{}, error) {
+		header := b.Header()
+		signer, err := header.Signer()
+		if err != nil {
+			return nil, err
+		}
+		receipts, err := chain.GetBlockReceipts(header.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		addresses := make(map[thor.Address]struct{})
+		add := func(addr thor.Address) { addresses[addr] = struct{}{} }
+
+		add(header.Beneficiary())
+		add(signer)
+		for i, tx := range b.Transactions() {
+			origin, err := tx.Signer()
+			if err != nil {
+				return nil, err
+			}
+			add(origin)
+			for _, clause := range tx.Clauses() {
+				if to := clause.To(); to != nil {
+					add(*to)
+				}
+			}
+			add(receipts[i].GasPayer)
+			for _, output := range receipts[i].Outputs {
+				for _, event := range output.Events {
+					add(event.Address)
+				}
+				for _, transfer := range output.Transfers {
+					add(transfer.Sender)
+					add(transfer.Recipient)
+				}
+			}
+		}
+
+		bloom := thor.NewBloom(thor.EstimateBloomK(len(addresses)))
+		for addr := range addresses {
+			bloom.Add(addr.Bytes())
+		}
+		return []interface{}{&BeatMessage{
+			Number:    header.Number(),
+			ID:        header.ID(),
+			ParentID:  header.ParentID(),
+			Timestamp: header.Timestamp(),
+			Bloom:     hexutil.Encode(bloom.Bits[:]),
+			K:         bloom.K,
+			Obsolete:  obsolete,
+		}}, nil
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vechain/thor/tracing"
+)
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// serveTraced serves the request by h in a span, named by path template of the matching route.
+// The span joins the caller's trace if the request carries trace context.
+func serveTraced(w http.ResponseWriter, req *http.Request, path string, h http.Handler) {
+	ctx, span := tracing.Start(tracing.Extract(req.Context(), req.Header), req.Method+" "+path,
+		tracing.String("http.method", req.Method),
+		tracing.String("http.target", req.URL.RequestURI()))
+	defer span.End()
+
+	rec := &statusRecorder{w, http.StatusOK}
+	h.ServeHTTP(rec, req.WithContext(ctx))
+	span.SetAttributes(tracing.Int("http.status_code", rec.status))
+	if rec.status >= http.StatusInternalServerError {
+		span.Fail(errors.New(http.StatusText(rec.status)))
+	}
+}
@@ -7,7 +7,6 @@ package transactions
 
 import (
 	"errors"
-	"math"
 	"net/http"
 	"strconv"
 
@@ -22,6 +21,8 @@ import (
 	"github.com/vechain/thor/txpool"
 )
 
+const maxBatchTxs = 500
+
 type Transactions struct {
 	chain *chain.Chain
 	pool  *txpool.TxPool
@@ -113,11 +114,11 @@ func (t *Transactions) getTransactionReceiptByID(txID thor.Bytes32, blockID thor
 	if err != nil {
 		return nil, err
 	}
-	return convertReceipt(receipt, h, tx)
+	return ConvertReceipt(receipt, h, tx)
 }
 
 func (t *Transactions) sendTx(tx *tx.Transaction) (thor.Bytes32, error) {
-	if err := t.pool.Add(tx); err != nil {
+	if err := t.pool.AddLocal(tx); err != nil {
 		return thor.Bytes32{}, err
 	}
 	return tx.ID(), nil
@@ -148,6 +149,106 @@ func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Re
 	})
 }
 
+// sendTxs decodes raw txs and adds them into pool one by one, so that a bad one doesn't affect others.
+func (t *Transactions) sendTxs(raws []string) []*BatchSendResult {
+	results := make([]*BatchSendResult, 0, len(raws))
+	for _, raw := range raws {
+		result := &BatchSendResult{}
+		results = append(results, result)
+
+		tx, err := (&RawTx{Raw: raw}).decode()
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		txID := tx.ID()
+		result.ID = &txID
+		if _, err := t.sendTx(tx); err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return results
+}
+
+func (t *Transactions) handleSendTransactions(w http.ResponseWriter, req *http.Request) error {
+	var body BatchRawTx
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if len(body.Raws) > maxBatchTxs {
+		return utils.BadRequest(errors.New("exceeds "+strconv.Itoa(maxBatchTxs)), "raws")
+	}
+	return utils.WriteJSON(w, t.sendTxs(body.Raws))
+}
+
+func (t *Transactions) handleGetDelegationHashes(w http.ResponseWriter, req *http.Request) error {
+	var body DelegationHashRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	tx, err := body.decode()
+	if err != nil {
+		return utils.BadRequest(err, "raw")
+	}
+	if !tx.Features().IsDelegated() {
+		return utils.BadRequest(errors.New("delegation feature not set"), "raw")
+	}
+	return utils.WriteJSON(w, &DelegationHashes{
+		SigningHash:          tx.SigningHash(),
+		DelegatorSigningHash: tx.DelegatorSigningHash(body.Origin),
+	})
+}
+
+// handleCombineDelegation builds the delegated tx with signatures of origin and delegator,
+// and recovers signers to let the caller verify.
+func (t *Transactions) handleCombineDelegation(w http.ResponseWriter, req *http.Request) error {
+	var body DelegationSignatures
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	tx, err := body.decode()
+	if err != nil {
+		return utils.BadRequest(err, "raw")
+	}
+	if !tx.Features().IsDelegated() {
+		return utils.BadRequest(errors.New("delegation feature not set"), "raw")
+	}
+	originSig, err := hexutil.Decode(body.OriginSignature)
+	if err != nil {
+		return utils.BadRequest(err, "originSignature")
+	}
+	if len(originSig) != 65 {
+		return utils.BadRequest(errors.New("invalid length"), "originSignature")
+	}
+	delegatorSig, err := hexutil.Decode(body.DelegatorSignature)
+	if err != nil {
+		return utils.BadRequest(err, "delegatorSignature")
+	}
+	if len(delegatorSig) != 65 {
+		return utils.BadRequest(errors.New("invalid length"), "delegatorSignature")
+	}
+
+	tx = tx.WithSignature(append(originSig, delegatorSig...))
+	origin, err := tx.Signer()
+	if err != nil {
+		return utils.BadRequest(err, "originSignature")
+	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return utils.BadRequest(err, "delegatorSignature")
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &DelegatedTx{
+		RawTx:     RawTx{hexutil.Encode(raw)},
+		ID:        tx.ID(),
+		Origin:    origin,
+		Delegator: *delegator,
+	})
+}
+
 func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["id"]
 	txID, err := thor.ParseBytes32(id)
@@ -198,42 +299,25 @@ func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, re
 	return utils.WriteJSON(w, receipt)
 }
 
+// getBlockHeader returns nil header if the block not found.
 func (t *Transactions) getBlockHeader(revision string) (*block.Header, error) {
-	if revision == "" || revision == "best" {
-		return t.chain.BestBlock().Header(), nil
-	}
-	blkID, err := thor.ParseBytes32(revision)
-	if err != nil {
-		n, err := strconv.ParseUint(revision, 0, 0)
-		if err != nil {
-			return nil, err
-		}
-		if n > math.MaxUint32 {
-			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
-		}
-		b, err := t.chain.GetTrunkBlock(uint32(n))
-		if err != nil {
-			if t.chain.IsNotFound(err) {
-				return nil, nil
-			}
-			return nil, err
-		}
-		return b.Header(), nil
-	}
-	b, err := t.chain.GetBlock(blkID)
+	header, err := utils.GetBlockHeader(t.chain, revision)
 	if err != nil {
 		if t.chain.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return b.Header(), nil
+	return header, nil
 }
 
 func (t *Transactions) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
 	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleSendTransaction))
+	sub.Path("/batch").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleSendTransactions))
+	sub.Path("/delegation/hashes").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleGetDelegationHashes))
+	sub.Path("/delegation/combine").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleCombineDelegation))
 
 	sub.Path("/{id}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(t.handleGetTransactionByID))
 	sub.Path("/{id}").Methods("GET").Queries("revision", "{revision}").HandlerFunc(utils.WrapHandlerFunc(t.handleGetTransactionByID))
@@ -43,6 +43,8 @@ func TestTransaction(t *testing.T) {
 	getTx(t)
 	getTxReceipt(t)
 	senTx(t)
+	sendTxs(t)
+	delegation(t)
 }
 
 func getTx(t *testing.T) {
@@ -105,6 +107,94 @@ func senTx(t *testing.T) {
 	assert.Equal(t, tx.ID().String(), txObj["id"], "shoudl be the same transaction")
 }
 
+func sendTxs(t *testing.T) {
+	newRaw := func(nonce uint64, chainTag byte) string {
+		tx := new(tx.Builder).
+			ChainTag(chainTag).
+			Expiration(10).
+			Gas(21000).
+			Nonce(nonce).
+			Build()
+		sig, err := crypto.Sign(tx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rlpTx, err := rlp.EncodeToBytes(tx.WithSignature(sig))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hexutil.Encode(rlpTx)
+	}
+
+	good := newRaw(1, c.Tag())
+	body, err := json.Marshal(transactions.BatchRawTx{Raws: []string{good, "0xbad", newRaw(2, c.Tag()+1), good}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := httpPost(t, ts.URL+"/transactions/batch", body)
+	var results []*transactions.BatchSendResult
+	if err := json.Unmarshal(res, &results); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Equal(t, 4, len(results)) {
+		return
+	}
+	assert.NotNil(t, results[0].ID)
+	assert.Empty(t, results[0].Error)
+	// undecodable
+	assert.Nil(t, results[1].ID)
+	assert.NotEmpty(t, results[1].Error)
+	// bad chain tag
+	assert.NotNil(t, results[2].ID)
+	assert.Equal(t, "chain tag mismatched", results[2].Error)
+	// duplicated
+	assert.Equal(t, results[0].ID, results[3].ID)
+	assert.Equal(t, "known transaction", results[3].Error)
+}
+
+func delegation(t *testing.T) {
+	origin := genesis.DevAccounts()[0]
+	delegator := genesis.DevAccounts()[1]
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Expiration(10).
+		Gas(21000).
+		Features(tx.DelegationFeature).
+		Build()
+	rlpTx, err := rlp.EncodeToBytes(trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := transactions.RawTx{Raw: hexutil.Encode(rlpTx)}
+
+	body, _ := json.Marshal(&transactions.DelegationHashRequest{RawTx: raw, Origin: origin.Address})
+	var hashes transactions.DelegationHashes
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/transactions/delegation/hashes", body), &hashes); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, trx.SigningHash(), hashes.SigningHash)
+	assert.Equal(t, trx.DelegatorSigningHash(origin.Address), hashes.DelegatorSigningHash)
+
+	originSig, _ := crypto.Sign(hashes.SigningHash.Bytes(), origin.PrivateKey)
+	delegatorSig, _ := crypto.Sign(hashes.DelegatorSigningHash.Bytes(), delegator.PrivateKey)
+	body, _ = json.Marshal(&transactions.DelegationSignatures{
+		RawTx:              raw,
+		OriginSignature:    hexutil.Encode(originSig),
+		DelegatorSignature: hexutil.Encode(delegatorSig),
+	})
+	var delegated transactions.DelegatedTx
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/transactions/delegation/combine", body), &delegated); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, origin.Address, delegated.Origin)
+	assert.Equal(t, delegator.Address, delegated.Delegator)
+
+	signed := trx.WithSignature(append(originSig, delegatorSig...))
+	assert.Equal(t, signed.ID(), delegated.ID)
+	rlpTx, _ = rlp.EncodeToBytes(signed)
+	assert.Equal(t, hexutil.Encode(rlpTx), delegated.Raw)
+}
+
 func httpPost(t *testing.T, url string, data []byte) []byte {
 	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
 	if err != nil {
@@ -168,7 +258,7 @@ func initTransactionServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	transaction = transaction.WithSignature(sig)
-	packer := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	err = flow.Adopt(transaction)
 	if err != nil {
@@ -185,7 +275,7 @@ func initTransactionServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	router := mux.NewRouter()
-	transactions.New(c, txpool.New(c, stateC)).Mount(router, "/transactions")
+	transactions.New(c, txpool.New(c, stateC, thor.NoFork, txpool.DefaultPoolConfig)).Mount(router, "/transactions")
 	ts = httptest.NewServer(router)
 
 }
@@ -61,6 +61,45 @@ func (r *RawTx) decode() (*tx.Transaction, error) {
 	return tx, nil
 }
 
+//DelegationHashRequest body of computing signing hashes of a delegated tx, which may be unsigned.
+type DelegationHashRequest struct {
+	RawTx
+	Origin thor.Address `json:"origin"`
+}
+
+//DelegationHashes hashes for origin and delegator to sign respectively.
+type DelegationHashes struct {
+	SigningHash          thor.Bytes32 `json:"signingHash"`
+	DelegatorSigningHash thor.Bytes32 `json:"delegatorSigningHash"`
+}
+
+//DelegationSignatures body of combining signatures into a delegated tx.
+type DelegationSignatures struct {
+	RawTx
+	OriginSignature    string `json:"originSignature"`
+	DelegatorSignature string `json:"delegatorSignature"`
+}
+
+//DelegatedTx the delegated tx with signatures combined.
+type DelegatedTx struct {
+	RawTx
+	ID        thor.Bytes32 `json:"id"`
+	Origin    thor.Address `json:"origin"`
+	Delegator thor.Address `json:"delegator"`
+}
+
+//BatchRawTx body of sending raw txs in batch
+type BatchRawTx struct {
+	Raws []string `json:"raws"` //hex of transactions which rlp encoded
+}
+
+//BatchSendResult result of each tx sent in batch.
+//ID is nil if the tx can't be decoded, and Error is empty if the tx accepted by pool.
+type BatchSendResult struct {
+	ID    *thor.Bytes32 `json:"id"`
+	Error string        `json:"error,omitempty"`
+}
+
 //Transaction transaction
 type Transaction struct {
 	ID           thor.Bytes32        `json:"id,string"`
@@ -74,6 +113,7 @@ type Transaction struct {
 	DependsOn    *thor.Bytes32       `json:"dependsOn,string"`
 	Nonce        math.HexOrDecimal64 `json:"nonce"`
 	Origin       thor.Address        `json:"origin,string"`
+	Delegator    *thor.Address       `json:"delegator"`
 	Block        BlockContext        `json:"block"`
 }
 
@@ -89,6 +129,10 @@ func ConvertTransaction(tx *tx.Transaction) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return nil, err
+	}
 	cls := make(Clauses, len(tx.Clauses()))
 	for i, c := range tx.Clauses() {
 		cls[i] = ConvertClause(c)
@@ -98,6 +142,7 @@ func ConvertTransaction(tx *tx.Transaction) (*Transaction, error) {
 		ChainTag:     tx.ChainTag(),
 		ID:           tx.ID(),
 		Origin:       signer,
+		Delegator:    delegator,
 		BlockRef:     hexutil.Encode(br[:]),
 		Expiration:   tx.Expiration(),
 		Nonce:        math.HexOrDecimal64(tx.Nonce()),
@@ -123,14 +168,16 @@ type TxContext struct {
 
 //Receipt for json marshal
 type Receipt struct {
-	GasUsed  uint64                `json:"gasUsed"`
-	GasPayer thor.Address          `json:"gasPayer"`
-	Paid     *math.HexOrDecimal256 `json:"paid,string"`
-	Reward   *math.HexOrDecimal256 `json:"reward,string"`
-	Reverted bool                  `json:"reverted"`
-	Block    BlockContext          `json:"block"`
-	Tx       TxContext             `json:"tx"`
-	Outputs  []*Output             `json:"outputs"`
+	GasUsed         uint64                `json:"gasUsed"`
+	GasPayer        thor.Address          `json:"gasPayer"`
+	Paid            *math.HexOrDecimal256 `json:"paid,string"`
+	Reward          *math.HexOrDecimal256 `json:"reward,string"`
+	Reverted        bool                  `json:"reverted"`
+	VMErrorCategory string                `json:"vmErrorCategory,omitempty"`
+	RevertReason    string                `json:"revertReason,omitempty"`
+	Block           BlockContext          `json:"block"`
+	Tx              TxContext             `json:"tx"`
+	Outputs         []*Output             `json:"outputs"`
 }
 
 // Output output of clause execution.
@@ -154,8 +201,8 @@ type Transfer struct {
 	Amount    *math.HexOrDecimal256 `json:"amount"`
 }
 
-//ConvertReceipt convert a raw clause into a jason format clause
-func convertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transaction) (*Receipt, error) {
+//ConvertReceipt convert a raw receipt into a json format receipt
+func ConvertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transaction) (*Receipt, error) {
 	reward := math.HexOrDecimal256(*txReceipt.Reward)
 	paid := math.HexOrDecimal256(*txReceipt.Paid)
 	signer, err := tx.Signer()
@@ -163,11 +210,13 @@ func convertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transact
 		return nil, err
 	}
 	receipt := &Receipt{
-		GasUsed:  txReceipt.GasUsed,
-		GasPayer: txReceipt.GasPayer,
-		Paid:     &paid,
-		Reward:   &reward,
-		Reverted: txReceipt.Reverted,
+		GasUsed:         txReceipt.GasUsed,
+		GasPayer:        txReceipt.GasPayer,
+		Paid:            &paid,
+		Reward:          &reward,
+		Reverted:        txReceipt.Reverted,
+		VMErrorCategory: txReceipt.VMErrorCategory,
+		RevertReason:    txReceipt.RevertReason,
 		Tx: TxContext{
 			tx.ID(),
 			signer,
@@ -7,6 +7,7 @@ package transfers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -42,12 +43,20 @@ func (t *Transfers) handleFilterTransferLogs(w http.ResponseWriter, req *http.Re
 	if err := utils.ParseJSON(req.Body, &filter); err != nil {
 		return err
 	}
+	if len(filter.AddressSets) > logdb.MaxCriteria {
+		return utils.BadRequest(fmt.Errorf("exceeds %v", logdb.MaxCriteria), "AddressSets")
+	}
 	order := req.URL.Query().Get("order")
 	if order != string(logdb.DESC) {
 		filter.Order = logdb.ASC
 	} else {
 		filter.Order = logdb.DESC
 	}
+	options, err := utils.PageOptions(filter.Options)
+	if err != nil {
+		return err
+	}
+	filter.Options = options
 	tLogs, err := t.filter(req.Context(), &filter)
 	if err != nil {
 		return err
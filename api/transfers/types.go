@@ -18,6 +18,7 @@ type FilteredTransfer struct {
 	Amount    *math.HexOrDecimal256     `json:"amount"`
 	Block     transactions.BlockContext `json:"block"`
 	Tx        transactions.TxContext    `json:"tx"`
+	Cursor    *logdb.Cursor             `json:"cursor"` // to query the next page after this transfer
 }
 
 func ConvertTransfer(transfer *logdb.Transfer) *FilteredTransfer {
@@ -35,5 +36,6 @@ func ConvertTransfer(transfer *logdb.Transfer) *FilteredTransfer {
 			ID:     transfer.TxID,
 			Origin: transfer.TxOrigin,
 		},
+		Cursor: transfer.Cursor(),
 	}
 }
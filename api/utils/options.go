@@ -0,0 +1,31 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/vechain/thor/logdb"
+)
+
+// MaxPageSize is the max number of logs returned by one query.
+const MaxPageSize = 1000
+
+// PageOptions returns paging options of a log query, with page size defaults to MaxPageSize.
+// Page size exceeding MaxPageSize is rejected, the cursor of the last log should be used to query the next page.
+func PageOptions(options *logdb.Options) (*logdb.Options, error) {
+	if options == nil {
+		return &logdb.Options{Limit: MaxPageSize}, nil
+	}
+	if options.Limit > MaxPageSize {
+		return nil, BadRequest(fmt.Errorf("exceeds %v", MaxPageSize), "options.limit")
+	}
+	opts := *options
+	if opts.Limit == 0 {
+		opts.Limit = MaxPageSize
+	}
+	return &opts, nil
+}
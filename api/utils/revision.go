@@ -0,0 +1,60 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package utils
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+)
+
+// Revision identifies a block by ID, or by number on trunk.
+// The zero value stands for the best block.
+type Revision struct {
+	ID     *thor.Bytes32
+	Number *uint32
+}
+
+// ParseRevision parses the revision, which is a block ID, a block number, or empty or 'best' for the best block.
+// A bad request error is returned if it's malformed.
+func ParseRevision(revision string) (Revision, error) {
+	if revision == "" || revision == "best" {
+		return Revision{}, nil
+	}
+	if id, err := thor.ParseBytes32(revision); err == nil {
+		return Revision{ID: &id}, nil
+	}
+	n, err := strconv.ParseUint(revision, 0, 0)
+	if err != nil {
+		return Revision{}, BadRequest(err, "revision")
+	}
+	if n > math.MaxUint32 {
+		return Revision{}, BadRequest(errors.New("block number exceeded"), "revision")
+	}
+	num := uint32(n)
+	return Revision{Number: &num}, nil
+}
+
+// GetBlockHeader gets the header of the block identified by the revision.
+// The error of chain is returned as is, so that callers can check chain.IsNotFound.
+func GetBlockHeader(chain *chain.Chain, revision string) (*block.Header, error) {
+	rev, err := ParseRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case rev.ID != nil:
+		return chain.GetBlockHeader(*rev.ID)
+	case rev.Number != nil:
+		return chain.GetTrunkBlockHeader(*rev.Number)
+	default:
+		return chain.BestBlock().Header(), nil
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vechain/thor/trie"
+)
+
+var errStatePruned = errors.New("state of the revision is pruned, query a recent revision or use an archive node")
+
+// StateError converts the error of opening a state.
+// If the state root is missing, which means the state was pruned by a full mode node,
+// a bad request error is returned to tell the reason. Other errors are returned as is.
+func StateError(err error) error {
+	if _, ok := err.(*trie.MissingNodeError); ok {
+		return HTTPError(errStatePruned, http.StatusBadRequest)
+	}
+	return err
+}
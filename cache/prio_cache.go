@@ -94,6 +94,17 @@ func (pc *PrioCache) Remove(key interface{}) *PrioEntry {
 	return nil
 }
 
+// Lowest returns the entry with lowest priority.
+func (pc *PrioCache) Lowest() *PrioEntry {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+	if len(pc.s) == 0 {
+		return nil
+	}
+	cpy := pc.s[0].PrioEntry
+	return &cpy
+}
+
 // ForEach iterates all cache entries.
 func (pc *PrioCache) ForEach(cb func(*PrioEntry) bool) bool {
 	pc.lock.Lock()
@@ -28,6 +28,19 @@ func TestPrioCacheAddRemove(t *testing.T) {
 	assert.Equal(t, false, b)
 }
 
+func TestPrioCacheLowest(t *testing.T) {
+	c := cache.NewPrioCache(16)
+	assert.Nil(t, c.Lowest())
+
+	c.Set("a", 1, 3)
+	c.Set("b", 2, 1)
+	c.Set("c", 3, 2)
+	assert.Equal(t, &cache.PrioEntry{Entry: cache.Entry{Key: "b", Value: 2}, Priority: 1}, c.Lowest())
+
+	c.Remove("b")
+	assert.Equal(t, "c", c.Lowest().Key)
+}
+
 func TestPrioCache(t *testing.T) {
 	c := cache.NewPrioCache(5)
 	rand.Seed(time.Now().UnixNano())
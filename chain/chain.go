@@ -7,24 +7,32 @@ package chain
 
 import (
 	"bytes"
+	"context"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
 )
 
 const (
-	blockCacheLimit    = 512
-	receiptsCacheLimit = 512
+	// DefaultCacheSize is the default number of entries of each chain cache.
+	DefaultCacheSize = 512
+	freezeBatchSize  = 2048 // kv ops per batch when freezing
 )
 
 var errNotFound = errors.New("not found")
 var errBlockExist = errors.New("block already exists")
 
+var (
+	metricBestBlockNumber = metric.NewGauge("chain", "best_block_number", "number of the best block on trunk")
+	metricReorgCount      = metric.NewCounter("chain", "reorgs_total", "number of chain re-organizations")
+)
+
 // Chain describes a persistent block chain.
 // It's thread-safe.
 type Chain struct {
@@ -34,12 +42,16 @@ type Chain struct {
 	bestBlock    *block.Block
 	tag          byte
 	caches       caches
+	freezer      *Freezer
+	reorgs       []*Reorg
+	reorgSeq     uint64
 	rw           sync.RWMutex
 }
 
 type caches struct {
-	rawBlocks *cache
-	receipts  *cache
+	rawBlocks *cache // block id => raw block, with decoded header and body
+	receipts  *cache // block id => receipts
+	trunkIDs  *cache // block number => block id on trunk
 }
 
 // New create an instance of Chain.
@@ -100,29 +112,58 @@ func New(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
 		}
 	}
 
-	rawBlocksCache := newCache(blockCacheLimit, func(key interface{}) (interface{}, error) {
-		raw, err := loadBlockRaw(kv, key.(thor.Bytes32))
-		if err != nil {
-			return nil, err
-		}
-		return &rawBlock{raw: raw}, nil
-	})
+	reorgSeq, err := loadReorgSeq(kv)
+	if err != nil && !kv.IsNotFound(err) {
+		return nil, err
+	}
 
-	receiptsCache := newCache(receiptsCacheLimit, func(key interface{}) (interface{}, error) {
-		return loadBlockReceipts(kv, key.(thor.Bytes32))
-	})
+	metricBestBlockNumber.Set(float64(bestBlock.Header().Number()))
 
-	return &Chain{
+	c := &Chain{
 		kv:           kv,
 		ancestorTrie: ancestorTrie,
 		genesisBlock: genesisBlock,
 		bestBlock:    bestBlock,
 		tag:          genesisBlock.Header().ID()[31],
-		caches: caches{
-			rawBlocks: rawBlocksCache,
-			receipts:  receiptsCache,
-		},
-	}, nil
+		reorgSeq:     reorgSeq,
+	}
+	c.caches = c.newCaches(DefaultCacheSize)
+	return c, nil
+}
+
+func (c *Chain) newCaches(size int) caches {
+	return caches{
+		rawBlocks: newCache(size, func(key interface{}) (interface{}, error) {
+			raw, err := c.loadBlockRaw(key.(thor.Bytes32))
+			if err != nil {
+				return nil, err
+			}
+			return &rawBlock{raw: raw}, nil
+		}),
+		receipts: newCache(size, func(key interface{}) (interface{}, error) {
+			return c.loadBlockReceipts(key.(thor.Bytes32))
+		}),
+		trunkIDs: newCache(size, func(key interface{}) (interface{}, error) {
+			return c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), key.(uint32))
+		}),
+	}
+}
+
+// SetCacheSize sets the number of recent blocks, receipts and trunk block ids kept in memory.
+// It should be called before the chain is used.
+func (c *Chain) SetCacheSize(size int) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+	c.caches = c.newCaches(size)
+}
+
+// SetFreezer sets the freezer where ancient blocks are moved into by Freeze.
+// Blocks in freezer can be read as well as those in kv store.
+// It should be called before the chain is used.
+func (c *Chain) SetFreezer(freezer *Freezer) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+	c.freezer = freezer
 }
 
 // Tag returns chain tag, which is the last byte of genesis id.
@@ -130,6 +171,12 @@ func (c *Chain) Tag() byte {
 	return c.tag
 }
 
+// Check checks whether the underlying database is readable, bypassing caches.
+func (c *Chain) Check() error {
+	_, err := loadBestBlockID(c.kv)
+	return err
+}
+
 // GenesisBlock returns genesis block.
 func (c *Chain) GenesisBlock() *block.Block {
 	return c.genesisBlock
@@ -212,6 +259,11 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 		if err := saveBestBlockID(batch, newBlockID); err != nil {
 			return nil, err
 		}
+		if len(fork.Branch) > 0 {
+			if err := saveReorgSeq(batch, c.reorgSeq+1); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		fork = &Fork{Ancestor: parent, Branch: []*block.Header{newBlock.Header()}}
 	}
@@ -222,6 +274,13 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 
 	if isTrunk {
 		c.bestBlock = newBlock
+		metricBestBlockNumber.Set(float64(newBlock.Header().Number()))
+		if len(fork.Branch) > 0 {
+			metricReorgCount.Inc()
+			c.recordReorg(fork)
+			// trunk ids after the ancestor are replaced
+			c.caches.trunkIDs.Purge()
+		}
 	}
 
 	c.caches.rawBlocks.Add(newBlockID, newRawBlock(raw, newBlock))
@@ -229,6 +288,177 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 	return fork, nil
 }
 
+// Rewind sets best block back to the given ancestor of current best block.
+// Blocks after it leave trunk, and are returned as Fork.Branch.
+// It breaks fork choice rule, so should be used for development only.
+func (c *Chain) Rewind(ancestorID thor.Bytes32) (*Fork, error) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	ancestor, err := c.getBlock(ancestorID)
+	if err != nil {
+		return nil, err
+	}
+	trunkID, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), ancestor.Header().Number())
+	if err != nil {
+		return nil, err
+	}
+	if trunkID != ancestorID {
+		return nil, errors.New("not an ancestor of best block")
+	}
+
+	fork, err := c.buildFork(ancestor.Header(), c.bestBlock.Header())
+	if err != nil {
+		return nil, err
+	}
+	batch := c.kv.NewBatch()
+	if err := saveBestBlockID(batch, ancestorID); err != nil {
+		return nil, err
+	}
+	if len(fork.Branch) > 0 {
+		if err := saveReorgSeq(batch, c.reorgSeq+1); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	c.bestBlock = ancestor
+	c.caches.trunkIDs.Purge()
+	if len(fork.Branch) > 0 {
+		c.recordReorg(fork)
+	}
+	metricBestBlockNumber.Set(float64(ancestor.Header().Number()))
+	return fork, nil
+}
+
+// Freeze moves trunk blocks and receipts out of the latest 'keep' blocks, from kv store into freezer.
+// Blocks in freezer are regarded as final, so 'keep' should be large enough to cover any possible reorg.
+// It returns the count of blocks moved.
+func (c *Chain) Freeze(ctx context.Context, keep uint32) (int, error) {
+	c.rw.RLock()
+	freezer := c.freezer
+	best := c.bestBlock.Header().Number()
+	c.rw.RUnlock()
+
+	if freezer == nil {
+		return 0, errors.New("no freezer")
+	}
+	if best <= keep {
+		return 0, nil
+	}
+	target := best - keep
+
+	frozen := 0
+	for freezer.Count() < target {
+		batch := c.kv.NewBatch()
+		for num := freezer.Count(); num < target && batch.Len() < freezeBatchSize; num++ {
+			id, err := c.GetTrunkBlockID(num)
+			if err != nil {
+				return frozen, err
+			}
+			raw, err := loadBlockRaw(c.kv, id)
+			if err != nil {
+				return frozen, err
+			}
+			receiptsKey := append(blockReceiptsPrefix, id[:]...)
+			receiptsRaw, err := c.kv.Get(receiptsKey)
+			if err != nil {
+				if !c.kv.IsNotFound(err) {
+					return frozen, err
+				}
+				// genesis block has no receipts saved
+				if receiptsRaw, err = rlp.EncodeToBytes(tx.Receipts{}); err != nil {
+					return frozen, err
+				}
+			}
+			if err := freezer.append(id, raw, receiptsRaw); err != nil {
+				return frozen, err
+			}
+			if err := batch.Delete(append(blockPrefix, id[:]...)); err != nil {
+				return frozen, err
+			}
+			if err := batch.Delete(receiptsKey); err != nil {
+				return frozen, err
+			}
+		}
+		// data must be durable in freezer before deleted from kv store
+		if err := freezer.sync(); err != nil {
+			return frozen, err
+		}
+		if err := batch.Write(); err != nil {
+			return frozen, err
+		}
+		frozen += batch.Len() / 2
+
+		select {
+		case <-ctx.Done():
+			return frozen, ctx.Err()
+		default:
+		}
+	}
+	return frozen, nil
+}
+
+// IndexTransactions saves tx meta of txs in the block, if missing or mismatched.
+// It's used to repair the index broken by unclean shutdown.
+// It returns the count of tx meta repaired.
+func (c *Chain) IndexTransactions(blockID thor.Bytes32) (int, error) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	body, err := c.getBlockBody(blockID)
+	if err != nil {
+		return 0, err
+	}
+	if len(body.Txs) == 0 {
+		return 0, nil
+	}
+	receipts, err := c.getBlockReceipts(blockID)
+	if err != nil {
+		return 0, err
+	}
+	if len(receipts) != len(body.Txs) {
+		return 0, errors.New("receipts count mismatch")
+	}
+
+	repaired := 0
+	batch := c.kv.NewBatch()
+	for i, tx := range body.Txs {
+		expected := TxMeta{
+			BlockID:  blockID,
+			Index:    uint64(i),
+			Reverted: receipts[i].Reverted,
+		}
+		meta, err := loadTxMeta(c.kv, tx.ID())
+		if err != nil && !c.IsNotFound(err) {
+			return 0, err
+		}
+		pos := -1
+		for j, m := range meta {
+			if m.BlockID == blockID {
+				pos = j
+				break
+			}
+		}
+		if pos < 0 {
+			meta = append(meta, expected)
+		} else if meta[pos] != expected {
+			meta[pos] = expected
+		} else {
+			continue
+		}
+		if err := saveTxMeta(batch, tx.ID(), meta); err != nil {
+			return 0, err
+		}
+		repaired++
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return repaired, nil
+}
+
 // GetBlockHeader get block header by block id.
 func (c *Chain) GetBlockHeader(id thor.Bytes32) (*block.Header, error) {
 	c.rw.RLock()
@@ -297,18 +527,25 @@ func (c *Chain) GetTransactionReceipt(blockID thor.Bytes32, index uint64) (*tx.R
 	return receipts[index], nil
 }
 
+// GetBlockReceipts get all tx receipts in the block for given block id.
+func (c *Chain) GetBlockReceipts(id thor.Bytes32) (tx.Receipts, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.getBlockReceipts(id)
+}
+
 // GetTrunkBlockID get block id on trunk by given block number.
 func (c *Chain) GetTrunkBlockID(num uint32) (thor.Bytes32, error) {
 	c.rw.RLock()
 	defer c.rw.RUnlock()
-	return c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), num)
+	return c.getTrunkBlockID(num)
 }
 
 // GetTrunkBlockHeader get block header on trunk by given block number.
 func (c *Chain) GetTrunkBlockHeader(num uint32) (*block.Header, error) {
 	c.rw.RLock()
 	defer c.rw.RUnlock()
-	id, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), num)
+	id, err := c.getTrunkBlockID(num)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +556,7 @@ func (c *Chain) GetTrunkBlockHeader(num uint32) (*block.Header, error) {
 func (c *Chain) GetTrunkBlock(num uint32) (*block.Block, error) {
 	c.rw.RLock()
 	defer c.rw.RUnlock()
-	id, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), num)
+	id, err := c.getTrunkBlockID(num)
 	if err != nil {
 		return nil, err
 	}
@@ -330,7 +567,7 @@ func (c *Chain) GetTrunkBlock(num uint32) (*block.Block, error) {
 func (c *Chain) GetTrunkBlockRaw(num uint32) (block.Raw, error) {
 	c.rw.RLock()
 	defer c.rw.RUnlock()
-	id, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), num)
+	id, err := c.getTrunkBlockID(num)
 	if err != nil {
 		return nil, err
 	}
@@ -363,6 +600,13 @@ func (c *Chain) GetTrunkTransaction(txID thor.Bytes32) (*tx.Transaction, *TxMeta
 	return tx, meta, nil
 }
 
+// BuildFork builds the fork between the given trunk head and branch head.
+func (c *Chain) BuildFork(trunkHead *block.Header, branchHead *block.Header) (*Fork, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.buildFork(trunkHead, branchHead)
+}
+
 // NewSeeker returns a new seeker instance.
 func (c *Chain) NewSeeker(headBlockID thor.Bytes32) *Seeker {
 	return newSeeker(c, headBlockID)
@@ -444,6 +688,40 @@ func (c *Chain) buildFork(trunkHead *block.Header, branchHead *block.Header) (*F
 	}
 }
 
+// loadBlockRaw loads raw block from kv store, or freezer if not found.
+func (c *Chain) loadBlockRaw(id thor.Bytes32) (block.Raw, error) {
+	raw, err := loadBlockRaw(c.kv, id)
+	if err != nil && c.kv.IsNotFound(err) && c.freezer != nil {
+		frozen, ferr := c.freezer.get(c.freezer.blocks, id)
+		if ferr != nil {
+			return nil, ferr
+		}
+		if frozen != nil {
+			return frozen, nil
+		}
+	}
+	return raw, err
+}
+
+// loadBlockReceipts loads receipts from kv store, or freezer if not found.
+func (c *Chain) loadBlockReceipts(id thor.Bytes32) (tx.Receipts, error) {
+	receipts, err := loadBlockReceipts(c.kv, id)
+	if err != nil && c.kv.IsNotFound(err) && c.freezer != nil {
+		frozen, ferr := c.freezer.get(c.freezer.receipts, id)
+		if ferr != nil {
+			return nil, ferr
+		}
+		if frozen != nil {
+			var receipts tx.Receipts
+			if err := rlp.DecodeBytes(frozen, &receipts); err != nil {
+				return nil, err
+			}
+			return receipts, nil
+		}
+	}
+	return receipts, err
+}
+
 func (c *Chain) getRawBlock(id thor.Bytes32) (*rawBlock, error) {
 	raw, err := c.caches.rawBlocks.GetOrLoad(id)
 	if err != nil {
@@ -452,6 +730,14 @@ func (c *Chain) getRawBlock(id thor.Bytes32) (*rawBlock, error) {
 	return raw.(*rawBlock), nil
 }
 
+func (c *Chain) getTrunkBlockID(num uint32) (thor.Bytes32, error) {
+	id, err := c.caches.trunkIDs.GetOrLoad(num)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return id.(thor.Bytes32), nil
+}
+
 func (c *Chain) getBlockHeader(id thor.Bytes32) (*block.Header, error) {
 	raw, err := c.getRawBlock(id)
 	if err != nil {
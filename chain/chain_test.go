@@ -74,3 +74,125 @@ func TestAdd(t *testing.T) {
 		}
 	}
 }
+
+func TestRewind(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b3 := newBlock(b2, 1)
+	b2x := newBlock(b1, 2)
+	for _, b := range []*block.Block{b1, b2, b3} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	fork, err := ch.Rewind(b1.Header().ID())
+	assert.Nil(t, err)
+	assert.Equal(t, b1.Header().ID(), ch.BestBlock().Header().ID())
+	assert.Equal(t, b1.Header().ID(), fork.Ancestor.ID())
+	assert.Equal(t, 0, len(fork.Trunk))
+	assert.Equal(t, 2, len(fork.Branch))
+	assert.Equal(t, b2.Header().ID(), fork.Branch[0].ID())
+	assert.Equal(t, b3.Header().ID(), fork.Branch[1].ID())
+
+	id, err := ch.GetTrunkBlockID(1)
+	assert.Nil(t, err)
+	assert.Equal(t, b1.Header().ID(), id)
+
+	// not an ancestor
+	_, err = ch.AddBlock(b2x, nil)
+	assert.Nil(t, err)
+	_, err = ch.Rewind(b2.Header().ID())
+	assert.NotNil(t, err)
+}
+
+func TestTrunkCache(t *testing.T) {
+	ch := initChain()
+	ch.SetCacheSize(2)
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b3 := newBlock(b2, 1)
+	b3x := newBlock(b2, 2)
+	for _, b := range []*block.Block{b1, b2, b3} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	for _, b := range []*block.Block{b0, b1, b2, b3} {
+		h, err := ch.GetTrunkBlockHeader(b.Header().Number())
+		assert.Nil(t, err)
+		assert.Equal(t, b.Header().ID(), h.ID())
+	}
+
+	// reorg replaces cached trunk ids
+	_, err := ch.AddBlock(b3x, nil)
+	assert.Nil(t, err)
+	id, err := ch.GetTrunkBlockID(3)
+	assert.Nil(t, err)
+	assert.Equal(t, b3x.Header().ID(), id)
+
+	_, err = ch.Rewind(b1.Header().ID())
+	assert.Nil(t, err)
+	_, err = ch.GetTrunkBlockID(3)
+	assert.True(t, ch.IsNotFound(err))
+	id, err = ch.GetTrunkBlockID(1)
+	assert.Nil(t, err)
+	assert.Equal(t, b1.Header().ID(), id)
+}
+
+func TestRecentReorgs(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b2x := newBlock(b1, 2)
+	for _, b := range []*block.Block{b1, b2, b2x} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	reorgs := ch.RecentReorgs(0)
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, uint64(1), reorgs[0].Seq)
+	assert.Equal(t, b1.Header().ID(), reorgs[0].Ancestor.ID())
+	assert.Equal(t, b2.Header().ID(), reorgs[0].Branch[0].ID())
+	assert.Equal(t, b2x.Header().ID(), reorgs[0].Trunk[0].ID())
+
+	_, err := ch.Rewind(b0.Header().ID())
+	assert.Nil(t, err)
+	reorgs = ch.RecentReorgs(1)
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, uint64(2), reorgs[0].Seq)
+	assert.Equal(t, 0, len(reorgs[0].Trunk))
+	assert.Equal(t, 2, len(reorgs[0].Branch))
+	assert.Equal(t, uint64(1), ch.LowestReorgSeq())
+}
+
+func TestReorgSeqPersisted(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	ch, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+
+	b1 := newBlock(b0, 1)
+	b1x := newBlock(b0, 2)
+	for _, b := range []*block.Block{b1, b1x} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	// history is dropped on restart, but sequence continues
+	ch, err = chain.New(kv, b0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ch.RecentReorgs(0)))
+	assert.Equal(t, uint64(2), ch.LowestReorgSeq())
+
+	_, err = ch.Rewind(b0.Header().ID())
+	assert.Nil(t, err)
+	reorgs := ch.RecentReorgs(0)
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, uint64(2), reorgs[0].Seq)
+}
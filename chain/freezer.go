@@ -0,0 +1,282 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// Freezer stores ancient trunk blocks and their receipts in append-only flat files,
+// to reduce size and compaction pressure of the kv store.
+// Items are indexed by block number, so blocks are appended in order of number.
+type Freezer struct {
+	ids      *freezerTable
+	blocks   *freezerTable
+	receipts *freezerTable
+	count    uint32
+	rw       sync.RWMutex
+}
+
+// OpenFreezer opens the freezer in dir, or creates an empty one if not exists.
+// Items partially written due to unclean shutdown are truncated.
+func OpenFreezer(dir string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	f := &Freezer{}
+	var err error
+	if f.ids, err = openFreezerTable(dir, "ids"); err != nil {
+		return nil, err
+	}
+	if f.blocks, err = openFreezerTable(dir, "blocks"); err != nil {
+		f.ids.close()
+		return nil, err
+	}
+	if f.receipts, err = openFreezerTable(dir, "receipts"); err != nil {
+		f.ids.close()
+		f.blocks.close()
+		return nil, err
+	}
+
+	// align tables
+	count := f.ids.count
+	for _, t := range []*freezerTable{f.blocks, f.receipts} {
+		if t.count < count {
+			count = t.count
+		}
+	}
+	for _, t := range f.tables() {
+		if err := t.truncate(count); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	f.count = uint32(count)
+	return f, nil
+}
+
+func (f *Freezer) tables() []*freezerTable {
+	return []*freezerTable{f.ids, f.blocks, f.receipts}
+}
+
+// Count returns count of frozen blocks, which is also the number of the next block to be frozen.
+func (f *Freezer) Count() uint32 {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+	return f.count
+}
+
+// append appends a block, which must be numbered f.Count().
+func (f *Freezer) append(id thor.Bytes32, raw block.Raw, receiptsRaw []byte) error {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	if block.Number(id) != f.count {
+		return errors.New("freezer: block number not continuous")
+	}
+	for i, item := range [][]byte{id[:], raw, receiptsRaw} {
+		if err := f.tables()[i].append(item); err != nil {
+			return err
+		}
+	}
+	f.count++
+	return nil
+}
+
+// sync flushes all tables into disk.
+func (f *Freezer) sync() error {
+	for _, t := range f.tables() {
+		if err := t.sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get retrieves item of the block from table. It returns nil if the block not frozen.
+func (f *Freezer) get(t *freezerTable, id thor.Bytes32) ([]byte, error) {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	num := block.Number(id)
+	if num >= f.count {
+		return nil, nil
+	}
+	frozenID, err := f.ids.retrieve(uint64(num))
+	if err != nil {
+		return nil, err
+	}
+	if thor.BytesToBytes32(frozenID) != id {
+		// not the frozen trunk block
+		return nil, nil
+	}
+	return t.retrieve(uint64(num))
+}
+
+// Close closes all tables.
+func (f *Freezer) Close() error {
+	var err error
+	for _, t := range f.tables() {
+		if t == nil {
+			continue
+		}
+		if e := t.close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// freezerTable is a data file of items with an index file.
+// Index file holds fixed size entries, each is the end offset of the item in data file.
+type freezerTable struct {
+	data  *os.File
+	index *os.File
+	size  uint64 // size of data file
+	count uint64 // count of items
+}
+
+const indexEntrySize = 8
+
+func openFreezerTable(dir, name string) (*freezerTable, error) {
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		data.Close()
+		return nil, err
+	}
+	t := &freezerTable{data: data, index: index}
+	count, err := t.completeCount()
+	if err != nil {
+		t.close()
+		return nil, err
+	}
+	if err := t.truncate(count); err != nil {
+		t.close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// completeCount returns count of indexed items whose data is completely written.
+// The index may be flushed ahead of data on unclean shutdown.
+func (t *freezerTable) completeCount() (uint64, error) {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return 0, err
+	}
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return 0, err
+	}
+	count := uint64(indexStat.Size()) / indexEntrySize
+	for count > 0 {
+		_, end, err := t.bounds(count - 1)
+		if err != nil {
+			return 0, err
+		}
+		if end <= uint64(dataStat.Size()) {
+			break
+		}
+		count--
+	}
+	return count, nil
+}
+
+// truncate drops items since count, and data not indexed.
+func (t *freezerTable) truncate(count uint64) error {
+	var size uint64
+	if count > 0 {
+		var err error
+		if _, size, err = t.bounds(count - 1); err != nil {
+			return err
+		}
+	}
+	stat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+	// truncating to a larger size extends the file with zeros
+	if size > uint64(stat.Size()) {
+		return errors.New("freezer: data file shorter than indexed")
+	}
+	if err := t.index.Truncate(int64(count * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+	t.count, t.size = count, size
+	return nil
+}
+
+// bounds returns start and end offset of n-th item in data file.
+func (t *freezerTable) bounds(n uint64) (uint64, uint64, error) {
+	var buf [indexEntrySize * 2]byte
+	if n == 0 {
+		if _, err := t.index.ReadAt(buf[indexEntrySize:], 0); err != nil {
+			return 0, 0, err
+		}
+	} else if _, err := t.index.ReadAt(buf[:], int64((n-1)*indexEntrySize)); err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), binary.BigEndian.Uint64(buf[indexEntrySize:]), nil
+}
+
+func (t *freezerTable) append(item []byte) error {
+	if _, err := t.data.WriteAt(item, int64(t.size)); err != nil {
+		return err
+	}
+	var entry [indexEntrySize]byte
+	binary.BigEndian.PutUint64(entry[:], t.size+uint64(len(item)))
+	if _, err := t.index.WriteAt(entry[:], int64(t.count*indexEntrySize)); err != nil {
+		return err
+	}
+	t.size += uint64(len(item))
+	t.count++
+	return nil
+}
+
+func (t *freezerTable) retrieve(n uint64) ([]byte, error) {
+	start, end, err := t.bounds(n)
+	if err != nil {
+		return nil, err
+	}
+	if start > end {
+		return nil, errors.New("freezer: corrupted index")
+	}
+	item := make([]byte, end-start)
+	if _, err := t.data.ReadAt(item, int64(start)); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// sync flushes data file before index file, so that indexed items are always complete.
+func (t *freezerTable) sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+	return t.index.Sync()
+}
+
+func (t *freezerTable) close() error {
+	err := t.data.Close()
+	if e := t.index.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+)
+
+func TestFreeze(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	ch, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+
+	freezer, err := chain.OpenFreezer(dir)
+	assert.Nil(t, err)
+	ch.SetFreezer(freezer)
+
+	var blocks []*block.Block
+	parent := b0
+	for i := 0; i < 5; i++ {
+		b := newBlock(parent, 1)
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+		blocks = append(blocks, b)
+		parent = b
+	}
+
+	n, err := ch.Freeze(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, uint32(3), freezer.Count())
+
+	// frozen blocks are removed from kv store
+	noFreezer, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+	_, err = noFreezer.GetBlock(blocks[0].Header().ID())
+	assert.True(t, noFreezer.IsNotFound(err))
+
+	// and transparently read from freezer
+	assert.Nil(t, freezer.Close())
+	freezer, err = chain.OpenFreezer(dir)
+	assert.Nil(t, err)
+	defer freezer.Close()
+	assert.Equal(t, uint32(3), freezer.Count())
+
+	withFreezer, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+	withFreezer.SetFreezer(freezer)
+	for _, b := range blocks {
+		id := b.Header().ID()
+		blk, err := withFreezer.GetBlock(id)
+		assert.Nil(t, err)
+		assert.Equal(t, id, blk.Header().ID())
+		_, err = withFreezer.GetBlockReceipts(id)
+		assert.Nil(t, err)
+	}
+	trunkID, err := withFreezer.GetTrunkBlockID(0)
+	assert.Nil(t, err)
+	_, err = withFreezer.GetBlock(trunkID)
+	assert.Nil(t, err)
+}
+
+func TestFreezerPartialData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	ch, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+
+	freezer, err := chain.OpenFreezer(dir)
+	assert.Nil(t, err)
+	ch.SetFreezer(freezer)
+
+	parent := b0
+	for i := 0; i < 3; i++ {
+		b := newBlock(parent, 1)
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+		parent = b
+	}
+	_, err = ch.Freeze(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), freezer.Count())
+	assert.Nil(t, freezer.Close())
+
+	// index flushed ahead of data
+	path := filepath.Join(dir, "blocks.dat")
+	stat, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(path, stat.Size()-1))
+
+	freezer, err = chain.OpenFreezer(dir)
+	assert.Nil(t, err)
+	defer freezer.Close()
+	assert.Equal(t, uint32(2), freezer.Count())
+
+	// not extended with zeros
+	newStat, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.True(t, newStat.Size() < stat.Size())
+}
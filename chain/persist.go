@@ -15,6 +15,7 @@ import (
 
 var (
 	bestBlockKey        = []byte("best")
+	reorgSeqKey         = []byte("reorgseq")
 	blockPrefix         = []byte("b") // (prefix, block id) -> block
 	txMetaPrefix        = []byte("t") // (prefix, tx id) -> tx location
 	blockReceiptsPrefix = []byte("r") // (prefix, block id) -> receipts
@@ -61,6 +62,17 @@ func saveBestBlockID(w kv.Putter, id thor.Bytes32) error {
 	return w.Put(bestBlockKey, id[:])
 }
 
+// loadReorgSeq returns the sequence number of the latest reorg.
+func loadReorgSeq(r kv.Getter) (seq uint64, err error) {
+	err = loadRLP(r, reorgSeqKey, &seq)
+	return
+}
+
+// saveReorgSeq save the sequence number of the latest reorg.
+func saveReorgSeq(w kv.Putter, seq uint64) error {
+	return saveRLP(w, reorgSeqKey, seq)
+}
+
 // loadBlockRaw load rlp encoded block raw data.
 func loadBlockRaw(r kv.Getter, id thor.Bytes32) (block.Raw, error) {
 	return r.Get(append(blockPrefix, id[:]...))
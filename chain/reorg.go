@@ -0,0 +1,54 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import "time"
+
+const reorgHistoryLimit = 128
+
+// Reorg records a re-organization of trunk.
+// Fork.Branch are blocks left trunk, and Fork.Trunk are blocks joined trunk, both in ascending order.
+type Reorg struct {
+	*Fork
+	Seq       uint64 // sequence number starts from 1, increased by 1 per reorg, persisted across restarts
+	Timestamp uint64 // when the reorg happened
+}
+
+// recordReorg should be called with write lock held.
+func (c *Chain) recordReorg(fork *Fork) {
+	c.reorgSeq++
+	c.reorgs = append(c.reorgs, &Reorg{fork, c.reorgSeq, uint64(time.Now().Unix())})
+	if len(c.reorgs) > reorgHistoryLimit {
+		c.reorgs = append([]*Reorg(nil), c.reorgs[len(c.reorgs)-reorgHistoryLimit:]...)
+	}
+}
+
+// RecentReorgs returns reorgs happened after the given sequence number, in ascending order.
+// At most the latest 128 reorgs since the chain instance created are kept, see LowestReorgSeq.
+func (c *Chain) RecentReorgs(afterSeq uint64) []*Reorg {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	var reorgs []*Reorg
+	for _, r := range c.reorgs {
+		if r.Seq > afterSeq {
+			reorgs = append(reorgs, r)
+		}
+	}
+	return reorgs
+}
+
+// LowestReorgSeq returns the sequence number of the oldest retained reorg, or the one of the next reorg if none retained.
+// Reorgs with lower sequence numbers are dropped, or happened before the chain instance created.
+func (c *Chain) LowestReorgSeq() uint64 {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if len(c.reorgs) > 0 {
+		return c.reorgs[0].Seq
+	}
+	return c.reorgSeq + 1
+}
@@ -0,0 +1,372 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	tty "github.com/mattn/go-tty"
+	"github.com/pborman/uuid"
+	"github.com/vechain/thor/thor"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var accountCommand = cli.Command{
+	Name:  "account",
+	Usage: "manage accounts in keystore under config dir",
+	Subcommands: []cli.Command{
+		{
+			Name:   "new",
+			Usage:  "create a new account",
+			Flags:  []cli.Flag{configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action: accountNewAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "list existing accounts",
+			Flags:  []cli.Flag{configDirFlag},
+			Action: accountListAction,
+		},
+		{
+			Name:      "import",
+			Usage:     "import an account from keystore file",
+			ArgsUsage: "<keyfile>",
+			Flags:     []cli.Flag{configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action:    accountImportAction,
+		},
+		{
+			Name:      "export",
+			Usage:     "print keystore json of an account",
+			ArgsUsage: "<address>",
+			Flags:     []cli.Flag{configDirFlag},
+			Action:    accountExportAction,
+		},
+		{
+			Name:      "update",
+			Usage:     "change passphrase of an account",
+			ArgsUsage: "<address>",
+			Flags:     []cli.Flag{configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action:    accountUpdateAction,
+		},
+	},
+}
+
+// keystoreAccount is a key file in keystore dir.
+type keystoreAccount struct {
+	Address thor.Address
+	Path    string
+}
+
+func makeKeystoreDir(ctx *cli.Context) string {
+	dir := filepath.Join(makeConfigDir(ctx), "keystore")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		fatal(fmt.Sprintf("create keystore dir [%v]: %v", dir, err))
+	}
+	return dir
+}
+
+// listKeystore lists accounts in keystore dir, ordered by file name, which starts with creation time.
+func listKeystore(dir string) ([]*keystoreAccount, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []*keystoreAccount
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := keyJSONAddress(data)
+		if err != nil {
+			// skip malformed files
+			continue
+		}
+		accounts = append(accounts, &keystoreAccount{addr, path})
+	}
+	return accounts, nil
+}
+
+func findKeystoreAccount(dir string, addrStr string) (*keystoreAccount, error) {
+	addr, err := thor.ParseAddress(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := listKeystore(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, acc := range accounts {
+		if acc.Address == addr {
+			return acc, nil
+		}
+	}
+	return nil, fmt.Errorf("account %v not found", addr)
+}
+
+// loadKeystoreKey decrypts key of the account in keystore dir, asking passphrase by pr.
+func loadKeystoreKey(dir string, addrStr string, pr *passphraseReader) (*keystore.Key, error) {
+	acc, err := findKeystoreAccount(dir, addrStr)
+	if err != nil {
+		return nil, err
+	}
+	keyJSON, err := ioutil.ReadFile(acc.Path)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := pr.read("Enter passphrase: ", false)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.DecryptKey(keyJSON, passphrase)
+}
+
+func keyJSONAddress(data []byte) (thor.Address, error) {
+	var obj struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return thor.Address{}, err
+	}
+	if obj.Address == "" {
+		return thor.Address{}, errors.New("missing address")
+	}
+	return thor.ParseAddress("0x" + strings.TrimPrefix(obj.Address, "0x"))
+}
+
+// storeKey encrypts the key and writes it into keystore dir, file name is in the same form as go-ethereum.
+func storeKey(dir string, key *keystore.Key, passphrase string) (string, error) {
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("UTC--%s--%x", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), key.Address[:])
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, keyJSON, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// passphraseReader reads passphrases from password file, stdin or tty.
+// Password file and stdin provide one passphrase per line, in the order they are asked.
+type passphraseReader struct {
+	lines []string      // from password file
+	stdin *bufio.Reader // non-nil if reading from stdin
+}
+
+func newPassphraseReader(ctx *cli.Context) (*passphraseReader, error) {
+	passwordFile := ctx.String(passwordFileFlag.Name)
+	fromStdin := ctx.Bool(stdinPassphraseFlag.Name)
+	if passwordFile != "" && fromStdin {
+		return nil, fmt.Errorf("flag %s and %s are exclusive", passwordFileFlag.Name, stdinPassphraseFlag.Name)
+	}
+	r := &passphraseReader{}
+	if passwordFile != "" {
+		data, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		r.lines = strings.Split(strings.TrimRight(string(data), "\r\n"), "\n")
+		for i := range r.lines {
+			r.lines[i] = strings.TrimRight(r.lines[i], "\r")
+		}
+	} else if fromStdin {
+		r.stdin = bufio.NewReader(os.Stdin)
+	}
+	return r, nil
+}
+
+// read reads a passphrase. Confirmation is only asked when interactive.
+func (r *passphraseReader) read(prompt string, confirm bool) (string, error) {
+	if r.lines != nil {
+		if len(r.lines) == 0 {
+			return "", errors.New("not enough passphrases in password file")
+		}
+		line := r.lines[0]
+		r.lines = r.lines[1:]
+		return line, nil
+	}
+	if r.stdin != nil {
+		line, err := r.stdin.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", errors.New("read passphrase from stdin: " + err.Error())
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	t, err := tty.Open()
+	if err != nil {
+		return "", err
+	}
+	defer t.Close()
+
+	fmt.Print(prompt)
+	passphrase, err := t.ReadPassword()
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		fmt.Print("Repeat passphrase: ")
+		repeated, err := t.ReadPassword()
+		if err != nil {
+			return "", err
+		}
+		if repeated != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+func accountNewAction(ctx *cli.Context) error {
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return err
+	}
+	dir := makeKeystoreDir(ctx)
+	passphrase, err := pr.read("Enter passphrase: ", true)
+	if err != nil {
+		return err
+	}
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+	path, err := storeKey(dir, &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Address:", thor.Address(crypto.PubkeyToAddress(privateKey.PublicKey)))
+	fmt.Println("Keyfile:", path)
+	return nil
+}
+
+func accountListAction(ctx *cli.Context) error {
+	accounts, err := listKeystore(makeKeystoreDir(ctx))
+	if err != nil {
+		return err
+	}
+	for i, acc := range accounts {
+		fmt.Printf("#%d: %v %v\n", i, acc.Address, acc.Path)
+	}
+	return nil
+}
+
+func accountImportAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("keyfile required")
+	}
+	dir := makeKeystoreDir(ctx)
+	keyJSON, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	addr, err := keyJSONAddress(keyJSON)
+	if err != nil {
+		return fmt.Errorf("invalid keyfile: %v", err)
+	}
+	if _, err := findKeystoreAccount(dir, addr.String()); err == nil {
+		return fmt.Errorf("account %v already exists", addr)
+	}
+
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := pr.read("Enter passphrase: ", false)
+	if err != nil {
+		return err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return err
+	}
+	path, err := storeKey(dir, key, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Address:", thor.Address(key.Address))
+	fmt.Println("Keyfile:", path)
+	return nil
+}
+
+func accountExportAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("address required")
+	}
+	acc, err := findKeystoreAccount(makeKeystoreDir(ctx), ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	keyJSON, err := ioutil.ReadFile(acc.Path)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(keyJSON))
+	return nil
+}
+
+func accountUpdateAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("address required")
+	}
+	dir := makeKeystoreDir(ctx)
+	acc, err := findKeystoreAccount(dir, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	keyJSON, err := ioutil.ReadFile(acc.Path)
+	if err != nil {
+		return err
+	}
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := pr.read("Enter current passphrase: ", false)
+	if err != nil {
+		return err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return err
+	}
+	newPassphrase, err := pr.read("Enter new passphrase: ", true)
+	if err != nil {
+		return err
+	}
+	path, err := storeKey(dir, key, newPassphrase)
+	if err != nil {
+		return err
+	}
+	if path != acc.Path {
+		if err := os.Remove(acc.Path); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Keyfile:", path)
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/cmd/thor/node"
+	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+const (
+	importBatchSize = 1024            // number of blocks to decode before processing
+	progressPeriod  = 8 * time.Second // interval to report progress
+)
+
+func exportAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	path := ctx.Args().First()
+	if path == "" {
+		return errors.New("missing file path to export to")
+	}
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close() // in case of failure, no-op if already closed
+
+	var (
+		w  io.Writer = file
+		gz *gzip.Writer
+	)
+	if strings.HasSuffix(path, ".gz") {
+		gz = gzip.NewWriter(file)
+		w = gz
+	}
+	bw := bufio.NewWriter(w)
+
+	best := chain.BestBlock().Header().Number()
+	log.Info("exporting blocks", "file", path, "best", best)
+
+	startTime := time.Now()
+	reportTime := startTime
+	// genesis block excluded, since it's always built locally
+	for num := uint32(1); num <= best; num++ {
+		raw, err := chain.GetTrunkBlockRaw(num)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("get block #%v", num))
+		}
+		if _, err := bw.Write(raw); err != nil {
+			return err
+		}
+		if time.Since(reportTime) > progressPeriod {
+			log.Info("exporting blocks", "number", num, "progress", fmt.Sprintf("%.2f%%", float64(num)*100/float64(best)))
+			reportTime = time.Now()
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	// errors of closing are checked, since buffered data is written out then
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	log.Info("exported blocks", "count", best, "elapsed", time.Since(startTime))
+	return nil
+}
+
+func importAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	path := ctx.Args().First()
+	if path == "" {
+		return errors.New("missing file path to import from")
+	}
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = bufio.NewReader(file)
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	exitSignal := handleExitSignal()
+	importer := newBlockImporter(chain, state.NewCreator(mainDB), gene.ForkConfig(), logDB, verifyWorkers(ctx))
+	stream := rlp.NewStream(r, 0)
+
+	log.Info("importing blocks", "file", path)
+	for {
+		batch := make([]*block.Block, 0, importBatchSize)
+		for len(batch) < importBatchSize {
+			var blk block.Block
+			if err := stream.Decode(&blk); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return errors.Wrap(err, "decode block")
+			}
+			batch = append(batch, &blk)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := importer.Import(batch); err != nil {
+			return err
+		}
+
+		select {
+		case <-exitSignal.Done():
+			log.Info("import interrupted")
+			importer.Report()
+			return nil
+		default:
+		}
+	}
+	importer.Report()
+	return nil
+}
+
+// blockImporter executes and commits blocks, and tracks the progress.
+type blockImporter struct {
+	chain      *chain.Chain
+	cons       *consensus.Consensus
+	logDB      *logdb.LogDB
+	startTime  time.Time
+	reportTime time.Time
+	imported   int
+	ignored    int
+}
+
+func newBlockImporter(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig, logDB *logdb.LogDB, verifyWorkers int) *blockImporter {
+	cons := consensus.New(chain, stateCreator, forkConfig)
+	cons.SetVerifyWorkers(verifyWorkers)
+	now := time.Now()
+	return &blockImporter{
+		chain:      chain,
+		cons:       cons,
+		logDB:      logDB,
+		startTime:  now,
+		reportTime: now,
+	}
+}
+
+// Import imports a batch of ordered blocks. Blocks already in chain are skipped.
+func (bi *blockImporter) Import(blocks []*block.Block) error {
+	for _, blk := range blocks {
+		stage, receipts, err := bi.cons.Process(blk, uint64(time.Now().Unix()))
+		if err != nil {
+			if consensus.IsKnownBlock(err) {
+				bi.ignored++
+				continue
+			}
+			return errors.Wrap(err, fmt.Sprintf("process block #%v", blk.Header().Number()))
+		}
+		if _, err := stage.Commit(); err != nil {
+			return errors.Wrap(err, "commit state")
+		}
+		fork, err := bi.chain.AddBlock(blk, receipts)
+		if err != nil {
+			return errors.Wrap(err, "add block")
+		}
+		if err := node.WriteLogs(bi.logDB, blk, receipts, fork); err != nil {
+			return errors.Wrap(err, "commit logs")
+		}
+		bi.imported++
+
+		if time.Since(bi.reportTime) > progressPeriod {
+			bi.Report()
+		}
+	}
+	return nil
+}
+
+// Report logs the progress.
+func (bi *blockImporter) Report() {
+	best := bi.chain.BestBlock().Header()
+	log.Info("imported blocks",
+		"imported", bi.imported,
+		"ignored", bi.ignored,
+		"number", best.Number(),
+		"id", best.ID(),
+		"elapsed", time.Since(bi.startTime))
+	bi.reportTime = time.Now()
+}
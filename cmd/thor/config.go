@@ -0,0 +1,99 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// applyConfigFile loads the config file specified by config flag, and applies values to flags.
+// Keys of config are flag names, e.g.
+//
+//	network: test
+//	api-addr: 0.0.0.0:8669
+//	api-cors: [foo.com, bar.com]
+//
+// Flags set in command line take precedence over config file.
+func applyConfigFile(ctx *cli.Context) error {
+	path := ctx.String(configFlag.Name)
+	if path == "" {
+		return nil
+	}
+	values := make(map[string]interface{})
+	if err := decodeFile(path, &values); err != nil {
+		return errors.WithMessage(err, "config file")
+	}
+
+	for name, value := range values {
+		if name == configFlag.Name {
+			return errors.New("config file can not be nested")
+		}
+		str, err := formatConfigValue(value)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("config '%v'", name))
+		}
+		if ctx.IsSet(name) {
+			continue
+		}
+		if err := ctx.Set(name, str); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("config '%v'", name))
+		}
+	}
+	return nil
+}
+
+// decodeFile decodes JSON or YAML file into v, according to the file extension.
+func decodeFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "read")
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported file type '%v'", ext)
+	}
+	return errors.Wrap(err, "parse")
+}
+
+// formatConfigValue converts config value to flag value string.
+// Lists are joined with comma.
+func formatConfigValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []interface{}:
+		strs := make([]string, 0, len(v))
+		for _, item := range v {
+			str, err := formatConfigValue(item)
+			if err != nil {
+				return "", err
+			}
+			strs = append(strs, str)
+		}
+		return strings.Join(strs, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
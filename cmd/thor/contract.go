@@ -0,0 +1,483 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/abi"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+const contractTxExpiration = 720
+
+var contractCommand = cli.Command{
+	Name:  "contract",
+	Usage: "deploy and interact with contracts through node API",
+	Description: `Args of constructor or method are given in order after the command args.
+   Integers are in decimal or hex with '0x' prefix, bytes in hex. Array types are not supported.
+   Gas of tx is estimated by the node if flag --gas omitted.`,
+	Subcommands: []cli.Command{
+		{
+			Name:      "deploy",
+			Usage:     "deploy a contract, signed by an account in keystore",
+			ArgsUsage: "<from> [args...]",
+			Flags:     []cli.Flag{apiURLFlag, contractBytecodeFlag, contractABIFlag, contractValueFlag, contractGasFlag, configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action:    contractDeployAction,
+		},
+		{
+			Name:      "call",
+			Usage:     "call a contract method without sending tx, and print decoded outputs",
+			ArgsUsage: "<contract> <method> [args...]",
+			Flags:     []cli.Flag{apiURLFlag, contractABIFlag, contractValueFlag, contractCallerFlag},
+			Action:    contractCallAction,
+		},
+		{
+			Name:      "send",
+			Usage:     "send a tx to call a contract method, signed by an account in keystore",
+			ArgsUsage: "<from> <contract> <method> [args...]",
+			Flags:     []cli.Flag{apiURLFlag, contractABIFlag, contractValueFlag, contractGasFlag, configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action:    contractSendAction,
+		},
+	},
+}
+
+// apiClient talks to node API.
+type apiClient struct {
+	url    string
+	client *http.Client
+}
+
+func newAPIClient(ctx *cli.Context) *apiClient {
+	return &apiClient{
+		url:    strings.TrimRight(ctx.String(apiURLFlag.Name), "/"),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do sends the request with body encoded in JSON if not nil, and decodes response into result.
+func (c *apiClient) do(method, path string, body interface{}, result interface{}) error {
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, c.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, result)
+}
+
+// apiClause clause in requests of contract call.
+type apiClause struct {
+	To    *thor.Address         `json:"to"`
+	Value *math.HexOrDecimal256 `json:"value"`
+	Data  string                `json:"data"`
+}
+
+// apiCallResult result of contract call, fields not used are omitted.
+type apiCallResult struct {
+	Data         string `json:"data"`
+	GasUsed      uint64 `json:"gasUsed"`
+	Reverted     bool   `json:"reverted"`
+	VMError      string `json:"vmError"`
+	RevertReason string `json:"revertReason"`
+}
+
+func (r *apiCallResult) err() error {
+	if !r.Reverted {
+		return nil
+	}
+	if r.RevertReason != "" {
+		return fmt.Errorf("reverted: %s", r.RevertReason)
+	}
+	return fmt.Errorf("reverted: %s", r.VMError)
+}
+
+// call simulates a clause executed by caller on the best block.
+func (c *apiClient) call(caller thor.Address, clause *tx.Clause) (*apiCallResult, error) {
+	body := struct {
+		Clauses []*apiClause `json:"clauses"`
+		Caller  thor.Address `json:"caller"`
+	}{
+		[]*apiClause{{clause.To(), (*math.HexOrDecimal256)(clause.Value()), hexutil.Encode(clause.Data())}},
+		caller,
+	}
+	var results []*apiCallResult
+	if err := c.do("POST", "/accounts/*", &body, &results); err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, errors.New("unexpected number of call results")
+	}
+	return results[0], nil
+}
+
+// send builds a tx of the clause, signs it by key of from and sends to node.
+// Gas is estimated if zero.
+func (c *apiClient) send(ctx *cli.Context, from string, clause *tx.Clause, gas uint64) (*tx.Transaction, error) {
+	var genesis, best struct {
+		ID thor.Bytes32 `json:"id"`
+	}
+	if err := c.do("GET", "/blocks/0", nil, &genesis); err != nil {
+		return nil, err
+	}
+	if err := c.do("GET", "/blocks/best", nil, &best); err != nil {
+		return nil, err
+	}
+
+	origin, err := thor.ParseAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	if gas == 0 {
+		result, err := c.call(origin, clause)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.err(); err != nil {
+			return nil, err
+		}
+		intrinsicGas, err := new(tx.Builder).Clause(clause).Build().IntrinsicGas()
+		if err != nil {
+			return nil, err
+		}
+		// extra gas for vm invocation, not counted by the call
+		gas = intrinsicGas
+		if result.GasUsed > 0 {
+			gas += result.GasUsed + 15000
+		}
+	}
+
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	trx := new(tx.Builder).
+		ChainTag(genesis.ID[31]).
+		BlockRef(tx.NewBlockRefFromID(best.ID)).
+		Expiration(contractTxExpiration).
+		Clause(clause).
+		Gas(gas).
+		Nonce(binary.BigEndian.Uint64(nonce[:])).
+		Build()
+
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := loadKeystoreKey(makeKeystoreDir(ctx), from, pr)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	trx = trx.WithSignature(sig)
+
+	raw, err := rlp.EncodeToBytes(trx)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		ID thor.Bytes32 `json:"id"`
+	}
+	if err := c.do("POST", "/transactions", map[string]string{"raw": hexutil.Encode(raw)}, &result); err != nil {
+		return nil, err
+	}
+	return trx, nil
+}
+
+func readContractABI(ctx *cli.Context) (*abi.ABI, error) {
+	path := ctx.String(contractABIFlag.Name)
+	if path == "" {
+		return nil, fmt.Errorf("flag %s required", contractABIFlag.Name)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return abi.New(data)
+}
+
+func contractValue(ctx *cli.Context) (*big.Int, error) {
+	str := ctx.String(contractValueFlag.Name)
+	if str == "" {
+		return &big.Int{}, nil
+	}
+	value, ok := math.ParseBig256(str)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid value for flag -%s", contractValueFlag.Name)
+	}
+	return value, nil
+}
+
+// encodeArgs converts args from command line according to argument types, and encodes them by method.
+func encodeArgs(method *abi.Method, args []string) ([]byte, error) {
+	inputs := method.Inputs()
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("%d args required, got %d", len(inputs), len(args))
+	}
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		v, err := convertArg(inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("arg #%d (%s): %v", i, inputs[i].Type, err)
+		}
+		values = append(values, v)
+	}
+	return method.EncodeInput(values...)
+}
+
+func convertArg(t ethabi.Type, arg string) (interface{}, error) {
+	switch t.T {
+	case ethabi.IntTy, ethabi.UintTy:
+		n, ok := math.ParseBig256(arg)
+		if !ok {
+			if !strings.HasPrefix(arg, "-") {
+				return nil, errors.New("invalid integer")
+			}
+			// ParseBig256 does not accept negative numbers
+			if n, ok = math.ParseBig256(arg[1:]); !ok {
+				return nil, errors.New("invalid integer")
+			}
+			n.Neg(n)
+		}
+		if t.T == ethabi.UintTy && n.Sign() < 0 {
+			return nil, errors.New("negative unsigned integer")
+		}
+		if t.Type == reflect.TypeOf(&big.Int{}) {
+			return n, nil
+		}
+		v := reflect.New(t.Type).Elem()
+		if t.T == ethabi.UintTy {
+			if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+				return nil, errors.New("integer overflow")
+			}
+			v.SetUint(n.Uint64())
+		} else {
+			if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+				return nil, errors.New("integer overflow")
+			}
+			v.SetInt(n.Int64())
+		}
+		return v.Interface(), nil
+	case ethabi.BoolTy:
+		return strconv.ParseBool(arg)
+	case ethabi.StringTy:
+		return arg, nil
+	case ethabi.AddressTy:
+		addr, err := thor.ParseAddress(arg)
+		if err != nil {
+			return nil, err
+		}
+		return common.Address(addr), nil
+	case ethabi.BytesTy:
+		return hexutil.Decode(arg)
+	case ethabi.FixedBytesTy:
+		data, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > t.Size {
+			return nil, errors.New("too long")
+		}
+		v := reflect.New(t.Type).Elem()
+		reflect.Copy(v, reflect.ValueOf(data))
+		return v.Interface(), nil
+	}
+	return nil, errors.New("unsupported type")
+}
+
+// formatValue formats value decoded from output, bytes and addresses in hex.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Address:
+		return thor.Address(v).String()
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(data), rv)
+		return hexutil.Encode(data)
+	}
+	return fmt.Sprint(v)
+}
+
+func contractDeployAction(ctx *cli.Context) error {
+	if ctx.NArg() < 1 {
+		return errors.New("from required")
+	}
+	path := ctx.String(contractBytecodeFlag.Name)
+	if path == "" {
+		return fmt.Errorf("flag %s required", contractBytecodeFlag.Name)
+	}
+	hexCode, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	code, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(string(hexCode)), "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid bytecode: %v", err)
+	}
+
+	args := ctx.Args()[1:]
+	if ctx.String(contractABIFlag.Name) != "" || len(args) > 0 {
+		contractABI, err := readContractABI(ctx)
+		if err != nil {
+			return err
+		}
+		if constructor := contractABI.Constructor(); constructor != nil {
+			input, err := encodeArgs(constructor, args)
+			if err != nil {
+				return err
+			}
+			// constructor has no method id
+			code = append(code, input[4:]...)
+		} else if len(args) > 0 {
+			return errors.New("no constructor in abi")
+		}
+	}
+
+	value, err := contractValue(ctx)
+	if err != nil {
+		return err
+	}
+	client := newAPIClient(ctx)
+	trx, err := client.send(ctx, ctx.Args().First(), tx.NewClause(nil).WithValue(value).WithData(code), ctx.Uint64(contractGasFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Println("Transaction:", trx.ID())
+	fmt.Println("Contract:", thor.CreateContractAddress(trx.ID(), 0, 0))
+	return nil
+}
+
+func contractCallAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return errors.New("contract and method required")
+	}
+	contract, err := thor.ParseAddress(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	contractABI, err := readContractABI(ctx)
+	if err != nil {
+		return err
+	}
+	method, ok := contractABI.MethodByName(ctx.Args().Get(1))
+	if !ok {
+		return fmt.Errorf("method %s not found in abi", ctx.Args().Get(1))
+	}
+	input, err := encodeArgs(method, ctx.Args()[2:])
+	if err != nil {
+		return err
+	}
+	value, err := contractValue(ctx)
+	if err != nil {
+		return err
+	}
+	var caller thor.Address
+	if str := ctx.String(contractCallerFlag.Name); str != "" {
+		if caller, err = thor.ParseAddress(str); err != nil {
+			return fmt.Errorf("invalid value for flag -%s", contractCallerFlag.Name)
+		}
+	}
+
+	result, err := newAPIClient(ctx).call(caller, tx.NewClause(&contract).WithValue(value).WithData(input))
+	if err != nil {
+		return err
+	}
+	if err := result.err(); err != nil {
+		return err
+	}
+	output, err := hexutil.Decode(result.Data)
+	if err != nil {
+		return err
+	}
+	values, err := method.Outputs().UnpackValues(output)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		fmt.Println(formatValue(v))
+	}
+	return nil
+}
+
+func contractSendAction(ctx *cli.Context) error {
+	if ctx.NArg() < 3 {
+		return errors.New("from, contract and method required")
+	}
+	contract, err := thor.ParseAddress(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	contractABI, err := readContractABI(ctx)
+	if err != nil {
+		return err
+	}
+	method, ok := contractABI.MethodByName(ctx.Args().Get(2))
+	if !ok {
+		return fmt.Errorf("method %s not found in abi", ctx.Args().Get(2))
+	}
+	input, err := encodeArgs(method, ctx.Args()[3:])
+	if err != nil {
+		return err
+	}
+	value, err := contractValue(ctx)
+	if err != nil {
+		return err
+	}
+	trx, err := newAPIClient(ctx).send(ctx, ctx.Args().First(), tx.NewClause(&contract).WithValue(value).WithData(input), ctx.Uint64(contractGasFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Println("Transaction:", trx.ID())
+	return nil
+}
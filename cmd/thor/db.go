@@ -0,0 +1,147 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var dbCommand = cli.Command{
+	Name:  "db",
+	Usage: "maintain main database",
+	Subcommands: []cli.Command{
+		{
+			Name:   "compact",
+			Usage:  "compact main database",
+			Flags:  []cli.Flag{networkFlag, dataDirFlag, dbEngineFlag, verbosityFlag, vmoduleFlag},
+			Action: dbCompactAction,
+		},
+		{
+			Name:   "verify",
+			Usage:  "verify continuity of trunk blocks and consistency of tx index",
+			Flags:  []cli.Flag{networkFlag, dataDirFlag, dbEngineFlag, repairFlag, verbosityFlag, vmoduleFlag},
+			Action: dbVerifyAction,
+		},
+	},
+}
+
+func dbCompactAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	log.Info("compacting database...")
+	startTime := time.Now()
+	if err := mainDB.Compact(); err != nil {
+		return err
+	}
+	log.Info("compacted database", "elapsed", time.Since(startTime))
+	return nil
+}
+
+func dbVerifyAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	exitSignal := handleExitSignal()
+	repair := ctx.Bool(repairFlag.Name)
+	best := chain.BestBlock().Header()
+	log.Info("verifying blocks", "best", best.Number())
+
+	var (
+		problems  int
+		repaired  int
+		parent    *block.Header
+		startTime = time.Now()
+		report    = startTime
+	)
+	problem := func(header *block.Header, msg string) {
+		log.Warn(msg, "number", header.Number(), "id", header.ID())
+		problems++
+	}
+
+	for num := uint32(0); num <= best.Number(); num++ {
+		select {
+		case <-exitSignal.Done():
+			return exitSignal.Err()
+		default:
+		}
+
+		blk, err := chain.GetTrunkBlock(num)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("get block #%v", num))
+		}
+		header := blk.Header()
+		id := header.ID()
+
+		if parent != nil && header.ParentID() != parent.ID() {
+			problem(header, "parent link broken")
+		}
+		if blk.Transactions().RootHash() != header.TxsRoot() {
+			problem(header, "txs root mismatch")
+		}
+		// receipts of genesis block are not saved
+		if num > 0 {
+			if receipts, err := chain.GetBlockReceipts(id); err != nil {
+				problem(header, "receipts missing")
+			} else if receipts.RootHash() != header.ReceiptsRoot() {
+				problem(header, "receipts root mismatch")
+			}
+		}
+
+		broken := false
+		for i, tx := range blk.Transactions() {
+			meta, err := chain.GetTransactionMeta(tx.ID(), best.ID())
+			if err != nil || meta.BlockID != id || meta.Index != uint64(i) {
+				problem(header, fmt.Sprintf("tx index inconsistent for tx %v", tx.ID()))
+				broken = true
+			}
+		}
+		if broken && repair {
+			n, err := chain.IndexTransactions(id)
+			if err != nil {
+				log.Warn("failed to repair tx index", "number", num, "err", err)
+			} else {
+				repaired += n
+			}
+		}
+
+		parent = header
+		if time.Since(report) > progressPeriod {
+			log.Info("verifying blocks", "number", num, "progress", fmt.Sprintf("%.2f%%", float64(num)*100/float64(best.Number()+1)))
+			report = time.Now()
+		}
+	}
+
+	log.Info("verified blocks", "count", best.Number()+1, "problems", problems, "repaired", repaired, "elapsed", time.Since(startTime))
+	if problems > repaired {
+		return fmt.Errorf("%v problem(s) found", problems-repaired)
+	}
+	return nil
+}
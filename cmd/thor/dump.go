@@ -0,0 +1,241 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// dumpAccount is an account in state dump.
+// Address is nil if it can't be resolved from its hash.
+type dumpAccount struct {
+	Address     *thor.Address         `json:"address"`
+	AddressHash thor.Bytes32          `json:"addressHash"`
+	Balance     *math.HexOrDecimal256 `json:"balance"`
+	Energy      *math.HexOrDecimal256 `json:"energy"`
+	Master      *thor.Address         `json:"master,omitempty"`
+	CodeHash    *thor.Bytes32         `json:"codeHash,omitempty"`
+	Storage     []*dumpStorage        `json:"storage,omitempty"`
+}
+
+type dumpStorage struct {
+	Key     *thor.Bytes32 `json:"key"`
+	KeyHash thor.Bytes32  `json:"keyHash"`
+	Value   string        `json:"value"`
+}
+
+// stateDumper writes accounts in json or csv.
+type stateDumper interface {
+	Write(acc *dumpAccount) error
+	Close() error
+}
+
+type jsonDumper struct {
+	w     *bufio.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func newJSONDumper(w io.Writer) *jsonDumper {
+	bw := bufio.NewWriter(w)
+	return &jsonDumper{w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (d *jsonDumper) Write(acc *dumpAccount) error {
+	sep := ","
+	if d.count == 0 {
+		sep = "["
+	}
+	d.count++
+	if _, err := d.w.WriteString(sep); err != nil {
+		return err
+	}
+	return d.enc.Encode(acc)
+}
+
+func (d *jsonDumper) Close() error {
+	tail := "]\n"
+	if d.count == 0 {
+		tail = "[]\n"
+	}
+	if _, err := d.w.WriteString(tail); err != nil {
+		return err
+	}
+	return d.w.Flush()
+}
+
+type csvDumper struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (d *csvDumper) Write(acc *dumpAccount) error {
+	if !d.wroteHeader {
+		d.wroteHeader = true
+		if err := d.w.Write([]string{"address", "addressHash", "balance", "energy", "master", "codeHash"}); err != nil {
+			return err
+		}
+	}
+	optional := func(v fmt.Stringer, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return v.String()
+	}
+	return d.w.Write([]string{
+		optional(acc.Address, acc.Address != nil),
+		acc.AddressHash.String(),
+		(*big.Int)(acc.Balance).String(),
+		(*big.Int)(acc.Energy).String(),
+		optional(acc.Master, acc.Master != nil),
+		optional(acc.CodeHash, acc.CodeHash != nil),
+	})
+}
+
+func (d *csvDumper) Close() error {
+	d.w.Flush()
+	return d.w.Error()
+}
+
+func dumpStateAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	format := ctx.String(dumpFormatFlag.Name)
+	withStorage := ctx.Bool(dumpStorageFlag.Name)
+	switch format {
+	case "json":
+	case "csv":
+		if withStorage {
+			return fmt.Errorf("flag %s is not supported by csv format", dumpStorageFlag.Name)
+		}
+	default:
+		return fmt.Errorf("unsupported format '%v'", format)
+	}
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	header := chain.BestBlock().Header()
+	if rev := ctx.String(dumpRevisionFlag.Name); rev != "best" {
+		num, err := strconv.ParseUint(rev, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid revision '%v'", rev)
+		}
+		if header, err = chain.GetTrunkBlockHeader(uint32(num)); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("get block #%v", num))
+		}
+	}
+
+	st, err := state.New(header.StateRoot(), mainDB)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := ctx.Args().First(); path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	var dumper stateDumper
+	if format == "csv" {
+		dumper = &csvDumper{w: csv.NewWriter(out)}
+	} else {
+		dumper = newJSONDumper(out)
+	}
+
+	log.Info("dumping state", "number", header.Number(), "root", header.StateRoot())
+	exitSignal := handleExitSignal()
+	startTime := time.Now()
+	reportTime := startTime
+	count := 0
+	unresolved := 0
+	var iterErr error
+	if err := st.ForEachAccount(func(hash thor.Bytes32, addr *thor.Address, acc *state.Account) bool {
+		select {
+		case <-exitSignal.Done():
+			iterErr = exitSignal.Err()
+			return false
+		default:
+		}
+		dumpAcc := &dumpAccount{
+			Address:     addr,
+			AddressHash: hash,
+			Balance:     (*math.HexOrDecimal256)(acc.Balance),
+			Energy:      (*math.HexOrDecimal256)(acc.CalcEnergy(header.Timestamp())),
+		}
+		if len(acc.Master) > 0 {
+			master := thor.BytesToAddress(acc.Master)
+			dumpAcc.Master = &master
+		}
+		if len(acc.CodeHash) > 0 {
+			codeHash := thor.BytesToBytes32(acc.CodeHash)
+			dumpAcc.CodeHash = &codeHash
+		}
+		if addr == nil {
+			unresolved++
+		} else if withStorage {
+			if iterErr = st.ForEachStorage(*addr, thor.Bytes32{}, func(keyHash thor.Bytes32, key *thor.Bytes32, value []byte) bool {
+				dumpAcc.Storage = append(dumpAcc.Storage, &dumpStorage{key, keyHash, hexutil.Encode(value)})
+				return true
+			}); iterErr != nil {
+				return false
+			}
+		}
+		if iterErr = dumper.Write(dumpAcc); iterErr != nil {
+			return false
+		}
+		count++
+		if time.Since(reportTime) > progressPeriod {
+			log.Info("dumping state", "accounts", count)
+			reportTime = time.Now()
+		}
+		return true
+	}); err != nil {
+		return err
+	}
+	if iterErr != nil {
+		return iterErr
+	}
+	if err := dumper.Close(); err != nil {
+		return err
+	}
+	if unresolved > 0 {
+		log.Warn("some addresses not resolved, since they were last changed by an older version or before fast sync", "count", unresolved)
+	}
+	log.Info("dumped state", "accounts", count, "elapsed", time.Since(startTime))
+	return nil
+}
@@ -6,14 +6,24 @@
 package main
 
 import (
+	"strings"
+	"time"
+
 	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
 var (
+	configFlag = cli.StringFlag{
+		Name:  "config",
+		Usage: "load flags from yaml or json config file, overridden by command line flags",
+	}
 	networkFlag = cli.StringFlag{
 		Name:  "network",
-		Usage: "the network to join (test)",
+		Usage: "the network to join (test), or path to custom genesis JSON file",
 	}
 	configDirFlag = cli.StringFlag{
 		Name:   "config-dir",
@@ -40,11 +50,102 @@ var (
 		Value: "",
 		Usage: "comma separated list of domains from which to accept cross origin requests to API",
 	}
+	apiCORSPolicyFlag = cli.StringFlag{
+		Name:  "api-cors-policy",
+		Usage: "JSON or YAML file of per-path CORS rules for API, and a stricter rule for admin and debug endpoints (exclusive with api-cors)",
+	}
+	apiDebugFlag = cli.BoolFlag{
+		Name:  "api-debug",
+		Usage: "enable debug endpoints (tracing, tx replay, storage range) at '/debug' of API service",
+	}
+	apiGraphQLFlag = cli.BoolFlag{
+		Name:  "api-graphql",
+		Usage: "enable GraphQL endpoint at '/graphql' of API service",
+	}
+	apiTLSCertFlag = cli.StringFlag{
+		Name:  "api-tls-cert",
+		Usage: "TLS certificate file to serve API over HTTPS, requires api-tls-key",
+	}
+	apiTLSKeyFlag = cli.StringFlag{
+		Name:  "api-tls-key",
+		Usage: "TLS private key file to serve API over HTTPS, requires api-tls-cert",
+	}
+	apiAuthTokenFlag = cli.StringFlag{
+		Name:  "api-auth-token",
+		Usage: "require API requests to carry 'Authorization: Bearer <token>'",
+	}
+	apiBasicAuthFlag = cli.StringFlag{
+		Name:  "api-basic-auth",
+		Usage: "require API requests to carry basic auth credentials, in form of 'username:password'",
+	}
+	apiRateLimitFlag = cli.IntFlag{
+		Name:  "api-rate-limit",
+		Usage: "max API requests per second per client IP (unlimited if 0)",
+	}
+	apiRateBurstFlag = cli.IntFlag{
+		Name:  "api-rate-burst",
+		Usage: "max API requests burst per client IP (same as rate limit if 0)",
+	}
+	apiTrustProxyFlag = cli.StringFlag{
+		Name:  "api-trust-proxy",
+		Usage: "comma separated IPs or CIDRs of trusted reverse proxies, whose X-Forwarded-For/X-Real-IP headers identify API clients",
+	}
+	apiAllowIPsFlag = cli.StringFlag{
+		Name:  "api-allow-ips",
+		Usage: "comma separated IPs or CIDRs of clients allowed to access API and admin services (all if not set)",
+	}
+	apiAccessLogFlag = cli.StringFlag{
+		Name:  "api-access-log",
+		Usage: "write API access logs to the file, or stderr if set to '-' (disabled if empty)",
+	}
+	apiAccessLogFormatFlag = cli.StringFlag{
+		Name:  "api-access-log-format",
+		Value: "logfmt",
+		Usage: "format of API access logs (logfmt|json)",
+	}
+	apiRateWeightsFlag = cli.StringFlag{
+		Name:  "api-rate-weights",
+		Usage: "comma separated request weights of API paths, e.g. '/logs=5,/accounts=2'",
+	}
+	ethRPCFlag = cli.BoolFlag{
+		Name:  "eth-rpc",
+		Usage: "enable Ethereum compatible JSON-RPC endpoint at '/eth' of API service",
+	}
+	adminAddrFlag = cli.StringFlag{
+		Name:  "admin-addr",
+		Usage: "admin API service listening address, must be loopback (disabled if not set)",
+	}
+	metricsAddrFlag = cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "prometheus metrics service listening address (disabled if not set)",
+	}
+	otlpEndpointFlag = cli.StringFlag{
+		Name:  "otlp-endpoint",
+		Usage: "OpenTelemetry collector endpoint (host:port) to export traces via OTLP gRPC (disabled if not set), available if built with tag 'otel'",
+	}
+	otlpSampleRatioFlag = cli.Float64Flag{
+		Name:  "otlp-sample-ratio",
+		Value: 0.01,
+		Usage: "ratio (0 to 1) of traces sampled, except those joining sampled traces of API callers",
+	}
+	pprofFlag = cli.BoolFlag{
+		Name:  "pprof",
+		Usage: "turn on go-pprof",
+	}
+	pprofAddrFlag = cli.StringFlag{
+		Name:  "pprof-addr",
+		Value: "localhost:6060",
+		Usage: "go-pprof service listening address",
+	}
 	verbosityFlag = cli.IntFlag{
 		Name:  "verbosity",
 		Value: int(log15.LvlInfo),
 		Usage: "log verbosity (0-9)",
 	}
+	vmoduleFlag = cli.StringFlag{
+		Name:  "vmodule",
+		Usage: "comma separated per-module log verbosity overriding --verbosity, e.g. 'comm=debug,txpool=warn' (modules: " + strings.Join(logModules, ", ") + ")",
+	}
 
 	maxPeersFlag = cli.IntFlag{
 		Name:  "max-peers",
@@ -61,10 +162,201 @@ var (
 		Value: "none",
 		Usage: "port mapping mechanism (any|none|upnp|pmp|extip:<IP>)",
 	}
+	bootnodesDNSFlag = cli.StringFlag{
+		Name:  "bootnodes-dns",
+		Usage: "comma separated list of EIP-1459 DNS node list urls (enrtree://<key>@<domain>) to find bootstrap nodes",
+	}
+	permissionedFlag = cli.BoolFlag{
+		Name:  "permissioned",
+		Usage: "only accept P2P connections from nodes listed in allowlist file under config dir, and disable discovery",
+	}
+	noTxRelayFlag = cli.BoolFlag{
+		Name:  "no-tx-relay",
+		Usage: "relay blocks only, txs are neither accepted from nor relayed to peers except local ones",
+	}
+	txRelayMinGasPriceCoefFlag = cli.IntFlag{
+		Name:  "tx-relay-min-gas-price-coef",
+		Usage: "min gas price coef (0-255) of txs accepted from and relayed to peers",
+	}
+	peerTxRateLimitFlag = cli.IntFlag{
+		Name:  "peer-tx-rate-limit",
+		Usage: "max txs accepted from a peer per second, excess ones are dropped (unlimited if 0)",
+	}
+	maxUploadFlag = cli.IntFlag{
+		Name:  "max-upload",
+		Usage: "max upload rate of block/tx propagation in KB/s (unlimited if 0)",
+	}
+	maxDownloadFlag = cli.IntFlag{
+		Name:  "max-download",
+		Usage: "max download rate of block/tx propagation and block sync in KB/s (unlimited if 0)",
+	}
+	dbEngineFlag = cli.StringFlag{
+		Name:  "db-engine",
+		Value: "leveldb",
+		Usage: "storage engine of main database (leveldb|pebble), pebble is available if built with tag 'pebble'",
+	}
+	cacheFlag = cli.IntFlag{
+		Name:  "cache",
+		Usage: "megabytes of memory allocated to main database cache (0 to detect from available memory)",
+	}
+	chainCacheFlag = cli.IntFlag{
+		Name:  "chain-cache",
+		Value: chain.DefaultCacheSize,
+		Usage: "number of recent blocks and receipts kept decoded in memory",
+	}
+	stateCacheFlag = cli.IntFlag{
+		Name:  "state-cache",
+		Value: 65536,
+		Usage: "number of trie nodes cached in memory, shared by block execution and API calls (0 to disable)",
+	}
+	handlesFlag = cli.IntFlag{
+		Name:  "handles",
+		Usage: "number of file handles allocated to main database (0 to derive from fd limit)",
+	}
+	logDBDSNFlag = cli.StringFlag{
+		Name:  "logdb-dsn",
+		Usage: "store logs in PostgreSQL database described by the dsn, instead of the embedded one",
+	}
+	modeFlag = cli.StringFlag{
+		Name:  "mode",
+		Value: "archive",
+		Usage: "node mode (archive|full), full mode retains states of recent blocks only",
+	}
+	pruneFlag = cli.BoolFlag{
+		Name:  "prune",
+		Usage: "prune obsolete states in background (same as --mode full)",
+	}
+	pruneKeepFlag = cli.IntFlag{
+		Name:  "prune-keep",
+		Value: 8640,
+		Usage: "number of latest blocks whose states are retained from pruning",
+	}
+	freezerFlag = cli.BoolFlag{
+		Name:  "freezer",
+		Usage: "move ancient blocks and receipts out of main database into flat files (once enabled, frozen blocks are always read from them)",
+	}
+	freezerKeepFlag = cli.IntFlag{
+		Name:  "freezer-keep",
+		Value: 100000,
+		Usage: "number of latest blocks kept in main database when freezer enabled",
+	}
+	dumpRevisionFlag = cli.StringFlag{
+		Name:  "revision",
+		Value: "best",
+		Usage: "number of trunk block whose state to dump, or 'best'",
+	}
+	dumpFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Value: "json",
+		Usage: "output format (json|csv)",
+	}
+	dumpStorageFlag = cli.BoolFlag{
+		Name:  "storage",
+		Usage: "include storage of accounts, json format only",
+	}
+	repairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "repair inconsistent tx index found",
+	}
+	fastSyncFlag = cli.BoolFlag{
+		Name:  "fast-sync",
+		Usage: "download state of the checkpoint block instead of executing all blocks from genesis",
+	}
+	fastSyncCheckpointFlag = cli.StringFlag{
+		Name:  "fast-sync-checkpoint",
+		Usage: "ID of the trusted block to fast sync to, required by --fast-sync",
+	}
+	lightFlag = cli.BoolFlag{
+		Name:  "light",
+		Usage: "run as light client, which stores headers only and requests verified states from peers (headers are NOT verified against authorities, for trusted peers only, see -permissioned)",
+	}
+	preExecuteFlag = cli.BoolFlag{
+		Name:  "pre-execute",
+		Usage: "execute pending txs in advance while waiting for the time to pack block",
+	}
+	standbyFlag = cli.IntFlag{
+		Name:  "standby",
+		Usage: "run as standby of another node with the same master, and take over block production after it misses so many consecutive slots (0 to disable). NOT a safety guarantee: without external fencing, both nodes may produce conflicting blocks when partitioned",
+	}
+	alertURLFlag = cli.StringFlag{
+		Name:  "alert-url",
+		Usage: "url to post json alerts to when the master misses slots to produce blocks",
+	}
+	remoteSignerFlag = cli.StringFlag{
+		Name:  "remote-signer",
+		Usage: "url of external signer service holding the master key, instead of master.key in config dir",
+	}
+	remoteSignerTokenFlag = cli.StringFlag{
+		Name:  "remote-signer-token",
+		Usage: "bearer token to authenticate to remote signer",
+	}
+	noBlockProductionFlag = cli.BoolFlag{
+		Name:  "no-block-production",
+		Usage: "never propose blocks even if the master is an authority, but still validate and relay blocks and txs",
+	}
+	targetGasLimitFlag = cli.Uint64Flag{
+		Name:  "target-gas-limit",
+		Usage: "target gas limit of blocks packed by this node, approached within protocol rules (0 to adapt to packing performance)",
+	}
+	txPoolSizeFlag = cli.IntFlag{
+		Name:  "txpool-size",
+		Value: txpool.DefaultPoolConfig.PoolSize,
+		Usage: "maximum number of transactions in tx pool",
+	}
+	txPoolOriginLimitFlag = cli.IntFlag{
+		Name:  "txpool-origin-limit",
+		Value: txpool.DefaultPoolConfig.OriginLimit,
+		Usage: "maximum number of transactions of each origin in tx pool",
+	}
+	verifyWorkersFlag = cli.IntFlag{
+		Name:  "verify-workers",
+		Usage: "number of workers to pre-verify tx signatures of incoming blocks in parallel (0 for number of CPUs, 1 to disable)",
+	}
+	shutdownTimeoutFlag = cli.DurationFlag{
+		Name:  "shutdown-timeout",
+		Value: 10 * time.Second,
+		Usage: "max time to drain in-flight API requests on shutdown, before connections are closed",
+	}
+	txPoolLifetimeFlag = cli.DurationFlag{
+		Name:  "txpool-lifetime",
+		Value: txpool.DefaultPoolConfig.Lifetime,
+		Usage: "maximum amount of time transactions stay in tx pool",
+	}
+	txPoolScheduleSizeFlag = cli.IntFlag{
+		Name:  "txpool-schedule-size",
+		Value: txpool.DefaultPoolConfig.ScheduleSize,
+		Usage: "maximum number of scheduled transactions held until their block ref reached (0 to reject them)",
+	}
+	txPoolScheduleRangeFlag = cli.IntFlag{
+		Name:  "txpool-schedule-range",
+		Value: int(txpool.DefaultPoolConfig.ScheduleRange),
+		Usage: "maximum number of blocks the block ref of scheduled transactions ahead of best block",
+	}
 	onDemandFlag = cli.BoolFlag{
 		Name:  "on-demand",
 		Usage: "create new block when there is pending transaction",
 	}
+	blockIntervalFlag = cli.Uint64Flag{
+		Name:  "block-interval",
+		Value: thor.BlockInterval,
+		Usage: "interval in seconds to pack blocks",
+	}
+	genesisTimeFlag = cli.Uint64Flag{
+		Name:  "genesis-time",
+		Usage: "unix timestamp of genesis block, if set, block timestamps advance by block interval instead of following wall clock",
+	}
+	soloAccountsFlag = cli.IntFlag{
+		Name:  "accounts",
+		Usage: "number of random accounts to generate and fund at genesis",
+	}
+	soloAccountKeyFlag = cli.StringFlag{
+		Name:  "account-key",
+		Usage: "comma separated hex private keys of accounts to fund at genesis",
+	}
+	soloAccountsFileFlag = cli.StringFlag{
+		Name:  "accounts-file",
+		Usage: "JSON file of accounts to fund at genesis, e.g. [{\"address\": \"0x...\", \"balance\": \"1000\", \"energy\": \"0x3e8\"}]",
+	}
 	persistFlag = cli.BoolFlag{
 		Name:  "persist",
 		Usage: "blockchain data storage option, if setted data will be saved to disk",
@@ -77,4 +369,37 @@ var (
 		Name:  "export",
 		Usage: "export master key to keystore",
 	}
+	passwordFileFlag = cli.StringFlag{
+		Name:  "password-file",
+		Usage: "read passphrases from file instead of tty, one per line",
+	}
+	stdinPassphraseFlag = cli.BoolFlag{
+		Name:  "stdin-passphrase",
+		Usage: "read passphrases from stdin instead of tty, one per line (preceding key json when importing master key)",
+	}
+	apiURLFlag = cli.StringFlag{
+		Name:  "api-url",
+		Value: "http://localhost:8669",
+		Usage: "URL of node API to talk to",
+	}
+	contractABIFlag = cli.StringFlag{
+		Name:  "abi",
+		Usage: "path to ABI JSON file of the contract",
+	}
+	contractBytecodeFlag = cli.StringFlag{
+		Name:  "bytecode",
+		Usage: "path to file of contract bytecode in hex",
+	}
+	contractValueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "amount of VET in wei to transfer to the contract",
+	}
+	contractGasFlag = cli.Uint64Flag{
+		Name:  "gas",
+		Usage: "gas limit of tx (0 to estimate)",
+	}
+	contractCallerFlag = cli.StringFlag{
+		Name:  "caller",
+		Usage: "address of caller to simulate the call",
+	}
 )
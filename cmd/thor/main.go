@@ -6,23 +6,29 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/inconshreveable/log15"
-	tty "github.com/mattn/go-tty"
 	"github.com/pborman/uuid"
 	"github.com/vechain/thor/api"
 	"github.com/vechain/thor/cmd/thor/node"
 	"github.com/vechain/thor/cmd/thor/solo"
+	"github.com/vechain/thor/cmd/thor/thornode"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/light"
 	"github.com/vechain/thor/logdb"
-	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
@@ -49,16 +55,74 @@ func main() {
 		Usage:     "Node of VeChain Thor Network",
 		Copyright: "2018 VeChain Foundation <https://vechain.org/>",
 		Flags: []cli.Flag{
+			configFlag,
 			networkFlag,
 			configDirFlag,
 			dataDirFlag,
 			beneficiaryFlag,
 			apiAddrFlag,
 			apiCorsFlag,
+			apiCORSPolicyFlag,
+			apiTLSCertFlag,
+			apiTLSKeyFlag,
+			apiAuthTokenFlag,
+			apiBasicAuthFlag,
+			apiRateLimitFlag,
+			apiRateBurstFlag,
+			apiRateWeightsFlag,
+			apiTrustProxyFlag,
+			apiAllowIPsFlag,
+			apiAccessLogFlag,
+			apiAccessLogFormatFlag,
+			apiDebugFlag,
+			apiGraphQLFlag,
+			ethRPCFlag,
+			shutdownTimeoutFlag,
+			adminAddrFlag,
+			metricsAddrFlag,
+			otlpEndpointFlag,
+			otlpSampleRatioFlag,
+			pprofFlag,
+			pprofAddrFlag,
 			verbosityFlag,
+			vmoduleFlag,
 			maxPeersFlag,
 			p2pPortFlag,
 			natFlag,
+			bootnodesDNSFlag,
+			permissionedFlag,
+			noTxRelayFlag,
+			txRelayMinGasPriceCoefFlag,
+			peerTxRateLimitFlag,
+			maxUploadFlag,
+			maxDownloadFlag,
+			dbEngineFlag,
+			cacheFlag,
+			chainCacheFlag,
+			stateCacheFlag,
+			handlesFlag,
+			logDBDSNFlag,
+			modeFlag,
+			pruneFlag,
+			pruneKeepFlag,
+			freezerFlag,
+			freezerKeepFlag,
+			fastSyncFlag,
+			fastSyncCheckpointFlag,
+			lightFlag,
+			preExecuteFlag,
+			targetGasLimitFlag,
+			remoteSignerFlag,
+			remoteSignerTokenFlag,
+			noBlockProductionFlag,
+			standbyFlag,
+			alertURLFlag,
+			verifyWorkersFlag,
+			txPoolSizeFlag,
+			txPoolOriginLimitFlag,
+			txPoolLifetimeFlag,
+			txPoolScheduleSizeFlag,
+			txPoolScheduleRangeFlag,
 		},
 		Action: defaultAction,
 		Commands: []cli.Command{
@@ -66,15 +130,128 @@ func main() {
 				Name:  "solo",
 				Usage: "client runs in solo mode for test & dev",
 				Flags: []cli.Flag{
+					configFlag,
 					dataDirFlag,
 					apiAddrFlag,
 					apiCorsFlag,
+					apiCORSPolicyFlag,
+					apiTLSCertFlag,
+					apiTLSKeyFlag,
+					apiAuthTokenFlag,
+					apiBasicAuthFlag,
+					apiRateLimitFlag,
+					apiRateBurstFlag,
+					apiRateWeightsFlag,
+					apiTrustProxyFlag,
+					apiAllowIPsFlag,
+					apiAccessLogFlag,
+					apiAccessLogFormatFlag,
+					apiDebugFlag,
+					apiGraphQLFlag,
+					ethRPCFlag,
+					shutdownTimeoutFlag,
+					adminAddrFlag,
+					metricsAddrFlag,
+					otlpEndpointFlag,
+					otlpSampleRatioFlag,
+					pprofFlag,
+					pprofAddrFlag,
 					onDemandFlag,
+					blockIntervalFlag,
+					genesisTimeFlag,
+					soloAccountsFlag,
+					soloAccountKeyFlag,
+					soloAccountsFileFlag,
 					persistFlag,
+					dbEngineFlag,
+					cacheFlag,
+					chainCacheFlag,
+					stateCacheFlag,
+					handlesFlag,
 					verbosityFlag,
+					vmoduleFlag,
+					txPoolSizeFlag,
+					txPoolOriginLimitFlag,
+					txPoolLifetimeFlag,
+					txPoolScheduleSizeFlag,
+					txPoolScheduleRangeFlag,
 				},
 				Action: soloAction,
 			},
+			{
+				Name:      "export",
+				Usage:     "export blocks on trunk to file in RLP format",
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					dbEngineFlag,
+					logDBDSNFlag,
+					verbosityFlag,
+					vmoduleFlag,
+				},
+				Action: exportAction,
+			},
+			{
+				Name:      "import",
+				Usage:     "import blocks from RLP file exported by 'export' command",
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					dbEngineFlag,
+					logDBDSNFlag,
+					verifyWorkersFlag,
+					verbosityFlag,
+					vmoduleFlag,
+				},
+				Action: importAction,
+			},
+			{
+				Name:  "prune",
+				Usage: "prune obsolete states",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					pruneKeepFlag,
+					dbEngineFlag,
+					cacheFlag,
+					handlesFlag,
+					verbosityFlag,
+					vmoduleFlag,
+				},
+				Action: pruneAction,
+			},
+			{
+				Name:  "reindex-logs",
+				Usage: "rebuild log database from receipts of trunk blocks",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					dbEngineFlag,
+					logDBDSNFlag,
+					verbosityFlag,
+					vmoduleFlag,
+				},
+				Action: reindexLogsAction,
+			},
+			{
+				Name:      "dump-state",
+				Usage:     "dump accounts of trunk state to file, or stdout if file omitted (addresses and storage keys last changed before upgrade or fast sync are not resolved)",
+				ArgsUsage: "[file]",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					dbEngineFlag,
+					logDBDSNFlag,
+					dumpRevisionFlag,
+					dumpFormatFlag,
+					dumpStorageFlag,
+					verbosityFlag,
+					vmoduleFlag,
+				},
+				Action: dumpStateAction,
+			},
 			{
 				Name:  "master-key",
 				Usage: "import and export master key",
@@ -82,9 +259,15 @@ func main() {
 					configDirFlag,
 					importMasterKeyFlag,
 					exportMasterKeyFlag,
+					passwordFileFlag,
+					stdinPassphraseFlag,
 				},
 				Action: masterKeyAction,
 			},
+			accountCommand,
+			txCommand,
+			contractCommand,
+			dbCommand,
 		},
 	}
 
@@ -95,43 +278,120 @@ func main() {
 }
 
 func defaultAction(ctx *cli.Context) error {
+	if err := applyConfigFile(ctx); err != nil {
+		return err
+	}
+	if ctx.Bool(lightFlag.Name) {
+		return lightAction(ctx)
+	}
 	defer func() { log.Info("exited") }()
 
-	initLogger(ctx)
+	logLevel := initLogger(ctx)
+	if metricsSrv := startMetricsServer(ctx); metricsSrv != nil {
+		defer func() { log.Info("stopping metrics server..."); metricsSrv.Shutdown(context.Background()) }()
+	}
+	if shutdownTracing := initTracing(ctx); shutdownTracing != nil {
+		defer func() { log.Info("flushing traces..."); shutdownTracing(context.Background()) }()
+	}
+	if pprofSrv := startPProfServer(ctx); pprofSrv != nil {
+		defer func() { log.Info("stopping pprof server..."); pprofSrv.Shutdown(context.Background()) }()
+	}
 	gene := selectGenesis(ctx)
 	instanceDir := makeInstanceDir(ctx, gene)
+	var master *node.Master
+	if !ctx.Bool(noBlockProductionFlag.Name) {
+		master = loadNodeMaster(ctx)
+	}
 
-	mainDB := openMainDB(ctx, instanceDir)
-	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+	state.SetNodeCacheSize(stateCacheSize(ctx))
+	thorNode := thornode.New(nodeConfig(ctx, gene, instanceDir, master))
+	if err := thorNode.Start(); err != nil {
+		fatal(err)
+	}
+	defer func() {
+		log.Info("stopping node...")
+		// error of node exiting on its own is returned by defaultAction already
+		if err := thorNode.Stop(); err != nil && err != thorNode.Err() {
+			log.Warn("failed to stop node", "err", err)
+		}
+	}()
 
-	logDB := openLogDB(ctx, instanceDir)
-	defer func() { log.Info("closing log database..."); logDB.Close() }()
+	apiSrv, apiURL := startAPIServer(ctx, thorNode.APIHandler())
+	defer func() { log.Info("stopping API server..."); shutdownServer(ctx, apiSrv) }()
 
-	chain := initChain(gene, mainDB, logDB)
-	master := loadNodeMaster(ctx)
+	if adminSrv := startAdminServer(ctx, thorNode.AdminHandler(logLevel)); adminSrv != nil {
+		defer func() { log.Info("stopping admin server..."); shutdownServer(ctx, adminSrv) }()
+	}
 
-	txPool := txpool.New(chain, state.NewCreator(mainDB))
-	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
+	printStartupMessage(gene, thorNode.Chain(), master, instanceDir, apiURL)
 
-	p2pcom := startP2PComm(ctx, chain, txPool, instanceDir)
-	defer p2pcom.Shutdown()
+	select {
+	case <-handleExitSignal().Done():
+		return nil
+	case <-thorNode.Done():
+		return thorNode.Err()
+	}
+}
 
-	apiSrv, apiURL := startAPIServer(ctx, api.New(chain, state.NewCreator(mainDB), txPool, logDB, p2pcom.comm))
-	defer func() { log.Info("stopping API server..."); apiSrv.Shutdown(context.Background()) }()
+// lightAction runs the node as light client, for wallets and embedded devices.
+// Only headers are synced, and accounts are requested from peers with merkle proofs.
+func lightAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
 
-	printStartupMessage(gene, chain, master, instanceDir, apiURL)
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	// kept apart from data of full node
+	instanceDir := filepath.Join(makeInstanceDir(ctx, gene), "light")
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	// the genesis state is small, and kept to verify the genesis block
+	genesisBlock, _, err := gene.Build(state.NewCreator(mainDB))
+	if err != nil {
+		fatal("build genesis block: ", err)
+	}
+	headerChain, err := light.NewHeaderChain(mainDB, genesisBlock.Header())
+	if err != nil {
+		fatal("initialize header chain:", err)
+	}
 
-	return node.New(master, chain, state.NewCreator(mainDB), logDB, txPool, p2pcom.comm).
-		Run(handleExitSignal())
+	// headers can't be verified against authorities without the state
+	if !ctx.Bool(permissionedFlag.Name) {
+		log.Warn("light client trusts headers from any peer, use -" + permissionedFlag.Name + " to connect trusted peers only")
+	}
+	lightP2P := startLightClient(ctx, headerChain, instanceDir)
+	defer lightP2P.Shutdown()
+
+	apiSrv, apiURL := startAPIServer(ctx, api.NewLight(lightP2P.client))
+	defer func() { log.Info("stopping API server..."); shutdownServer(ctx, apiSrv) }()
+
+	best := headerChain.Best()
+	log.Info("light client started", "network", genesisBlock.Header().ID(), "best", best.Number(), "api", apiURL, "data-dir", instanceDir)
+
+	<-handleExitSignal().Done()
+	return nil
 }
 
 func soloAction(ctx *cli.Context) error {
+	if err := applyConfigFile(ctx); err != nil {
+		return err
+	}
 	defer func() { log.Info("exited") }()
 
-	initLogger(ctx)
-	gene := soloGenesis(ctx)
+	logLevel := initLogger(ctx)
+	if metricsSrv := startMetricsServer(ctx); metricsSrv != nil {
+		defer func() { log.Info("stopping metrics server..."); metricsSrv.Shutdown(context.Background()) }()
+	}
+	if shutdownTracing := initTracing(ctx); shutdownTracing != nil {
+		defer func() { log.Info("flushing traces..."); shutdownTracing(context.Background()) }()
+	}
+	if pprofSrv := startPProfServer(ctx); pprofSrv != nil {
+		defer func() { log.Info("stopping pprof server..."); pprofSrv.Shutdown(context.Background()) }()
+	}
+	gene, accounts := soloGenesis(ctx)
 
-	var mainDB *lvldb.LevelDB
+	var mainDB kv.Store
 	var logDB *logdb.LogDB
 	var instanceDir string
 
@@ -148,21 +408,125 @@ func soloAction(ctx *cli.Context) error {
 	defer func() { log.Info("closing main database..."); mainDB.Close() }()
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
+	state.SetNodeCacheSize(stateCacheSize(ctx))
 	chain := initChain(gene, mainDB, logDB)
+	chain.SetCacheSize(chainCacheSize(ctx))
+	if ctx.Bool("persist") {
+		closeFreezer := attachFreezer(chain, instanceDir)
+		defer closeFreezer()
+	}
+	if err := node.SyncLogDB(context.Background(), chain, logDB); err != nil {
+		fatal("sync log db:", err)
+	}
 
-	txPool := txpool.New(chain, state.NewCreator(mainDB))
+	txPool := txpool.New(chain, state.NewCreator(mainDB), gene.ForkConfig(), txPoolConfig(ctx))
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
+	if ctx.Bool("persist") {
+		openTxJournal(txPool, instanceDir)
+	}
 
-	soloContext := solo.New(chain, state.NewCreator(mainDB), logDB, txPool, ctx.Bool("on-demand"))
+	blockInterval := ctx.Uint64(blockIntervalFlag.Name)
+	if blockInterval == 0 {
+		return fmt.Errorf("flag %s should be greater than 0", blockIntervalFlag.Name)
+	}
+	soloContext := solo.New(chain, state.NewCreator(mainDB), gene.ForkConfig(), logDB, txPool, ctx.Bool("on-demand"), blockInterval, ctx.IsSet(genesisTimeFlag.Name))
 
-	apiSrv, apiURL := startAPIServer(ctx, api.New(chain, state.NewCreator(mainDB), txPool, logDB, solo.Communicator{}))
-	defer func() { log.Info("stopping API server..."); apiSrv.Shutdown(context.Background()) }()
+	abiRegistry := openABIRegistry(mainDB)
 
-	printSoloStartupMessage(gene, chain, instanceDir, apiURL)
+	apiHandler := api.New(chain, state.NewCreator(mainDB), txPool, logDB, gene.ForkConfig(), solo.Communicator{}, nil, nil, soloContext, abiRegistry, fullVersion(), ctx.Bool(apiDebugFlag.Name), ctx.Bool(apiGraphQLFlag.Name), ctx.Bool(ethRPCFlag.Name))
+	apiSrv, apiURL := startAPIServer(ctx, api.WithDev(apiHandler, chain, soloContext))
+	defer func() { log.Info("stopping API server..."); shutdownServer(ctx, apiSrv) }()
+
+	if adminSrv := startAdminServer(ctx, api.NewAdmin(logLevel, mainDB, nil, nil, nil, abiRegistry)); adminSrv != nil {
+		defer func() { log.Info("stopping admin server..."); shutdownServer(ctx, adminSrv) }()
+	}
+
+	printSoloStartupMessage(gene, chain, instanceDir, apiURL, accounts)
 
 	return soloContext.Run(handleExitSignal())
 }
 
+func pruneAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	pruner := node.NewStatePruner(chain, mainDB, uint32(ctx.Int(pruneKeepFlag.Name)))
+	// no concurrent commits in offline mode
+	if _, err := pruner.Prune(handleExitSignal(), &sync.Mutex{}, func() []thor.Bytes32 { return nil }); err != nil {
+		return err
+	}
+	log.Info("compacting database...")
+	return mainDB.Compact()
+}
+
+func reindexLogsAction(ctx *cli.Context) error {
+	defer func() { log.Info("exited") }()
+
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	var (
+		logDB   *logdb.LogDB
+		path    string
+		tmpPath string
+	)
+	if ctx.String(logDBDSNFlag.Name) != "" {
+		// rebuilt in place for external database
+		logDB = openLogDB(ctx, instanceDir)
+	} else {
+		// logs are rebuilt into a new file, which replaces the old one when done
+		path = filepath.Join(instanceDir, "logs.db")
+		tmpPath = path + ".reindex"
+		if err := os.RemoveAll(tmpPath); err != nil {
+			return err
+		}
+		var err error
+		if logDB, err = logdb.New(tmpPath); err != nil {
+			return fmt.Errorf("open log database [%v]: %v", tmpPath, err)
+		}
+		defer os.RemoveAll(tmpPath)
+	}
+	defer logDB.Close()
+
+	chain := initChain(gene, mainDB, logDB)
+	if err := logDB.Rewind(chain.GenesisBlock().Header()); err != nil {
+		return err
+	}
+	closeFreezer := attachFreezer(chain, instanceDir)
+	defer closeFreezer()
+
+	log.Info("reindexing logs", "best", chain.BestBlock().Header().Number())
+	startTime := time.Now()
+	if err := node.RebuildLogs(handleExitSignal(), chain, logDB, 1); err != nil {
+		return err
+	}
+	if tmpPath != "" {
+		logDB.Close()
+		if err := os.Rename(tmpPath, path); err != nil {
+			return err
+		}
+	}
+	log.Info("reindexed logs", "elapsed", time.Since(startTime))
+	return nil
+}
+
 func masterKeyAction(ctx *cli.Context) error {
 	hasImportFlag := ctx.Bool(importMasterKeyFlag.Name)
 	hasExportFlag := ctx.Bool(exportMasterKeyFlag.Name)
@@ -174,31 +538,37 @@ func masterKeyAction(ctx *cli.Context) error {
 		return fmt.Errorf("missing flag, either %s or %s", importMasterKeyFlag.Name, exportMasterKeyFlag.Name)
 	}
 
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return err
+	}
+
 	configDir := makeConfigDir(ctx)
 	if hasImportFlag {
-		var keyjson string
-		for {
-			if _, err := fmt.Fscanln(os.Stdin, &keyjson); err != nil {
-				if err == io.EOF {
-					break
-				}
+		var passwd string
+		if pr.stdin != nil {
+			// passphrase line precedes key json
+			if passwd, err = pr.read("", false); err != nil {
 				return err
 			}
 		}
 
-		t, err := tty.Open()
+		var stdin io.Reader = os.Stdin
+		if pr.stdin != nil {
+			stdin = pr.stdin
+		}
+		keyjson, err := ioutil.ReadAll(stdin)
 		if err != nil {
 			return err
 		}
-		defer t.Close()
 
-		fmt.Printf("Enter passphrase:")
-		passwd, err := t.ReadPassword()
-		if err != nil {
-			return err
+		if pr.stdin == nil {
+			if passwd, err = pr.read("Enter passphrase: ", false); err != nil {
+				return err
+			}
 		}
 
-		key, err := keystore.DecryptKey([]byte(keyjson), passwd)
+		key, err := keystore.DecryptKey(bytes.TrimSpace(keyjson), passwd)
 		if err != nil {
 			return err
 		}
@@ -212,15 +582,7 @@ func masterKeyAction(ctx *cli.Context) error {
 			return err
 		}
 
-		t, err := tty.Open()
-		if err != nil {
-			return err
-		}
-		defer t.Close()
-
-		fmt.Printf("Enter passphrase: ")
-		passwd, err := t.ReadPassword()
-
+		passwd, err := pr.read("Enter passphrase: ", false)
 		if err != nil {
 			return err
 		}
@@ -6,44 +6,66 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/big"
 	"math/rand"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	ethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/nat"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/handlers"
 	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/api"
+	"github.com/vechain/thor/api/abis"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/cmd/thor/node"
+	"github.com/vechain/thor/cmd/thor/thornode"
 	"github.com/vechain/thor/comm"
 	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/light"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/p2psrv"
-	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/p2psrv/dnsdisc"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
 	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
-func initLogger(ctx *cli.Context) {
-	logLevel := ctx.Int(verbosityFlag.Name)
-	log15.Root().SetHandler(log15.LvlFilterHandler(log15.Lvl(logLevel), log15.StderrHandler))
+func initLogger(ctx *cli.Context) *logLevel {
+	level := &logLevel{}
+	level.Set(log15.Lvl(ctx.Int(verbosityFlag.Name)))
+	if vmodule := ctx.String(vmoduleFlag.Name); vmodule != "" {
+		modules, err := parseVModule(vmodule)
+		if err != nil {
+			fatal(fmt.Sprintf("invalid value for flag -%s: %v", vmoduleFlag.Name, err))
+		}
+		level.SetModules(modules)
+	}
+	log15.Root().SetHandler(level.filterHandler(log15.StderrHandler))
 	// set go-ethereum log lvl to Warn
 	ethLogHandler := ethlog.NewGlogHandler(ethlog.StreamHandler(os.Stderr, ethlog.TerminalFormat(true)))
 	ethLogHandler.Verbosity(ethlog.LvlWarn)
 	ethlog.Root().SetHandler(ethLogHandler)
+	return level
 }
 
 func selectGenesis(ctx *cli.Context) *genesis.Genesis {
@@ -56,6 +78,11 @@ func selectGenesis(ctx *cli.Context) *genesis.Genesis {
 		}
 		return gene
 	default:
+		if network != "" {
+			if _, err := os.Stat(network); err == nil {
+				return loadCustomGenesis(network)
+			}
+		}
 		cli.ShowAppHelp(ctx)
 		if network == "" {
 			fmt.Printf("network flag not specified: -%s\n", networkFlag.Name)
@@ -67,6 +94,27 @@ func selectGenesis(ctx *cli.Context) *genesis.Genesis {
 	}
 }
 
+// loadCustomGenesis builds genesis from JSON file.
+func loadCustomGenesis(path string) *genesis.Genesis {
+	file, err := os.Open(path)
+	if err != nil {
+		fatal(fmt.Sprintf("open genesis file: %v", err))
+	}
+	defer file.Close()
+
+	var gen genesis.CustomGenesis
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&gen); err != nil {
+		fatal(fmt.Sprintf("decode genesis file: %v", err))
+	}
+	gene, err := genesis.NewCustomNet(&gen)
+	if err != nil {
+		fatal(fmt.Sprintf("build genesis: %v", err))
+	}
+	return gene
+}
+
 func makeConfigDir(ctx *cli.Context) string {
 	configDir := ctx.String(configDirFlag.Name)
 	if configDir == "" {
@@ -99,55 +147,119 @@ func makeInstanceDir(ctx *cli.Context, gene *genesis.Genesis) string {
 	return instanceDir
 }
 
-func openMainDB(ctx *cli.Context, dataDir string) *lvldb.LevelDB {
-	limit, err := fdlimit.Current()
-	if err != nil {
-		fatal("failed to get fd limit:", err)
+// dbOptions returns options of main database from flags.
+func dbOptions(ctx *cli.Context) thornode.DBOptions {
+	opts := thornode.DBOptions{
+		Engine:    ctx.String(dbEngineFlag.Name),
+		CacheSize: ctx.Int(cacheFlag.Name),
+		Handles:   ctx.Int(handlesFlag.Name),
 	}
-	if limit <= 1024 {
-		log.Warn("low fd limit, increase it if possible", "limit", limit)
+	switch opts.Engine {
+	case "leveldb", "pebble":
+	default:
+		fatal(fmt.Sprintf("invalid value for flag -%s: %v", dbEngineFlag.Name, opts.Engine))
 	}
-
-	fileCache := limit / 2
-	if fileCache > 1024 {
-		fileCache = 1024
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaultCacheSize()
 	}
+	return opts
+}
 
-	dir := filepath.Join(dataDir, "main.db")
-	db, err := lvldb.New(dir, lvldb.Options{
-		CacheSize:              128,
-		OpenFilesCacheCapacity: fileCache,
-	})
+func openMainDB(ctx *cli.Context, dataDir string) kv.Store {
+	db, err := thornode.OpenMainDB(dataDir, dbOptions(ctx))
 	if err != nil {
-		fatal(fmt.Sprintf("open chain database [%v]: %v", dir, err))
+		fatal(err)
 	}
 	return db
 }
 
+func txPoolConfig(ctx *cli.Context) txpool.PoolConfig {
+	config := txpool.PoolConfig{
+		PoolSize:    ctx.Int(txPoolSizeFlag.Name),
+		OriginLimit: ctx.Int(txPoolOriginLimitFlag.Name),
+		Lifetime:    ctx.Duration(txPoolLifetimeFlag.Name),
+
+		ScheduleSize: ctx.Int(txPoolScheduleSizeFlag.Name),
+	}
+	if config.PoolSize < 1 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", txPoolSizeFlag.Name))
+	}
+	if config.OriginLimit < 1 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", txPoolOriginLimitFlag.Name))
+	}
+	if config.ScheduleSize < 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", txPoolScheduleSizeFlag.Name))
+	}
+	scheduleRange := ctx.Int(txPoolScheduleRangeFlag.Name)
+	if scheduleRange < 0 || uint64(scheduleRange) > math.MaxUint32 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", txPoolScheduleRangeFlag.Name))
+	}
+	config.ScheduleRange = uint32(scheduleRange)
+	return config
+}
+
+func txRelayPolicy(ctx *cli.Context) comm.TxRelayPolicy {
+	minCoef := ctx.Int(txRelayMinGasPriceCoefFlag.Name)
+	if minCoef < 0 || minCoef > math.MaxUint8 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", txRelayMinGasPriceCoefFlag.Name))
+	}
+	rateLimit := ctx.Int(peerTxRateLimitFlag.Name)
+	if rateLimit < 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", peerTxRateLimitFlag.Name))
+	}
+	return comm.TxRelayPolicy{
+		Disabled:        ctx.Bool(noTxRelayFlag.Name),
+		MinGasPriceCoef: uint8(minCoef),
+		PeerRateLimit:   rateLimit,
+	}
+}
+
+// isFullMode returns whether the node runs in full mode, in which states out of the retention window are pruned.
+func isFullMode(ctx *cli.Context) bool {
+	switch mode := ctx.String(modeFlag.Name); mode {
+	case "archive":
+		return ctx.Bool(pruneFlag.Name)
+	case "full":
+		return true
+	default:
+		fatal(fmt.Sprintf("invalid value for flag -%s: %v", modeFlag.Name, mode))
+		return false
+	}
+}
+
+func openTxJournal(txPool *txpool.TxPool, dataDir string) {
+	path := filepath.Join(dataDir, "txpool.journal")
+	if err := txPool.OpenJournal(path); err != nil {
+		fatal(fmt.Sprintf("open tx journal [%v]: %v", path, err))
+	}
+}
+
 func openLogDB(ctx *cli.Context, dataDir string) *logdb.LogDB {
-	dir := filepath.Join(dataDir, "logs.db")
-	db, err := logdb.New(dir)
+	db, err := thornode.OpenLogDB(dataDir, ctx.String(logDBDSNFlag.Name))
 	if err != nil {
-		fatal(fmt.Sprintf("open log database [%v]: %v", dir, err))
+		fatal(err)
 	}
 	return db
 }
 
-func initChain(gene *genesis.Genesis, mainDB *lvldb.LevelDB, logDB *logdb.LogDB) *chain.Chain {
-	genesisBlock, genesisEvents, err := gene.Build(state.NewCreator(mainDB))
+// attachFreezer attaches the freezer in the instance dir to the chain, if it was ever created.
+// It returns the func to close the freezer.
+func attachFreezer(chain *chain.Chain, instanceDir string) func() {
+	freezer, err := thornode.OpenExistingFreezer(instanceDir)
 	if err != nil {
-		fatal("build genesis block: ", err)
+		fatal(err)
 	}
-
-	chain, err := chain.New(mainDB, genesisBlock)
-	if err != nil {
-		fatal("initialize block chain:", err)
+	if freezer == nil {
+		return func() {}
 	}
+	chain.SetFreezer(freezer)
+	return func() { freezer.Close() }
+}
 
-	if err := logDB.Prepare(genesisBlock.Header()).
-		ForTransaction(thor.Bytes32{}, thor.Address{}).
-		Insert(genesisEvents, nil).Commit(); err != nil {
-		fatal("write genesis events: ", err)
+func initChain(gene *genesis.Genesis, mainDB kv.GetPutter, logDB *logdb.LogDB) *chain.Chain {
+	chain, err := thornode.InitChain(gene, mainDB, logDB)
+	if err != nil {
+		fatal(err)
 	}
 	return chain
 }
@@ -174,6 +286,15 @@ func loadNodeMaster(ctx *cli.Context) *node.Master {
 			Beneficiary: bene(acc.Address),
 		}
 	}
+	if url := ctx.String(remoteSignerFlag.Name); url != "" {
+		signer, err := node.NewRemoteSigner(url, ctx.String(remoteSignerTokenFlag.Name))
+		if err != nil {
+			fatal("connect remote signer:", err)
+		}
+		master := &node.Master{Signer: signer}
+		master.Beneficiary = bene(master.Address())
+		return master
+	}
 	key, err := loadOrGeneratePrivateKey(filepath.Join(configDir, "master.key"))
 	if err != nil {
 		fatal("load or generate master key:", err)
@@ -183,13 +304,52 @@ func loadNodeMaster(ctx *cli.Context) *node.Master {
 	return master
 }
 
-type p2pComm struct {
-	comm      *comm.Communicator
-	p2pSrv    *p2psrv.Server
-	savePeers func()
+// nodeConfig returns config of the node from flags.
+func nodeConfig(ctx *cli.Context, gene *genesis.Genesis, instanceDir string, master *node.Master) thornode.Config {
+	config := thornode.Config{
+		Genesis:     gene,
+		InstanceDir: instanceDir,
+		Master:      master,
+		Version:     fullVersion(),
+
+		DB:             dbOptions(ctx),
+		LogDBDSN:       ctx.String(logDBDSNFlag.Name),
+		ChainCacheSize: chainCacheSize(ctx),
+
+		TxPool:      txPoolConfig(ctx),
+		TxRelay:     txRelayPolicy(ctx),
+		P2P:         p2pOptions(ctx),
+		MaxUpload:   ctx.Int(maxUploadFlag.Name) * 1024,
+		MaxDownload: ctx.Int(maxDownloadFlag.Name) * 1024,
+
+		VerifyWorkers:     verifyWorkers(ctx),
+		FastSync:          ctx.Bool(fastSyncFlag.Name),
+		PreExecute:        ctx.Bool(preExecuteFlag.Name),
+		NoBlockProduction: ctx.Bool(noBlockProductionFlag.Name),
+		StandbySlots:      standbySlots(ctx),
+		AlertURL:          ctx.String(alertURLFlag.Name),
+		TargetGasLimit:    targetGasLimit(ctx),
+
+		FastSyncCheckpoint: fastSyncCheckpoint(ctx),
+
+		EnableDebug:   ctx.Bool(apiDebugFlag.Name),
+		EnableGraphQL: ctx.Bool(apiGraphQLFlag.Name),
+		EnableEthRPC:  ctx.Bool(ethRPCFlag.Name),
+	}
+	if ctx.Bool(freezerFlag.Name) {
+		if config.FreezeKeep = uint32(ctx.Int(freezerKeepFlag.Name)); config.FreezeKeep == 0 {
+			fatal(fmt.Sprintf("invalid value for flag -%s", freezerKeepFlag.Name))
+		}
+	}
+	if isFullMode(ctx) {
+		config.Prune = true
+		config.PruneKeep = uint32(ctx.Int(pruneKeepFlag.Name))
+	}
+	return config
 }
 
-func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, instanceDir string) *p2pComm {
+// p2pOptions returns options of P2P server from flags, with key loaded from config dir.
+func p2pOptions(ctx *cli.Context) p2psrv.Options {
 	configDir := makeConfigDir(ctx)
 	key, err := loadOrGeneratePrivateKey(filepath.Join(configDir, "p2p.key"))
 	if err != nil {
@@ -202,7 +362,7 @@ func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, i
 		fmt.Println("parse -nat flag:", err)
 		os.Exit(1)
 	}
-	opts := &p2psrv.Options{
+	opts := p2psrv.Options{
 		Name:           common.MakeName("thor", fullVersion()),
 		PrivateKey:     key,
 		MaxPeers:       ctx.Int(maxPeersFlag.Name),
@@ -210,52 +370,113 @@ func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, i
 		BootstrapNodes: bootstrapNodes,
 		NAT:            nat,
 	}
-
-	peersCachePath := filepath.Join(instanceDir, "peers.cache")
-
-	if data, err := ioutil.ReadFile(peersCachePath); err != nil {
-		if !os.IsNotExist(err) {
-			log.Warn("failed to load peers cache", "err", err)
+	if lists := ctx.String(bootnodesDNSFlag.Name); lists != "" {
+		for _, url := range strings.Split(lists, ",") {
+			url = strings.TrimSpace(url)
+			if _, _, err := dnsdisc.ParseURL(url); err != nil {
+				fatal(fmt.Sprintf("invalid DNS node list [%v]: %v", url, err))
+			}
+			opts.DNSNodeLists = append(opts.DNSNodeLists, url)
 		}
-	} else if err := rlp.DecodeBytes(data, &opts.KnownNodes); err != nil {
-		log.Warn("failed to load peers cache", "err", err)
 	}
-	srv := p2psrv.New(opts)
+	if ctx.Bool(permissionedFlag.Name) {
+		allowlistPath := filepath.Join(configDir, "allowlist")
+		allowlist, err := p2psrv.LoadAllowlist(allowlistPath)
+		if err != nil {
+			fatal("load allowlist:", err)
+		}
+		log.Info("permissioned mode enabled", "allowlist", allowlistPath, "nodes", len(allowlist.Nodes()))
+		opts.Allowlist = allowlist
+	}
+	return opts
+}
 
-	comm := comm.New(chain, txPool)
-	if err := srv.Start(comm.Protocols()); err != nil {
+type lightP2P struct {
+	client    *comm.LightClient
+	p2pSrv    *p2psrv.Server
+	savePeers func()
+}
+
+func startLightClient(ctx *cli.Context, hc *light.HeaderChain, instanceDir string) *lightP2P {
+	srv, savePeers := thornode.NewP2PServer(p2pOptions(ctx), instanceDir)
+
+	client := comm.NewLightClient(hc)
+	if err := srv.Start(client.Protocols()); err != nil {
 		fatal("start P2P server:", err)
 	}
-	comm.Start()
+	client.Start()
 
-	return &p2pComm{
-		comm:   comm,
-		p2pSrv: srv,
-		savePeers: func() {
-			nodes := srv.KnownNodes()
-			data, err := rlp.EncodeToBytes(nodes)
-			if err != nil {
-				log.Warn("failed to encode cached peers", "err", err)
-				return
-			}
-			if err := ioutil.WriteFile(peersCachePath, data, 0600); err != nil {
-				log.Warn("failed to write peers cache", "err", err)
-			}
-		},
+	return &lightP2P{
+		client:    client,
+		p2pSrv:    srv,
+		savePeers: savePeers,
 	}
 }
 
-func (c *p2pComm) Shutdown() {
-	c.comm.Stop()
-	log.Info("stopping communicator...")
+func (l *lightP2P) Shutdown() {
+	l.client.Stop()
+	log.Info("stopping light client...")
 
-	c.p2pSrv.Stop()
+	l.p2pSrv.Stop()
 	log.Info("stopping P2P server...")
 
-	c.savePeers()
+	l.savePeers()
 	log.Info("saving peers cache...")
 }
 
+// apiRateLimiter creates the API rate limiter from flags, or returns nil if rate limit is disabled.
+func apiRateLimiter(ctx *cli.Context) *api.RateLimiter {
+	limit := ctx.Int(apiRateLimitFlag.Name)
+	if limit <= 0 {
+		return nil
+	}
+	burst := ctx.Int(apiRateBurstFlag.Name)
+	if burst <= 0 {
+		burst = limit
+	}
+	weights, err := api.ParseRateLimitWeights(ctx.String(apiRateWeightsFlag.Name), burst)
+	if err != nil {
+		fatal(err)
+	}
+	return api.NewRateLimiter(float64(limit), burst, weights)
+}
+
+// apiCORSPolicy loads the CORS policy file, or returns nil if not specified.
+func apiCORSPolicy(ctx *cli.Context) *api.CORSPolicy {
+	path := ctx.String(apiCORSPolicyFlag.Name)
+	if path == "" {
+		return nil
+	}
+	if ctx.String(apiCorsFlag.Name) != "" {
+		fatal(fmt.Sprintf("flag -%s and -%s are exclusive", apiCorsFlag.Name, apiCORSPolicyFlag.Name))
+	}
+	var policy api.CORSPolicy
+	if err := decodeFile(path, &policy); err != nil {
+		fatal(fmt.Sprintf("load CORS policy: %v", err))
+	}
+	if err := policy.Validate(); err != nil {
+		fatal(fmt.Sprintf("load CORS policy: %v", err))
+	}
+	return &policy
+}
+
+// apiAccessLog creates the API access log writing to the file at dest, or stderr if dest is '-'.
+func apiAccessLog(ctx *cli.Context, dest string) *api.AccessLog {
+	w := io.Writer(os.Stderr)
+	if dest != "-" {
+		f, err := os.OpenFile(dest, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			fatal(fmt.Sprintf("open API access log: %v", err))
+		}
+		w = f
+	}
+	accessLog, err := api.NewAccessLog(w, ctx.String(apiAccessLogFormatFlag.Name))
+	if err != nil {
+		fatal(fmt.Sprintf("invalid value for flag -%s: %v", apiAccessLogFormatFlag.Name, err))
+	}
+	return accessLog
+}
+
 func startAPIServer(ctx *cli.Context, handler http.Handler) (*http.Server, string) {
 	addr := ctx.String(apiAddrFlag.Name)
 	listener, err := net.Listen("tcp", addr)
@@ -263,20 +484,267 @@ func startAPIServer(ctx *cli.Context, handler http.Handler) (*http.Server, strin
 		fatal(fmt.Sprintf("listen API addr [%v]: %v", addr, err))
 	}
 
-	if origins := ctx.String(apiCorsFlag.Name); origins != "" {
+	allowedHeaders := []string{"content-type"}
+	if credentials := apiCredentials(ctx); credentials != nil {
+		handler = api.Authenticate(handler, *credentials)
+		allowedHeaders = append(allowedHeaders, "authorization")
+	}
+
+	if policy := apiCORSPolicy(ctx); policy != nil {
+		handler = api.CORS(handler, policy.APIRules())
+	} else if origins := ctx.String(apiCorsFlag.Name); origins != "" {
 		handler = handlers.CORS(
 			handlers.AllowedOrigins(strings.Split(origins, ",")),
-			handlers.AllowedHeaders([]string{"content-type"}),
+			handlers.AllowedHeaders(allowedHeaders),
 		)(handler)
 	}
 
-	srv := &http.Server{Handler: requestBodyLimit(handler)}
+	if limiter := apiRateLimiter(ctx); limiter != nil {
+		handler = limiter.Handler(handler)
+	}
+
+	if dest := ctx.String(apiAccessLogFlag.Name); dest != "" {
+		handler = apiAccessLog(ctx, dest).Handler(handler)
+	}
+
+	handler = filterClients(ctx, handler)
+
+	srv := &http.Server{Handler: requestBodyLimit(handler), TLSConfig: apiTLSConfig(ctx)}
+	if srv.TLSConfig != nil {
+		go func() {
+			srv.ServeTLS(listener, "", "")
+		}()
+		return srv, "https://" + listener.Addr().String() + "/"
+	}
 	go func() {
 		srv.Serve(listener)
 	}()
 	return srv, "http://" + listener.Addr().String() + "/"
 }
 
+// filterClients wraps handler to reject clients not allowed, and to identify clients behind trusted proxies.
+// It should be the outermost, so that all other handlers see the real client.
+func filterClients(ctx *cli.Context, handler http.Handler) http.Handler {
+	if ips := ctx.String(apiAllowIPsFlag.Name); ips != "" {
+		allowed, err := api.ParseAllowedIPs(ips)
+		if err != nil {
+			fatal(fmt.Sprintf("invalid value for flag -%s: %v", apiAllowIPsFlag.Name, err))
+		}
+		handler = api.AllowIPs(handler, allowed)
+	}
+	if proxies := ctx.String(apiTrustProxyFlag.Name); proxies != "" {
+		trusted, err := api.ParseTrustedProxies(proxies)
+		if err != nil {
+			fatal(fmt.Sprintf("invalid value for flag -%s: %v", apiTrustProxyFlag.Name, err))
+		}
+		handler = api.TrustProxy(handler, trusted)
+	}
+	return handler
+}
+
+// shutdownServer stops the server gracefully, in-flight requests are drained within the shutdown timeout,
+// then remaining connections are closed.
+func shutdownServer(ctx *cli.Context, srv *http.Server) {
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), ctx.Duration(shutdownTimeoutFlag.Name))
+	defer cancel()
+	if err := srv.Shutdown(timeoutCtx); err != nil {
+		log.Warn("timeout draining requests, closing connections", "err", err)
+		srv.Close()
+	}
+}
+
+// apiTLSConfig loads TLS certificate for API service, returns nil if not configured.
+func apiTLSConfig(ctx *cli.Context) *tls.Config {
+	certFile, keyFile := ctx.String(apiTLSCertFlag.Name), ctx.String(apiTLSKeyFlag.Name)
+	if certFile == "" && keyFile == "" {
+		return nil
+	}
+	if certFile == "" || keyFile == "" {
+		fatal(fmt.Sprintf("both %v and %v are required to enable TLS", apiTLSCertFlag.Name, apiTLSKeyFlag.Name))
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		fatal(fmt.Sprintf("load API TLS certificate: %v", err))
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// apiCredentials returns credentials required by API service, returns nil if auth not enabled.
+func apiCredentials(ctx *cli.Context) *api.Credentials {
+	var credentials api.Credentials
+	credentials.Token = ctx.String(apiAuthTokenFlag.Name)
+	if basicAuth := ctx.String(apiBasicAuthFlag.Name); basicAuth != "" {
+		username, password, ok := api.ParseBasicAuth(basicAuth)
+		if !ok {
+			fatal(fmt.Sprintf("invalid %v, should be in form of 'username:password'", apiBasicAuthFlag.Name))
+		}
+		credentials.Username, credentials.Password = username, password
+	}
+	if credentials.Token == "" && credentials.Username == "" {
+		return nil
+	}
+	return &credentials
+}
+
+// startMetricsServer starts serving metrics if metrics addr specified.
+func startMetricsServer(ctx *cli.Context) *http.Server {
+	addr := ctx.String(metricsAddrFlag.Name)
+	if addr == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen metrics addr [%v]: %v", addr, err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metric.Handler())
+	srv := &http.Server{Handler: mux}
+	go func() {
+		srv.Serve(listener)
+	}()
+	log.Info("metrics service started", "url", "http://"+listener.Addr().String()+"/metrics")
+	return srv
+}
+
+func chainCacheSize(ctx *cli.Context) int {
+	n := ctx.Int(chainCacheFlag.Name)
+	if n <= 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", chainCacheFlag.Name))
+	}
+	return n
+}
+
+func stateCacheSize(ctx *cli.Context) int {
+	n := ctx.Int(stateCacheFlag.Name)
+	if n < 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", stateCacheFlag.Name))
+	}
+	return n
+}
+
+// fastSyncCheckpoint returns ID of the checkpoint block, or zero if fast sync disabled.
+func fastSyncCheckpoint(ctx *cli.Context) thor.Bytes32 {
+	if !ctx.Bool(fastSyncFlag.Name) {
+		return thor.Bytes32{}
+	}
+	checkpoint, err := thor.ParseBytes32(ctx.String(fastSyncCheckpointFlag.Name))
+	if err != nil || checkpoint.IsZero() {
+		fatal(fmt.Sprintf("invalid value for flag -%s", fastSyncCheckpointFlag.Name))
+	}
+	return checkpoint
+}
+
+func targetGasLimit(ctx *cli.Context) uint64 {
+	gl := ctx.Uint64(targetGasLimitFlag.Name)
+	if gl != 0 && gl < thor.MinGasLimit {
+		fatal(fmt.Sprintf("invalid value for flag -%s", targetGasLimitFlag.Name))
+	}
+	return gl
+}
+
+func standbySlots(ctx *cli.Context) uint32 {
+	n := ctx.Int(standbyFlag.Name)
+	if n < 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", standbyFlag.Name))
+	}
+	return uint32(n)
+}
+
+// verifyWorkers returns the number of workers to pre-verify txs, 0 for number of CPUs.
+func verifyWorkers(ctx *cli.Context) int {
+	n := ctx.Int(verifyWorkersFlag.Name)
+	if n < 0 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", verifyWorkersFlag.Name))
+	}
+	return n
+}
+
+// initTracing enables exporting traces if otlp endpoint specified.
+// It returns the func to flush and stop exporting.
+func initTracing(ctx *cli.Context) func(context.Context) error {
+	endpoint := ctx.String(otlpEndpointFlag.Name)
+	if endpoint == "" {
+		return nil
+	}
+	ratio := ctx.Float64(otlpSampleRatioFlag.Name)
+	if ratio < 0 || ratio > 1 {
+		fatal(fmt.Sprintf("invalid value for flag -%s", otlpSampleRatioFlag.Name))
+	}
+	shutdown, err := tracing.Init(endpoint, fullVersion(), ratio)
+	if err != nil {
+		fatal(fmt.Sprintf("init tracing [%v]: %v", endpoint, err))
+	}
+	log.Info("tracing enabled", "otlp-endpoint", endpoint, "sample-ratio", ratio)
+	return shutdown
+}
+
+// startPProfServer starts serving go-pprof if pprof flag set.
+func startPProfServer(ctx *cli.Context) *http.Server {
+	if !ctx.Bool(pprofFlag.Name) {
+		return nil
+	}
+	addr := ctx.String(pprofAddrFlag.Name)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen pprof addr [%v]: %v", addr, err))
+	}
+
+	// served by a dedicated mux rather than http.DefaultServeMux
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv := &http.Server{Handler: mux}
+	go func() {
+		srv.Serve(listener)
+	}()
+	log.Info("pprof service started", "url", "http://"+listener.Addr().String()+"/debug/pprof/")
+	return srv
+}
+
+// startAdminServer starts serving admin API if admin addr specified.
+// Only loopback address is allowed, since admin API is not protected.
+func startAdminServer(ctx *cli.Context, handler http.Handler) *http.Server {
+	addr := ctx.String(adminAddrFlag.Name)
+	if addr == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		fatal(fmt.Sprintf("parse admin addr [%v]: %v", addr, err))
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		fatal(fmt.Sprintf("admin addr [%v] must be a loopback address", addr))
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen admin addr [%v]: %v", addr, err))
+	}
+
+	// cross origin requests are refused unless allowed by admin rule of CORS policy
+	handler = api.CORS(handler, apiCORSPolicy(ctx).AdminRules())
+
+	if limiter := apiRateLimiter(ctx); limiter != nil {
+		handler = limiter.Handler(handler)
+	}
+
+	handler = filterClients(ctx, handler)
+
+	srv := &http.Server{Handler: requestBodyLimit(handler)}
+	go func() {
+		srv.Serve(listener)
+	}()
+	log.Info("admin service started", "url", "http://"+listener.Addr().String()+"/admin/")
+	return srv
+}
+
 func printStartupMessage(
 	gene *genesis.Genesis,
 	chain *chain.Chain,
@@ -285,9 +753,15 @@ func printStartupMessage(
 	apiURL string,
 ) {
 	bestBlock := chain.BestBlock()
+	// no master if block production disabled
+	var masterAddr, beneficiary interface{} = "none", "none"
+	if master != nil {
+		masterAddr, beneficiary = master.Address(), master.Beneficiary
+	}
 
 	fmt.Printf(`Starting %v
     Network      [ %v %v ]    
+    Forks        [ %v ]
     Best block   [ %v #%v @%v ]
     Master       [ %v ]
     Beneficiary  [ %v ]
@@ -296,18 +770,81 @@ func printStartupMessage(
 `,
 		common.MakeName("Thor", fullVersion()),
 		gene.ID(), gene.Name(),
+		gene.ForkConfig(),
 		bestBlock.Header().ID(), bestBlock.Header().Number(), time.Unix(int64(bestBlock.Header().Timestamp()), 0),
-		master.Address(), master.Beneficiary,
+		masterAddr, beneficiary,
 		dataDir,
 		apiURL)
 }
 
-func soloGenesis(ctx *cli.Context) *genesis.Genesis {
-	gene, err := genesis.NewDevnet()
+func soloGenesis(ctx *cli.Context) (*genesis.Genesis, []genesis.DevAccount) {
+	launchTime := genesis.DefaultDevnetLaunchTime
+	if ctx.IsSet(genesisTimeFlag.Name) {
+		launchTime = ctx.Uint64(genesisTimeFlag.Name)
+	}
+	allocs, accounts := soloAccounts(ctx)
+	gene, err := genesis.NewDevnetAt(launchTime, allocs...)
 	if err != nil {
 		fatal(err)
 	}
-	return gene
+	return gene, accounts
+}
+
+// soloAccounts collects extra accounts to be funded in solo genesis.
+// Accounts with known private key are also returned, to be printed.
+func soloAccounts(ctx *cli.Context) ([]genesis.DevAlloc, []genesis.DevAccount) {
+	defaultFund, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < ctx.Int(soloAccountsFlag.Name); i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			fatal(fmt.Sprintf("generate account key: %v", err))
+		}
+		keys = append(keys, key)
+	}
+	if hexKeys := ctx.String(soloAccountKeyFlag.Name); hexKeys != "" {
+		for _, hexKey := range strings.Split(hexKeys, ",") {
+			key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+			if err != nil {
+				fatal(fmt.Sprintf("parse %v: %v", soloAccountKeyFlag.Name, err))
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	var (
+		allocs   []genesis.DevAlloc
+		accounts []genesis.DevAccount
+	)
+	for _, key := range keys {
+		addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+		allocs = append(allocs, genesis.DevAlloc{Address: addr, Balance: defaultFund, Energy: defaultFund})
+		accounts = append(accounts, genesis.DevAccount{Address: addr, PrivateKey: key})
+	}
+
+	if file := ctx.String(soloAccountsFileFlag.Name); file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fatal(fmt.Sprintf("read %v: %v", soloAccountsFileFlag.Name, err))
+		}
+		var entries []struct {
+			Address thor.Address          `json:"address"`
+			Balance *math.HexOrDecimal256 `json:"balance"`
+			Energy  *math.HexOrDecimal256 `json:"energy"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			fatal(fmt.Sprintf("parse %v: %v", soloAccountsFileFlag.Name, err))
+		}
+		for _, entry := range entries {
+			allocs = append(allocs, genesis.DevAlloc{
+				Address: entry.Address,
+				Balance: (*big.Int)(entry.Balance),
+				Energy:  (*big.Int)(entry.Energy),
+			})
+		}
+	}
+	return allocs, accounts
 }
 
 func openMemMainDB() *lvldb.LevelDB {
@@ -318,6 +855,15 @@ func openMemMainDB() *lvldb.LevelDB {
 	return db
 }
 
+// openABIRegistry loads contract ABIs registered via admin API.
+func openABIRegistry(mainDB kv.GetPutter) *abis.Registry {
+	registry, err := abis.New(mainDB)
+	if err != nil {
+		fatal(fmt.Sprintf("load abi registry: %v", err))
+	}
+	return registry
+}
+
 func openMemLogDB() *logdb.LogDB {
 	db, err := logdb.NewMem()
 	if err != nil {
@@ -331,6 +877,7 @@ func printSoloStartupMessage(
 	chain *chain.Chain,
 	dataDir string,
 	apiURL string,
+	accounts []genesis.DevAccount,
 ) {
 	tableHead := `
 ┌────────────────────────────────────────────┬────────────────────────────────────────────────────────────────────┐
@@ -345,18 +892,20 @@ func printSoloStartupMessage(
 
 	info := fmt.Sprintf(`Starting %v
     Network     [ %v %v ]    
+    Forks       [ %v ]
     Best block  [ %v #%v @%v ]
     Data dir    [ %v ]
     API portal  [ %v ]`,
 		common.MakeName("Thor solo", fullVersion()),
 		gene.ID(), gene.Name(),
+		gene.ForkConfig(),
 		bestBlock.Header().ID(), bestBlock.Header().Number(), time.Unix(int64(bestBlock.Header().Timestamp()), 0),
 		dataDir,
 		apiURL)
 
 	info += tableHead
 
-	for _, a := range genesis.DevAccounts() {
+	for _, a := range append(genesis.DevAccounts(), accounts...) {
 		info += fmt.Sprintf(tableContent,
 			a.Address,
 			thor.BytesToBytes32(crypto.FromECDSA(a.PrivateKey)),
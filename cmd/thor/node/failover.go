@@ -0,0 +1,58 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"sync/atomic"
+)
+
+// failover coordinates a standby node with the primary node sharing the same master.
+// Blocks of the master received from network serve as heartbeats of the primary.
+// The standby takes over block production once the primary misses consecutive slots,
+// and steps back as soon as a heartbeat is received again.
+//
+// It's NOT a safety guarantee. There's no lease or fencing between the two nodes, so both may propose:
+//   - in the slot where the primary recovers, since the standby steps back only after receiving its block;
+//   - in every slot, for as long as a network partition keeps the primary's blocks from the standby.
+//
+// Conflicting blocks of the same master fork the chain until one of them is abandoned.
+// Operators must make sure the primary is really down, e.g. by external fencing, before relying on it.
+type failover struct {
+	maxMissed uint32
+	missed    uint32 // slots passed since the last heartbeat, accessed atomically
+	active    uint32 // 1 if took over, accessed atomically
+	lastSlot  uint64 // only accessed by packer loop
+}
+
+func newFailover(maxMissed uint32) *failover {
+	log.Warn("running as standby, which may produce blocks conflicting with the primary node if partitioned from it", "maxMissed", maxMissed)
+	return &failover{maxMissed: maxMissed}
+}
+
+// heartbeat is called when a block of the master is received from network.
+func (f *failover) heartbeat() {
+	atomic.StoreUint32(&f.missed, 0)
+	if atomic.CompareAndSwapUint32(&f.active, 1, 0) {
+		log.Warn("primary node is back, switched to standby")
+	}
+}
+
+// shouldPack is called when it's time to pack block in the slot, and returns whether to pack.
+func (f *failover) shouldPack(slot uint64) bool {
+	if atomic.LoadUint32(&f.active) == 1 {
+		return true
+	}
+	if slot <= f.lastSlot {
+		return false
+	}
+	f.lastSlot = slot
+	if atomic.AddUint32(&f.missed, 1) > f.maxMissed {
+		atomic.StoreUint32(&f.active, 1)
+		log.Warn("primary node missed slots, took over block production", "missed", f.maxMissed)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailover(t *testing.T) {
+	f := newFailover(2)
+
+	// primary alive
+	assert.False(t, f.shouldPack(10))
+	f.heartbeat()
+	assert.False(t, f.shouldPack(20))
+	assert.False(t, f.shouldPack(20), "same slot counted once")
+	f.heartbeat()
+
+	// primary missed 2 slots
+	assert.False(t, f.shouldPack(30))
+	assert.False(t, f.shouldPack(40))
+	assert.True(t, f.shouldPack(50))
+	assert.True(t, f.shouldPack(60))
+
+	// primary is back
+	f.heartbeat()
+	assert.False(t, f.shouldPack(70))
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/tx"
+)
+
+// runFastSync keeps retrying fast sync until done, or ctx canceled.
+func (n *Node) runFastSync(ctx context.Context) error {
+	const retryInterval = 10 * time.Second
+	for {
+		err := n.comm.FastSync(ctx, n.commitFastSyncedBlock)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Warn("fast sync failed, will retry", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// commitFastSyncedBlock adds block without execution, since its state is not available.
+func (n *Node) commitFastSyncedBlock(blk *block.Block, receipts tx.Receipts) error {
+	n.commitLock.Lock()
+	defer n.commitLock.Unlock()
+
+	_, err := n.addBlock(blk, receipts)
+	return err
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"time"
+)
+
+const freezeInterval = time.Minute
+
+func (n *Node) freezerLoop(ctx context.Context) {
+	log.Debug("enter freezer loop")
+	defer log.Debug("leave freezer loop")
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-n.comm.Synced():
+	}
+
+	ticker := time.NewTicker(freezeInterval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := n.chain.Freeze(ctx, n.freezeKeep); err != nil {
+			if err != context.Canceled {
+				log.Warn("failed to freeze blocks", "err", err)
+			}
+		} else if count > 0 {
+			log.Debug("blocks frozen", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// prepareLogs prepares batch to write logs of the block.
+func prepareLogs(logDB *logdb.LogDB, blk *block.Block, receipts tx.Receipts) *logdb.BlockBatch {
+	batch := logDB.Prepare(blk.Header())
+	for i, tx := range blk.Transactions() {
+		origin, _ := tx.Signer()
+		txBatch := batch.ForTransaction(tx.ID(), origin)
+		for _, output := range receipts[i].Outputs {
+			txBatch.Insert(output.Events, output.Transfers)
+		}
+	}
+	return batch
+}
+
+// WriteLogs writes logs of the block just added into chain, and removes logs of blocks left trunk by the fork.
+func WriteLogs(logDB *logdb.LogDB, blk *block.Block, receipts tx.Receipts, fork *chain.Fork) error {
+	forkIDs := make([]thor.Bytes32, 0, len(fork.Branch))
+	for _, header := range fork.Branch {
+		forkIDs = append(forkIDs, header.ID())
+	}
+	return prepareLogs(logDB, blk, receipts).Commit(forkIDs...)
+}
+
+// SyncLogDB brings log db in line with the chain.
+// Blocks are committed into chain before their logs, so log db may fall behind or
+// stay on an abandoned branch after unclean shutdown. Logs since the last trunk block
+// recorded in log db are rebuilt from receipts.
+func SyncLogDB(ctx context.Context, chain *chain.Chain, logDB *logdb.LogDB) error {
+	head, err := logDB.Head()
+	if err != nil {
+		return errors.WithMessage(err, "load log db head")
+	}
+	best := chain.BestBlock().Header()
+	if head.IsZero() || head == best.ID() {
+		// not recorded or in sync
+		return nil
+	}
+
+	// find the latest trunk block of which logs are committed
+	var ancestor *block.Header
+	if header, err := chain.GetBlockHeader(head); err != nil {
+		if !chain.IsNotFound(err) {
+			return err
+		}
+		// head block lost, which is also rebuilt
+		num := block.Number(head)
+		if num > best.Number() {
+			num = best.Number() + 1
+		}
+		if ancestor, err = chain.GetTrunkBlockHeader(num - 1); err != nil {
+			return err
+		}
+	} else {
+		for {
+			trunkID, err := chain.GetTrunkBlockID(header.Number())
+			if err != nil && !chain.IsNotFound(err) {
+				return err
+			}
+			if trunkID == header.ID() {
+				break
+			}
+			if header, err = chain.GetBlockHeader(header.ParentID()); err != nil {
+				return err
+			}
+		}
+		ancestor = header
+	}
+
+	log.Info("syncing log db", "from", ancestor.Number()+1, "to", best.Number())
+	if err := logDB.Rewind(ancestor); err != nil {
+		return errors.WithMessage(err, "rewind log db")
+	}
+	return RebuildLogs(ctx, chain, logDB, ancestor.Number()+1)
+}
+
+// RebuildLogs writes logs of trunk blocks from the given number up to the best block,
+// by re-reading receipts from the chain.
+func RebuildLogs(ctx context.Context, chain *chain.Chain, logDB *logdb.LogDB, from uint32) error {
+	best := chain.BestBlock().Header().Number()
+	reportTime := time.Now()
+	for num := from; num <= best; num++ {
+		blk, err := chain.GetTrunkBlock(num)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("get block #%v", num))
+		}
+		receipts, err := chain.GetBlockReceipts(blk.Header().ID())
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("get receipts of block #%v", num))
+		}
+		if err := prepareLogs(logDB, blk, receipts).Commit(); err != nil {
+			return errors.WithMessage(err, "commit logs")
+		}
+
+		if time.Since(reportTime) > 8*time.Second {
+			log.Info("writing logs", "number", num, "best", best)
+			reportTime = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
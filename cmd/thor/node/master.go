@@ -12,11 +12,29 @@ import (
 	"github.com/vechain/thor/thor"
 )
 
+// Signer signs blocks on behalf of master, without exposing the private key.
+type Signer interface {
+	Address() thor.Address
+	Sign(hash thor.Bytes32) ([]byte, error)
+}
+
 type Master struct {
 	PrivateKey  *ecdsa.PrivateKey
+	Signer      Signer // used instead of PrivateKey if not nil
 	Beneficiary thor.Address
 }
 
 func (m *Master) Address() thor.Address {
+	if m.Signer != nil {
+		return m.Signer.Address()
+	}
 	return thor.Address(crypto.PubkeyToAddress(m.PrivateKey.PublicKey))
 }
+
+// Sign signs the block signing hash.
+func (m *Master) Sign(hash thor.Bytes32) ([]byte, error) {
+	if m.Signer != nil {
+		return m.Signer.Sign(hash)
+	}
+	return crypto.Sign(hash.Bytes(), m.PrivateKey)
+}
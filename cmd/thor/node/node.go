@@ -25,16 +25,26 @@ import (
 	"github.com/vechain/thor/comm"
 	"github.com/vechain/thor/consensus"
 	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/packer"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
 	"github.com/vechain/thor/tx"
 	"github.com/vechain/thor/txpool"
 )
 
 var log = log15.New("pkg", "node")
 
+var (
+	metricBlockImportDuration = metric.NewHistogram("node", "block_import_duration_seconds", "time spent to process and commit a block", nil)
+	metricBlocksImported      = metric.NewCounter("node", "blocks_imported_total", "number of blocks imported")
+)
+
 type Node struct {
+	// keep 64-bit aligned for atomic access on 32-bit platforms
+	targetGasLimit uint64 // set by operator, accessed atomically
+
 	goes   co.Goes
 	packer *packer.Packer
 	cons   *consensus.Consensus
@@ -45,37 +55,110 @@ type Node struct {
 	txPool     *txpool.TxPool
 	comm       *comm.Communicator
 	commitLock sync.Mutex
+	blockFeed  event.Feed
+	feedScope  event.SubscriptionScope
+
+	pruner      *StatePruner
+	recentRoots []thor.Bytes32 // roots of states committed during pruning, guarded by commitLock
+	freezeKeep  uint32
+	fastSync    bool
+	preExecute  bool
+	noProduce   bool
+	failover    *failover // nil if not running as standby
+	production  production
+	alertURL    string
+	alerts      chan *SlotMissedAlert // nil if alert disabled
+
+	adaptiveGasLimit uint64 // calculated from packing performance, only accessed by packer loop
 }
 
 func New(
-	master *Master,
+	master *Master, // nil if noProduce
 	chain *chain.Chain,
 	stateCreator *state.Creator,
+	forkConfig thor.ForkConfig,
 	logDB *logdb.LogDB,
 	txPool *txpool.TxPool,
 	comm *comm.Communicator,
+	pruner *StatePruner, // nil to disable online pruning
+	freezeKeep uint32, // count of latest blocks kept out of freezer, 0 to disable freezing
+	verifyWorkers int, // number of workers to pre-verify txs of blocks, 0 for number of CPUs
+	fastSync bool,
+	preExecute bool, // execute pending txs in advance while waiting for the time to pack block
+	noProduce bool, // never pack blocks, but still validate and relay, e.g. as a sentry or standby of the master
+	standbySlots uint32, // run as standby of the primary node with the same master, and take over after it misses so many consecutive slots, 0 to disable (best effort, see failover)
+	alertURL string, // url to post alerts when slots missed, empty to disable
 ) *Node {
+	cons := consensus.New(chain, stateCreator, forkConfig)
+	cons.SetVerifyWorkers(verifyWorkers)
+	var (
+		pk     *packer.Packer
+		fo     *failover
+		alerts chan *SlotMissedAlert
+	)
+	if !noProduce {
+		pk = packer.New(chain, stateCreator, master.Address(), master.Beneficiary, forkConfig)
+		if standbySlots > 0 {
+			fo = newFailover(standbySlots)
+		}
+		if alertURL != "" {
+			alerts = make(chan *SlotMissedAlert, maxQueuedAlerts)
+		}
+	}
 	return &Node{
-		packer: packer.New(chain, stateCreator, master.Address(), master.Beneficiary),
-		cons:   consensus.New(chain, stateCreator),
-		master: master,
-		chain:  chain,
-		logDB:  logDB,
-		txPool: txPool,
-		comm:   comm,
+		packer:     pk,
+		cons:       cons,
+		master:     master,
+		chain:      chain,
+		logDB:      logDB,
+		txPool:     txPool,
+		comm:       comm,
+		pruner:     pruner,
+		freezeKeep: freezeKeep,
+		fastSync:   fastSync,
+		preExecute: preExecute,
+		noProduce:  noProduce,
+		failover:   fo,
+		alertURL:   alertURL,
+		alerts:     alerts,
 	}
 }
 
 func (n *Node) Run(ctx context.Context) error {
+	if n.fastSync {
+		if err := n.runFastSync(ctx); err != nil {
+			// only fails when ctx canceled
+			return nil
+		}
+	}
 	n.comm.Sync(n.handleBlockStream)
 
 	n.goes.Go(func() { n.houseKeeping(ctx) })
-	n.goes.Go(func() { n.packerLoop(ctx) })
+	if n.noProduce {
+		log.Info("block production disabled")
+	} else {
+		n.goes.Go(func() { n.packerLoop(ctx) })
+		if n.alerts != nil {
+			n.goes.Go(func() { n.alertLoop(ctx) })
+		}
+	}
+	if n.pruner != nil {
+		n.goes.Go(func() { n.prunerLoop(ctx) })
+	}
+	if n.freezeKeep > 0 {
+		n.goes.Go(func() { n.freezerLoop(ctx) })
+	}
 
 	n.goes.Wait()
+	n.feedScope.Close()
 	return nil
 }
 
+// SubscribeBlock subscribe the event that new blocks committed into trunk.
+func (n *Node) SubscribeBlock(ch chan *chain.Fork) event.Subscription {
+	return n.feedScope.Track(n.blockFeed.Subscribe(ch))
+}
+
 func (n *Node) handleBlockStream(ctx context.Context, stream <-chan *block.Block) (err error) {
 	log.Debug("start to process block stream")
 	defer log.Debug("process block stream done", "err", err)
@@ -90,7 +173,10 @@ func (n *Node) handleBlockStream(ctx context.Context, stream <-chan *block.Block
 
 	var blk *block.Block
 	for blk = range stream {
-		if _, err := n.processBlock(blk, &stats); err != nil {
+		if _, err := n.processBlock(ctx, blk, &stats); err != nil {
+			if consensus.IsCritical(err) {
+				return &comm.InvalidBlockError{Err: err}
+			}
 			return err
 		}
 
@@ -137,11 +223,13 @@ func (n *Node) houseKeeping(ctx context.Context) {
 			return
 		case newBlock := <-newBlockCh:
 			var stats blockStats
-			if isTrunk, err := n.processBlock(newBlock.Block, &stats); err != nil {
+			if isTrunk, err := n.processBlock(ctx, newBlock.Block, &stats); err != nil {
 				if consensus.IsFutureBlock(err) ||
 					(consensus.IsParentMissing(err) && futureBlocks.Contains(newBlock.Header().ParentID())) {
 					log.Debug("future block added", "id", newBlock.Header().ID())
 					futureBlocks.Set(newBlock.Header().ID(), newBlock.Block)
+				} else if consensus.IsCritical(err) {
+					n.comm.ReportInvalidBlock(newBlock)
 				}
 			} else if isTrunk {
 				n.comm.BroadcastBlock(newBlock.Block)
@@ -159,7 +247,7 @@ func (n *Node) houseKeeping(ctx context.Context) {
 			})
 			var stats blockStats
 			for i, block := range blocks {
-				if isTrunk, err := n.processBlock(block, &stats); err == nil || consensus.IsKnownBlock(err) {
+				if isTrunk, err := n.processBlock(ctx, block, &stats); err == nil || consensus.IsKnownBlock(err) {
 					log.Debug("future block consumed", "id", block.Header().ID())
 					futureBlocks.Remove(block.Header().ID())
 					if isTrunk {
@@ -185,10 +273,24 @@ func (n *Node) houseKeeping(ctx context.Context) {
 	}
 }
 
-func (n *Node) processBlock(blk *block.Block, stats *blockStats) (bool, error) {
+func (n *Node) processBlock(ctx context.Context, blk *block.Block, stats *blockStats) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "node.processBlock",
+		tracing.Int64("block.number", int64(blk.Header().Number())),
+		tracing.String("block.id", blk.Header().ID().String()),
+		tracing.Int("block.txs", len(blk.Transactions())))
+	defer func() {
+		// future and orphan blocks are queued to process later, not failed
+		if consensus.IsFutureBlock(err) || consensus.IsParentMissing(err) {
+			span.SetAttributes(tracing.String("block.result", "queued"))
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+
 	startTime := mclock.Now()
 	now := uint64(time.Now().Unix())
-	stage, receipts, err := n.cons.Process(blk, now)
+	stage, receipts, err := n.cons.ProcessContext(ctx, blk, now)
 	if err != nil {
 		switch {
 		case consensus.IsKnownBlock(err):
@@ -207,12 +309,9 @@ func (n *Node) processBlock(blk *block.Block, stats *blockStats) (bool, error) {
 
 	execElapsed := mclock.Now() - startTime
 
-	if _, err := stage.Commit(); err != nil {
-		log.Error("failed to commit state", "err", err)
-		return false, err
-	}
-
-	fork, err := n.commitBlock(blk, receipts)
+	_, commitSpan := tracing.Start(ctx, "node.commitBlock")
+	fork, err := n.commitBlock(stage, blk, receipts)
+	tracing.End(commitSpan, err)
 	if err != nil {
 		if !n.chain.IsBlockExist(err) {
 			log.Error("failed to commit block", "err", err)
@@ -220,36 +319,46 @@ func (n *Node) processBlock(blk *block.Block, stats *blockStats) (bool, error) {
 		return false, err
 	}
 	commitElapsed := mclock.Now() - startTime - execElapsed
+	metricBlockImportDuration.Observe(time.Duration(execElapsed + commitElapsed).Seconds())
+	metricBlocksImported.Inc()
 	stats.UpdateProcessed(1, len(receipts), execElapsed, commitElapsed, blk.Header().GasUsed())
+	if n.failover != nil {
+		if signer, _ := blk.Header().Signer(); signer == n.master.Address() {
+			n.failover.heartbeat()
+		}
+	}
 	n.processFork(fork)
 	return len(fork.Trunk) > 0, nil
 }
 
-func (n *Node) commitBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.Fork, error) {
+func (n *Node) commitBlock(stage *state.Stage, newBlock *block.Block, receipts tx.Receipts) (*chain.Fork, error) {
 	n.commitLock.Lock()
 	defer n.commitLock.Unlock()
 
+	if _, err := stage.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit state")
+	}
+	if n.recentRoots != nil {
+		n.recentRoots = append(n.recentRoots, newBlock.Header().StateRoot())
+	}
+	return n.addBlock(newBlock, receipts)
+}
+
+// addBlock adds the block into chain and writes its logs.
+// commitLock should be held.
+func (n *Node) addBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.Fork, error) {
 	fork, err := n.chain.AddBlock(newBlock, receipts)
 	if err != nil {
 		return nil, err
 	}
 
-	forkIDs := make([]thor.Bytes32, 0, len(fork.Branch))
-	for _, header := range fork.Branch {
-		forkIDs = append(forkIDs, header.ID())
-	}
-
-	batch := n.logDB.Prepare(newBlock.Header())
-	for i, tx := range newBlock.Transactions() {
-		origin, _ := tx.Signer()
-		txBatch := batch.ForTransaction(tx.ID(), origin)
-		for _, output := range receipts[i].Outputs {
-			txBatch.Insert(output.Events, output.Transfers)
-		}
+	if err := WriteLogs(n.logDB, newBlock, receipts, fork); err != nil {
+		return nil, errors.Wrap(err, "commit logs")
 	}
 
-	if err := batch.Commit(forkIDs...); err != nil {
-		return nil, errors.Wrap(err, "commit logs")
+	if len(fork.Trunk) > 0 {
+		// not tracked by n.goes, since blocks may also be committed by the sync process
+		go n.blockFeed.Send(fork)
 	}
 	return fork, nil
 }
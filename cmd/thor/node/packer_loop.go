@@ -8,6 +8,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/packer"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 )
 
 func (n *Node) packerLoop(ctx context.Context) {
@@ -30,10 +32,11 @@ func (n *Node) packerLoop(ctx context.Context) {
 	log.Info("synchronization process done")
 
 	var (
-		authorized bool
-		flow       *packer.Flow
-		err        error
-		ticker     = time.NewTicker(time.Second)
+		authorized  bool
+		flow        *packer.Flow
+		execElapsed mclock.AbsTime // time spent on executing txs of the flow in advance
+		err         error
+		ticker      = time.NewTicker(time.Second)
 	)
 	defer ticker.Stop()
 
@@ -48,6 +51,7 @@ func (n *Node) packerLoop(ctx context.Context) {
 		now := uint64(time.Now().Unix())
 
 		if flow == nil {
+			n.packer.SetTargetGasLimit(n.packTargetGasLimit())
 			if flow, err = n.packer.Schedule(best.Header(), now); err != nil {
 				if authorized {
 					authorized = false
@@ -60,25 +64,47 @@ func (n *Node) packerLoop(ctx context.Context) {
 				log.Info("prepared to pack block")
 			}
 			log.Debug("scheduled to pack block", "after", time.Duration(flow.When()-now)*time.Second)
+			execElapsed = 0
 			continue
 		}
 
 		if flow.ParentHeader().ID() != best.Header().ID() {
+			// the new best block taking or passing the slot means it's missed, e.g. the loop lagged behind.
+			// a standby is not expected to pack in every slot, so doesn't count.
+			if n.failover == nil && best.Header().Timestamp() >= flow.When() {
+				if signer, err := best.Header().Signer(); err == nil && signer != n.master.Address() {
+					n.slotScheduled()
+					n.slotMissed(flow.When(), "slot passed before packing")
+				}
+			}
 			flow = nil
 			log.Debug("re-schedule packer due to new best block")
 			continue
 		}
 
 		if now+1 >= flow.When() {
-			if err := n.pack(flow); err != nil {
-				log.Error("failed to pack block", "err", err)
+			if n.failover != nil && !n.failover.shouldPack(flow.When()) {
+				log.Debug("standby, skip packing block")
+			} else {
+				n.slotScheduled()
+				if err := n.pack(ctx, flow, execElapsed); err != nil {
+					log.Error("failed to pack block", "err", err)
+					n.slotMissed(flow.When(), err.Error())
+				}
 			}
 			flow = nil
+		} else if n.preExecute {
+			// results are kept in the flow, so that only txs arrived later are executed when packing
+			startTime := mclock.Now()
+			n.adoptTxs(ctx, flow)
+			execElapsed += mclock.Now() - startTime
 		}
 	}
 }
 
-func (n *Node) pack(flow *packer.Flow) error {
+// adoptTxs executes pending txs not yet adopted by the flow, and removes bad ones from tx pool.
+// It returns false if ctx is canceled.
+func (n *Node) adoptTxs(ctx context.Context, flow *packer.Flow) bool {
 	txs := n.txPool.Pending(true)
 	var txsToRemove []thor.Bytes32
 	defer func() {
@@ -87,8 +113,15 @@ func (n *Node) pack(flow *packer.Flow) error {
 		}
 	}()
 
-	startTime := mclock.Now()
 	for _, tx := range txs {
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+		if flow.Adopted(tx.ID()) {
+			continue
+		}
 		if err := flow.Adopt(tx); err != nil {
 			if packer.IsGasLimitReached(err) {
 				break
@@ -99,26 +132,60 @@ func (n *Node) pack(flow *packer.Flow) error {
 			txsToRemove = append(txsToRemove, tx.ID())
 		}
 	}
+	return true
+}
 
-	newBlock, stage, receipts, err := flow.Pack(n.master.PrivateKey)
+// allInPool returns whether all given txs are still in tx pool.
+func (n *Node) allInPool(txs []*tx.Transaction) bool {
+	for _, t := range txs {
+		if n.txPool.Get(t.ID()) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// pack packs and commits a block. If ctx is canceled while adopting txs, the block is abandoned,
+// otherwise it's committed before returning, so that a stopping node is left in consistent state.
+// preElapsed is the time already spent on executing txs of the flow in advance.
+func (n *Node) pack(ctx context.Context, flow *packer.Flow, preElapsed mclock.AbsTime) error {
+	startTime := mclock.Now()
+	if preElapsed > 0 && !n.allInPool(flow.Txs()) {
+		// txs executed in advance may have been removed or replaced since, so start over
+		log.Debug("discard pre-executed txs no longer in pool")
+		newFlow, err := n.packer.Schedule(flow.ParentHeader(), flow.When())
+		if err != nil {
+			return err
+		}
+		if newFlow.When() != flow.When() {
+			return errors.New("re-scheduled to another slot")
+		}
+		flow = newFlow
+	}
+	if !n.adoptTxs(ctx, flow) {
+		log.Info("block packing abandoned due to shutdown")
+		return nil
+	}
+
+	newBlock, stage, receipts, err := flow.PackWith(n.master.Sign)
 	if err != nil {
 		return err
 	}
 	execElapsed := mclock.Now() - startTime
 
-	if _, err := stage.Commit(); err != nil {
-		return errors.WithMessage(err, "commit state")
-	}
-
-	fork, err := n.commitBlock(newBlock, receipts)
+	fork, err := n.commitBlock(stage, newBlock, receipts)
 	if err != nil {
 		return errors.WithMessage(err, "commit block")
 	}
 	commitElapsed := mclock.Now() - startTime - execElapsed
+	execElapsed += preElapsed
 
 	n.processFork(fork)
 
-	if len(fork.Trunk) > 0 {
+	if len(fork.Trunk) == 0 {
+		n.slotMissed(flow.When(), "packed block not in trunk")
+	} else {
+		n.blockProduced()
 		n.comm.BroadcastBlock(newBlock)
 		log.Info("📦 new block packed",
 			"txs", len(receipts),
@@ -128,15 +195,34 @@ func (n *Node) pack(flow *packer.Flow) error {
 		)
 	}
 
-	n.packer.SetTargetGasLimit(0)
+	n.adaptiveGasLimit = 0
 	if execElapsed > 0 {
 		gasUsed := newBlock.Header().GasUsed()
 		// calc target gas limit only if gas used above third of gas limit
 		if gasUsed > newBlock.Header().GasLimit()/3 {
-			targetGasLimit := uint64(thor.TolerableBlockPackingTime) * gasUsed / uint64(execElapsed)
-			n.packer.SetTargetGasLimit(targetGasLimit)
-			log.Debug("reset target gas limit", "value", targetGasLimit)
+			n.adaptiveGasLimit = uint64(thor.TolerableBlockPackingTime) * gasUsed / uint64(execElapsed)
+			log.Debug("reset adaptive gas limit", "value", n.adaptiveGasLimit)
 		}
 	}
 	return nil
 }
+
+// TargetGasLimit returns the target gas limit set by SetTargetGasLimit.
+func (n *Node) TargetGasLimit() uint64 {
+	return atomic.LoadUint64(&n.targetGasLimit)
+}
+
+// SetTargetGasLimit sets the target gas limit of blocks to be packed. 0 to follow the adaptive gas limit.
+// Block gas limit approaches it as fast as protocol allows, but never exceeds the adaptive one,
+// which is calculated from packing performance.
+func (n *Node) SetTargetGasLimit(gl uint64) {
+	atomic.StoreUint64(&n.targetGasLimit, gl)
+}
+
+func (n *Node) packTargetGasLimit() uint64 {
+	target := n.TargetGasLimit()
+	if target == 0 || (n.adaptiveGasLimit != 0 && n.adaptiveGasLimit < target) {
+		return n.adaptiveGasLimit
+	}
+	return target
+}
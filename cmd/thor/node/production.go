@@ -0,0 +1,122 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	apinode "github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	alertTimeout    = 10 * time.Second
+	maxQueuedAlerts = 16 // alerts are dropped once the queue is full
+)
+
+var (
+	metricSlotsScheduled = metric.NewCounter("node", "slots_scheduled_total", "number of slots scheduled for the master")
+	metricBlocksProduced = metric.NewCounter("node", "blocks_produced_total", "number of blocks produced by the master into trunk")
+	metricSlotsMissed    = metric.NewCounter("node", "slots_missed_total", "number of slots scheduled for the master without block produced")
+)
+
+// production tracks slots scheduled for the master against blocks actually produced.
+// Fields are accessed atomically.
+type production struct {
+	scheduled  uint64
+	produced   uint64
+	missed     uint64
+	lastMissed uint64
+}
+
+// SlotMissedAlert is posted in json to the alert url once a slot is missed.
+type SlotMissedAlert struct {
+	Master thor.Address `json:"master"`
+	Slot   uint64       `json:"slot"`   // timestamp of the missed slot
+	Missed uint64       `json:"missed"` // count of missed slots since started
+	Reason string       `json:"reason"`
+}
+
+func (n *Node) slotScheduled() {
+	atomic.AddUint64(&n.production.scheduled, 1)
+	metricSlotsScheduled.Inc()
+}
+
+func (n *Node) blockProduced() {
+	atomic.AddUint64(&n.production.produced, 1)
+	metricBlocksProduced.Inc()
+}
+
+func (n *Node) slotMissed(slot uint64, reason string) {
+	missed := atomic.AddUint64(&n.production.missed, 1)
+	atomic.StoreUint64(&n.production.lastMissed, slot)
+	metricSlotsMissed.Inc()
+	log.Warn("missed slot to produce block", "slot", slot, "reason", reason)
+
+	if n.alerts != nil {
+		select {
+		case n.alerts <- &SlotMissedAlert{n.master.Address(), slot, missed, reason}:
+		default:
+			log.Warn("too many alerts queued, dropped", "slot", slot)
+		}
+	}
+}
+
+// alertLoop posts queued alerts one by one.
+func (n *Node) alertLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case a := <-n.alerts:
+			n.alert(ctx, a)
+		}
+	}
+}
+
+func (n *Node) alert(ctx context.Context, a *SlotMissedAlert) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		log.Warn("failed to encode alert", "err", err)
+		return
+	}
+	req, err := http.NewRequest("POST", n.alertURL, bytes.NewReader(data))
+	if err != nil {
+		log.Warn("failed to post alert", "err", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Timeout: alertTimeout}
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		log.Warn("failed to post alert", "err", err)
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		log.Warn("failed to post alert", "status", res.Status)
+	}
+}
+
+// Production implements api/node.Producer.
+func (n *Node) Production() *apinode.Production {
+	if n.noProduce {
+		return nil
+	}
+	return &apinode.Production{
+		Master:     n.master.Address(),
+		Scheduled:  atomic.LoadUint64(&n.production.scheduled),
+		Produced:   atomic.LoadUint64(&n.production.produced),
+		Missed:     atomic.LoadUint64(&n.production.missed),
+		LastMissed: atomic.LoadUint64(&n.production.lastMissed),
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotMissedAlert(t *testing.T) {
+	received := make(chan *SlotMissedAlert, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var a SlotMissedAlert
+		if err := json.NewDecoder(req.Body).Decode(&a); err != nil {
+			t.Error(err)
+		}
+		select {
+		case received <- &a:
+		default:
+		}
+	}))
+	defer ts.Close()
+
+	key, _ := crypto.GenerateKey()
+	n := &Node{
+		master:   &Master{PrivateKey: key},
+		alertURL: ts.URL,
+		alerts:   make(chan *SlotMissedAlert, maxQueuedAlerts),
+	}
+
+	// never blocks even if alerts are not consumed
+	for i := 0; i < maxQueuedAlerts+1; i++ {
+		n.slotMissed(uint64(i+1)*10, "test")
+	}
+	assert.Equal(t, maxQueuedAlerts, len(n.alerts))
+	assert.Equal(t, uint64(maxQueuedAlerts+1), n.production.missed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.alertLoop(ctx)
+
+	a := <-received
+	assert.Equal(t, n.master.Address(), a.Master)
+	assert.Equal(t, uint64(10), a.Slot)
+	assert.Equal(t, uint64(1), a.Missed)
+}
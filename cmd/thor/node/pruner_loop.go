@@ -0,0 +1,59 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/vechain/thor/thor"
+)
+
+const pruneInterval = time.Hour
+
+func (n *Node) prunerLoop(ctx context.Context) {
+	log.Debug("enter pruner loop")
+	defer log.Debug("leave pruner loop")
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-n.comm.Synced():
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		n.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *Node) prune(ctx context.Context) {
+	// start tracking roots of states committed during pruning
+	n.commitLock.Lock()
+	n.recentRoots = make([]thor.Bytes32, 0)
+	n.commitLock.Unlock()
+
+	defer func() {
+		n.commitLock.Lock()
+		n.recentRoots = nil
+		n.commitLock.Unlock()
+	}()
+
+	if _, err := n.pruner.Prune(ctx, &n.commitLock, func() []thor.Bytes32 {
+		// called with commitLock held
+		return n.recentRoots
+	}); err != nil && err != context.Canceled {
+		log.Warn("failed to prune states", "err", err)
+	}
+}
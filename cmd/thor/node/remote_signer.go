@@ -0,0 +1,104 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/thor"
+)
+
+const remoteSignerTimeout = 3 * time.Second
+
+// RemoteSigner delegates signing to an external signer service over HTTP, so that the master key
+// can be kept off the node host, e.g. in an HSM. The service should serve:
+//
+//	GET  <url>/address  responds {"address": "0x..."}
+//	POST <url>/sign     requests {"hash": "0x..."}, responds {"signature": "0x..."}
+//
+// Requests carry 'Authorization: Bearer <token>' if token is not empty.
+type RemoteSigner struct {
+	url     string
+	token   string
+	client  *http.Client
+	address thor.Address
+}
+
+// NewRemoteSigner creates a remote signer, and queries the address of the key held by the service.
+func NewRemoteSigner(url, token string) (*RemoteSigner, error) {
+	s := &RemoteSigner{
+		url:    strings.TrimRight(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: remoteSignerTimeout},
+	}
+	var res struct {
+		Address thor.Address `json:"address"`
+	}
+	if err := s.call("GET", "/address", nil, &res); err != nil {
+		return nil, errors.WithMessage(err, "query address")
+	}
+	s.address = res.Address
+	return s, nil
+}
+
+// Address returns the address of the key held by the service.
+func (s *RemoteSigner) Address() thor.Address {
+	return s.address
+}
+
+// Sign requests the service to sign the hash.
+func (s *RemoteSigner) Sign(hash thor.Bytes32) ([]byte, error) {
+	req := struct {
+		Hash thor.Bytes32 `json:"hash"`
+	}{hash}
+	var res struct {
+		Signature hexutil.Bytes `json:"signature"`
+	}
+	if err := s.call("POST", "/sign", &req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+func (s *RemoteSigner) call(method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = data
+	}
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote signer responded %v: %s", res.Status, bytes.TrimSpace(data))
+	}
+	return json.Unmarshal(data, out)
+}
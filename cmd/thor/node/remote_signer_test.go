@@ -0,0 +1,63 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/address":
+			json.NewEncoder(w).Encode(map[string]interface{}{"address": &addr})
+		case "/sign":
+			var body struct {
+				Hash thor.Bytes32 `json:"hash"`
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			sig, _ := crypto.Sign(body.Hash.Bytes(), key)
+			json.NewEncoder(w).Encode(map[string]interface{}{"signature": hexutil.Bytes(sig)})
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer ts.Close()
+
+	_, err := NewRemoteSigner(ts.URL, "wrong")
+	assert.NotNil(t, err)
+
+	signer, err := NewRemoteSigner(ts.URL+"/", "secret")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, addr, signer.Address())
+
+	master := &Master{Signer: signer}
+	assert.Equal(t, addr, master.Address())
+
+	hash := thor.Blake2b([]byte("block"))
+	sig, err := master.Sign(hash)
+	assert.Nil(t, err)
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, thor.Address(crypto.PubkeyToAddress(*pub)))
+}
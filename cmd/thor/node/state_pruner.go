@@ -0,0 +1,164 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+var prunedNumKey = []byte("prunedNum") // block number below which states of trunk blocks were pruned
+
+// maxCollectedNodes bounds nodes collected by a batch of sweeping, which are deleted before sweeping more.
+const maxCollectedNodes = 1 << 20
+
+// StatePruner prunes states of trunk blocks out of the retention window.
+// States of non-trunk blocks are not guaranteed to be retained.
+type StatePruner struct {
+	chain *chain.Chain
+	kv    kv.GetPutter
+	keep  uint32
+}
+
+// NewStatePruner create a state pruner which retains states of the latest 'keep' trunk blocks.
+func NewStatePruner(chain *chain.Chain, kv kv.GetPutter, keep uint32) *StatePruner {
+	return &StatePruner{
+		chain,
+		kv,
+		keep,
+	}
+}
+
+func (sp *StatePruner) loadPrunedNum() (uint32, error) {
+	data, err := sp.kv.Get(prunedNumKey)
+	if err != nil {
+		if sp.kv.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+
+func (sp *StatePruner) savePrunedNum(num uint32) error {
+	var data [4]byte
+	binary.BigEndian.PutUint32(data[:], num)
+	return sp.kv.Put(prunedNumKey, data[:])
+}
+
+// Prune prunes states of trunk blocks since last pruned, up to the retention window.
+// States are swept in batches, and the progress is saved after each batch, so that it resumes
+// from there if interrupted.
+// To work with a running node, lock is held while deleting nodes, and recentRoots should
+// return roots of states committed since Prune called, which will be retained.
+// It returns the count of deleted trie nodes.
+func (sp *StatePruner) Prune(ctx context.Context, lock sync.Locker, recentRoots func() []thor.Bytes32) (int, error) {
+	best := sp.chain.BestBlock().Header()
+	if best.Number() <= sp.keep {
+		return 0, nil
+	}
+	target := best.Number() - sp.keep
+
+	prunedNum, err := sp.loadPrunedNum()
+	if err != nil {
+		return 0, errors.WithMessage(err, "load pruned num")
+	}
+	if prunedNum >= target {
+		return 0, nil
+	}
+
+	startTime := time.Now()
+	pruner := state.NewPruner(sp.kv)
+
+	stateRoot := func(num uint32) (thor.Bytes32, error) {
+		// locate on trunk of the best block, in case the trunk changed during pruning
+		id, err := sp.chain.GetAncestorBlockID(best.ID(), num)
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		header, err := sp.chain.GetBlockHeader(id)
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		return header.StateRoot(), nil
+	}
+
+	for num := target; num <= best.Number(); num++ {
+		root, err := stateRoot(num)
+		if err != nil {
+			return 0, err
+		}
+		if err := pruner.Mark(root); err != nil {
+			return 0, errors.WithMessage(err, "mark state")
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	commit := func(next uint32) (int, error) {
+		if next < target {
+			// nodes shared with the next state are deleted when it's swept
+			root, err := stateRoot(next)
+			if err != nil {
+				return 0, err
+			}
+			if err := pruner.Hold(root); err != nil {
+				log.Debug("failed to hold state", "num", next, "err", err)
+			}
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		for _, root := range recentRoots() {
+			if err := pruner.Mark(root); err != nil {
+				return 0, errors.WithMessage(err, "mark state")
+			}
+		}
+		n, err := pruner.Commit()
+		if err != nil {
+			return 0, errors.WithMessage(err, "delete nodes")
+		}
+		if err := sp.savePrunedNum(next); err != nil {
+			return 0, errors.WithMessage(err, "save pruned num")
+		}
+		return n, nil
+	}
+
+	total := 0
+	for num := prunedNum; num < target; num++ {
+		root, err := stateRoot(num)
+		if err != nil {
+			return total, err
+		}
+		if err := pruner.Sweep(root); err != nil {
+			// states may be partially pruned by interrupted pruning
+			log.Debug("failed to sweep state", "num", num, "err", err)
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		if pruner.Collected() >= maxCollectedNodes || num+1 == target {
+			n, err := commit(num + 1)
+			if err != nil {
+				return total, err
+			}
+			total += n
+			log.Debug("states pruned in batch", "to", num+1, "nodes", n)
+		}
+	}
+	log.Info("states pruned", "from", prunedNum, "to", target, "nodes", total, "elapsed", time.Since(startTime))
+	return total, nil
+}
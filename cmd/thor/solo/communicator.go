@@ -15,3 +15,19 @@ type Communicator struct {
 func (comm Communicator) PeersStats() []*comm.PeerStats {
 	return nil
 }
+
+// SyncProgress returns nil solo doesn't sync from peers
+func (comm Communicator) SyncProgress() *comm.SyncProgress {
+	return nil
+}
+
+var syncedCh = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// Synced returns a closed channel, solo is always synced
+func (comm Communicator) Synced() <-chan struct{} {
+	return syncedCh
+}
@@ -7,9 +7,12 @@ package solo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
@@ -27,28 +30,43 @@ var log = log15.New()
 
 // Solo mode is the standalone client without p2p server
 type Solo struct {
-	chain       *chain.Chain
-	txPool      *txpool.TxPool
-	packer      *packer.Packer
-	logDB       *logdb.LogDB
-	bestBlockCh chan *block.Block
-	onDemand    bool
+	chain         *chain.Chain
+	txPool        *txpool.TxPool
+	packer        *packer.Packer
+	logDB         *logdb.LogDB
+	bestBlockCh   chan *block.Block
+	onDemand      bool
+	blockInterval uint64
+	simulateTime  bool
+	blockFeed     event.Feed
+	feedScope     event.SubscriptionScope
+
+	lock      sync.Mutex // guards packing and dev operations below
+	snapshots []thor.Bytes32
+	timeShift uint64
 }
 
-// New returns Solo instance
+// New returns Solo instance.
+// Blocks are packed every blockInterval seconds. If simulateTime is true, block timestamps
+// advance by blockInterval from parent instead of following wall clock, so they are deterministic.
 func New(
 	chain *chain.Chain,
 	stateCreator *state.Creator,
+	forkConfig thor.ForkConfig,
 	logDB *logdb.LogDB,
 	txPool *txpool.TxPool,
 	onDemand bool,
+	blockInterval uint64,
+	simulateTime bool,
 ) *Solo {
 	return &Solo{
-		chain:    chain,
-		txPool:   txPool,
-		packer:   packer.New(chain, stateCreator, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address),
-		logDB:    logDB,
-		onDemand: onDemand,
+		chain:         chain,
+		txPool:        txPool,
+		packer:        packer.New(chain, stateCreator, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, forkConfig),
+		logDB:         logDB,
+		onDemand:      onDemand,
+		blockInterval: blockInterval,
+		simulateTime:  simulateTime,
 	}
 }
 
@@ -58,6 +76,7 @@ func (s *Solo) Run(ctx context.Context) error {
 	defer func() {
 		<-ctx.Done()
 		goes.Wait()
+		s.feedScope.Close()
 	}()
 
 	goes.Go(func() {
@@ -73,11 +92,16 @@ func (s *Solo) Run(ctx context.Context) error {
 	return nil
 }
 
+// SubscribeBlock subscribe the event that new blocks committed into trunk.
+func (s *Solo) SubscribeBlock(ch chan *chain.Fork) event.Subscription {
+	return s.feedScope.Track(s.blockFeed.Subscribe(ch))
+}
+
 func (s *Solo) interval(ctx context.Context) {
 	if s.onDemand {
 		return
 	}
-	ticker := time.NewTicker(time.Duration(10) * time.Second)
+	ticker := time.NewTicker(time.Duration(s.blockInterval) * time.Second)
 	defer ticker.Stop()
 	s.packing()
 
@@ -117,13 +141,32 @@ func (s *Solo) watcher(ctx context.Context) {
 	}
 }
 
+// blockTime returns timestamp for the block to be packed on parent.
+// Time shift applies to all later blocks when following wall clock, while it's consumed
+// by the next block when time is simulated, since timestamps derive from parent.
+func (s *Solo) blockTime(parent *block.Header) uint64 {
+	if s.simulateTime {
+		return parent.Timestamp() + s.blockInterval + s.timeShift
+	}
+	return uint64(time.Now().Unix()) + s.timeShift
+}
+
 func (s *Solo) packing() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
+	if err := s.pack(false); err != nil {
+		log.Error(fmt.Sprintf("%+v", err))
+	}
+}
+
+// pack packs a block with pending txs. Empty block is skipped in on-demand mode unless force is true.
+func (s *Solo) pack(force bool) error {
 	best := s.chain.BestBlock()
 
-	flow, err := s.packer.Mock(best.Header(), uint64(time.Now().Unix()))
+	flow, err := s.packer.Mock(best.Header(), s.blockTime(best.Header()))
 	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return err
 	}
 
 	pendingTxs := s.txPool.Pending(true)
@@ -147,15 +190,16 @@ func (s *Solo) packing() {
 
 	b, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
 	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
-	}
-	if _, err := stage.Commit(); err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return err
 	}
 
 	// If there is no tx packed in the on-demand mode then skip
-	if s.onDemand && len(b.Transactions()) == 0 {
-		return
+	if !force && s.onDemand && len(b.Transactions()) == 0 {
+		return nil
+	}
+
+	if _, err := stage.Commit(); err != nil {
+		return err
 	}
 
 	blockID := b.Header().ID()
@@ -176,12 +220,72 @@ func (s *Solo) packing() {
 		}
 	}
 	if err := batch.Commit(); err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return err
 	}
 
 	// ignore fork when s
-	_, err = s.chain.AddBlock(b, receipts)
+	fork, err := s.chain.AddBlock(b, receipts)
 	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return err
 	}
+	if s.simulateTime {
+		s.timeShift = 0
+	}
+	go s.blockFeed.Send(fork)
+	return nil
+}
+
+// Snapshot records current best block, and returns id of the snapshot.
+func (s *Solo) Snapshot() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.snapshots = append(s.snapshots, s.chain.BestBlock().Header().ID())
+	return len(s.snapshots) - 1
+}
+
+// Revert rewinds chain to the snapshot. The snapshot and later ones are dropped.
+func (s *Solo) Revert(id int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if id < 0 || id >= len(s.snapshots) {
+		return errors.New("snapshot not found")
+	}
+	fork, err := s.chain.Rewind(s.snapshots[id])
+	if err != nil {
+		return err
+	}
+	s.snapshots = s.snapshots[:id]
+
+	abandoned := make([]thor.Bytes32, 0, len(fork.Branch))
+	for _, header := range fork.Branch {
+		abandoned = append(abandoned, header.ID())
+	}
+	if err := s.logDB.Prepare(fork.Ancestor).Commit(abandoned...); err != nil {
+		return err
+	}
+	go s.blockFeed.Send(fork)
+	return nil
+}
+
+// Mine packs n blocks immediately, even if there is no pending tx.
+func (s *Solo) Mine(n int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := 0; i < n; i++ {
+		if err := s.pack(true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IncreaseTime advances timestamps of blocks to be packed.
+func (s *Solo) IncreaseTime(seconds uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.timeShift += seconds
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thornode
+
+import (
+	"errors"
+
+	"github.com/vechain/thor/cmd/thor/node"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+// Config configures the node. Genesis and InstanceDir are required, so is Master unless NoBlockProduction,
+// and zero values of others fall back to defaults or disable the feature.
+type Config struct {
+	Genesis     *genesis.Genesis
+	InstanceDir string // dir of databases and caches, created if not exist
+	Master      *node.Master
+	Version     string // reported to peers and by API
+
+	DB             DBOptions
+	LogDBDSN       string // postgres DSN of log database, in instance dir if empty
+	ChainCacheSize int    // number of recent blocks kept decoded, chain.DefaultCacheSize if 0
+	FreezeKeep     uint32 // count of latest blocks kept in main database, 0 to disable freezer
+	Prune          bool   // prune obsolete states in background
+	PruneKeep      uint32 // count of latest blocks whose states are retained from pruning
+
+	TxPool  txpool.PoolConfig // txpool.DefaultPoolConfig if zero
+	TxRelay comm.TxRelayPolicy
+
+	// P2P options of the P2P server. The private key is generated if nil, name is derived from version
+	// if empty, and known nodes are loaded from peers cache in instance dir.
+	P2P         p2psrv.Options
+	MaxUpload   int // bytes per second of block/tx propagation, unlimited if 0
+	MaxDownload int // bytes per second of block downloading, unlimited if 0
+
+	VerifyWorkers     int    // number of workers to pre-verify txs of blocks, 0 for number of CPUs
+	FastSync          bool   // download state of the checkpoint block instead of executing all blocks from genesis
+	PreExecute        bool   // execute pending txs in advance while waiting for the time to pack block
+	NoBlockProduction bool   // never pack blocks, but still validate and relay
+	StandbySlots      uint32 // run as standby of the primary node with the same master, 0 to disable
+	AlertURL          string // url to post alerts when slots missed, empty to disable
+	TargetGasLimit    uint64 // target gas limit of blocks packed, 0 for adaptive
+
+	// FastSyncCheckpoint ID of the trusted block to fast sync to, required if FastSync.
+	FastSyncCheckpoint thor.Bytes32
+
+	EnableDebug   bool // mount debug endpoints at '/debug' of API, which are expensive to serve
+	EnableGraphQL bool // mount GraphQL endpoint at '/graphql' of API
+	EnableEthRPC  bool // mount eth JSON-RPC endpoint at '/eth' of API
+}
+
+func (c *Config) validate() error {
+	if c.Genesis == nil {
+		return errors.New("genesis required")
+	}
+	if c.InstanceDir == "" {
+		return errors.New("instance dir required")
+	}
+	if c.Master == nil && !c.NoBlockProduction {
+		return errors.New("master required")
+	}
+	if c.ChainCacheSize < 0 {
+		return errors.New("negative chain cache size")
+	}
+	if c.MaxUpload < 0 || c.MaxDownload < 0 {
+		return errors.New("negative bandwidth limit")
+	}
+	if c.VerifyWorkers < 0 {
+		return errors.New("negative verify workers")
+	}
+	if c.FastSync && c.FastSyncCheckpoint.IsZero() {
+		return errors.New("fast sync checkpoint required")
+	}
+	if c.TargetGasLimit != 0 && c.TargetGasLimit < thor.MinGasLimit {
+		return errors.New("target gas limit too low")
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package thornode assembles a full node from its parts, so that it can be embedded in
+// other programs and integration tests, besides the thor binary.
+package thornode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api"
+	"github.com/vechain/thor/api/abis"
+	"github.com/vechain/thor/api/admin"
+	apinode "github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/cmd/thor/node"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/txpool"
+)
+
+var log = log15.New("pkg", "thornode")
+
+// Node a full node, which syncs and produces blocks, and serves API.
+// It's started by Start and stopped by Stop, and can't be restarted.
+// Accessors are only valid after Start succeeded and before Stop.
+type Node struct {
+	config Config
+
+	lock    sync.Mutex
+	closers []closer // called in reverse order when closing
+	cancel  context.CancelFunc
+	done    chan struct{}
+	runErr  error
+
+	mainDB      kv.Store
+	logDB       *logdb.LogDB
+	chain       *chain.Chain
+	txPool      *txpool.TxPool
+	p2pSrv      *p2psrv.Server
+	comm        *comm.Communicator
+	node        *node.Node
+	abiRegistry *abis.Registry
+	apiHandler  http.Handler
+}
+
+type closer struct {
+	msg string
+	fn  func()
+}
+
+// New creates a node with the config. Nothing is opened until Start.
+func New(config Config) *Node {
+	return &Node{config: config}
+}
+
+// Start opens databases, starts P2P networking, and runs block processing in background.
+// Anything opened is closed if it fails.
+func (n *Node) Start() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.done != nil {
+		return errors.New("node started already")
+	}
+	if err := n.config.validate(); err != nil {
+		return errors.WithMessage(err, "invalid config")
+	}
+	if err := n.open(); err != nil {
+		n.close()
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	n.done = make(chan struct{})
+	go func() {
+		defer close(n.done)
+		n.runErr = n.node.Run(ctx)
+	}()
+	return nil
+}
+
+// Stop stops block processing and P2P networking, and closes databases.
+// API handlers should be stopped serving before.
+func (n *Node) Stop() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.done == nil {
+		return errors.New("node not started")
+	}
+	if n.cancel == nil {
+		// stopped already
+		return n.runErr
+	}
+	n.cancel()
+	n.cancel = nil
+	<-n.done
+	n.close()
+	return n.runErr
+}
+
+// Done returns a channel closed when block processing exits, either stopped or failed.
+// It's nil if not started.
+func (n *Node) Done() <-chan struct{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.done
+}
+
+// Err returns the error block processing exited with, or nil if it's still running.
+func (n *Node) Err() error {
+	done := n.Done()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return n.runErr
+	default:
+		return nil
+	}
+}
+
+func (n *Node) onClose(msg string, fn func()) {
+	n.closers = append(n.closers, closer{msg, fn})
+}
+
+func (n *Node) close() {
+	for i := len(n.closers) - 1; i >= 0; i-- {
+		log.Info(n.closers[i].msg)
+		n.closers[i].fn()
+	}
+	n.closers = nil
+}
+
+func (n *Node) open() (err error) {
+	config := &n.config
+	dir := config.InstanceDir
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("create instance dir [%v]", dir))
+	}
+
+	if n.mainDB, err = OpenMainDB(dir, config.DB); err != nil {
+		return err
+	}
+	n.onClose("closing main database...", func() { n.mainDB.Close() })
+
+	if n.logDB, err = OpenLogDB(dir, config.LogDBDSN); err != nil {
+		return err
+	}
+	n.onClose("closing log database...", func() { n.logDB.Close() })
+
+	if n.chain, err = InitChain(config.Genesis, n.mainDB, n.logDB); err != nil {
+		return err
+	}
+	if config.ChainCacheSize > 0 {
+		n.chain.SetCacheSize(config.ChainCacheSize)
+	}
+	openFreezer := OpenExistingFreezer
+	if config.FreezeKeep > 0 {
+		openFreezer = OpenFreezer
+	}
+	freezer, err := openFreezer(dir)
+	if err != nil {
+		return err
+	}
+	if freezer != nil {
+		n.onClose("closing freezer...", func() { freezer.Close() })
+		n.chain.SetFreezer(freezer)
+	}
+	if err := node.SyncLogDB(context.Background(), n.chain, n.logDB); err != nil {
+		return errors.WithMessage(err, "sync log db")
+	}
+
+	poolConfig := config.TxPool
+	if poolConfig == (txpool.PoolConfig{}) {
+		poolConfig = txpool.DefaultPoolConfig
+	}
+	n.txPool = txpool.New(n.chain, state.NewCreator(n.mainDB), config.Genesis.ForkConfig(), poolConfig)
+	n.onClose("closing tx pool...", func() { n.txPool.Close() })
+	journalPath := filepath.Join(dir, "txpool.journal")
+	if err := n.txPool.OpenJournal(journalPath); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("open tx journal [%v]", journalPath))
+	}
+
+	if err := n.startP2P(); err != nil {
+		return err
+	}
+
+	var pruner *node.StatePruner
+	if config.Prune {
+		pruner = node.NewStatePruner(n.chain, n.mainDB, config.PruneKeep)
+	}
+	n.node = node.New(
+		config.Master,
+		n.chain,
+		state.NewCreator(n.mainDB),
+		config.Genesis.ForkConfig(),
+		n.logDB,
+		n.txPool,
+		n.comm,
+		pruner,
+		config.FreezeKeep,
+		config.VerifyWorkers,
+		config.FastSync,
+		config.PreExecute,
+		config.NoBlockProduction,
+		config.StandbySlots,
+		config.AlertURL,
+	)
+	n.node.SetTargetGasLimit(config.TargetGasLimit)
+
+	if n.abiRegistry, err = abis.New(n.mainDB); err != nil {
+		return errors.WithMessage(err, "load abi registry")
+	}
+	n.apiHandler = api.New(
+		n.chain,
+		state.NewCreator(n.mainDB),
+		n.txPool,
+		n.logDB,
+		config.Genesis.ForkConfig(),
+		n.comm,
+		n.node,
+		n.p2pSrv,
+		n.node,
+		n.abiRegistry,
+		config.Version,
+		config.EnableDebug,
+		config.EnableGraphQL,
+		config.EnableEthRPC,
+	)
+	return nil
+}
+
+func (n *Node) startP2P() error {
+	config := &n.config
+	opts := config.P2P
+	if opts.PrivateKey == nil {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return errors.WithMessage(err, "generate P2P key")
+		}
+		opts.PrivateKey = key
+	}
+	if opts.Name == "" {
+		opts.Name = common.MakeName("thor", config.Version)
+	}
+	srv, savePeers := NewP2PServer(opts, config.InstanceDir)
+
+	comm := comm.New(n.chain, n.txPool, n.mainDB)
+	comm.SetBandwidthLimits(config.MaxUpload, config.MaxDownload)
+	comm.SetTxRelayPolicy(config.TxRelay)
+	comm.SetFastSyncCheckpoint(config.FastSyncCheckpoint)
+	if err := srv.Start(comm.Protocols()); err != nil {
+		return errors.WithMessage(err, "start P2P server")
+	}
+	n.onClose("saving peers cache...", savePeers)
+	n.onClose("stopping P2P server...", srv.Stop)
+	comm.Start()
+	n.onClose("stopping communicator...", comm.Stop)
+
+	n.p2pSrv = srv
+	n.comm = comm
+	return nil
+}
+
+// Config returns the config of the node.
+func (n *Node) Config() Config {
+	return n.config
+}
+
+// MainDB returns the main database.
+func (n *Node) MainDB() kv.Store {
+	return n.mainDB
+}
+
+// LogDB returns the database of event and transfer logs.
+func (n *Node) LogDB() *logdb.LogDB {
+	return n.logDB
+}
+
+// Chain returns the block chain.
+func (n *Node) Chain() *chain.Chain {
+	return n.chain
+}
+
+// TxPool returns the tx pool.
+func (n *Node) TxPool() *txpool.TxPool {
+	return n.txPool
+}
+
+// Communicator returns the communicator which syncs blocks and txs with peers.
+func (n *Node) Communicator() *comm.Communicator {
+	return n.comm
+}
+
+// P2PServer returns the P2P server.
+func (n *Node) P2PServer() *p2psrv.Server {
+	return n.p2pSrv
+}
+
+// ABIRegistry returns the registry of contract ABIs to decode events.
+func (n *Node) ABIRegistry() *abis.Registry {
+	return n.abiRegistry
+}
+
+// APIHandler returns the handler of API.
+func (n *Node) APIHandler() http.Handler {
+	return n.apiHandler
+}
+
+// AdminHandler returns the handler of admin API, with log level controlled by logLevel.
+func (n *Node) AdminHandler(logLevel admin.LogLevel) http.Handler {
+	var allowlist admin.Allowlist
+	if n.config.P2P.Allowlist != nil {
+		allowlist = n.p2pSrv
+	}
+	return api.NewAdmin(logLevel, n.mainDB, n, allowlist, n, n.abiRegistry)
+}
+
+// SubscribeBlock subscribes the event that new blocks committed into trunk.
+func (n *Node) SubscribeBlock(ch chan *chain.Fork) event.Subscription {
+	return n.node.SubscribeBlock(ch)
+}
+
+// Production returns block production of the master.
+func (n *Node) Production() *apinode.Production {
+	return n.node.Production()
+}
+
+// TargetGasLimit returns the target gas limit of blocks to be packed.
+func (n *Node) TargetGasLimit() uint64 {
+	return n.node.TargetGasLimit()
+}
+
+// SetTargetGasLimit sets the target gas limit of blocks to be packed. 0 to follow the adaptive gas limit.
+func (n *Node) SetTargetGasLimit(gl uint64) {
+	n.node.SetTargetGasLimit(gl)
+}
+
+// AddStatic connects to the node and keeps the connection.
+func (n *Node) AddStatic(node *discover.Node) {
+	n.p2pSrv.AddStatic(node)
+}
+
+// RemoveStatic disconnects from the node.
+func (n *Node) RemoveStatic(node *discover.Node) {
+	n.p2pSrv.RemoveStatic(node)
+	n.comm.DisconnectPeer(node.ID)
+}
+
+// BanPeer disconnects from the node and refuses it in the duration.
+func (n *Node) BanPeer(id discover.NodeID, duration time.Duration) {
+	n.comm.BanPeer(id, duration)
+}
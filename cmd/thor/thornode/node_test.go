@@ -0,0 +1,67 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thornode
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/p2psrv"
+)
+
+func TestNode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thornode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, New(Config{Genesis: gene}).Start(), "invalid config")
+	assert.NotNil(t, New(Config{Genesis: gene, InstanceDir: dir}).Start(), "master required to produce blocks")
+
+	n := New(Config{
+		Genesis:           gene,
+		InstanceDir:       filepath.Join(dir, "instance"),
+		Version:           "test",
+		P2P:               p2psrv.Options{MaxPeers: 1, NoDiscovery: true, NoDial: true},
+		NoBlockProduction: true,
+	})
+	assert.NotNil(t, n.Stop(), "not started")
+	if err := n.Start(); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, n.Start(), "started already")
+	assert.Nil(t, n.Err(), "running")
+
+	assert.Equal(t, gene.ID(), n.Chain().GenesisBlock().Header().ID())
+	assert.Equal(t, gene.ID(), n.Chain().BestBlock().Header().ID())
+
+	rec := httptest.NewRecorder()
+	n.APIHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/blocks/best", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	n.SetTargetGasLimit(20000000)
+	assert.Equal(t, uint64(20000000), n.TargetGasLimit())
+
+	assert.Nil(t, n.Stop())
+	<-n.Done()
+	assert.Nil(t, n.Err())
+	assert.Nil(t, n.Stop(), "stopped already")
+	assert.NotNil(t, n.Start(), "can't be restarted")
+
+	_, err = os.Stat(filepath.Join(dir, "instance", "peers.cache"))
+	assert.Nil(t, err, "peers cache saved")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thornode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/p2psrv"
+)
+
+// NewP2PServer creates the P2P server with known nodes loaded from peers cache in the instance dir,
+// and returns the func to save known nodes back.
+func NewP2PServer(opts p2psrv.Options, instanceDir string) (*p2psrv.Server, func()) {
+	peersCachePath := filepath.Join(instanceDir, "peers.cache")
+
+	if data, err := ioutil.ReadFile(peersCachePath); err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("failed to load peers cache", "err", err)
+		}
+	} else if err := rlp.DecodeBytes(data, &opts.KnownNodes); err != nil {
+		log.Warn("failed to load peers cache", "err", err)
+	}
+	srv := p2psrv.New(&opts)
+
+	return srv, func() {
+		nodes := srv.KnownNodes()
+		data, err := rlp.EncodeToBytes(nodes)
+		if err != nil {
+			log.Warn("failed to encode cached peers", "err", err)
+			return
+		}
+		if err := ioutil.WriteFile(peersCachePath, data, 0600); err != nil {
+			log.Warn("failed to write peers cache", "err", err)
+		}
+	}
+}
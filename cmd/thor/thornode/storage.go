@@ -0,0 +1,161 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thornode
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/pebbledb"
+	"github.com/vechain/thor/state"
+)
+
+// DBOptions options of the main database.
+type DBOptions struct {
+	Engine    string // 'leveldb' or 'pebble', 'leveldb' if empty
+	CacheSize int    // in MB, 128 if 0
+	Handles   int    // max open files, half of fd limit (at most 1024) if 0
+}
+
+// mainDBDirs maps database engines to dir names of main database.
+var mainDBDirs = map[string]string{
+	"leveldb": "main.db",
+	"pebble":  "main.pebble.db",
+}
+
+// OpenMainDB opens the main database in the instance dir.
+// It fails if the instance dir is created with another engine.
+func OpenMainDB(instanceDir string, opts DBOptions) (kv.Store, error) {
+	limit, err := fdlimit.Current()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get fd limit")
+	}
+	if limit <= 1024 {
+		log.Warn("low fd limit, increase it if possible", "limit", limit)
+	}
+
+	fileCache := opts.Handles
+	if fileCache <= 0 {
+		fileCache = limit / 2
+		if fileCache > 1024 {
+			fileCache = 1024
+		}
+	} else if fileCache > limit {
+		log.Warn("handles exceed fd limit, reduced", "handles", fileCache, "limit", limit)
+		fileCache = limit
+	}
+
+	cacheSize := opts.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = 128
+	}
+	log.Debug("main database options", "cache", cacheSize, "handles", fileCache)
+
+	// log db and freezer are shared by engines, and only consistent with the main db they're built from,
+	// so an instance is bound to the engine it's created with
+	for engine, dir := range mainDBDirs {
+		if engine == opts.Engine || (engine == "leveldb" && opts.Engine == "") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(instanceDir, dir)); err == nil {
+			return nil, fmt.Errorf("instance dir [%v] is created with database engine '%v', switching engine is not supported", instanceDir, engine)
+		}
+	}
+
+	var (
+		db  kv.Store
+		dir string
+	)
+	switch opts.Engine {
+	case "", "leveldb":
+		dir = filepath.Join(instanceDir, mainDBDirs["leveldb"])
+		db, err = lvldb.New(dir, lvldb.Options{
+			CacheSize:              cacheSize,
+			OpenFilesCacheCapacity: fileCache,
+		})
+	case "pebble":
+		// data files are incompatible between engines, so use separated dir
+		dir = filepath.Join(instanceDir, mainDBDirs["pebble"])
+		db, err = pebbledb.New(dir, pebbledb.Options{
+			CacheSize:    cacheSize,
+			MaxOpenFiles: fileCache,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported database engine '%v'", opts.Engine)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("open chain database [%v]", dir))
+	}
+	return db, nil
+}
+
+// OpenLogDB opens the log database in postgres if dsn not empty, or in the instance dir.
+func OpenLogDB(instanceDir string, dsn string) (*logdb.LogDB, error) {
+	if dsn != "" {
+		db, err := logdb.NewPostgres(dsn)
+		if err != nil {
+			return nil, errors.WithMessage(err, "open log database in postgres")
+		}
+		return db, nil
+	}
+	dir := filepath.Join(instanceDir, "logs.db")
+	db, err := logdb.New(dir)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("open log database [%v]", dir))
+	}
+	return db, nil
+}
+
+// OpenFreezer opens the freezer of ancient blocks in the instance dir, or creates it if not exists.
+func OpenFreezer(instanceDir string) (*chain.Freezer, error) {
+	dir := filepath.Join(instanceDir, "ancient")
+	freezer, err := chain.OpenFreezer(dir)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("open freezer [%v]", dir))
+	}
+	return freezer, nil
+}
+
+// OpenExistingFreezer opens the freezer in the instance dir if it was ever created, or returns nil.
+// Once created, the freezer must be always opened, since frozen blocks are no longer in the main db.
+func OpenExistingFreezer(instanceDir string) (*chain.Freezer, error) {
+	dir := filepath.Join(instanceDir, "ancient")
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("open freezer [%v]", dir))
+	}
+	return OpenFreezer(instanceDir)
+}
+
+// InitChain builds the genesis block, and creates the chain on it with genesis events written to log db.
+func InitChain(gene *genesis.Genesis, mainDB kv.GetPutter, logDB *logdb.LogDB) (*chain.Chain, error) {
+	genesisBlock, genesisEvents, err := gene.Build(state.NewCreator(mainDB))
+	if err != nil {
+		return nil, errors.WithMessage(err, "build genesis block")
+	}
+
+	chain, err := chain.New(mainDB, genesisBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "initialize block chain")
+	}
+
+	if err := logDB.Prepare(genesisBlock.Header()).
+		ForBlock().
+		Insert(genesisEvents, nil).Commit(); err != nil {
+		return nil, errors.WithMessage(err, "write genesis events")
+	}
+	return chain, nil
+}
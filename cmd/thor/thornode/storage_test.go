@@ -0,0 +1,58 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thornode
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenMainDBEngineSwitch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thornode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := OpenMainDB(dir, DBOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	_, err = OpenMainDB(dir, DBOptions{Engine: "pebble"})
+	assert.Contains(t, err.Error(), "switching engine is not supported")
+
+	db, err = OpenMainDB(dir, DBOptions{Engine: "leveldb"})
+	if assert.Nil(t, err) {
+		db.Close()
+	}
+}
+
+func TestOpenExistingFreezer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thornode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	freezer, err := OpenExistingFreezer(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, freezer, "not created")
+
+	freezer, err = OpenFreezer(dir)
+	if assert.Nil(t, err) {
+		freezer.Close()
+	}
+
+	freezer, err = OpenExistingFreezer(dir)
+	if assert.Nil(t, err) && assert.NotNil(t, freezer) {
+		freezer.Close()
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var txCommand = cli.Command{
+	Name:  "tx",
+	Usage: "build and sign transactions offline",
+	Description: `Transactions are described in JSON, e.g.
+   {
+     "chainTag": 74,
+     "blockRef": "0x0000000000000000",
+     "expiration": 720,
+     "clauses": [{"to": "0x7567d83b7b8d80addcb281a71d54fc7b3364ffed", "value": "1000000000000000000", "data": "0x"}],
+     "gasPriceCoef": 0,
+     "gas": 21000,
+     "dependsOn": null,
+     "nonce": "0x1234"
+   }
+   A random nonce is taken if omitted. Input is read from stdin if file is '-'.
+
+   For cold signing, 'build' the unsigned tx on an online machine, copy the output to the
+   air-gapped one to 'inspect' and 'sign' it, then send the signed tx back to broadcast.`,
+	Subcommands: []cli.Command{
+		{
+			Name:      "build",
+			Usage:     "build an unsigned tx from JSON, and print its raw hex",
+			ArgsUsage: "<file>",
+			Action:    txBuildAction,
+		},
+		{
+			Name:      "sign",
+			Usage:     "sign a tx, either in JSON or unsigned raw hex, with an account in keystore, and print the signed raw hex",
+			ArgsUsage: "<address> <file>",
+			Flags:     []cli.Flag{configDirFlag, passwordFileFlag, stdinPassphraseFlag},
+			Action:    txSignAction,
+		},
+		{
+			Name:      "inspect",
+			Usage:     "decode a raw tx and print it in JSON, along with ID and origin if signed",
+			ArgsUsage: "<file>",
+			Action:    txInspectAction,
+		},
+	},
+}
+
+// txSpec JSON form of a tx.
+type txSpec struct {
+	ChainTag     byte                 `json:"chainTag"`
+	BlockRef     hexutil.Bytes        `json:"blockRef"`
+	Expiration   uint32               `json:"expiration"`
+	Clauses      []*clauseSpec        `json:"clauses"`
+	GasPriceCoef uint8                `json:"gasPriceCoef"`
+	Gas          uint64               `json:"gas"`
+	DependsOn    *thor.Bytes32        `json:"dependsOn"`
+	Nonce        *math.HexOrDecimal64 `json:"nonce"`
+
+	// present when inspecting signed tx
+	ID     *thor.Bytes32 `json:"id,omitempty"`
+	Origin *thor.Address `json:"origin,omitempty"`
+}
+
+// clauseSpec JSON form of a clause. Nil 'to' to deploy contract.
+type clauseSpec struct {
+	To    *thor.Address         `json:"to"`
+	Value *math.HexOrDecimal256 `json:"value"`
+	Data  hexutil.Bytes         `json:"data"`
+}
+
+func (s *txSpec) build() (*tx.Transaction, error) {
+	if len(s.Clauses) == 0 {
+		return nil, errors.New("clauses required")
+	}
+	if s.Gas == 0 {
+		return nil, errors.New("gas required")
+	}
+	var blockRef tx.BlockRef
+	if len(s.BlockRef) > 0 {
+		if len(s.BlockRef) != len(blockRef) {
+			return nil, fmt.Errorf("blockRef should be %d bytes", len(blockRef))
+		}
+		copy(blockRef[:], s.BlockRef)
+	}
+	var nonce uint64
+	if s.Nonce != nil {
+		nonce = uint64(*s.Nonce)
+	} else {
+		var b [8]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, err
+		}
+		nonce = binary.BigEndian.Uint64(b[:])
+	}
+
+	builder := new(tx.Builder).
+		ChainTag(s.ChainTag).
+		BlockRef(blockRef).
+		Expiration(s.Expiration).
+		GasPriceCoef(s.GasPriceCoef).
+		Gas(s.Gas).
+		DependsOn(s.DependsOn).
+		Nonce(nonce)
+	for i, c := range s.Clauses {
+		if c == nil {
+			return nil, fmt.Errorf("clauses[%d]: null", i)
+		}
+		clause := tx.NewClause(c.To).WithData(c.Data)
+		if c.Value != nil {
+			if (*big.Int)(c.Value).Sign() < 0 {
+				return nil, fmt.Errorf("clauses[%d]: negative value", i)
+			}
+			clause = clause.WithValue((*big.Int)(c.Value))
+		}
+		builder.Clause(clause)
+	}
+	return builder.Build(), nil
+}
+
+func newTxSpec(trx *tx.Transaction) *txSpec {
+	blockRef := trx.BlockRef()
+	nonce := math.HexOrDecimal64(trx.Nonce())
+	s := &txSpec{
+		ChainTag:     trx.ChainTag(),
+		BlockRef:     blockRef[:],
+		Expiration:   trx.Expiration(),
+		GasPriceCoef: trx.GasPriceCoef(),
+		Gas:          trx.Gas(),
+		DependsOn:    trx.DependsOn(),
+		Nonce:        &nonce,
+	}
+	for _, c := range trx.Clauses() {
+		s.Clauses = append(s.Clauses, &clauseSpec{
+			To:    c.To(),
+			Value: (*math.HexOrDecimal256)(c.Value()),
+			Data:  c.Data(),
+		})
+	}
+	if len(trx.Signature()) > 0 {
+		if origin, err := trx.Signer(); err == nil {
+			id := trx.ID()
+			s.ID = &id
+			s.Origin = &origin
+		}
+	}
+	return s
+}
+
+// readTxInput reads the file, or stdin if path is '-'.
+func readTxInput(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// parseTx parses tx either in JSON or raw hex.
+func parseTx(data []byte) (*tx.Transaction, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var spec txSpec
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("invalid tx json: %v", err)
+		}
+		if spec.ID != nil || spec.Origin != nil {
+			return nil, errors.New("invalid tx json: id and origin not allowed")
+		}
+		return spec.build()
+	}
+	raw, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw tx: %v", err)
+	}
+	var trx tx.Transaction
+	if err := rlp.DecodeBytes(raw, &trx); err != nil {
+		return nil, fmt.Errorf("invalid raw tx: %v", err)
+	}
+	return &trx, nil
+}
+
+func printRawTx(trx *tx.Transaction) error {
+	raw, err := rlp.EncodeToBytes(trx)
+	if err != nil {
+		return err
+	}
+	fmt.Println(hexutil.Encode(raw))
+	return nil
+}
+
+func txBuildAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("file required")
+	}
+	data, err := readTxInput(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	trx, err := parseTx(data)
+	if err != nil {
+		return err
+	}
+	return printRawTx(trx)
+}
+
+func txSignAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("address and file required")
+	}
+	path := ctx.Args().Get(1)
+	if path == "-" && ctx.Bool(stdinPassphraseFlag.Name) {
+		return fmt.Errorf("can't read tx from stdin with flag %s", stdinPassphraseFlag.Name)
+	}
+	if _, err := findKeystoreAccount(makeKeystoreDir(ctx), ctx.Args().First()); err != nil {
+		return err
+	}
+	data, err := readTxInput(path)
+	if err != nil {
+		return err
+	}
+	trx, err := parseTx(data)
+	if err != nil {
+		return err
+	}
+	if len(trx.Signature()) > 0 {
+		return errors.New("tx already signed")
+	}
+
+	pr, err := newPassphraseReader(ctx)
+	if err != nil {
+		return err
+	}
+	key, err := loadKeystoreKey(makeKeystoreDir(ctx), ctx.Args().First(), pr)
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), key.PrivateKey)
+	if err != nil {
+		return err
+	}
+	trx = trx.WithSignature(sig)
+	fmt.Fprintln(os.Stderr, "ID:", trx.ID())
+	return printRawTx(trx)
+}
+
+func txInspectAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("file required")
+	}
+	data, err := readTxInput(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	trx, err := parseTx(data)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(newTxSpec(trx), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
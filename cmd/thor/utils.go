@@ -6,8 +6,10 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,9 +18,14 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/api/admin"
 )
 
 func fatal(args ...interface{}) {
@@ -94,24 +101,175 @@ func homeDir() string {
 	return ""
 }
 
+// handleExitSignal returns a context canceled on the first exit signal, to stop gracefully.
+// The second signal forces the process to exit immediately.
 func handleExitSignal() context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
-		exitSignalCh := make(chan os.Signal)
+		exitSignalCh := make(chan os.Signal, 1)
 		signal.Notify(exitSignalCh, os.Interrupt, os.Kill, syscall.SIGTERM)
 
-		select {
-		case sig := <-exitSignalCh:
-			log.Info("exit signal received", "signal", sig)
-			cancel()
-		}
+		sig := <-exitSignalCh
+		log.Info("exit signal received, send again to force exit", "signal", sig)
+		cancel()
+
+		sig = <-exitSignalCh
+		log.Warn("exit signal received again, force exit", "signal", sig)
+		os.Exit(1)
 	}()
 	return ctx
 }
 
+// availableMemory returns available physical memory in bytes.
+// It's only supported on linux by reading /proc/meminfo.
+func availableMemory() (uint64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var total uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// line in form of 'MemAvailable:   1024 kB'
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemAvailable:":
+			return kb * 1024, nil
+		case "MemTotal:":
+			total = kb * 1024
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, errors.New("memory info not found")
+	}
+	// MemAvailable is absent on old kernels
+	return total, nil
+}
+
+// defaultCacheSize returns cache size in MB for main database, detected from available memory.
+func defaultCacheSize() int {
+	const (
+		minCacheSize = 128
+		maxCacheSize = 4096
+	)
+	mem, err := availableMemory()
+	if err != nil {
+		log.Debug("failed to detect available memory", "err", err)
+		return minCacheSize
+	}
+	// a quarter of available memory
+	size := int(mem / 4 / 1024 / 1024)
+	if size < minCacheSize {
+		return minCacheSize
+	}
+	if size > maxCacheSize {
+		return maxCacheSize
+	}
+	return size
+}
+
 func requestBodyLimit(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.Body = http.MaxBytesReader(w, r.Body, 96*1000)
 		h.ServeHTTP(w, r)
 	})
 }
+
+// logLevel log verbosity which can be changed at runtime.
+// A module is identified by the 'pkg' context of loggers, and its level overrides the global one.
+type logLevel struct {
+	lvl     int32
+	modules atomic.Value // map[string]log15.Lvl, replaced as a whole on change
+}
+
+func (l *logLevel) Get() log15.Lvl {
+	return log15.Lvl(atomic.LoadInt32(&l.lvl))
+}
+
+func (l *logLevel) Set(lvl log15.Lvl) {
+	atomic.StoreInt32(&l.lvl, int32(lvl))
+}
+
+// Modules returns levels of modules which override the global one.
+func (l *logLevel) Modules() map[string]log15.Lvl {
+	modules, _ := l.modules.Load().(map[string]log15.Lvl)
+	return modules
+}
+
+// SetModules replaces levels of modules.
+func (l *logLevel) SetModules(modules map[string]log15.Lvl) {
+	l.modules.Store(modules)
+}
+
+// levelOf returns the level applied to the record.
+func (l *logLevel) levelOf(r *log15.Record) log15.Lvl {
+	if modules := l.Modules(); len(modules) > 0 {
+		for i := 0; i+1 < len(r.Ctx); i += 2 {
+			if r.Ctx[i] == "pkg" {
+				if pkg, ok := r.Ctx[i+1].(string); ok {
+					if lvl, ok := modules[pkg]; ok {
+						return lvl
+					}
+				}
+				break
+			}
+		}
+	}
+	return l.Get()
+}
+
+// filterHandler returns a handler that only emits records not exceeding current level.
+func (l *logLevel) filterHandler(h log15.Handler) log15.Handler {
+	return log15.FilterHandler(func(r *log15.Record) bool {
+		return r.Lvl <= l.levelOf(r)
+	}, h)
+}
+
+// logModules names of modules having their own loggers, which can be set by --vmodule.
+var logModules = []string{"node", "thornode", "comm", "p2psrv", "rpc", "txpool", "admin", "subscriptions"}
+
+// parseVModule parses per-module levels in form of 'comm=debug,txpool=warn'.
+// Unknown modules are rejected.
+func parseVModule(s string) (map[string]log15.Lvl, error) {
+	modules := make(map[string]log15.Lvl)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expect <module>=<level>, got '%v'", item)
+		}
+		module := strings.TrimSpace(parts[0])
+		if !isLogModule(module) {
+			return nil, fmt.Errorf("unknown module '%v', expect one of %v", module, strings.Join(logModules, ", "))
+		}
+		lvl, err := admin.ParseLogLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		modules[module] = lvl
+	}
+	return modules, nil
+}
+
+func isLogModule(name string) bool {
+	for _, m := range logModules {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
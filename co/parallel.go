@@ -16,17 +16,24 @@ type Enqueue func(work func())
 
 // Parallel to run a batch of work using as many CPU as it can.
 func Parallel(cb func(Enqueue)) {
-	if numCPU < 2 {
+	ParallelN(numCPU, cb)
+}
+
+// ParallelN to run a batch of work using n workers.
+// Works run in the calling goroutine if n < 2.
+func ParallelN(n int, cb func(Enqueue)) {
+	if n < 2 {
 		cb(func(work func()) {
 			work()
 		})
+		return
 	}
 
 	var goes Goes
 	defer goes.Wait()
-	ch := make(chan func(), numCPU*2)
+	ch := make(chan func(), n*2)
 	defer close(ch)
-	for i := 0; i < numCPU; i++ {
+	for i := 0; i < n; i++ {
 		goes.Go(func() {
 			for {
 				select {
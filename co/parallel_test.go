@@ -6,9 +6,11 @@
 package co_test
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/co"
 )
 
@@ -32,3 +34,15 @@ func TestParallel(t *testing.T) {
 	})
 	t.Log("parallel", time.Duration(time.Now().UnixNano()-startTime))
 }
+
+func TestParallelN(t *testing.T) {
+	for _, n := range []int{0, 1, 4} {
+		var count int32
+		co.ParallelN(n, func(en co.Enqueue) {
+			for i := 0; i < 100; i++ {
+				en(func() { atomic.AddInt32(&count, 1) })
+			}
+		})
+		assert.Equal(t, int32(100), count, "workers %v", n)
+	}
+}
@@ -71,20 +71,27 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID thor.Bytes32) {
 	result, err := proto.GetBlockByID(c.ctx, peer, newBlockID)
 	if err != nil {
 		peer.logger.Debug("failed to get block by id", "err", err)
+		c.penalizeCallError(peer, err)
 		return
 	}
 	if len(result) == 0 {
 		peer.logger.Debug("get nil block by id")
+		c.penalize(peer, penaltyUselessResponse, "nil block announced")
 		return
 	}
 
+	c.downloadLimit.consume(len(result))
+
 	var blk block.Block
 	if err := rlp.DecodeBytes(result, &blk); err != nil {
 		peer.logger.Debug("failed to decode block got by id", "err", err)
+		c.penalize(peer, penaltyInvalidBlock, "undecodable block")
 		return
 	}
+	c.reward(peer)
 
 	c.newBlockFeed.Send(&NewBlockEvent{
 		Block: &blk,
+		peer:  peer,
 	})
 }
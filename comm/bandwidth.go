@@ -0,0 +1,94 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/metric"
+)
+
+var metricThrottled = metric.NewCounter("p2p", "throttled_msgs_total", "number of propagation messages skipped or delayed due to bandwidth limits")
+
+// bandwidthLimiter limits bytes (or other units, e.g. messages) transferred per second, using a token bucket
+// which holds at most one second of traffic. The budget can be overdrawn by a single large message,
+// and later traffic is throttled until it recovered.
+type bandwidthLimiter struct {
+	rate   float64 // bytes per second, unlimited if not positive
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newBandwidthLimiter(rate int) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// refill should be called with lock held.
+func (l *bandwidthLimiter) refill() {
+	now := l.now()
+	l.tokens = math.Min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// allow consumes n bytes of budget, if it's not overdrawn.
+func (l *bandwidthLimiter) allow(n int) bool {
+	if l.rate <= 0 {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+	if l.tokens <= 0 {
+		metricThrottled.Inc()
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// consume consumes n bytes of budget unconditionally, and returns the time until the budget recovered.
+func (l *bandwidthLimiter) consume(n int) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait consumes n bytes of budget unconditionally, and waits until the budget recovered, or ctx done.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	delay := l.consume(n)
+	if delay <= 0 {
+		return nil
+	}
+	metricThrottled.Inc()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
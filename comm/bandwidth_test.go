@@ -0,0 +1,42 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newBandwidthLimiter(1000)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	assert.True(t, l.allow(600))
+	assert.True(t, l.allow(600), "budget can be overdrawn once")
+	assert.False(t, l.allow(1))
+
+	now = now.Add(100 * time.Millisecond)
+	assert.False(t, l.allow(1), "still overdrawn")
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, l.allow(1))
+
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, time.Duration(0), l.consume(1000), "refilled up to one second of traffic")
+	assert.Equal(t, 500*time.Millisecond, l.consume(500))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, l.wait(ctx, 1000))
+
+	unlimited := newBandwidthLimiter(0)
+	assert.True(t, unlimited.allow(1<<30))
+	assert.Nil(t, unlimited.wait(context.Background(), 1<<30))
+}
@@ -9,17 +9,22 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/p2psrv"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -28,10 +33,13 @@ import (
 
 var log = log15.New("pkg", "comm")
 
+var metricPeerCount = metric.NewGauge("p2p", "peer_count", "number of connected peers")
+
 // Communicator communicates with remote p2p peers to exchange blocks and txs, etc.
 type Communicator struct {
 	chain          *chain.Chain
 	txPool         *txpool.TxPool
+	stateDB        kv.GetPutter
 	ctx            context.Context
 	cancel         context.CancelFunc
 	peerSet        *PeerSet
@@ -41,20 +49,53 @@ type Communicator struct {
 	feedScope      event.SubscriptionScope
 	goes           co.Goes
 	onceSynced     sync.Once
+	bannedPeers    struct {
+		sync.Mutex
+		m map[discover.NodeID]time.Time
+	}
+	syncStart struct {
+		sync.Mutex
+		time time.Time
+		num  uint32
+	}
+	reconstructing struct {
+		sync.Mutex
+		m map[thor.Bytes32]struct{}
+	}
+	uploadLimit   *bandwidthLimiter
+	downloadLimit *bandwidthLimiter
+	txRelay       TxRelayPolicy
+
+	fastSyncCheckpoint thor.Bytes32
 }
 
 // New create a new Communicator instance.
-func New(chain *chain.Chain, txPool *txpool.TxPool) *Communicator {
+// stateDB is the storage of states, to serve and receive state nodes.
+func New(chain *chain.Chain, txPool *txpool.TxPool, stateDB kv.GetPutter) *Communicator {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Communicator{
+	c := &Communicator{
 		chain:          chain,
 		txPool:         txPool,
+		stateDB:        stateDB,
 		ctx:            ctx,
 		cancel:         cancel,
 		peerSet:        newPeerSet(),
 		syncedCh:       make(chan struct{}),
 		announcementCh: make(chan *announcement),
+		uploadLimit:    newBandwidthLimiter(0),
+		downloadLimit:  newBandwidthLimiter(0),
 	}
+	c.bannedPeers.m = make(map[discover.NodeID]time.Time)
+	c.reconstructing.m = make(map[thor.Bytes32]struct{})
+	return c
+}
+
+// SetBandwidthLimits limits rates of block/tx propagation and block downloading, in bytes per second.
+// When exceeded, new blocks are announced by ID instead of full content, txs are neither relayed nor
+// accepted from peers, and downloading is delayed. 0 means unlimited. It should be called before Start.
+func (c *Communicator) SetBandwidthLimits(upload, download int) {
+	c.uploadLimit = newBandwidthLimiter(upload)
+	c.downloadLimit = newBandwidthLimiter(download)
 }
 
 // Synced returns a channel indicates if synchronization process passed.
@@ -108,7 +149,12 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 					// if more than 3 peers connected, we are assumed to be the best
 					log.Debug("synchronization done, best assumed")
 				} else {
-					if err := c.sync(peer, best.Number(), handler); err != nil {
+					c.markSyncStart(best.Number())
+					err := c.sync(peer, best.Number(), handler)
+					if p := c.SyncProgress(); p.Current >= p.Target {
+						c.resetSyncStart()
+					}
+					if err != nil {
 						peer.logger.Debug("synchronization failed", "err", err)
 						break
 					}
@@ -128,18 +174,27 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 }
 
 // Protocols returns all supported protocols.
+// Version 1 is kept for peers not upgraded, and the latest version is chosen by peers supporting both.
 func (c *Communicator) Protocols() []*p2psrv.Protocol {
 	genesisID := c.chain.GenesisBlock().Header().ID()
-	return []*p2psrv.Protocol{
-		&p2psrv.Protocol{
+	newProtocol := func(version uint, length uint64) *p2psrv.Protocol {
+		return &p2psrv.Protocol{
 			Protocol: p2p.Protocol{
 				Name:    proto.Name,
-				Version: proto.Version,
-				Length:  proto.Length,
-				Run:     c.servePeer,
+				Version: version,
+				Length:  length,
+				Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+					return c.servePeer(newPeer(p, rw, version, length))
+				},
 			},
-			DiscTopic: fmt.Sprintf("%v%v@%x", proto.Name, proto.Version, genesisID[24:]),
-		}}
+			DiscTopic: fmt.Sprintf("%v%v@%x", proto.Name, version, genesisID[24:]),
+		}
+	}
+	// topic of the last one is searched, which is registered by nodes of both versions
+	return []*p2psrv.Protocol{
+		newProtocol(proto.Version, proto.Length),
+		newProtocol(proto.Version1, proto.Length1),
+	}
 }
 
 // Start start the communicator.
@@ -160,8 +215,10 @@ type txsToSync struct {
 	synced bool
 }
 
-func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
-	peer := newPeer(p, rw)
+func (c *Communicator) servePeer(peer *Peer) error {
+	if c.txRelay.PeerRateLimit > 0 {
+		peer.txLimiter = newBandwidthLimiter(c.txRelay.PeerRateLimit)
+	}
 	c.goes.Go(func() {
 		c.runPeer(peer)
 	})
@@ -176,35 +233,25 @@ func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
 func (c *Communicator) runPeer(peer *Peer) {
 	defer peer.Disconnect(p2p.DiscRequested)
 
-	// 5sec timeout for handshake
-	ctx, cancel := context.WithTimeout(c.ctx, time.Second*5)
-	defer cancel()
-
-	status, err := proto.GetStatus(ctx, peer)
-	if err != nil {
-		peer.logger.Debug("failed to get status", "err", err)
-		return
-	}
-	if status.GenesisBlockID != c.chain.GenesisBlock().Header().ID() {
-		peer.logger.Debug("failed to handshake", "err", "genesis id mismatch")
+	if c.isBanned(peer.ID()) {
+		peer.logger.Debug("failed to handshake", "err", "peer banned")
 		return
 	}
-	now := uint64(time.Now().Unix())
-	diff := now - status.SysTimestamp
-	if now < status.SysTimestamp {
-		diff = status.SysTimestamp
-	}
-	if diff > thor.BlockInterval {
-		peer.logger.Debug("failed to handshake", "err", "sys time diff too large")
+
+	status, err := handshake(c.ctx, peer, c.chain.GenesisBlock().Header().ID())
+	if err != nil {
+		peer.logger.Debug("failed to handshake", "err", err)
 		return
 	}
 
 	peer.UpdateHead(status.BestBlockID, status.TotalScore)
 	c.peerSet.Add(peer)
+	metricPeerCount.Set(float64(c.peerSet.Len()))
 	peer.logger.Debug(fmt.Sprintf("peer added (%v)", c.peerSet.Len()))
 
 	defer func() {
 		c.peerSet.Remove(peer.ID())
+		metricPeerCount.Set(float64(c.peerSet.Len()))
 		peer.logger.Debug(fmt.Sprintf("peer removed (%v)", c.peerSet.Len()))
 	}()
 
@@ -212,7 +259,9 @@ func (c *Communicator) runPeer(peer *Peer) {
 	case <-peer.Done():
 	case <-c.ctx.Done():
 	case <-c.syncedCh:
-		c.syncTxs(peer)
+		if !c.txRelay.Disabled {
+			c.syncTxs(peer)
+		}
 		select {
 		case <-peer.Done():
 		case <-c.ctx.Done():
@@ -220,6 +269,32 @@ func (c *Communicator) runPeer(peer *Peer) {
 	}
 }
 
+// handshake gets status of the peer, and checks if it's compatible.
+func handshake(ctx context.Context, peer *Peer, genesisID thor.Bytes32) (*proto.Status, error) {
+	// 5sec timeout for handshake
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	status, err := proto.GetStatus(ctx, peer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "get status")
+	}
+	if status.GenesisBlockID != genesisID {
+		return nil, errors.New("genesis id mismatch")
+	}
+	now := uint64(time.Now().Unix())
+	diff := now - status.SysTimestamp
+	if now < status.SysTimestamp {
+		diff = status.SysTimestamp
+	}
+	if diff > thor.BlockInterval {
+		return nil, errors.New("sys time diff too large")
+	}
+	peer.SetCompression(status.HasCap(proto.CapSnappy))
+	peer.compactBlock = status.HasCap(proto.CapCompactBlock)
+	return status, nil
+}
+
 // SubscribeBlock subscribe the event that new block received.
 func (c *Communicator) SubscribeBlock(ch chan *NewBlockEvent) event.Subscription {
 	return c.feedScope.Track(c.newBlockFeed.Subscribe(ch))
@@ -231,7 +306,22 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 		return !p.IsBlockKnown(blk.Header().ID())
 	})
 
+	// blocks are propagated in compact form if accepted, since txs mostly reached peers before the block
+	cb, cbSize := newCompactBlock(blk)
+	size := int(blk.Size())
+
 	p := int(math.Sqrt(float64(len(peers))))
+	// fall back to announcing ID if upload rate exceeded
+	for i := 0; i < p; i++ {
+		n := size
+		if peers[i].compactBlock {
+			n = cbSize
+		}
+		if !c.uploadLimit.allow(n) {
+			p = i
+			break
+		}
+	}
 	toPropagate := peers[:p]
 	toAnnounce := peers[p:]
 
@@ -239,7 +329,13 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 		peer := peer
 		peer.MarkBlock(blk.Header().ID())
 		c.goes.Go(func() {
-			if err := proto.NotifyNewBlock(c.ctx, peer, blk); err != nil {
+			var err error
+			if peer.compactBlock {
+				err = proto.NotifyNewCompactBlock(c.ctx, peer, cb)
+			} else {
+				err = proto.NotifyNewBlock(c.ctx, peer, blk)
+			}
+			if err != nil {
 				peer.logger.Debug("failed to broadcast new block", "err", err)
 			}
 		})
@@ -257,6 +353,50 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 	}
 }
 
+// markSyncStart records the start point to measure sync rate, if not recorded yet.
+func (c *Communicator) markSyncStart(num uint32) {
+	c.syncStart.Lock()
+	defer c.syncStart.Unlock()
+	if c.syncStart.time.IsZero() {
+		c.syncStart.time = time.Now()
+		c.syncStart.num = num
+	}
+}
+
+// resetSyncStart clears the start point once caught up with peers.
+func (c *Communicator) resetSyncStart() {
+	c.syncStart.Lock()
+	defer c.syncStart.Unlock()
+	c.syncStart.time = time.Time{}
+}
+
+// SyncProgress returns progress of block synchronization.
+// Rate and ETA are zero if not syncing.
+func (c *Communicator) SyncProgress() *SyncProgress {
+	best := c.chain.BestBlock().Header().Number()
+	progress := &SyncProgress{Current: best, Target: best}
+	for _, peer := range c.peerSet.Slice() {
+		id, _ := peer.Head()
+		if num := block.Number(id); num > progress.Target {
+			progress.Target = num
+		}
+	}
+
+	c.syncStart.Lock()
+	startTime, startNum := c.syncStart.time, c.syncStart.num
+	c.syncStart.Unlock()
+
+	if !startTime.IsZero() && best > startNum {
+		if elapsed := time.Since(startTime).Seconds(); elapsed > 0 {
+			progress.Rate = float64(best-startNum) / elapsed
+		}
+	}
+	if progress.Rate > 0 && progress.Target > progress.Current {
+		progress.ETA = uint64(float64(progress.Target-progress.Current) / progress.Rate)
+	}
+	return progress
+}
+
 // PeerCount returns count of peers.
 func (c *Communicator) PeerCount() int {
 	return c.peerSet.Len()
@@ -269,12 +409,14 @@ func (c *Communicator) PeersStats() []*PeerStats {
 		bestID, totalScore := peer.Head()
 		stats = append(stats, &PeerStats{
 			Name:        peer.Name(),
+			Enode:       peerEnode(peer),
 			BestBlockID: bestID,
 			TotalScore:  totalScore,
 			PeerID:      peer.ID().String(),
 			NetAddr:     peer.RemoteAddr().String(),
 			Inbound:     peer.Inbound(),
 			Duration:    uint64(time.Duration(peer.Duration()) / time.Second),
+			Score:       peer.Score(),
 		})
 	}
 	sort.Slice(stats, func(i, j int) bool {
@@ -282,3 +424,49 @@ func (c *Communicator) PeersStats() []*PeerStats {
 	})
 	return stats
 }
+
+// DisconnectPeer disconnects the peer with the given node ID.
+// Returns false if no such peer connected.
+func (c *Communicator) DisconnectPeer(id discover.NodeID) bool {
+	peer := c.peerSet.Find(id)
+	if peer == nil {
+		return false
+	}
+	peer.Disconnect(p2p.DiscRequested)
+	return true
+}
+
+// BanPeer disconnects the peer with the given node ID, and refuses it
+// to reconnect until the duration elapsed.
+func (c *Communicator) BanPeer(id discover.NodeID, duration time.Duration) {
+	c.bannedPeers.Lock()
+	c.bannedPeers.m[id] = time.Now().Add(duration)
+	c.bannedPeers.Unlock()
+
+	c.DisconnectPeer(id)
+}
+
+func (c *Communicator) isBanned(id discover.NodeID) bool {
+	c.bannedPeers.Lock()
+	defer c.bannedPeers.Unlock()
+
+	until, ok := c.bannedPeers.m[id]
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(c.bannedPeers.m, id)
+		return false
+	}
+	return true
+}
+
+// peerEnode returns enode url of the peer built from its remote address.
+// For inbound peers, the port is not necessarily the listening port.
+func peerEnode(peer *Peer) string {
+	addr, ok := peer.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return discover.NewNode(peer.ID(), nil, 0, 0).String()
+	}
+	return discover.NewNode(peer.ID(), addr.IP, uint16(addr.Port), uint16(addr.Port)).String()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// maxReconstructing limits compact blocks being reconstructed concurrently.
+const maxReconstructing = 16
+
+var (
+	metricCompactBlocksReconstructed = metric.NewCounter("p2p", "compact_blocks_reconstructed_total", "number of compact blocks reconstructed")
+	metricCompactBlockTxsFetched     = metric.NewCounter("p2p", "compact_block_txs_fetched_total", "number of txs missing in pool and fetched to reconstruct compact blocks")
+)
+
+// newCompactBlock returns the compact form of the block, and its encoded size.
+func newCompactBlock(blk *block.Block) (*proto.CompactBlock, int) {
+	txs := blk.Transactions()
+	cb := &proto.CompactBlock{
+		Header: blk.Header(),
+		TxIDs:  make([]thor.Bytes32, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		cb.TxIDs = append(cb.TxIDs, tx.ID())
+	}
+	data, _ := rlp.EncodeToBytes(cb)
+	return cb, len(data)
+}
+
+// beginReconstruct marks the block as being reconstructed. It returns false if it's already in progress
+// (e.g. the same block received from several peers), or too many in progress.
+func (c *Communicator) beginReconstruct(blockID thor.Bytes32) bool {
+	c.reconstructing.Lock()
+	defer c.reconstructing.Unlock()
+
+	if _, ok := c.reconstructing.m[blockID]; ok {
+		return false
+	}
+	if len(c.reconstructing.m) >= maxReconstructing {
+		metricThrottled.Inc()
+		return false
+	}
+	c.reconstructing.m[blockID] = struct{}{}
+	return true
+}
+
+func (c *Communicator) endReconstruct(blockID thor.Bytes32) {
+	c.reconstructing.Lock()
+	defer c.reconstructing.Unlock()
+	delete(c.reconstructing.m, blockID)
+}
+
+// reconstructBlock rebuilds the block sent by the peer in compact form, with txs in pool and missing
+// ones fetched from the peer. If txs root mismatches, the block is fetched in full as if its ID announced.
+func (c *Communicator) reconstructBlock(peer *Peer, cb *proto.CompactBlock) {
+	blockID := cb.Header.ID()
+	if _, err := c.chain.GetBlockHeader(blockID); err != nil {
+		if !c.chain.IsNotFound(err) {
+			peer.logger.Error("failed to get block header", "err", err)
+		}
+	} else {
+		// already in chain
+		return
+	}
+
+	txs := make(tx.Transactions, len(cb.TxIDs))
+	var missing []uint32
+	for i, id := range cb.TxIDs {
+		if txs[i] = c.txPool.Get(id); txs[i] == nil {
+			missing = append(missing, uint32(i))
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := proto.GetBlockTxs(c.ctx, peer, blockID, missing)
+		if err != nil {
+			peer.logger.Debug("failed to get block txs", "err", err)
+			c.penalizeCallError(peer, err)
+			return
+		}
+		if len(fetched) != len(missing) {
+			peer.logger.Debug("incomplete block txs", "expected", len(missing), "got", len(fetched))
+			c.penalize(peer, penaltyUselessResponse, "incomplete block txs")
+			return
+		}
+		for i, index := range missing {
+			if fetched[i].ID() != cb.TxIDs[index] {
+				c.penalize(peer, penaltyInvalidBlock, "mismatched block txs")
+				return
+			}
+			txs[index] = fetched[i]
+		}
+		metricCompactBlockTxsFetched.Add(float64(len(missing)))
+	}
+
+	// txs with the same ID may still differ in encoding, e.g. malleable signature
+	if txs.RootHash() != cb.Header.TxsRoot() {
+		peer.logger.Debug("txs root mismatch for reconstructed block", "id", blockID)
+		c.fetchFullBlock(peer, blockID)
+		return
+	}
+	metricCompactBlocksReconstructed.Inc()
+
+	c.newBlockFeed.Send(&NewBlockEvent{
+		Block: block.Compose(cb.Header, txs),
+		peer:  peer,
+	})
+}
+
+// fetchFullBlock queues the block ID to be fetched from the peer by announcement loop.
+func (c *Communicator) fetchFullBlock(peer *Peer, blockID thor.Bytes32) {
+	select {
+	case <-c.ctx.Done():
+	case c.announcementCh <- &announcement{blockID, peer}:
+	}
+}
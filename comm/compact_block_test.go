@@ -0,0 +1,29 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
+)
+
+func TestReconstructDedupe(t *testing.T) {
+	c := New(nil, nil, nil)
+
+	id := thor.BytesToBytes32([]byte("block"))
+	assert.True(t, c.beginReconstruct(id))
+	assert.False(t, c.beginReconstruct(id), "already in progress")
+	c.endReconstruct(id)
+	assert.True(t, c.beginReconstruct(id))
+	c.endReconstruct(id)
+
+	for i := 0; i < maxReconstructing; i++ {
+		assert.True(t, c.beginReconstruct(thor.BytesToBytes32([]byte{byte(i)})))
+	}
+	assert.False(t, c.beginReconstruct(id), "too many in progress")
+}
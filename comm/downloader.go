@@ -0,0 +1,305 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
+)
+
+const (
+	maxBodiesPerReq     = 128 // also the size of a body fetching task
+	maxBodyTasks        = 8   // max body fetching tasks in flight
+	maxBodyFetchRetries = 3   // max other peers tried for a task, before falling back to the sync peer
+)
+
+type bodiesResult struct {
+	blocks []*block.Block
+	err    error
+}
+
+// fetchBlocks downloads headers from the sync peer first, then fetches bodies of them from multiple peers in parallel.
+// Blocks are sent to blockCh in order, as soon as all preceding ones arrived, so that validation by the handler
+// is pipelined with downloading.
+// Peers not supporting block bodies fetching are downloaded from in the legacy way, by fetchBlocksByNumber.
+// It returns nil if ctx is done.
+func (c *Communicator) fetchBlocks(ctx context.Context, peer *Peer, fromNum uint32, blockCh chan<- *block.Block) error {
+	if !peer.Supports(proto.MsgGetBlockBodies) {
+		return c.fetchBlocksByNumber(ctx, peer, fromNum, blockCh)
+	}
+	var (
+		lastLog = time.Now()
+		prev    *block.Header
+	)
+	for {
+		headers, err := c.fetchHeaders(ctx, peer, fromNum, prev)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.penalizeCallError(peer, err)
+			return err
+		}
+		if len(headers) == 0 {
+			return nil
+		}
+
+		var chunks [][]*block.Header
+		for i := 0; i < len(headers); i += maxBodiesPerReq {
+			end := i + maxBodiesPerReq
+			if end > len(headers) {
+				end = len(headers)
+			}
+			chunks = append(chunks, headers[i:end])
+		}
+
+		results := make([]chan *bodiesResult, len(chunks))
+		for i := range results {
+			results[i] = make(chan *bodiesResult, 1)
+		}
+		tasksCtx, cancelTasks := context.WithCancel(ctx)
+		sem := make(chan struct{}, maxBodyTasks)
+
+		var goes co.Goes
+		goes.Go(func() {
+			for i, chunk := range chunks {
+				select {
+				case <-tasksCtx.Done():
+					return
+				case sem <- struct{}{}:
+				}
+				i, chunk := i, chunk
+				goes.Go(func() {
+					blocks, err := c.fetchBodies(tasksCtx, peer, chunk)
+					results[i] <- &bodiesResult{blocks, err}
+				})
+			}
+		})
+
+		err = func() error {
+			for _, resultCh := range results {
+				var result *bodiesResult
+				select {
+				case <-ctx.Done():
+					return nil
+				case result = <-resultCh:
+					<-sem
+				}
+				if result.err != nil {
+					return result.err
+				}
+				var size int
+				for _, blk := range result.blocks {
+					size += int(blk.Size())
+				}
+				if err := c.downloadLimit.wait(ctx, size); err != nil {
+					return nil
+				}
+				for _, blk := range result.blocks {
+					select {
+					case <-ctx.Done():
+						return nil
+					case blockCh <- blk:
+					}
+				}
+
+				if time.Since(lastLog) >= progressLogInterval {
+					lastLog = time.Now()
+					p := c.SyncProgress()
+					log.Info("syncing",
+						"current", p.Current,
+						"target", p.Target,
+						"rate", fmt.Sprintf("%.1f blk/s", p.Rate),
+						"eta", time.Duration(p.ETA)*time.Second)
+				}
+			}
+			return nil
+		}()
+		cancelTasks()
+		goes.Wait()
+
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		prev = headers[len(headers)-1]
+		fromNum = prev.Number() + 1
+	}
+}
+
+// fetchBlocksByNumber downloads whole blocks from the sync peer only, batch by batch.
+// It returns nil if ctx is done.
+func (c *Communicator) fetchBlocksByNumber(ctx context.Context, peer *Peer, fromNum uint32, blockCh chan<- *block.Block) error {
+	lastLog := time.Now()
+	for {
+		if time.Since(lastLog) >= progressLogInterval {
+			lastLog = time.Now()
+			p := c.SyncProgress()
+			log.Info("syncing",
+				"current", p.Current,
+				"target", p.Target,
+				"rate", fmt.Sprintf("%.1f blk/s", p.Rate),
+				"eta", time.Duration(p.ETA)*time.Second)
+		}
+		_, span := tracing.Start(ctx, "comm.getBlocks", tracing.Int64("from", int64(fromNum)))
+		result, err := proto.GetBlocksFromNumber(ctx, peer, fromNum)
+		tracing.End(span, err)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.penalizeCallError(peer, err)
+			return err
+		}
+		if len(result) == 0 {
+			return nil
+		}
+
+		var size int
+		for _, raw := range result {
+			size += len(raw)
+		}
+		if err := c.downloadLimit.wait(ctx, size); err != nil {
+			return nil
+		}
+		for _, raw := range result {
+			var blk block.Block
+			if err := rlp.DecodeBytes(raw, &blk); err != nil {
+				return errors.Wrap(err, "invalid block")
+			}
+			if _, err := blk.Header().Signer(); err != nil {
+				return errors.Wrap(err, "invalid block")
+			}
+			if blk.Header().Number() != fromNum {
+				return errors.New("broken sequence")
+			}
+			peer.MarkBlock(blk.Header().ID())
+			fromNum++
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case blockCh <- &blk:
+			}
+		}
+	}
+}
+
+// fetchHeaders fetches a batch of headers starts with fromNum from the peer.
+// Headers are checked to be signed and linked one by one, starting from prev if not nil.
+func (c *Communicator) fetchHeaders(ctx context.Context, peer *Peer, fromNum uint32, prev *block.Header) ([]*block.Header, error) {
+	_, span := tracing.Start(ctx, "comm.getHeaders", tracing.Int64("from", int64(fromNum)))
+	result, err := proto.GetHeadersFromNumber(ctx, peer, fromNum)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*block.Header, 0, len(result))
+	for _, raw := range result {
+		var header block.Header
+		if err := rlp.DecodeBytes(raw, &header); err != nil {
+			return nil, errors.Wrap(err, "invalid header")
+		}
+		if _, err := header.Signer(); err != nil {
+			return nil, errors.Wrap(err, "invalid header")
+		}
+		if header.Number() != fromNum || (prev != nil && header.ParentID() != prev.ID()) {
+			return nil, errors.New("broken sequence")
+		}
+		peer.MarkBlock(header.ID())
+		headers = append(headers, &header)
+		prev = &header
+		fromNum++
+	}
+	return headers, nil
+}
+
+// fetchBodies fetches bodies of the headers, and composes them into blocks.
+// Peers supporting it, whose head is not lower than the last header, are tried in random order,
+// to spread the load, and the sync peer is the last resort.
+func (c *Communicator) fetchBodies(ctx context.Context, syncPeer *Peer, headers []*block.Header) ([]*block.Block, error) {
+	last := headers[len(headers)-1]
+	candidates := c.peerSet.Slice().Filter(func(p *Peer) bool {
+		if p == syncPeer || !p.Supports(proto.MsgGetBlockBodies) {
+			return false
+		}
+		_, totalScore := p.Head()
+		return totalScore >= last.TotalScore()
+	})
+	if len(candidates) > maxBodyFetchRetries {
+		candidates = candidates[:maxBodyFetchRetries]
+	}
+	candidates = append(candidates, syncPeer)
+
+	var err error
+	for _, peer := range candidates {
+		var blocks []*block.Block
+		if blocks, err = fetchBodiesFrom(ctx, peer, headers); err == nil {
+			c.reward(peer)
+			return blocks, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		peer.logger.Debug("failed to fetch block bodies", "err", err)
+		c.penalizeCallError(peer, err)
+	}
+	return nil, err
+}
+
+// fetchBodiesFrom fetches bodies of the headers from the peer, and verifies them by txs root.
+func fetchBodiesFrom(ctx context.Context, peer *Peer, headers []*block.Header) ([]*block.Block, error) {
+	_, span := tracing.Start(ctx, "comm.getBodies",
+		tracing.String("peer", peer.ID().String()),
+		tracing.Int64("from", int64(headers[0].Number())))
+	blocks, err := func() ([]*block.Block, error) {
+		blocks := make([]*block.Block, 0, len(headers))
+		for len(blocks) < len(headers) {
+			pending := headers[len(blocks):]
+			ids := make([]thor.Bytes32, 0, len(pending))
+			for _, header := range pending {
+				ids = append(ids, header.ID())
+			}
+			result, err := proto.GetBlockBodies(ctx, peer, ids)
+			if err != nil {
+				return nil, err
+			}
+			if len(result) == 0 {
+				return nil, errors.WithMessage(errUselessResponse, "bodies unavailable")
+			}
+			if len(result) > len(pending) {
+				return nil, errors.New("too many bodies")
+			}
+			for i, raw := range result {
+				var body block.Body
+				if err := rlp.DecodeBytes(raw, &body); err != nil {
+					return nil, errors.Wrap(err, "invalid body")
+				}
+				header := pending[i]
+				if body.Txs.RootHash() != header.TxsRoot() {
+					return nil, errors.New("txs root mismatch")
+				}
+				peer.MarkBlock(header.ID())
+				blocks = append(blocks, block.Compose(header, body.Txs))
+			}
+		}
+		return blocks, nil
+	}()
+	tracing.End(span, err)
+	return blocks, err
+}
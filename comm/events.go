@@ -14,7 +14,18 @@ import (
 // NewBlockEvent event emitted when received block announcement.
 type NewBlockEvent struct {
 	*block.Block
+	peer *Peer // where the block came from
 }
 
 // HandleBlockStream to handle the stream of downloaded blocks in sync process.
+// If a block fails validation, InvalidBlockError should be returned to penalize the peer providing it.
 type HandleBlockStream func(ctx context.Context, stream <-chan *block.Block) error
+
+// InvalidBlockError wraps the validation error of a block in the stream.
+type InvalidBlockError struct {
+	Err error
+}
+
+func (e *InvalidBlockError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+const (
+	minFastSyncPeers       = 3 // min number of peers connected before fast sync begins
+	fastSyncPeersTimeout   = time.Minute
+	fastSyncAnchorInterval = 2048 // interval of blocks whose IDs are fetched in advance to verify downloaded blocks
+	maxStateNodesPerReq    = 384
+	maxReceiptsPerReq      = 256
+	fastSyncReportPeriod   = 8 * time.Second
+)
+
+var errCheckpointMismatch = errors.New("chain mismatches checkpoint")
+
+// CommitBlock commits a block along with its receipts into chain, without execution.
+type CommitBlock func(blk *block.Block, receipts tx.Receipts) error
+
+// SetFastSyncCheckpoint sets ID of the trusted block to fast sync to. It should be called before Start.
+func (c *Communicator) SetFastSyncCheckpoint(id thor.Bytes32) {
+	c.fastSyncCheckpoint = id
+}
+
+// FastSync downloads blocks with receipts up to the checkpoint block, then downloads the state of it.
+// Since blocks are not executed, they are trusted only by being linked to the checkpoint, which is verified
+// before they are committed.
+// Blocks after the checkpoint are left to the normal sync process, which verifies them by execution.
+// It returns immediately if the best block is not lower than the checkpoint.
+func (c *Communicator) FastSync(ctx context.Context, commit CommitBlock) error {
+	checkpoint := c.fastSyncCheckpoint
+	if checkpoint.IsZero() {
+		return errors.New("no checkpoint to fast sync to")
+	}
+	pivotNum := block.Number(checkpoint)
+
+	peer, err := c.waitFastSyncPeer(ctx, pivotNum)
+	if err != nil {
+		return err
+	}
+	if peer == nil {
+		log.Info("no peer to fast sync, skipped")
+		return nil
+	}
+
+	// resume downloading state of best block, if previous fast sync interrupted
+	best := c.chain.BestBlock().Header()
+	if err := c.syncState(ctx, peer, best); err != nil {
+		return errors.WithMessage(err, "sync state")
+	}
+	if best.Number() >= pivotNum {
+		return nil
+	}
+
+	peer.logger.Info("fast sync started", "pivot", pivotNum, "checkpoint", checkpoint)
+	anchors, err := c.fetchAnchors(ctx, peer, best, checkpoint)
+	if err != nil {
+		if err == errCheckpointMismatch {
+			c.penalize(peer, penaltyInvalidBlock, "checkpoint mismatch")
+		}
+		return errors.WithMessage(err, "fetch anchors")
+	}
+	pivot, err := c.downloadTo(ctx, peer, best, pivotNum, anchors, commit)
+	if err != nil {
+		if err == errCheckpointMismatch {
+			c.penalize(peer, penaltyInvalidBlock, "checkpoint mismatch")
+		}
+		return errors.WithMessage(err, "download blocks")
+	}
+	if err := c.syncState(ctx, peer, pivot); err != nil {
+		return errors.WithMessage(err, "sync state")
+	}
+	peer.logger.Info("fast sync done", "pivot", pivotNum)
+	return nil
+}
+
+// waitFastSyncPeer waits for enough peers connected, and returns the one with highest total score,
+// among those whose head is not lower than the pivot.
+// Once timeout, any connected peer is acceptable, and nil returned if no peer.
+func (c *Communicator) waitFastSyncPeer(ctx context.Context, pivotNum uint32) (*Peer, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	deadline := time.Now().Add(fastSyncPeersTimeout)
+	for {
+		peers := c.peerSet.Slice().Filter(func(p *Peer) bool {
+			headID, _ := p.Head()
+			// messages for fast sync are introduced in version 2
+			return p.Supports(proto.MsgGetStateNodes) && block.Number(headID) >= pivotNum
+		})
+		timeout := time.Now().After(deadline)
+		if len(peers) >= minFastSyncPeers || (timeout && len(peers) > 0) {
+			var best *Peer
+			var bestScore uint64
+			for _, peer := range peers {
+				if _, totalScore := peer.Head(); best == nil || totalScore > bestScore {
+					best, bestScore = peer, totalScore
+				}
+			}
+			return best, nil
+		}
+		if timeout {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchAnchors fetches headers backward from the checkpoint to the block next to parent, and verifies that
+// they are linked one by one. It returns IDs of every fastSyncAnchorInterval-th block, the checkpoint included,
+// as anchors to verify blocks downloaded later.
+func (c *Communicator) fetchAnchors(ctx context.Context, peer *Peer, parent *block.Header, checkpoint thor.Bytes32) (map[uint32]thor.Bytes32, error) {
+	anchors := map[uint32]thor.Bytes32{block.Number(checkpoint): checkpoint}
+	expected := checkpoint
+	for end := block.Number(checkpoint); end > parent.Number(); {
+		start := parent.Number() + 1
+		if end-start >= fastSyncAnchorInterval {
+			start = end - fastSyncAnchorInterval + 1
+		}
+		headers, err := fetchHeaderRange(ctx, peer, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(headers) - 1; i >= 0; i-- {
+			if headers[i].ID() != expected {
+				return nil, errCheckpointMismatch
+			}
+			expected = headers[i].ParentID()
+		}
+		end = start - 1
+		anchors[end] = expected
+	}
+	if expected != parent.ID() {
+		return nil, errCheckpointMismatch
+	}
+	return anchors, nil
+}
+
+// fetchHeaderRange fetches headers numbered from start to end (inclusive) from the peer.
+func fetchHeaderRange(ctx context.Context, peer *Peer, start, end uint32) ([]*block.Header, error) {
+	headers := make([]*block.Header, 0, end-start+1)
+	for num := start; num <= end; {
+		result, err := proto.GetHeadersFromNumber(ctx, peer, num)
+		if err != nil {
+			return nil, err
+		}
+		if len(result) == 0 {
+			return nil, errors.New("unexpected end of headers")
+		}
+		for _, raw := range result {
+			if num > end {
+				break
+			}
+			var header block.Header
+			if err := rlp.DecodeBytes(raw, &header); err != nil {
+				return nil, errors.Wrap(err, "invalid header")
+			}
+			if header.Number() != num {
+				return nil, errors.New("broken sequence")
+			}
+			headers = append(headers, &header)
+			num++
+		}
+	}
+	return headers, nil
+}
+
+// downloadTo downloads blocks and receipts after parent up to toNum, and returns header of the last block.
+// Blocks are buffered until one of them matches the anchor, and then committed.
+func (c *Communicator) downloadTo(ctx context.Context, peer *Peer, parent *block.Header, toNum uint32, anchors map[uint32]thor.Bytes32, commit CommitBlock) (*block.Header, error) {
+	reportTime := time.Now()
+	var pending []*block.Block // blocks not verified by anchor yet
+	for parent.Number() < toNum {
+		result, err := proto.GetBlocksFromNumber(ctx, peer, parent.Number()+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(result) == 0 {
+			return nil, errors.New("unexpected end of blocks")
+		}
+		if remain := toNum - parent.Number(); uint32(len(result)) > remain {
+			result = result[:remain]
+		}
+
+		for _, raw := range result {
+			var blk block.Block
+			if err := rlp.DecodeBytes(raw, &blk); err != nil {
+				return nil, errors.Wrap(err, "invalid block")
+			}
+			if err := verifyFastSyncBlock(&blk, parent); err != nil {
+				return nil, errors.Wrap(err, "invalid block")
+			}
+			peer.MarkBlock(blk.Header().ID())
+			pending = append(pending, &blk)
+			parent = blk.Header()
+
+			if anchor, ok := anchors[parent.Number()]; ok {
+				if parent.ID() != anchor {
+					return nil, errCheckpointMismatch
+				}
+				if err := c.commitFastSyncBlocks(ctx, peer, pending, commit); err != nil {
+					return nil, err
+				}
+				pending = nil
+			}
+		}
+
+		if time.Since(reportTime) > fastSyncReportPeriod {
+			peer.logger.Info("fast sync downloading blocks", "number", parent.Number(), "pivot", toNum)
+			reportTime = time.Now()
+		}
+	}
+	return parent, nil
+}
+
+// commitFastSyncBlocks downloads receipts of verified blocks, and commits them.
+func (c *Communicator) commitFastSyncBlocks(ctx context.Context, peer *Peer, blocks []*block.Block, commit CommitBlock) error {
+	receipts, err := c.downloadReceipts(ctx, peer, blocks)
+	if err != nil {
+		return err
+	}
+	for i, blk := range blocks {
+		if err := commit(blk, receipts[i]); err != nil {
+			return errors.WithMessage(err, "commit block")
+		}
+	}
+	return nil
+}
+
+func verifyFastSyncBlock(blk *block.Block, parent *block.Header) error {
+	header := blk.Header()
+	if header.ParentID() != parent.ID() {
+		return errors.New("broken sequence")
+	}
+	if _, err := header.Signer(); err != nil {
+		return err
+	}
+	if header.TxsRoot() != blk.Transactions().RootHash() {
+		return fmt.Errorf("txs root mismatch: want %v, have %v", header.TxsRoot(), blk.Transactions().RootHash())
+	}
+	return nil
+}
+
+func (c *Communicator) downloadReceipts(ctx context.Context, peer *Peer, blocks []*block.Block) ([]tx.Receipts, error) {
+	all := make([]tx.Receipts, 0, len(blocks))
+	for len(all) < len(blocks) {
+		pending := blocks[len(all):]
+		if len(pending) > maxReceiptsPerReq {
+			pending = pending[:maxReceiptsPerReq]
+		}
+		ids := make([]thor.Bytes32, 0, len(pending))
+		for _, blk := range pending {
+			ids = append(ids, blk.Header().ID())
+		}
+
+		result, err := proto.GetBlockReceipts(ctx, peer, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(result) == 0 || len(result) > len(ids) {
+			return nil, errors.New("unexpected receipts count")
+		}
+		for i, raw := range result {
+			var receipts tx.Receipts
+			if err := rlp.DecodeBytes(raw, &receipts); err != nil {
+				return nil, errors.Wrap(err, "invalid receipts")
+			}
+			header := pending[i].Header()
+			if len(receipts) != len(pending[i].Transactions()) {
+				return nil, errors.New("receipts count mismatch")
+			}
+			if root := receipts.RootHash(); root != header.ReceiptsRoot() {
+				return nil, fmt.Errorf("receipts root mismatch: want %v, have %v", header.ReceiptsRoot(), root)
+			}
+			all = append(all, receipts)
+		}
+	}
+	return all, nil
+}
+
+// isStateNode returns whether data is a state trie node or code, keyed by its hash.
+// Trie nodes are hashed by blake2b, and codes by keccak256.
+func isStateNode(hash thor.Bytes32, data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	return thor.Blake2b(data) == hash || thor.Bytes32(crypto.Keccak256Hash(data)) == hash
+}
+
+// syncState downloads the whole state of the block, if not present locally.
+func (c *Communicator) syncState(ctx context.Context, peer *Peer, header *block.Header) error {
+	root := header.StateRoot()
+	// root node is committed after all its descendants, so the state is complete if root presents
+	if has, err := c.stateDB.Has(root[:]); err != nil {
+		return err
+	} else if has {
+		return nil
+	}
+
+	peer.logger.Info("fast sync downloading state", "number", header.Number(), "root", root)
+	sync := state.NewSync(root, c.stateDB)
+	reportTime := time.Now()
+	count := 0
+	for sync.Pending() > 0 {
+		hashes := sync.Missing(maxStateNodesPerReq)
+		if len(hashes) == 0 {
+			return errors.New("state sync stalled")
+		}
+		nodes, err := proto.GetStateNodes(ctx, peer, hashes)
+		if err != nil {
+			return err
+		}
+		if len(nodes) != len(hashes) {
+			return errors.New("unexpected state nodes count")
+		}
+		for i, data := range nodes {
+			if len(data) == 0 {
+				return errors.New("state node not available")
+			}
+			if err := sync.Process(hashes[i], data); err != nil {
+				return errors.WithMessage(err, "process state node")
+			}
+		}
+
+		batch := c.stateDB.NewBatch()
+		if _, err := sync.Commit(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		count += len(nodes)
+
+		if time.Since(reportTime) > fastSyncReportPeriod {
+			peer.logger.Info("fast sync downloading state", "nodes", count, "pending", sync.Pending())
+			reportTime = time.Now()
+		}
+	}
+	peer.logger.Info("fast sync state downloaded", "number", header.Number(), "nodes", count)
+	return nil
+}
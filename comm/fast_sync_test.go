@@ -0,0 +1,23 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
+)
+
+func TestIsStateNode(t *testing.T) {
+	data := []byte("node")
+	assert.True(t, isStateNode(thor.Blake2b(data), data))
+	assert.True(t, isStateNode(thor.Bytes32(crypto.Keccak256Hash(data)), data))
+
+	assert.False(t, isStateNode(thor.BytesToBytes32([]byte("key")), data), "not content-addressed")
+	assert.False(t, isStateNode(thor.Blake2b(nil), nil))
+}
@@ -15,8 +15,10 @@ import (
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/comm/proto"
 	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
 )
 
 // peer will be disconnected if error returned
@@ -38,21 +40,47 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 		}
 
 		best := c.chain.BestBlock().Header()
-		write(&proto.Status{
+		status := &proto.Status{
 			GenesisBlockID: c.chain.GenesisBlock().Header().ID(),
 			SysTimestamp:   uint64(time.Now().Unix()),
 			TotalScore:     best.TotalScore(),
 			BestBlockID:    best.ID(),
-		})
+		}
+		// peers of version 1 fail to decode status with caps
+		if peer.version >= proto.Version {
+			status.Caps = []string{proto.CapSnappy, proto.CapCompactBlock}
+		}
+		write(status)
 	case proto.MsgNewBlock:
 		var newBlock *block.Block
 		if err := msg.Decode(&newBlock); err != nil {
 			return errors.WithMessage(err, "decode msg")
 		}
 
+		c.downloadLimit.consume(int(msg.Size))
 		peer.MarkBlock(newBlock.Header().ID())
 		peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
-		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock})
+		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock, peer: peer})
+		write(&struct{}{})
+	case proto.MsgNewCompactBlock:
+		var cb proto.CompactBlock
+		if err := msg.Decode(&cb); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if cb.Header == nil {
+			return errors.New("nil header")
+		}
+
+		c.downloadLimit.consume(int(msg.Size))
+		peer.MarkBlock(cb.Header.ID())
+		peer.UpdateHead(cb.Header.ID(), cb.Header.TotalScore())
+		// missing txs are fetched by calls to the peer, which should not block its message loop
+		if blockID := cb.Header.ID(); c.beginReconstruct(blockID) {
+			c.goes.Go(func() {
+				defer c.endReconstruct(blockID)
+				c.reconstructBlock(peer, &cb)
+			})
+		}
 		write(&struct{}{})
 	case proto.MsgNewBlockID:
 		var newBlockID thor.Bytes32
@@ -70,8 +98,16 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 		if err := msg.Decode(&newTx); err != nil {
 			return errors.WithMessage(err, "decode msg")
 		}
+
+		// the tx is already received, dropping it saves no bandwidth, so it's only accounted
+		c.downloadLimit.consume(int(msg.Size))
 		peer.MarkTransaction(newTx.ID())
-		c.txPool.Add(newTx)
+		// txs are dropped if rejected by relay policy
+		if c.acceptTx(peer, newTx) {
+			if err := c.txPool.Add(newTx); txpool.IsBadTx(err) {
+				c.penalize(peer, penaltyInvalidTx, "invalid tx")
+			}
+		}
 		write(&struct{}{})
 	case proto.MsgGetBlockByID:
 		var blockID thor.Bytes32
@@ -131,7 +167,7 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			return errors.WithMessage(err, "decode msg")
 		}
 
-		if txsToSync.synced {
+		if txsToSync.synced || c.txRelay.Disabled {
 			write(tx.Transactions(nil))
 		} else {
 			if len(txsToSync.txs) == 0 {
@@ -146,7 +182,7 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 
 			for _, tx := range txsToSync.txs {
 				n++
-				if peer.IsTransactionKnown(tx.ID()) {
+				if peer.IsTransactionKnown(tx.ID()) || !c.relayable(tx) {
 					continue
 				}
 				peer.MarkTransaction(tx.ID())
@@ -164,6 +200,171 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			}
 			write(toSend)
 		}
+	case proto.MsgGetStateNodes:
+		const maxNodes = 384
+		var hashes []thor.Bytes32
+		if err := msg.Decode(&hashes); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(hashes) > maxNodes {
+			return errors.New("too many hashes")
+		}
+		result := make([][]byte, 0, len(hashes))
+		for _, hash := range hashes {
+			data, err := c.stateDB.Get(hash[:])
+			if err != nil && !c.stateDB.IsNotFound(err) {
+				log.Error("failed to get state node", "err", err)
+			}
+			// the db is shared with other data, so only content-addressed entries are served
+			if !isStateNode(hash, data) {
+				data = nil
+			}
+			result = append(result, data)
+		}
+		write(result)
+	case proto.MsgGetBlockReceipts:
+		const maxBlocks = 256
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(ids) > maxBlocks {
+			return errors.New("too many block ids")
+		}
+		result := make([]rlp.RawValue, 0, len(ids))
+		var size metric.StorageSize
+		for _, id := range ids {
+			if size >= maxResultSize {
+				break
+			}
+			receipts, err := c.chain.GetBlockReceipts(id)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block receipts", "err", err)
+				}
+				break
+			}
+			raw, err := rlp.EncodeToBytes(receipts)
+			if err != nil {
+				log.Error("failed to encode block receipts", "err", err)
+				break
+			}
+			result = append(result, rlp.RawValue(raw))
+			size += metric.StorageSize(len(raw))
+		}
+		write(result)
+	case proto.MsgGetHeadersFromNumber:
+		var num uint32
+		if err := msg.Decode(&num); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxHeaders = 2048
+		result := make([]rlp.RawValue, 0, maxHeaders)
+		for len(result) < maxHeaders {
+			header, err := c.chain.GetTrunkBlockHeader(num)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block header by number", "err", err)
+				}
+				break
+			}
+			raw, err := rlp.EncodeToBytes(header)
+			if err != nil {
+				log.Error("failed to encode block header", "err", err)
+				break
+			}
+			result = append(result, rlp.RawValue(raw))
+			num++
+		}
+		write(result)
+	case proto.MsgGetAccountProof:
+		var req proto.AccountProofRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		var result [][]byte
+		header, err := c.chain.GetBlockHeader(req.BlockID)
+		if err != nil {
+			if !c.chain.IsNotFound(err) {
+				log.Error("failed to get block header", "err", err)
+			}
+		} else if proof, err := state.ProveAccount(c.stateDB, header.StateRoot(), req.Address); err != nil {
+			// state may be pruned
+			log.Debug("failed to prove account", "err", err)
+		} else {
+			result = proof
+		}
+		write(result)
+	case proto.MsgGetReceiptProof:
+		var req proto.ReceiptProofRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		var result [][]byte
+		receipts, err := c.chain.GetBlockReceipts(req.BlockID)
+		if err != nil {
+			if !c.chain.IsNotFound(err) {
+				log.Error("failed to get block receipts", "err", err)
+			}
+		} else if proof, err := receipts.Prove(int(req.Index)); err != nil {
+			log.Error("failed to prove receipt", "err", err)
+		} else {
+			result = proof
+		}
+		write(result)
+	case proto.MsgGetBlockBodies:
+		const maxBlocks = 256
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(ids) > maxBlocks {
+			return errors.New("too many block ids")
+		}
+		result := make([]rlp.RawValue, 0, len(ids))
+		var size metric.StorageSize
+		for _, id := range ids {
+			if size >= maxResultSize {
+				break
+			}
+			body, err := c.chain.GetBlockBody(id)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block body", "err", err)
+				}
+				break
+			}
+			raw, err := rlp.EncodeToBytes(body)
+			if err != nil {
+				log.Error("failed to encode block body", "err", err)
+				break
+			}
+			result = append(result, rlp.RawValue(raw))
+			size += metric.StorageSize(len(raw))
+		}
+		write(result)
+	case proto.MsgGetBlockTxs:
+		var req proto.BlockTxsRequest
+		if err := msg.Decode(&req); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		var result tx.Transactions
+		blk, err := c.chain.GetBlock(req.BlockID)
+		if err != nil {
+			if !c.chain.IsNotFound(err) {
+				log.Error("failed to get block", "err", err)
+			}
+		} else {
+			txs := blk.Transactions()
+			for _, index := range req.Indexes {
+				if int(index) >= len(txs) {
+					return errors.New("tx index out of range")
+				}
+				result = append(result, txs[index])
+			}
+		}
+		write(result)
 	default:
 		return fmt.Errorf("unknown message (%v)", msg.Code)
 	}
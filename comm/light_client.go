@@ -0,0 +1,336 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/light"
+	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+	"github.com/vechain/thor/tx"
+)
+
+const (
+	lightSyncInterval = 10 * time.Second
+	maxPendingHeaders = 65536 // max headers of a fork kept in memory, before it outscores the trunk
+)
+
+var errNoPeer = errors.New("no peer available")
+
+// LightClient syncs headers from full nodes, and requests merkle proofs of accounts and receipts from them,
+// without storing blocks and states.
+// It speaks the same protocol as Communicator, but serves nothing except status and trunk block IDs.
+type LightClient struct {
+	chain     *light.HeaderChain
+	ctx       context.Context
+	cancel    context.CancelFunc
+	peerSet   *PeerSet
+	syncReqCh chan struct{}
+	goes      co.Goes
+}
+
+// NewLightClient create a new LightClient instance.
+func NewLightClient(chain *light.HeaderChain) *LightClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LightClient{
+		chain:     chain,
+		ctx:       ctx,
+		cancel:    cancel,
+		peerSet:   newPeerSet(),
+		syncReqCh: make(chan struct{}, 1),
+	}
+}
+
+// Protocols returns all supported protocols.
+// Only the latest version is supported, since full nodes of version 1 serve no headers.
+func (lc *LightClient) Protocols() []*p2psrv.Protocol {
+	genesisID := lc.chain.Genesis().ID()
+	return []*p2psrv.Protocol{
+		&p2psrv.Protocol{
+			Protocol: p2p.Protocol{
+				Name:    proto.Name,
+				Version: proto.Version,
+				Length:  proto.Length,
+				Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+					return lc.servePeer(newPeer(p, rw, proto.Version, proto.Length))
+				},
+			},
+			DiscTopic: fmt.Sprintf("%v%v@%x", proto.Name, proto.Version, genesisID[24:]),
+		}}
+}
+
+// Start start the light client.
+func (lc *LightClient) Start() {
+	lc.goes.Go(lc.syncLoop)
+}
+
+// Stop stop the light client.
+func (lc *LightClient) Stop() {
+	lc.cancel()
+	lc.goes.Wait()
+}
+
+// Chain returns the header chain.
+func (lc *LightClient) Chain() *light.HeaderChain {
+	return lc.chain
+}
+
+// PeerCount returns count of peers.
+func (lc *LightClient) PeerCount() int {
+	return lc.peerSet.Len()
+}
+
+// GetAccount requests the account in state of the block from peers, and verifies it against the state root.
+// The header should be a recent one, since full nodes may prune old states.
+func (lc *LightClient) GetAccount(ctx context.Context, header *block.Header, addr thor.Address) (*state.Account, error) {
+	err := errNoPeer
+	for _, peer := range lc.peerSet.Slice() {
+		var proof [][]byte
+		if proof, err = proto.GetAccountProof(ctx, peer, header.ID(), addr); err != nil {
+			continue
+		}
+		if len(proof) == 0 {
+			err = errors.New("proof unavailable")
+			continue
+		}
+		var acc *state.Account
+		if acc, err = state.VerifyAccountProof(header.StateRoot(), addr, trie.ProofList(proof)); err != nil {
+			peer.logger.Debug("invalid account proof", "err", err)
+			continue
+		}
+		return acc, nil
+	}
+	return nil, err
+}
+
+// GetReceipt requests the receipt at index in the block from peers, and verifies it against the receipts root.
+// It returns nil if index out of range.
+func (lc *LightClient) GetReceipt(ctx context.Context, header *block.Header, index uint32) (*tx.Receipt, error) {
+	err := errNoPeer
+	for _, peer := range lc.peerSet.Slice() {
+		var proof [][]byte
+		if proof, err = proto.GetReceiptProof(ctx, peer, header.ID(), index); err != nil {
+			continue
+		}
+		if len(proof) == 0 {
+			err = errors.New("proof unavailable")
+			continue
+		}
+		var receipt *tx.Receipt
+		if receipt, err = tx.VerifyReceiptProof(header.ReceiptsRoot(), int(index), trie.ProofList(proof)); err != nil {
+			peer.logger.Debug("invalid receipt proof", "err", err)
+			continue
+		}
+		return receipt, nil
+	}
+	return nil, err
+}
+
+func (lc *LightClient) servePeer(peer *Peer) error {
+	lc.goes.Go(func() {
+		lc.runPeer(peer)
+	})
+	return peer.Serve(func(msg *p2p.Msg, w func(interface{})) error {
+		return lc.handleRPC(peer, msg, w)
+	}, proto.MaxMsgSize)
+}
+
+func (lc *LightClient) runPeer(peer *Peer) {
+	defer peer.Disconnect(p2p.DiscRequested)
+
+	status, err := handshake(lc.ctx, peer, lc.chain.Genesis().ID())
+	if err != nil {
+		peer.logger.Debug("failed to handshake", "err", err)
+		return
+	}
+	peer.UpdateHead(status.BestBlockID, status.TotalScore)
+	lc.peerSet.Add(peer)
+	peer.logger.Debug(fmt.Sprintf("peer added (%v)", lc.peerSet.Len()))
+	defer func() {
+		lc.peerSet.Remove(peer.ID())
+		peer.logger.Debug(fmt.Sprintf("peer removed (%v)", lc.peerSet.Len()))
+	}()
+
+	lc.requestSync()
+	select {
+	case <-peer.Done():
+	case <-lc.ctx.Done():
+	}
+}
+
+// handleRPC serves calls from full nodes.
+// Since no block is stored, it claims genesis as the best block, so that full nodes never sync from it.
+func (lc *LightClient) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{})) error {
+	switch msg.Code {
+	case proto.MsgGetStatus:
+		if err := msg.Decode(&struct{}{}); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		write(&proto.Status{
+			GenesisBlockID: lc.chain.Genesis().ID(),
+			SysTimestamp:   uint64(time.Now().Unix()),
+			BestBlockID:    lc.chain.Genesis().ID(),
+			Caps:           []string{proto.CapSnappy, proto.CapCompactBlock},
+		})
+	case proto.MsgNewBlock:
+		var newBlock *block.Block
+		if err := msg.Decode(&newBlock); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		header := newBlock.Header()
+		peer.MarkBlock(header.ID())
+		peer.UpdateHead(header.ID(), header.TotalScore())
+		if header.ParentID() != lc.chain.Best().ID() || lc.chain.Insert([]*block.Header{header}) != nil {
+			lc.requestSync()
+		}
+		write(&struct{}{})
+	case proto.MsgNewCompactBlock:
+		var cb proto.CompactBlock
+		if err := msg.Decode(&cb); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if cb.Header == nil {
+			return errors.New("nil header")
+		}
+		// header is all a light client needs
+		header := cb.Header
+		peer.MarkBlock(header.ID())
+		peer.UpdateHead(header.ID(), header.TotalScore())
+		if header.ParentID() != lc.chain.Best().ID() || lc.chain.Insert([]*block.Header{header}) != nil {
+			lc.requestSync()
+		}
+		write(&struct{}{})
+	case proto.MsgNewBlockID:
+		var newBlockID thor.Bytes32
+		if err := msg.Decode(&newBlockID); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		peer.MarkBlock(newBlockID)
+		lc.requestSync()
+		write(&struct{}{})
+	case proto.MsgNewTx:
+		write(&struct{}{})
+	case proto.MsgGetBlockIDByNumber:
+		var num uint32
+		if err := msg.Decode(&num); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		header, err := lc.chain.GetHeader(num)
+		if err != nil {
+			write(thor.Bytes32{})
+		} else {
+			write(header.ID())
+		}
+	case proto.MsgGetBlockByID, proto.MsgGetBlocksFromNumber, proto.MsgGetBlockReceipts, proto.MsgGetHeadersFromNumber, proto.MsgGetBlockBodies:
+		write([]rlp.RawValue(nil))
+	case proto.MsgGetTxs, proto.MsgGetBlockTxs:
+		write(tx.Transactions(nil))
+	case proto.MsgGetStateNodes, proto.MsgGetAccountProof, proto.MsgGetReceiptProof:
+		write([][]byte(nil))
+	default:
+		return fmt.Errorf("unknown message (%v)", msg.Code)
+	}
+	return nil
+}
+
+func (lc *LightClient) requestSync() {
+	select {
+	case lc.syncReqCh <- struct{}{}:
+	default:
+	}
+}
+
+func (lc *LightClient) syncLoop() {
+	ticker := time.NewTicker(lightSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lc.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-lc.syncReqCh:
+		}
+
+		best := lc.chain.Best()
+		// choose peer which has the head block with the highest total score
+		var peer *Peer
+		var peerScore uint64
+		for _, p := range lc.peerSet.Slice() {
+			if _, totalScore := p.Head(); totalScore > best.TotalScore() && totalScore > peerScore {
+				peer, peerScore = p, totalScore
+			}
+		}
+		if peer == nil {
+			continue
+		}
+		if err := lc.syncHeaders(peer); err != nil {
+			peer.logger.Debug("failed to sync headers", "err", err)
+			continue
+		}
+		if newBest := lc.chain.Best(); newBest.ID() != best.ID() {
+			log.Info("headers synced", "best", newBest.Number(), "id", newBest.ID())
+		}
+	}
+}
+
+func (lc *LightClient) syncHeaders(peer *Peer) error {
+	getTrunkID := func(num uint32) (thor.Bytes32, error) {
+		header, err := lc.chain.GetHeader(num)
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		return header.ID(), nil
+	}
+	ancestor, err := findCommonAncestor(lc.ctx, peer, lc.chain.Best().Number(), getTrunkID)
+	if err != nil {
+		return errors.WithMessage(err, "find common ancestor")
+	}
+
+	// headers of a fork are kept until they outscore the trunk
+	var pending []*block.Header
+	fromNum := ancestor + 1
+	for {
+		result, err := proto.GetHeadersFromNumber(lc.ctx, peer, fromNum)
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		for _, raw := range result {
+			var header block.Header
+			if err := rlp.DecodeBytes(raw, &header); err != nil {
+				return errors.Wrap(err, "invalid header")
+			}
+			if header.Number() != fromNum {
+				return errors.New("broken sequence")
+			}
+			peer.MarkBlock(header.ID())
+			pending = append(pending, &header)
+			fromNum++
+		}
+		if pending[len(pending)-1].TotalScore() > lc.chain.Best().TotalScore() {
+			if err := lc.chain.Insert(pending); err != nil {
+				return err
+			}
+			pending = nil
+		} else if len(pending) > maxPendingHeaders {
+			return errors.New("too many pending headers")
+		}
+	}
+}
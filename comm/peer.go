@@ -32,7 +32,9 @@ func init() {
 type Peer struct {
 	*p2p.Peer
 	*rpc.RPC
-	logger log15.Logger
+	logger  log15.Logger
+	version uint   // negotiated protocol version
+	length  uint64 // count of messages of the protocol version
 
 	createdTime mclock.AbsTime
 	knownTxs    *lru.Cache
@@ -42,9 +44,15 @@ type Peer struct {
 		id         thor.Bytes32
 		totalScore uint64
 	}
+	score struct {
+		sync.Mutex
+		value int
+	}
+	txLimiter    *bandwidthLimiter // limits rate of txs accepted from the peer, nil if unlimited
+	compactBlock bool              // whether the peer accepts new blocks in compact form, set on handshake
 }
 
-func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter, version uint, length uint64) *Peer {
 	dir := "outbound"
 	if peer.Inbound() {
 		dir = "inbound"
@@ -52,17 +60,28 @@ func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
 	ctx := []interface{}{
 		"peer", peer,
 		"dir", dir,
+		"ver", version,
 	}
 	knownTxs, _ := lru.New(maxKnownTxs)
 	knownBlocks, _ := lru.New(maxKnownBlocks)
-	return &Peer{
+	p := &Peer{
 		Peer:        peer,
 		RPC:         rpc.New(peer, rw),
 		logger:      log.New(ctx...),
+		version:     version,
+		length:      length,
 		createdTime: mclock.Now(),
 		knownTxs:    knownTxs,
 		knownBlocks: knownBlocks,
 	}
+	p.score.value = initialPeerScore
+	return p
+}
+
+// Supports returns whether the message is in the protocol version negotiated with the peer.
+// Peers disconnect on messages unknown to them.
+func (p *Peer) Supports(msgCode uint64) bool {
+	return msgCode < p.length
 }
 
 // Head returns head block ID and total score.
@@ -81,6 +100,25 @@ func (p *Peer) UpdateHead(id thor.Bytes32, totalScore uint64) {
 	}
 }
 
+// Score returns the reputation score.
+func (p *Peer) Score() int {
+	p.score.Lock()
+	defer p.score.Unlock()
+	return p.score.value
+}
+
+// adjustScore adds delta to the reputation score, which never exceeds maxPeerScore.
+// The new score is returned.
+func (p *Peer) adjustScore(delta int) int {
+	p.score.Lock()
+	defer p.score.Unlock()
+	p.score.value += delta
+	if p.score.value > maxPeerScore {
+		p.score.value = maxPeerScore
+	}
+	return p.score.value
+}
+
 // MarkTransaction marks a transaction to known.
 func (p *Peer) MarkTransaction(id thor.Bytes32) {
 	p.knownTxs.Add(id, struct{}{})
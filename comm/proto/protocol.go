@@ -12,9 +12,13 @@ import (
 // Constants
 const (
 	Name              = "thor"
-	Version    uint   = 1
-	Length     uint64 = 8
+	Version    uint   = 2
+	Length     uint64 = 16
 	MaxMsgSize        = 10 * 1024 * 1024
+
+	// Version1 is still served for peers not upgraded, with messages before MsgGetStateNodes only.
+	Version1 uint   = 1
+	Length1  uint64 = 8
 )
 
 // Protocol messages of thor
@@ -27,6 +31,14 @@ const (
 	MsgGetBlockIDByNumber
 	MsgGetBlocksFromNumber // fetch blocks from given number (including given number)
 	MsgGetTxs
+	MsgGetStateNodes        // fetch state trie nodes or codes by hashes, since version 2
+	MsgGetBlockReceipts     // fetch receipts of blocks by IDs
+	MsgGetHeadersFromNumber // fetch headers from given number (including given number), for light client
+	MsgGetAccountProof      // fetch merkle proof of an account in state of a block, for light client
+	MsgGetReceiptProof      // fetch merkle proof of a receipt in a block, for light client
+	MsgGetBlockBodies       // fetch bodies of blocks by IDs
+	MsgNewCompactBlock      // new block with header and tx IDs only
+	MsgGetBlockTxs          // fetch txs of a block by indexes, to reconstruct compact block
 )
 
 // MsgName convert msg code to string.
@@ -48,6 +60,22 @@ func MsgName(msgCode uint64) string {
 		return "MsgGetBlocksFromNumber"
 	case MsgGetTxs:
 		return "MsgGetTxs"
+	case MsgGetStateNodes:
+		return "MsgGetStateNodes"
+	case MsgGetBlockReceipts:
+		return "MsgGetBlockReceipts"
+	case MsgGetHeadersFromNumber:
+		return "MsgGetHeadersFromNumber"
+	case MsgGetAccountProof:
+		return "MsgGetAccountProof"
+	case MsgGetReceiptProof:
+		return "MsgGetReceiptProof"
+	case MsgGetBlockBodies:
+		return "MsgGetBlockBodies"
+	case MsgNewCompactBlock:
+		return "MsgNewCompactBlock"
+	case MsgGetBlockTxs:
+		return "MsgGetBlockTxs"
 	default:
 		return fmt.Sprintf("unknown msg code(%v)", msgCode)
 	}
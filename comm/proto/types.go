@@ -22,9 +22,51 @@ type (
 		SysTimestamp   uint64
 		BestBlockID    thor.Bytes32
 		TotalScore     uint64
+		Caps           []string `rlp:"tail"` // optional capabilities, e.g. CapSnappy, since version 2
 	}
+
+	// AccountProofRequest arg of MsgGetAccountProof.
+	AccountProofRequest struct {
+		BlockID thor.Bytes32
+		Address thor.Address
+	}
+
+	// ReceiptProofRequest arg of MsgGetReceiptProof.
+	ReceiptProofRequest struct {
+		BlockID thor.Bytes32
+		Index   uint32
+	}
+
+	// CompactBlock arg of MsgNewCompactBlock.
+	// Receiver reconstructs the block with txs in its pool, and fetches missing ones by MsgGetBlockTxs.
+	CompactBlock struct {
+		Header *block.Header
+		TxIDs  []thor.Bytes32
+	}
+
+	// BlockTxsRequest arg of MsgGetBlockTxs.
+	BlockTxsRequest struct {
+		BlockID thor.Bytes32
+		Indexes []uint32
+	}
+)
+
+// Capabilities advertised in status.
+const (
+	CapSnappy       = "snappy"        // accepting snappy compressed payloads
+	CapCompactBlock = "compact-block" // accepting new blocks in compact form by MsgNewCompactBlock
 )
 
+// HasCap returns whether the capability is advertised in status.
+func (s *Status) HasCap(cap string) bool {
+	for _, c := range s.Caps {
+		if c == cap {
+			return true
+		}
+	}
+	return false
+}
+
 // RPC defines RPC interface.
 type RPC interface {
 	Notify(ctx context.Context, msgCode uint64, arg interface{}) error
@@ -50,6 +92,11 @@ func NotifyNewBlock(ctx context.Context, rpc RPC, block *block.Block) error {
 	return rpc.Notify(ctx, MsgNewBlock, block)
 }
 
+// NotifyNewCompactBlock notify new block in compact form to remote peer.
+func NotifyNewCompactBlock(ctx context.Context, rpc RPC, cb *CompactBlock) error {
+	return rpc.Notify(ctx, MsgNewCompactBlock, cb)
+}
+
 // NotifyNewTx notify new tx to remote peer.
 func NotifyNewTx(ctx context.Context, rpc RPC, tx *tx.Transaction) error {
 	return rpc.Notify(ctx, MsgNewTx, tx)
@@ -94,3 +141,72 @@ func GetTxs(ctx context.Context, rpc RPC) (tx.Transactions, error) {
 	}
 	return txs, nil
 }
+
+// GetStateNodes get state trie nodes or codes by hashes from remote peer.
+// The result is in the same order as hashes, and empty item for unknown hash.
+func GetStateNodes(ctx context.Context, rpc RPC, hashes []thor.Bytes32) ([][]byte, error) {
+	var nodes [][]byte
+	if err := rpc.Call(ctx, MsgGetStateNodes, hashes, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetBlockReceipts get receipts of blocks by IDs from remote peer.
+// The result is in the same order as ids, and may be truncated.
+func GetBlockReceipts(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]rlp.RawValue, error) {
+	var receipts []rlp.RawValue
+	if err := rpc.Call(ctx, MsgGetBlockReceipts, ids, &receipts); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// GetHeadersFromNumber get a batch of headers starts with num from remote peer.
+func GetHeadersFromNumber(ctx context.Context, rpc RPC, num uint32) ([]rlp.RawValue, error) {
+	var headers []rlp.RawValue
+	if err := rpc.Call(ctx, MsgGetHeadersFromNumber, num, &headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// GetAccountProof get merkle proof of the account in state of the block from remote peer.
+// The result is empty if the block or its state is unavailable.
+func GetAccountProof(ctx context.Context, rpc RPC, blockID thor.Bytes32, addr thor.Address) ([][]byte, error) {
+	var proof [][]byte
+	if err := rpc.Call(ctx, MsgGetAccountProof, &AccountProofRequest{blockID, addr}, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// GetReceiptProof get merkle proof of the receipt at index in the block from remote peer.
+// The result is empty if receipts of the block are unavailable.
+func GetReceiptProof(ctx context.Context, rpc RPC, blockID thor.Bytes32, index uint32) ([][]byte, error) {
+	var proof [][]byte
+	if err := rpc.Call(ctx, MsgGetReceiptProof, &ReceiptProofRequest{blockID, index}, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// GetBlockBodies get bodies of blocks by IDs from remote peer.
+// The result is in the same order as ids, and may be truncated.
+func GetBlockBodies(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]rlp.RawValue, error) {
+	var bodies []rlp.RawValue
+	if err := rpc.Call(ctx, MsgGetBlockBodies, ids, &bodies); err != nil {
+		return nil, err
+	}
+	return bodies, nil
+}
+
+// GetBlockTxs get txs of a block at given indexes from remote peer.
+// The result is in the same order as indexes, and is empty if the block not found.
+func GetBlockTxs(ctx context.Context, rpc RPC, blockID thor.Bytes32, indexes []uint32) (tx.Transactions, error) {
+	var txs tx.Transactions
+	if err := rpc.Call(ctx, MsgGetBlockTxs, &BlockTxsRequest{blockID, indexes}, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
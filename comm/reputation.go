@@ -0,0 +1,71 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Each peer starts with initialPeerScore, loses points on misbehaviors, and regains points slowly
+// by useful responses. Once the score drops to zero, the peer is banned for peerBanDuration.
+const (
+	initialPeerScore = 100
+	maxPeerScore     = 100
+	peerBanDuration  = 30 * time.Minute
+
+	penaltyInvalidBlock    = 50 // invalid block, or malformed response
+	penaltyInvalidTx       = 10
+	penaltyTimeout         = 5
+	penaltyUselessResponse = 5 // e.g. empty result for data it claims to have
+	rewardUsefulResponse   = 1
+)
+
+var errUselessResponse = errors.New("useless response")
+
+// penalize lowers the score of the peer, and bans it if the score drops to zero.
+func (c *Communicator) penalize(peer *Peer, penalty int, reason string) {
+	score := peer.adjustScore(-penalty)
+	peer.logger.Debug("peer penalized", "reason", reason, "score", score)
+	if score <= 0 {
+		peer.logger.Info("banning peer with low score", "reason", reason, "duration", peerBanDuration)
+		c.BanPeer(peer.ID(), peerBanDuration)
+	}
+}
+
+// reward raises the score of the peer for a useful response.
+func (c *Communicator) reward(peer *Peer) {
+	peer.adjustScore(rewardUsefulResponse)
+}
+
+// penalizeCallError penalizes the peer according to the error of a failed request to it.
+// Errors due to cancellation or disconnection are ignored, and other errors except timeout and
+// useless response are considered as malformed or invalid responses.
+func (c *Communicator) penalizeCallError(peer *Peer, err error) {
+	select {
+	case <-peer.Done():
+		return
+	default:
+	}
+	switch errors.Cause(err) {
+	case context.Canceled:
+	case context.DeadlineExceeded:
+		c.penalize(peer, penaltyTimeout, "timeout")
+	case errUselessResponse:
+		c.penalize(peer, penaltyUselessResponse, "useless response")
+	default:
+		c.penalize(peer, penaltyInvalidBlock, err.Error())
+	}
+}
+
+// ReportInvalidBlock penalizes the peer which sent the block of the event, if the block failed validation.
+func (c *Communicator) ReportInvalidBlock(ev *NewBlockEvent) {
+	if ev.peer != nil {
+		c.penalize(ev.peer, penaltyInvalidBlock, "invalid block")
+	}
+}
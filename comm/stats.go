@@ -18,10 +18,20 @@ import (
 // PeerStats records stats of a peer.
 type PeerStats struct {
 	Name        string
+	Enode       string
 	BestBlockID thor.Bytes32
 	TotalScore  uint64
 	PeerID      string
 	NetAddr     string
 	Inbound     bool
 	Duration    uint64 // in seconds
+	Score       int    // reputation score
+}
+
+// SyncProgress progress of block synchronization.
+type SyncProgress struct {
+	Current uint32  // number of the best block
+	Target  uint32  // number of the highest block known from peers
+	Rate    float64 // blocks imported per second since synchronization started
+	ETA     uint64  // estimated seconds to reach the target, 0 if unknown or reached
 }
@@ -8,72 +8,57 @@ package comm
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
+	"github.com/vechain/thor/txpool"
 )
 
-func (c *Communicator) sync(peer *Peer, headNum uint32, handler HandleBlockStream) error {
+const progressLogInterval = 10 * time.Second
+
+func (c *Communicator) sync(peer *Peer, headNum uint32, handler HandleBlockStream) (err error) {
+	ctx, span := tracing.Start(c.ctx, "comm.sync", tracing.String("peer", peer.ID().String()))
+	defer func() { tracing.End(span, err) }()
+
+	_, ancestorSpan := tracing.Start(ctx, "comm.findCommonAncestor")
 	ancestor, err := c.findCommonAncestor(peer, headNum)
+	tracing.End(ancestorSpan, err)
 	if err != nil {
+		c.penalizeCallError(peer, err)
 		return errors.WithMessage(err, "find common ancestor")
 	}
-	return c.download(peer, ancestor+1, handler)
+	span.SetAttributes(tracing.Int64("from", int64(ancestor+1)))
+	return c.download(ctx, peer, ancestor+1, handler)
 }
 
-func (c *Communicator) download(peer *Peer, fromNum uint32, handler HandleBlockStream) error {
+// download fetches blocks starting from fromNum, and feeds them to the handler.
+func (c *Communicator) download(ctx context.Context, peer *Peer, fromNum uint32, handler HandleBlockStream) error {
 
 	// it's important to set cap to 2
 	errCh := make(chan error, 2)
 
-	ctx, cancel := context.WithCancel(c.ctx)
+	ctx, cancel := context.WithCancel(ctx)
 	blockCh := make(chan *block.Block, 32)
 
 	var goes co.Goes
 	goes.Go(func() {
 		defer cancel()
 		if err := handler(ctx, blockCh); err != nil {
+			if _, ok := err.(*InvalidBlockError); ok {
+				c.penalize(peer, penaltyInvalidBlock, "invalid block")
+			}
 			errCh <- err
 		}
 	})
 	goes.Go(func() {
 		defer close(blockCh)
-		for {
-			result, err := proto.GetBlocksFromNumber(ctx, peer, fromNum)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			if len(result) == 0 {
-				return
-			}
-
-			for _, raw := range result {
-				var blk block.Block
-				if err := rlp.DecodeBytes(raw, &blk); err != nil {
-					errCh <- errors.Wrap(err, "invalid block")
-					return
-				}
-				if _, err := blk.Header().Signer(); err != nil {
-					errCh <- errors.Wrap(err, "invalid block")
-					return
-				}
-				if blk.Header().Number() != fromNum {
-					errCh <- errors.New("broken sequence")
-					return
-				}
-				peer.MarkBlock(blk.Header().ID())
-				fromNum++
-
-				select {
-				case <-ctx.Done():
-					return
-				case blockCh <- &blk:
-				}
-			}
+		if err := c.fetchBlocks(ctx, peer, fromNum, blockCh); err != nil {
+			errCh <- err
 		}
 	})
 	goes.Wait()
@@ -87,16 +72,22 @@ func (c *Communicator) download(peer *Peer, fromNum uint32, handler HandleBlockS
 }
 
 func (c *Communicator) findCommonAncestor(peer *Peer, headNum uint32) (uint32, error) {
+	return findCommonAncestor(c.ctx, peer, headNum, c.chain.GetTrunkBlockID)
+}
+
+// findCommonAncestor finds the highest block on both trunks of local and the peer,
+// where getTrunkID returns ID of local trunk block by number.
+func findCommonAncestor(ctx context.Context, peer *Peer, headNum uint32, getTrunkID func(uint32) (thor.Bytes32, error)) (uint32, error) {
 	if headNum == 0 {
 		return headNum, nil
 	}
 
 	isOverlapped := func(num uint32) (bool, error) {
-		result, err := proto.GetBlockIDByNumber(c.ctx, peer, num)
+		result, err := proto.GetBlockIDByNumber(ctx, peer, num)
 		if err != nil {
 			return false, err
 		}
-		id, err := c.chain.GetTrunkBlockID(num)
+		id, err := getTrunkID(num)
 		if err != nil {
 			return false, err
 		}
@@ -178,7 +169,12 @@ func (c *Communicator) syncTxs(peer *Peer) {
 
 		for _, tx := range result {
 			peer.MarkTransaction(tx.ID())
-			c.txPool.Add(tx)
+			if !c.relayable(tx) {
+				continue
+			}
+			if err := c.txPool.Add(tx); txpool.IsBadTx(err) {
+				c.penalize(peer, penaltyInvalidTx, "invalid tx")
+			}
 			select {
 			case <-c.ctx.Done():
 				return
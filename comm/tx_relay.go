@@ -0,0 +1,39 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"github.com/vechain/thor/tx"
+)
+
+// TxRelayPolicy controls tx gossip with peers.
+type TxRelayPolicy struct {
+	// Disabled means block-only relay. Txs are neither accepted from nor served to peers,
+	// and only local txs are broadcast.
+	Disabled bool
+	// Txs with lower gas price coef are neither accepted from nor relayed to peers.
+	MinGasPriceCoef uint8
+	// Max txs accepted from a peer per second, and excess ones are dropped. Unlimited if 0.
+	PeerRateLimit int
+}
+
+// SetTxRelayPolicy sets the policy of tx gossip. It should be called before Start.
+func (c *Communicator) SetTxRelayPolicy(policy TxRelayPolicy) {
+	c.txRelay = policy
+}
+
+// acceptTx returns whether the tx received from the peer should be accepted.
+func (c *Communicator) acceptTx(peer *Peer, tx *tx.Transaction) bool {
+	if c.txRelay.Disabled || !c.relayable(tx) {
+		return false
+	}
+	return peer.txLimiter == nil || peer.txLimiter.allow(1)
+}
+
+// relayable returns whether the tx can be relayed to peers.
+func (c *Communicator) relayable(tx *tx.Transaction) bool {
+	return tx.GasPriceCoef() >= c.txRelay.MinGasPriceCoef
+}
@@ -21,11 +21,18 @@ func (c *Communicator) txsLoop() {
 		case <-c.ctx.Done():
 			return
 		case tx := <-txCh:
+			if !c.relayable(tx) {
+				continue
+			}
 			peers := c.peerSet.Slice().Filter(func(p *Peer) bool {
 				return !p.IsTransactionKnown(tx.ID())
 			})
 
+			size := int(tx.Size())
 			for _, peer := range peers {
+				if !c.uploadLimit.allow(size) {
+					break
+				}
 				peer := peer
 				peer.MarkTransaction(tx.ID())
 				c.goes.Go(func() {
@@ -6,29 +6,65 @@
 package consensus
 
 import (
+	"context"
+	"runtime"
+
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
 	"github.com/vechain/thor/tx"
 )
 
 // Consensus check whether the block is verified,
 // and predicate which trunk it belong to.
 type Consensus struct {
-	chain        *chain.Chain
-	stateCreator *state.Creator
+	chain         *chain.Chain
+	stateCreator  *state.Creator
+	forkConfig    thor.ForkConfig
+	verifyWorkers int
 }
 
 // New create a Consensus instance.
-func New(chain *chain.Chain, stateCreator *state.Creator) *Consensus {
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Consensus {
 	return &Consensus{
-		chain:        chain,
-		stateCreator: stateCreator}
+		chain:         chain,
+		stateCreator:  stateCreator,
+		forkConfig:    forkConfig,
+		verifyWorkers: runtime.NumCPU(),
+	}
+}
+
+// SetVerifyWorkers sets the number of workers to pre-verify txs of a block in parallel.
+// Number of CPUs is used if n is 0, and txs are verified sequentially if n is 1.
+func (c *Consensus) SetVerifyWorkers(n int) {
+	if n == 0 {
+		n = runtime.NumCPU()
+	}
+	c.verifyWorkers = n
+}
+
+// endSpan ends the span of processing a block. Known, future and orphan blocks are normal
+// in propagation, so they're recorded as result instead of failure.
+func endSpan(span tracing.Span, err error) {
+	if IsKnownBlock(err) || IsFutureBlock(err) || IsParentMissing(err) {
+		span.SetAttributes(tracing.String("block.result", err.Error()))
+		err = nil
+	}
+	tracing.End(span, err)
 }
 
 // Process process a block.
 func (c *Consensus) Process(blk *block.Block, nowTimestamp uint64) (*state.Stage, tx.Receipts, error) {
+	return c.ProcessContext(context.Background(), blk, nowTimestamp)
+}
+
+// ProcessContext process a block, and traces it as child span of the one in ctx.
+func (c *Consensus) ProcessContext(ctx context.Context, blk *block.Block, nowTimestamp uint64) (stage *state.Stage, receipts tx.Receipts, err error) {
 	header := blk.Header()
+	ctx, span := tracing.Start(ctx, "consensus.Process", tracing.Int64("block.number", int64(header.Number())))
+	defer func() { endSpan(span, err) }()
 
 	if _, err := c.chain.GetBlockHeader(header.ID()); err != nil {
 		if !c.chain.IsNotFound(err) {
@@ -51,7 +87,7 @@ func (c *Consensus) Process(blk *block.Block, nowTimestamp uint64) (*state.Stage
 		return nil, nil, err
 	}
 
-	stage, receipts, err := c.validate(state, blk, parentHeader, nowTimestamp)
+	stage, receipts, err = c.validate(ctx, state, blk, parentHeader, nowTimestamp)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6,20 +6,24 @@
 package consensus
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/poa"
 	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracing"
 	"github.com/vechain/thor/tx"
 	"github.com/vechain/thor/xenv"
 )
 
 func (c *Consensus) validate(
+	ctx context.Context,
 	state *state.State,
 	block *block.Block,
 	parentHeader *block.Header,
@@ -39,7 +43,7 @@ func (c *Consensus) validate(
 		return nil, nil, err
 	}
 
-	stage, receipts, err := c.verifyBlock(block, state)
+	stage, receipts, err := c.verifyBlock(ctx, block, state)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,6 +118,26 @@ func (c *Consensus) validateProposer(header *block.Header, parent *block.Header,
 	return nil
 }
 
+// preVerify recovers signers and computes intrinsic gas of txs in parallel. Results are cached in txs,
+// so that ECDSA recovery, which dominates import time, is mostly paid off before sequential validation.
+// Errors are left to be reported by sequential validation.
+func (c *Consensus) preVerify(txs tx.Transactions) {
+	if c.verifyWorkers < 2 || len(txs) < 2 {
+		return
+	}
+	co.ParallelN(c.verifyWorkers, func(enqueue co.Enqueue) {
+		for _, t := range txs {
+			t := t
+			enqueue(func() {
+				if _, err := t.Signer(); err == nil {
+					t.ID()
+					t.IntrinsicGas()
+				}
+			})
+		}
+	})
+}
+
 func (c *Consensus) validateBlockBody(blk *block.Block) error {
 	header := blk.Header()
 	txs := blk.Transactions()
@@ -121,10 +145,18 @@ func (c *Consensus) validateBlockBody(blk *block.Block) error {
 		return consensusError(fmt.Sprintf("block txs root mismatch: want %v, have %v", header.TxsRoot(), txs.RootHash()))
 	}
 
+	c.preVerify(txs)
+	supportedFeatures := tx.SupportedFeatures(c.forkConfig, header.Number())
 	for _, tx := range txs {
 		if _, err := tx.Signer(); err != nil {
 			return consensusError(fmt.Sprintf("tx signer unavailable: %v", err))
 		}
+		if err := tx.TestFeatures(supportedFeatures); err != nil {
+			return consensusError(fmt.Sprintf("tx features: %v", err))
+		}
+		if _, err := tx.Delegator(); err != nil {
+			return consensusError(fmt.Sprintf("tx delegator unavailable: %v", err))
+		}
 
 		switch {
 		case tx.ChainTag() != c.chain.Tag():
@@ -133,15 +165,13 @@ func (c *Consensus) validateBlockBody(blk *block.Block) error {
 			return consensusError(fmt.Sprintf("tx ref future block: ref %v, current %v", tx.BlockRef().Number(), header.Number()))
 		case tx.IsExpired(header.Number()):
 			return consensusError(fmt.Sprintf("tx expired: ref %v, current %v, expiration %v", tx.BlockRef().Number(), header.Number(), tx.Expiration()))
-		case tx.HasReservedFields():
-			return consensusError(fmt.Sprintf("tx reserved fields not empty"))
 		}
 	}
 
 	return nil
 }
 
-func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.Stage, tx.Receipts, error) {
+func (c *Consensus) verifyBlock(ctx context.Context, blk *block.Block, state *state.State) (*state.Stage, tx.Receipts, error) {
 	var totalGasUsed uint64
 	txs := blk.Transactions()
 	receipts := make(tx.Receipts, 0, len(txs))
@@ -158,7 +188,8 @@ func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.St
 			Time:        header.Timestamp(),
 			GasLimit:    header.GasLimit(),
 			TotalScore:  header.TotalScore(),
-		})
+		},
+		c.forkConfig)
 
 	findTx := func(txID thor.Bytes32) (found bool, reverted bool, err error) {
 		if reverted, ok := processedTxs[txID]; ok {
@@ -197,7 +228,9 @@ func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.St
 			}
 		}
 
+		_, span := tracing.Start(ctx, "runtime.ExecuteTransaction", tracing.String("tx.id", tx.ID().String()))
 		receipt, err := rt.ExecuteTransaction(tx)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,169 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+// CustomGenesis describes a custom network, usually decoded from JSON file.
+// No EVM fork is activated if ForkConfig is nil, otherwise forks omitted are activated from genesis.
+type CustomGenesis struct {
+	LaunchTime uint64           `json:"launchTime"`
+	GasLimit   uint64           `json:"gasLimit"`
+	Accounts   []Account        `json:"accounts"`
+	Authority  []Authority      `json:"authority"`
+	Params     Params           `json:"params"`
+	ForkConfig *thor.ForkConfig `json:"forkConfig"`
+}
+
+// Account is an account allocated in genesis. Code and storage are used to deploy contracts.
+type Account struct {
+	Address thor.Address            `json:"address"`
+	Balance *math.HexOrDecimal256   `json:"balance"`
+	Energy  *math.HexOrDecimal256   `json:"energy"`
+	Code    string                  `json:"code"`
+	Storage map[string]thor.Bytes32 `json:"storage"`
+}
+
+// Authority is an initial block proposer.
+type Authority struct {
+	MasterAddress   thor.Address `json:"masterAddress"`
+	EndorsorAddress thor.Address `json:"endorsorAddress"`
+	Identity        thor.Bytes32 `json:"identity"`
+}
+
+// Params initial governance params. Nil fields take default values, except executor which is required.
+type Params struct {
+	ExecutorAddress     *thor.Address         `json:"executorAddress"`
+	RewardRatio         *math.HexOrDecimal256 `json:"rewardRatio"`
+	BaseGasPrice        *math.HexOrDecimal256 `json:"baseGasPrice"`
+	ProposerEndorsement *math.HexOrDecimal256 `json:"proposerEndorsement"`
+}
+
+// NewCustomNet create genesis for custom network.
+func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
+	if gen.LaunchTime == 0 {
+		return nil, errors.New("launchTime required")
+	}
+	if len(gen.Authority) == 0 {
+		return nil, errors.New("at least one authority node required")
+	}
+	if gen.Params.ExecutorAddress == nil {
+		return nil, errors.New("params.executorAddress required")
+	}
+	gasLimit := gen.GasLimit
+	if gasLimit == 0 {
+		gasLimit = thor.InitialGasLimit
+	}
+
+	codes := make([][]byte, len(gen.Accounts))
+	storages := make([]map[thor.Bytes32]thor.Bytes32, len(gen.Accounts))
+	for i, acc := range gen.Accounts {
+		if acc.Code != "" {
+			code, err := hexutil.Decode(acc.Code)
+			if err != nil {
+				return nil, errors.New("accounts: invalid code of " + acc.Address.String())
+			}
+			codes[i] = code
+		}
+		storages[i] = make(map[thor.Bytes32]thor.Bytes32)
+		for k, v := range acc.Storage {
+			key, err := thor.ParseBytes32(k)
+			if err != nil {
+				return nil, errors.New("accounts: invalid storage key of " + acc.Address.String())
+			}
+			storages[i][key] = v
+		}
+	}
+
+	launchTime := gen.LaunchTime
+	executor := *gen.Params.ExecutorAddress
+
+	builder := new(Builder).
+		Timestamp(launchTime).
+		GasLimit(gasLimit).
+		State(func(state *state.State) error {
+			// alloc precompiled contracts
+			for addr := range vm.PrecompiledContractsByzantium {
+				state.SetCode(thor.Address(addr), emptyRuntimeBytecode)
+			}
+
+			// setup builtin contracts
+			state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes())
+			state.SetCode(builtin.Energy.Address, builtin.Energy.RuntimeBytecodes())
+			state.SetCode(builtin.Params.Address, builtin.Params.RuntimeBytecodes())
+			state.SetCode(builtin.Prototype.Address, builtin.Prototype.RuntimeBytecodes())
+			state.SetCode(builtin.Extension.Address, builtin.Extension.RuntimeBytecodes())
+
+			tokenSupply := &big.Int{}
+			energySupply := &big.Int{}
+			for i, acc := range gen.Accounts {
+				if acc.Balance != nil {
+					bal := (*big.Int)(acc.Balance)
+					state.SetBalance(acc.Address, bal)
+					tokenSupply.Add(tokenSupply, bal)
+				}
+				if acc.Energy != nil {
+					energy := (*big.Int)(acc.Energy)
+					state.SetEnergy(acc.Address, energy, launchTime)
+					energySupply.Add(energySupply, energy)
+				}
+				if len(codes[i]) > 0 {
+					state.SetCode(acc.Address, codes[i])
+				}
+				for k, v := range storages[i] {
+					state.SetStorage(acc.Address, k, v)
+				}
+			}
+			builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
+			return nil
+		}).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyExecutorAddress, new(big.Int).SetBytes(executor[:]))),
+			thor.Address{}).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyRewardRatio, paramOrDefault(gen.Params.RewardRatio, thor.InitialRewardRatio))),
+			executor).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyBaseGasPrice, paramOrDefault(gen.Params.BaseGasPrice, thor.InitialBaseGasPrice))),
+			executor).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyProposerEndorsement, paramOrDefault(gen.Params.ProposerEndorsement, thor.InitialProposerEndorsement))),
+			executor)
+
+	for _, a := range gen.Authority {
+		builder.Call(
+			tx.NewClause(&builtin.Authority.Address).WithData(mustEncodeInput(builtin.Authority.ABI, "add", a.MasterAddress, a.EndorsorAddress, a.Identity)),
+			executor)
+	}
+
+	id, err := builder.ComputeID()
+	if err != nil {
+		return nil, err
+	}
+	forkConfig := thor.NoFork
+	if gen.ForkConfig != nil {
+		forkConfig = *gen.ForkConfig
+	}
+	return &Genesis{builder, id, "customnet", forkConfig}, nil
+}
+
+func paramOrDefault(v *math.HexOrDecimal256, def *big.Int) *big.Int {
+	if v == nil {
+		return def
+	}
+	return (*big.Int)(v)
+}
@@ -58,10 +58,23 @@ func DevAccounts() []DevAccount {
 	return accs
 }
 
+// DefaultDevnetLaunchTime launch time of devnet if not specified.
+const DefaultDevnetLaunchTime = uint64(1526400000) // 'Wed May 16 2018 00:00:00 GMT+0800 (CST)'
+
 // NewDevnet create genesis for solo mode.
 func NewDevnet() (*Genesis, error) {
-	launchTime := uint64(1526400000) // 'Wed May 16 2018 00:00:00 GMT+0800 (CST)'
+	return NewDevnetAt(DefaultDevnetLaunchTime)
+}
 
+// DevAlloc an extra account funded in devnet genesis.
+type DevAlloc struct {
+	Address thor.Address
+	Balance *big.Int
+	Energy  *big.Int
+}
+
+// NewDevnetAt create genesis for solo mode, with the given launch time and extra funded accounts.
+func NewDevnetAt(launchTime uint64, allocs ...DevAlloc) (*Genesis, error) {
 	executor := DevAccounts()[0].Address
 
 	builder := new(Builder).
@@ -89,6 +102,16 @@ func NewDevnet() (*Genesis, error) {
 				tokenSupply.Add(tokenSupply, bal)
 				energySupply.Add(energySupply, bal)
 			}
+			for _, alloc := range allocs {
+				if alloc.Balance != nil {
+					state.SetBalance(alloc.Address, new(big.Int).Add(state.GetBalance(alloc.Address), alloc.Balance))
+					tokenSupply.Add(tokenSupply, alloc.Balance)
+				}
+				if alloc.Energy != nil {
+					state.SetEnergy(alloc.Address, new(big.Int).Add(state.GetEnergy(alloc.Address, launchTime), alloc.Energy), launchTime)
+					energySupply.Add(energySupply, alloc.Energy)
+				}
+			}
 			builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
 			return nil
 		}).
@@ -116,5 +139,5 @@ func NewDevnet() (*Genesis, error) {
 		return nil, err
 	}
 
-	return &Genesis{builder, id, "devnet"}, nil
+	return &Genesis{builder, id, "devnet", thor.SoloFork}, nil
 }
@@ -17,9 +17,10 @@ import (
 
 // Genesis to build genesis block.
 type Genesis struct {
-	builder *Builder
-	id      thor.Bytes32
-	name    string
+	builder    *Builder
+	id         thor.Bytes32
+	name       string
+	forkConfig thor.ForkConfig
 }
 
 // Build build the genesis block.
@@ -44,6 +45,11 @@ func (g *Genesis) Name() string {
 	return g.name
 }
 
+// ForkConfig returns block numbers where EVM forks are activated in the network.
+func (g *Genesis) ForkConfig() thor.ForkConfig {
+	return g.forkConfig
+}
+
 func mustEncodeInput(abi *abi.ABI, name string, args ...interface{}) []byte {
 	m, found := abi.MethodByName(name)
 	if !found {
@@ -6,12 +6,15 @@
 package genesis_test
 
 import (
+	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 )
 
 func TestTestnetGenesis(t *testing.T) {
@@ -25,3 +28,80 @@ func TestTestnetGenesis(t *testing.T) {
 	_, err = state.New(b0.Header().StateRoot(), kv)
 	assert.Nil(t, err)
 }
+
+func TestDevnetGenesisAt(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnetAt(1600000000)
+	assert.Nil(t, err)
+
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1600000000), b0.Header().Timestamp())
+
+	def, err := genesis.NewDevnet()
+	assert.Nil(t, err)
+	assert.NotEqual(t, def.ID(), gene.ID())
+}
+
+func TestDevnetAlloc(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	addr := thor.BytesToAddress([]byte("alloc"))
+	gene, err := genesis.NewDevnetAt(genesis.DefaultDevnetLaunchTime, genesis.DevAlloc{
+		Address: addr,
+		Balance: big.NewInt(100),
+		Energy:  big.NewInt(200),
+	})
+	assert.Nil(t, err)
+
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	assert.Nil(t, err)
+
+	st, err := state.New(b0.Header().StateRoot(), kv)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), st.GetBalance(addr))
+	assert.Equal(t, big.NewInt(200), st.GetEnergy(addr, b0.Header().Timestamp()))
+}
+
+func TestCustomNetGenesis(t *testing.T) {
+	var gen genesis.CustomGenesis
+	err := json.Unmarshal([]byte(`{
+		"launchTime": 1530000000,
+		"accounts": [
+			{"address": "0x0000000000000000000000000000000000000001", "balance": "1000", "energy": "0x64"},
+			{"address": "0x0000000000000000000000000000000000000002", "code": "0x6060604052600256",
+				"storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}}
+		],
+		"authority": [
+			{"masterAddress": "0x0000000000000000000000000000000000000003", "endorsorAddress": "0x0000000000000000000000000000000000000001", "identity": "0x0000000000000000000000000000000000000000000000000000000000000004"}
+		],
+		"params": {"executorAddress": "0x0000000000000000000000000000000000000005"}
+	}`), &gen)
+	assert.Nil(t, err)
+
+	gene, err := genesis.NewCustomNet(&gen)
+	assert.Nil(t, err)
+
+	kv, _ := lvldb.NewMem()
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1530000000), b0.Header().Timestamp())
+
+	st, err := state.New(b0.Header().StateRoot(), kv)
+	assert.Nil(t, err)
+	acc1 := thor.BytesToAddress([]byte{1})
+	acc2 := thor.BytesToAddress([]byte{2})
+	assert.Equal(t, big.NewInt(1000), st.GetBalance(acc1))
+	assert.Equal(t, big.NewInt(100), st.GetEnergy(acc1, b0.Header().Timestamp()))
+	assert.NotEmpty(t, st.GetCode(acc2))
+	assert.Equal(t, thor.BytesToBytes32([]byte{2}), st.GetStorage(acc2, thor.BytesToBytes32([]byte{1})))
+	assert.Equal(t, thor.NoFork, gene.ForkConfig())
+
+	gen.ForkConfig = &thor.ForkConfig{Constantinople: 100}
+	gene, err = genesis.NewCustomNet(&gen)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(100), gene.ForkConfig().Constantinople)
+
+	gen.Params.ExecutorAddress = nil
+	_, err = genesis.NewCustomNet(&gen)
+	assert.NotNil(t, err)
+}
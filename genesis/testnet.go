@@ -81,5 +81,5 @@ func NewTestnet() (*Genesis, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Genesis{builder, id, "testnet"}, nil
+	return &Genesis{builder, id, "testnet", thor.NoFork}, nil
 }
@@ -36,6 +36,13 @@ type GetPutCloser interface {
 	Close() error
 }
 
+// Store is a persistent kv store backed by a storage engine.
+type Store interface {
+	GetPutCloser
+	// Compact compacts the whole key range.
+	Compact() error
+}
+
 // Batch defines batch of putting ops.
 type Batch interface {
 	Putter
@@ -0,0 +1,210 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package light
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	bestHeaderKey = []byte("light-best")
+	headerPrefix  = []byte("lh") // (prefix, block number) -> header on trunk
+)
+
+var errNotFound = errors.New("not found")
+
+// HeaderChain stores headers of trunk blocks only, without txs, receipts and states.
+//
+// Headers are only checked to be well linked, signed, not from the future, and to grow total score
+// by at most thor.MaxBlockProposers. Without the state, neither the authority set nor the proposer
+// schedule can be verified, so a chain forged by any key would be accepted. It's safe only if
+// headers come from trusted peers.
+type HeaderChain struct {
+	kv      kv.GetPutter
+	genesis *block.Header
+	best    *block.Header
+	lock    sync.RWMutex
+}
+
+// NewHeaderChain create an instance of HeaderChain.
+func NewHeaderChain(kv kv.GetPutter, genesis *block.Header) (*HeaderChain, error) {
+	if genesis.Number() != 0 {
+		return nil, errors.New("invalid genesis")
+	}
+	hc := &HeaderChain{kv: kv, genesis: genesis}
+
+	data, err := kv.Get(bestHeaderKey)
+	if err != nil {
+		if !kv.IsNotFound(err) {
+			return nil, err
+		}
+		batch := kv.NewBatch()
+		if err := saveHeader(batch, genesis); err != nil {
+			return nil, err
+		}
+		if err := batch.Put(bestHeaderKey, numberKey(0)); err != nil {
+			return nil, err
+		}
+		if err := batch.Write(); err != nil {
+			return nil, err
+		}
+		hc.best = genesis
+		return hc, nil
+	}
+
+	storedGenesis, err := hc.GetHeader(0)
+	if err != nil {
+		return nil, err
+	}
+	if storedGenesis.ID() != genesis.ID() {
+		return nil, errors.New("genesis mismatch")
+	}
+	if hc.best, err = hc.GetHeader(binary.BigEndian.Uint32(data)); err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+// Genesis returns the genesis header.
+func (hc *HeaderChain) Genesis() *block.Header {
+	return hc.genesis
+}
+
+// Best returns the header with the highest total score.
+func (hc *HeaderChain) Best() *block.Header {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	return hc.best
+}
+
+// GetHeader returns the trunk header of the number.
+func (hc *HeaderChain) GetHeader(num uint32) (*block.Header, error) {
+	data, err := hc.kv.Get(append(headerPrefix, numberKey(num)...))
+	if err != nil {
+		if hc.kv.IsNotFound(err) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	var header block.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// GetHeaderByID returns the header of the id, if it's on trunk.
+func (hc *HeaderChain) GetHeaderByID(id thor.Bytes32) (*block.Header, error) {
+	header, err := hc.GetHeader(block.Number(id))
+	if err != nil {
+		return nil, err
+	}
+	if header.ID() != id {
+		return nil, errNotFound
+	}
+	return header, nil
+}
+
+// IsNotFound returns if the error means header not found.
+func (hc *HeaderChain) IsNotFound(err error) bool {
+	return err == errNotFound
+}
+
+// Insert inserts continuous headers, whose parent should be on trunk.
+// Trunk is switched if the last header has higher total score than the best,
+// otherwise headers are dropped.
+func (hc *HeaderChain) Insert(headers []*block.Header) error {
+	if len(headers) == 0 {
+		return nil
+	}
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	parent, err := hc.GetHeaderByID(headers[0].ParentID())
+	if err != nil {
+		if hc.IsNotFound(err) {
+			return errors.New("parent missing")
+		}
+		return err
+	}
+	for _, header := range headers {
+		if err := verifyHeader(header, parent, uint64(time.Now().Unix())); err != nil {
+			return errors.WithMessage(err, "header "+header.ID().String())
+		}
+		parent = header
+	}
+	last := headers[len(headers)-1]
+	if last.TotalScore() <= hc.best.TotalScore() {
+		return nil
+	}
+
+	batch := hc.kv.NewBatch()
+	for _, header := range headers {
+		if err := saveHeader(batch, header); err != nil {
+			return err
+		}
+	}
+	// headers on the old trunk beyond the new best are obsolete
+	for num := last.Number() + 1; num <= hc.best.Number(); num++ {
+		if err := batch.Delete(append(headerPrefix, numberKey(num)...)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(bestHeaderKey, numberKey(last.Number())); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	hc.best = last
+	return nil
+}
+
+func verifyHeader(header, parent *block.Header, now uint64) error {
+	if header.ParentID() != parent.ID() {
+		return errors.New("parent mismatch")
+	}
+	if header.Timestamp() <= parent.Timestamp() ||
+		(header.Timestamp()-parent.Timestamp())%thor.BlockInterval != 0 {
+		return errors.New("invalid timestamp")
+	}
+	if header.Timestamp() > now+thor.BlockInterval {
+		return errors.New("future header")
+	}
+	if header.TotalScore() <= parent.TotalScore() ||
+		header.TotalScore()-parent.TotalScore() > thor.MaxBlockProposers {
+		return errors.New("invalid total score")
+	}
+	if header.GasUsed() > header.GasLimit() {
+		return errors.New("gas used exceeds limit")
+	}
+	if _, err := header.Signer(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func saveHeader(w kv.Putter, header *block.Header) error {
+	data, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return err
+	}
+	return w.Put(append(headerPrefix, numberKey(header.Number())...), data)
+}
+
+func numberKey(num uint32) []byte {
+	var key [4]byte
+	binary.BigEndian.PutUint32(key[:], num)
+	return key[:]
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/light"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+var privateKey, _ = crypto.GenerateKey()
+
+func newHeader(parent *block.Header, score uint64) *block.Header {
+	return newHeaderAt(parent, parent.Timestamp()+thor.BlockInterval, score)
+}
+
+func newHeaderAt(parent *block.Header, timestamp uint64, score uint64) *block.Header {
+	b := new(block.Builder).
+		ParentID(parent.ID()).
+		Timestamp(timestamp).
+		TotalScore(parent.TotalScore() + score).
+		Build()
+	sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), privateKey)
+	return b.WithSignature(sig).Header()
+}
+
+func TestHeaderChain(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	h0 := b0.Header()
+
+	hc, err := light.NewHeaderChain(kv, h0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, h0.ID(), hc.Best().ID())
+
+	h1 := newHeader(h0, 1)
+	h2 := newHeader(h1, 1)
+	h3 := newHeader(h2, 1)
+	assert.Nil(t, hc.Insert([]*block.Header{h1, h2, h3}))
+	assert.Equal(t, h3.ID(), hc.Best().ID())
+
+	// fork with lower score is dropped
+	h2x := newHeader(h1, 1)
+	assert.Nil(t, hc.Insert([]*block.Header{h2x}))
+	assert.Equal(t, h3.ID(), hc.Best().ID())
+
+	// fork with higher score switches trunk
+	h2y := newHeader(h1, 5)
+	assert.Nil(t, hc.Insert([]*block.Header{h2y}))
+	assert.Equal(t, h2y.ID(), hc.Best().ID())
+	_, err = hc.GetHeader(3)
+	assert.True(t, hc.IsNotFound(err), "obsolete header should be removed")
+	_, err = hc.GetHeaderByID(h2.ID())
+	assert.True(t, hc.IsNotFound(err))
+
+	assert.Error(t, hc.Insert([]*block.Header{h3}), "parent missing")
+	bad := new(block.Builder).ParentID(h2y.ID()).Timestamp(h2y.Timestamp()).TotalScore(h2y.TotalScore() + 1).Build().Header()
+	assert.Error(t, hc.Insert([]*block.Header{bad}), "invalid timestamp")
+
+	assert.Error(t, hc.Insert([]*block.Header{newHeader(h2y, thor.MaxBlockProposers+1)}), "invalid total score")
+	future := h2y.Timestamp() + (uint64(time.Now().Unix())-h2y.Timestamp())/thor.BlockInterval*thor.BlockInterval + 2*thor.BlockInterval
+	assert.Error(t, hc.Insert([]*block.Header{newHeaderAt(h2y, future, 1)}), "future header")
+	assert.Equal(t, h2y.ID(), hc.Best().ID())
+
+	// reopen
+	hc, err = light.NewHeaderChain(kv, h0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, h2y.ID(), hc.Best().ID())
+	header, err := hc.GetHeaderByID(h1.ID())
+	assert.Nil(t, err)
+	assert.Equal(t, h1.ID(), header.ID())
+
+	_, err = light.NewHeaderChain(kv, h1)
+	assert.Error(t, err, "invalid genesis")
+}
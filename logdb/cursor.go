@@ -0,0 +1,82 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of a record, to continue a query right after it.
+// Index is the index of the record in its block, which follows the order of
+// transactions and logs in the block, so (BlockNumber, Index) is unique and ordered.
+// It's encoded as an opaque string.
+type Cursor struct {
+	BlockNumber uint32
+	Index       uint32
+}
+
+// ParseCursor decodes the string returned by Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) != 8 {
+		return nil, errInvalidCursor
+	}
+	return &Cursor{
+		BlockNumber: binary.BigEndian.Uint32(data),
+		Index:       binary.BigEndian.Uint32(data[4:]),
+	}, nil
+}
+
+func (c Cursor) String() string {
+	var data [8]byte
+	binary.BigEndian.PutUint32(data[:], c.BlockNumber)
+	binary.BigEndian.PutUint32(data[4:], c.Index)
+	return base64.RawURLEncoding.EncodeToString(data[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Cursor) UnmarshalText(text []byte) error {
+	parsed, err := ParseCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// cursorCondition returns the condition to select records after the cursor in the given order.
+func cursorCondition(indexColumn string, cursor *Cursor, order Order) (string, []interface{}) {
+	op := ">"
+	if order == DESC {
+		op = "<"
+	}
+	return " AND (blockNumber " + op + " ? OR (blockNumber = ? AND " + indexColumn + " " + op + " ?)) ",
+		[]interface{}{cursor.BlockNumber, cursor.BlockNumber, cursor.Index}
+}
+
+// Cursor returns the cursor of the event.
+func (ev *Event) Cursor() *Cursor {
+	return &Cursor{ev.BlockNumber, ev.Index}
+}
+
+// Cursor returns the cursor of the transfer.
+func (t *Transfer) Cursor() *Cursor {
+	return &Cursor{t.BlockNumber, t.Index}
+}
+
+// Cursor returns the cursor of the transaction.
+func (t *Transaction) Cursor() *Cursor {
+	return &Cursor{t.BlockNumber, t.Index}
+}
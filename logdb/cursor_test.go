@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func TestParseCursor(t *testing.T) {
+	c := logdb.Cursor{BlockNumber: 100, Index: 2}
+	parsed, err := logdb.ParseCursor(c.String())
+	assert.Nil(t, err)
+	assert.Equal(t, c, *parsed)
+
+	_, err = logdb.ParseCursor("invalid")
+	assert.NotNil(t, err)
+}
+
+func TestFilterEventsByCursor(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	txEvent := &tx.Event{Address: thor.BytesToAddress([]byte("addr"))}
+	header := new(block.Builder).Build().Header()
+	for i := 0; i < 10; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		if err := db.Prepare(header).ForTransaction(thor.BytesToBytes32([]byte("txID")), thor.Address{}).
+			Insert(tx.Events{txEvent, txEvent, txEvent}, nil).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, order := range []logdb.Order{logdb.ASC, logdb.DESC} {
+		all, err := db.FilterEvents(context.Background(), &logdb.EventFilter{Order: order})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 30, len(all))
+
+		var (
+			paged  []*logdb.Event
+			cursor *logdb.Cursor
+		)
+		for {
+			page, err := db.FilterEvents(context.Background(), &logdb.EventFilter{
+				Options: &logdb.Options{Limit: 4, Cursor: cursor},
+				Order:   order,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			paged = append(paged, page...)
+			cursor = page[len(page)-1].Cursor()
+		}
+		assert.Equal(t, all, paged, "order %v", order)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"strconv"
+	"strings"
+
+	// register postgres driver
+	_ "github.com/lib/pq"
+)
+
+// dialect describes differences between sql databases.
+// Statements are written in '?' placeholders and portable syntax.
+type dialect struct {
+	driver string
+	schema string
+	// whether placeholders are in form of '$1, $2 ...'
+	numberedPlaceholders bool
+}
+
+var (
+	sqliteDialect = dialect{
+		driver: "sqlite3",
+		schema: eventTableSchema + transferTableSchema + transactionTableSchema + configTableSchema,
+	}
+	postgresDialect = dialect{
+		driver:               "postgres",
+		schema:               postgresSchema,
+		numberedPlaceholders: true,
+	}
+)
+
+// rebind converts placeholders of the statement into the dialect's form.
+func (d *dialect) rebind(stmt string) string {
+	if !d.numberedPlaceholders {
+		return stmt
+	}
+	var b strings.Builder
+	n := 0
+	for _, c := range stmt {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
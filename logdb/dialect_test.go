@@ -0,0 +1,18 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	stmt := "SELECT * FROM txn WHERE txOrigin = ? AND blockNumber >= ? LIMIT ? OFFSET ?"
+	assert.Equal(t, stmt, sqliteDialect.rebind(stmt))
+	assert.Equal(t, "SELECT * FROM txn WHERE txOrigin = $1 AND blockNumber >= $2 LIMIT $3 OFFSET $4", postgresDialect.rebind(stmt))
+}
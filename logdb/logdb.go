@@ -10,6 +10,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"strings"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/vechain/thor/block"
@@ -17,15 +18,38 @@ import (
 	"github.com/vechain/thor/tx"
 )
 
+const (
+	headKey = "head"
+
+	// MaxCriteria limits criteria of a filter, i.e. topic sets plus criteria of an event filter,
+	// or address sets of a transfer filter.
+	MaxCriteria = 64
+	// maxQueryParams limits parameters bound to a query, which is 999 by default for SQLite.
+	maxQueryParams = 999
+)
+
+var errTooManyParams = fmt.Errorf("too many query parameters, exceeds %v", maxQueryParams)
+
 type LogDB struct {
 	path          string
 	db            *sql.DB
+	dialect       *dialect
 	driverVersion string
 }
 
 // New create or open log db at given path.
-func New(path string) (logDB *LogDB, err error) {
-	db, err := sql.Open("sqlite3", path)
+func New(path string) (*LogDB, error) {
+	driverVer, _, _ := sqlite3.Version()
+	return open(path, &sqliteDialect, driverVer)
+}
+
+// NewPostgres create or open log db in PostgreSQL database, described by the dsn.
+func NewPostgres(dsn string) (*LogDB, error) {
+	return open(dsn, &postgresDialect, "")
+}
+
+func open(path string, dialect *dialect, driverVersion string) (logDB *LogDB, err error) {
+	db, err := sql.Open(dialect.driver, path)
 	if err != nil {
 		return nil, err
 	}
@@ -34,15 +58,14 @@ func New(path string) (logDB *LogDB, err error) {
 			db.Close()
 		}
 	}()
-	if _, err := db.Exec(eventTableSchema + transferTableSchema); err != nil {
+	if _, err := db.Exec(dialect.schema); err != nil {
 		return nil, err
 	}
-
-	driverVer, _, _ := sqlite3.Version()
 	return &LogDB{
 		path,
 		db,
-		driverVer,
+		dialect,
+		driverVersion,
 	}, nil
 }
 
@@ -60,9 +83,42 @@ func (db *LogDB) Path() string {
 	return db.path
 }
 
+// Head returns ID of the block whose logs were committed last.
+// Zero ID returned if not recorded yet.
+func (db *LogDB) Head() (thor.Bytes32, error) {
+	var value []byte
+	if err := db.db.QueryRow(db.dialect.rebind("SELECT value FROM config WHERE key = ?;"), headKey).Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return thor.Bytes32{}, nil
+		}
+		return thor.Bytes32{}, err
+	}
+	return thor.BytesToBytes32(value), nil
+}
+
+// Rewind deletes logs of blocks after the given block, and records the block as head.
+func (db *LogDB) Rewind(header *block.Header) error {
+	bb := BlockBatch{db: db, header: header}
+	return bb.execInTx(func(tx dbTx) error {
+		for _, table := range []string{"event", "transfer", "txn"} {
+			if err := tx.exec("DELETE FROM "+table+" WHERE blockNumber > ?;", header.Number()); err != nil {
+				return err
+			}
+		}
+		return saveHead(tx, header.ID())
+	})
+}
+
+func saveHead(tx dbTx, id thor.Bytes32) error {
+	if err := tx.exec("DELETE FROM config WHERE key = ?;", headKey); err != nil {
+		return err
+	}
+	return tx.exec("INSERT INTO config(key, value) VALUES (?, ?);", headKey, id.Bytes())
+}
+
 func (db *LogDB) Prepare(header *block.Header) *BlockBatch {
 	return &BlockBatch{
-		db:     db.db,
+		db:     db,
 		header: header,
 	}
 }
@@ -71,8 +127,11 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 	if filter == nil {
 		return db.queryEvents(ctx, "SELECT * FROM event")
 	}
+	if len(filter.TopicSet)+len(filter.CriteriaSet) > MaxCriteria {
+		return nil, fmt.Errorf("too many criteria, exceeds %v", MaxCriteria)
+	}
 	var args []interface{}
-	stmt := "SELECT * FROM event WHERE 1"
+	stmt := "SELECT * FROM event WHERE 1=1"
 	condition := "blockNumber"
 	if filter.Range != nil {
 		if filter.Range.Unit == Time {
@@ -93,9 +152,9 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 	if length > 0 {
 		for i, topics := range filter.TopicSet {
 			if i == 0 {
-				stmt += " AND (( 1 "
+				stmt += " AND (( 1=1 "
 			} else {
-				stmt += " OR ( 1 "
+				stmt += " OR ( 1=1 "
 			}
 			for j, topic := range topics {
 				if topic != nil {
@@ -110,7 +169,35 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 			}
 		}
 	}
+	if len(filter.CriteriaSet) > 0 {
+		for i, criteria := range filter.CriteriaSet {
+			if i == 0 {
+				stmt += " AND (( 1=1 "
+			} else {
+				stmt += " OR ( 1=1 "
+			}
+			if len(criteria.Addresses) > 0 {
+				stmt += " AND address IN (?" + strings.Repeat(",?", len(criteria.Addresses)-1) + ") "
+				for _, addr := range criteria.Addresses {
+					args = append(args, addr.Bytes())
+				}
+			}
+			for j, topic := range criteria.Topics {
+				if topic != nil {
+					args = append(args, topic.Bytes())
+					stmt += fmt.Sprintf(" AND topic%v = ? ", j)
+				}
+			}
+			stmt += " ) "
+		}
+		stmt += " ) "
+	}
 
+	if filter.Options != nil && filter.Options.Cursor != nil {
+		cond, condArgs := cursorCondition("eventIndex", filter.Options.Cursor, filter.Order)
+		stmt += cond
+		args = append(args, condArgs...)
+	}
 	if filter.Order == DESC {
 		stmt += " ORDER BY blockNumber DESC,eventIndex DESC "
 	} else {
@@ -118,8 +205,11 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 	}
 
 	if filter.Options != nil {
-		stmt += " limit ?, ? "
-		args = append(args, filter.Options.Offset, filter.Options.Limit)
+		stmt += " LIMIT ? OFFSET ? "
+		args = append(args, filter.Options.Limit, filter.Options.offset())
+	}
+	if len(args) > maxQueryParams {
+		return nil, errTooManyParams
 	}
 	return db.queryEvents(ctx, stmt, args...)
 }
@@ -128,8 +218,11 @@ func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([
 	if filter == nil {
 		return db.queryTransfers(ctx, "SELECT * FROM transfer")
 	}
+	if len(filter.AddressSets) > MaxCriteria {
+		return nil, fmt.Errorf("too many address sets, exceeds %v", MaxCriteria)
+	}
 	var args []interface{}
-	stmt := "SELECT * FROM transfer WHERE 1"
+	stmt := "SELECT * FROM transfer WHERE 1=1"
 	condition := "blockNumber"
 	if filter.Range != nil {
 		if filter.Range.Unit == Time {
@@ -150,9 +243,9 @@ func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([
 	if length > 0 {
 		for i, addressSet := range filter.AddressSets {
 			if i == 0 {
-				stmt += " AND (( 1 "
+				stmt += " AND (( 1=1 "
 			} else {
-				stmt += " OR ( 1 "
+				stmt += " OR ( 1=1 "
 			}
 			if addressSet.TxOrigin != nil {
 				args = append(args, addressSet.TxOrigin.Bytes())
@@ -173,20 +266,58 @@ func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([
 			}
 		}
 	}
+	if filter.Options != nil && filter.Options.Cursor != nil {
+		cond, condArgs := cursorCondition("transferIndex", filter.Options.Cursor, filter.Order)
+		stmt += cond
+		args = append(args, condArgs...)
+	}
 	if filter.Order == DESC {
 		stmt += " ORDER BY blockNumber DESC,transferIndex DESC "
 	} else {
 		stmt += " ORDER BY blockNumber ASC,transferIndex ASC "
 	}
 	if filter.Options != nil {
-		stmt += " limit ?, ? "
-		args = append(args, filter.Options.Offset, filter.Options.Limit)
+		stmt += " LIMIT ? OFFSET ? "
+		args = append(args, filter.Options.Limit, filter.Options.offset())
 	}
 	return db.queryTransfers(ctx, stmt, args...)
 }
 
+// FilterTransactions queries transactions sent by the given origin.
+func (db *LogDB) FilterTransactions(ctx context.Context, filter *TransactionFilter) ([]*Transaction, error) {
+	args := []interface{}{filter.TxOrigin.Bytes()}
+	stmt := "SELECT * FROM txn WHERE txOrigin = ?"
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	if filter.Options != nil && filter.Options.Cursor != nil {
+		cond, condArgs := cursorCondition("txIndex", filter.Options.Cursor, filter.Order)
+		stmt += cond
+		args = append(args, condArgs...)
+	}
+	if filter.Order == DESC {
+		stmt += " ORDER BY blockNumber DESC,txIndex DESC "
+	} else {
+		stmt += " ORDER BY blockNumber ASC,txIndex ASC "
+	}
+	if filter.Options != nil {
+		stmt += " LIMIT ? OFFSET ? "
+		args = append(args, filter.Options.Limit, filter.Options.offset())
+	}
+	return db.queryTransactions(ctx, stmt, args...)
+}
+
 func (db *LogDB) queryEvents(ctx context.Context, stmt string, args ...interface{}) ([]*Event, error) {
-	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +383,7 @@ func (db *LogDB) queryEvents(ctx context.Context, stmt string, args ...interface
 }
 
 func (db *LogDB) queryTransfers(ctx context.Context, stmt string, args ...interface{}) ([]*Transfer, error) {
-	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -307,6 +438,52 @@ func (db *LogDB) queryTransfers(ctx context.Context, stmt string, args ...interf
 	return transfers, nil
 }
 
+func (db *LogDB) queryTransactions(ctx context.Context, stmt string, args ...interface{}) ([]*Transaction, error) {
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var txs []*Transaction
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID     []byte
+			index       uint32
+			blockNumber uint32
+			blockTime   uint64
+			txID        []byte
+			txOrigin    []byte
+		)
+		if err := rows.Scan(
+			&blockID,
+			&index,
+			&blockNumber,
+			&blockTime,
+			&txID,
+			&txOrigin,
+		); err != nil {
+			return nil, err
+		}
+		txs = append(txs, &Transaction{
+			BlockID:     thor.BytesToBytes32(blockID),
+			Index:       index,
+			BlockNumber: blockNumber,
+			BlockTime:   blockTime,
+			TxID:        thor.BytesToBytes32(txID),
+			TxOrigin:    thor.BytesToAddress(txOrigin),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 func topicValue(topic *thor.Bytes32) []byte {
 	if topic == nil {
 		return nil
@@ -315,18 +492,30 @@ func topicValue(topic *thor.Bytes32) []byte {
 }
 
 type BlockBatch struct {
-	db        *sql.DB
+	db        *LogDB
 	header    *block.Header
 	events    []*Event
 	transfers []*Transfer
+	txs       []*Transaction
 }
 
-func (bb *BlockBatch) execInTx(proc func(*sql.Tx) error) (err error) {
-	tx, err := bb.db.Begin()
+// dbTx wraps sql tx to bind statements for the dialect.
+type dbTx struct {
+	*sql.Tx
+	dialect *dialect
+}
+
+func (tx dbTx) exec(stmt string, args ...interface{}) error {
+	_, err := tx.Exec(tx.dialect.rebind(stmt), args...)
+	return err
+}
+
+func (bb *BlockBatch) execInTx(proc func(dbTx) error) (err error) {
+	tx, err := bb.db.db.Begin()
 	if err != nil {
 		return err
 	}
-	if err := proc(tx); err != nil {
+	if err := proc(dbTx{tx, bb.db.dialect}); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -334,9 +523,26 @@ func (bb *BlockBatch) execInTx(proc func(*sql.Tx) error) (err error) {
 }
 
 func (bb *BlockBatch) Commit(abandonedBlocks ...thor.Bytes32) error {
-	return bb.execInTx(func(tx *sql.Tx) error {
+	return bb.execInTx(func(tx dbTx) error {
+		// replace logs of the block if committed before
+		blockID := bb.header.ID()
+		if len(bb.events) > 0 {
+			if err := tx.exec("DELETE FROM event WHERE blockID = ?;", blockID.Bytes()); err != nil {
+				return err
+			}
+		}
+		if len(bb.transfers) > 0 {
+			if err := tx.exec("DELETE FROM transfer WHERE blockID = ?;", blockID.Bytes()); err != nil {
+				return err
+			}
+		}
+		if len(bb.txs) > 0 {
+			if err := tx.exec("DELETE FROM txn WHERE blockID = ?;", blockID.Bytes()); err != nil {
+				return err
+			}
+		}
 		for _, event := range bb.events {
-			if _, err := tx.Exec("INSERT OR REPLACE INTO event(blockID ,eventIndex, blockNumber ,blockTime ,txID ,txOrigin ,address ,topic0 ,topic1 ,topic2 ,topic3 ,topic4, data) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			if err := tx.exec("INSERT INTO event(blockID ,eventIndex, blockNumber ,blockTime ,txID ,txOrigin ,address ,topic0 ,topic1 ,topic2 ,topic3 ,topic4, data) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
 				event.BlockID.Bytes(),
 				event.Index,
 				event.BlockNumber,
@@ -356,7 +562,7 @@ func (bb *BlockBatch) Commit(abandonedBlocks ...thor.Bytes32) error {
 		}
 
 		for _, transfer := range bb.transfers {
-			if _, err := tx.Exec("INSERT OR REPLACE INTO transfer(blockID ,transferIndex, blockNumber ,blockTime ,txID ,txOrigin ,sender ,recipient ,amount) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			if err := tx.exec("INSERT INTO transfer(blockID ,transferIndex, blockNumber ,blockTime ,txID ,txOrigin ,sender ,recipient ,amount) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?);",
 				transfer.BlockID.Bytes(),
 				transfer.Index,
 				transfer.BlockNumber,
@@ -370,11 +576,32 @@ func (bb *BlockBatch) Commit(abandonedBlocks ...thor.Bytes32) error {
 				return err
 			}
 		}
+		for _, trx := range bb.txs {
+			if err := tx.exec("INSERT INTO txn(blockID ,txIndex, blockNumber ,blockTime ,txID ,txOrigin) VALUES ( ?, ?, ?, ?, ?, ?);",
+				trx.BlockID.Bytes(),
+				trx.Index,
+				trx.BlockNumber,
+				trx.BlockTime,
+				trx.TxID.Bytes(),
+				trx.TxOrigin.Bytes(),
+			); err != nil {
+				return err
+			}
+		}
+		// genesis logs are committed on every start up, so excluded to not reset head
+		if bb.header.Number() > 0 {
+			if err := saveHead(tx, bb.header.ID()); err != nil {
+				return err
+			}
+		}
 		for _, id := range abandonedBlocks {
-			if _, err := tx.Exec("DELETE FROM event WHERE blockID = ?;", id.Bytes()); err != nil {
+			if err := tx.exec("DELETE FROM event WHERE blockID = ?;", id.Bytes()); err != nil {
+				return err
+			}
+			if err := tx.exec("DELETE FROM transfer WHERE blockID = ?;", id.Bytes()); err != nil {
 				return err
 			}
-			if _, err := tx.Exec("DELETE FROM transfer WHERE blockID = ?;", id.Bytes()); err != nil {
+			if err := tx.exec("DELETE FROM txn WHERE blockID = ?;", id.Bytes()); err != nil {
 				return err
 			}
 		}
@@ -382,8 +609,31 @@ func (bb *BlockBatch) Commit(abandonedBlocks ...thor.Bytes32) error {
 	})
 }
 
+// ForBlock returns a function to insert logs not emitted by any transaction, e.g. those of genesis block.
+// Unlike ForTransaction, no transaction is recorded.
+func (bb *BlockBatch) ForBlock() struct {
+	Insert func(tx.Events, tx.Transfers) *BlockBatch
+} {
+	return bb.insertFunc(thor.Bytes32{}, thor.Address{})
+}
+
+// ForTransaction records the transaction, and returns a function to insert its logs.
 func (bb *BlockBatch) ForTransaction(txID thor.Bytes32, txOrigin thor.Address) struct {
 	Insert func(tx.Events, tx.Transfers) *BlockBatch
+} {
+	bb.txs = append(bb.txs, &Transaction{
+		BlockID:     bb.header.ID(),
+		Index:       uint32(len(bb.txs)),
+		BlockNumber: bb.header.Number(),
+		BlockTime:   bb.header.Timestamp(),
+		TxID:        txID,
+		TxOrigin:    txOrigin,
+	})
+	return bb.insertFunc(txID, txOrigin)
+}
+
+func (bb *BlockBatch) insertFunc(txID thor.Bytes32, txOrigin thor.Address) struct {
+	Insert func(tx.Events, tx.Transfers) *BlockBatch
 } {
 	return struct {
 		Insert func(events tx.Events, transfers tx.Transfers) *BlockBatch
@@ -127,6 +127,120 @@ func TestTransfers(t *testing.T) {
 	assert.Equal(t, len(ts), count, "transfers searched")
 }
 
+func TestTransactions(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	origin := thor.BytesToAddress([]byte("txOrigin"))
+	header := new(block.Builder).Build().Header()
+	var abandoned thor.Bytes32
+	for i := 0; i < 10; i++ {
+		batch := db.Prepare(header)
+		batch.ForTransaction(thor.BytesToBytes32([]byte{byte(i), 0}), origin)
+		batch.ForTransaction(thor.BytesToBytes32([]byte{byte(i), 1}), thor.BytesToAddress([]byte("other")))
+		if err := batch.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		abandoned = header.ID()
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+	}
+
+	txs, err := db.FilterTransactions(context.Background(), &logdb.TransactionFilter{
+		TxOrigin: origin,
+		Options:  &logdb.Options{Offset: 1, Limit: 3},
+		Order:    logdb.DESC,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(txs))
+	assert.Equal(t, thor.BytesToBytes32([]byte{8, 0}), txs[0].TxID)
+	// block numbers start from 1, since the first block has zero parent ID
+	assert.Equal(t, uint32(9), txs[0].BlockNumber)
+	assert.Equal(t, uint32(0), txs[0].Index)
+
+	// txs in abandoned block are removed
+	if err := db.Prepare(header).Commit(abandoned); err != nil {
+		t.Fatal(err)
+	}
+	txs, err = db.FilterTransactions(context.Background(), &logdb.TransactionFilter{TxOrigin: origin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 9, len(txs))
+}
+
+func TestBlockLogs(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	header := new(block.Builder).Build().Header()
+	if err := db.Prepare(header).ForBlock().
+		Insert(tx.Events{{Address: thor.BytesToAddress([]byte("addr"))}}, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(es))
+
+	// no tx recorded
+	txs, err := db.FilterTransactions(context.Background(), &logdb.TransactionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(txs))
+}
+
+func TestHeadAndRewind(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	head, err := db.Head()
+	assert.Nil(t, err)
+	assert.True(t, head.IsZero())
+
+	origin := thor.BytesToAddress([]byte("txOrigin"))
+	var headers []*block.Header
+	header := new(block.Builder).Build().Header()
+	for i := 0; i < 5; i++ {
+		batch := db.Prepare(header)
+		batch.ForTransaction(thor.BytesToBytes32([]byte{byte(i)}), origin)
+		if err := batch.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		headers = append(headers, header)
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+	}
+	head, err = db.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, headers[4].ID(), head)
+
+	if err := db.Rewind(headers[2]); err != nil {
+		t.Fatal(err)
+	}
+	head, err = db.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, headers[2].ID(), head)
+
+	txs, err := db.FilterTransactions(context.Background(), &logdb.TransactionFilter{TxOrigin: origin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(txs))
+}
+
 func home() (string, error) {
 	// try to get HOME env
 	if home := os.Getenv("HOME"); home != "" {
@@ -176,3 +290,72 @@ func BenchmarkLog(b *testing.B) {
 		}
 	}
 }
+
+func TestFilterEventsByCriteria(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	addr3 := thor.BytesToAddress([]byte("addr3"))
+	t0 := thor.BytesToBytes32([]byte("topic0"))
+	t1 := thor.BytesToBytes32([]byte("topic1"))
+
+	header := new(block.Builder).Build().Header()
+	if err := db.Prepare(header).ForTransaction(thor.BytesToBytes32([]byte("txID")), thor.Address{}).
+		Insert(tx.Events{
+			{Address: addr1, Topics: []thor.Bytes32{t0}},
+			{Address: addr2, Topics: []thor.Bytes32{t0, t1}},
+			{Address: addr3, Topics: []thor.Bytes32{t0}},
+			{Address: addr3, Topics: []thor.Bytes32{t1}},
+		}, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := func(criteriaSet ...*logdb.EventCriteria) []thor.Address {
+		es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{CriteriaSet: criteriaSet})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var addrs []thor.Address
+		for _, e := range es {
+			addrs = append(addrs, e.Address)
+		}
+		return addrs
+	}
+
+	assert.Equal(t, []thor.Address{addr1, addr2}, filter(&logdb.EventCriteria{Addresses: []thor.Address{addr1, addr2}}))
+	assert.Equal(t, []thor.Address{addr2, addr3}, filter(&logdb.EventCriteria{Topics: [5]*thor.Bytes32{nil, &t1}},
+		&logdb.EventCriteria{Addresses: []thor.Address{addr3}, Topics: [5]*thor.Bytes32{&t1}}))
+	assert.Equal(t, []thor.Address{addr1, addr3}, filter(&logdb.EventCriteria{Addresses: []thor.Address{addr1, addr3}, Topics: [5]*thor.Bytes32{&t0}}))
+}
+
+func TestFilterLimits(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	criteriaSet := make([]*logdb.EventCriteria, logdb.MaxCriteria+1)
+	for i := range criteriaSet {
+		criteriaSet[i] = &logdb.EventCriteria{}
+	}
+	_, err = db.FilterEvents(context.Background(), &logdb.EventFilter{CriteriaSet: criteriaSet})
+	assert.NotNil(t, err, "too many criteria")
+
+	_, err = db.FilterEvents(context.Background(), &logdb.EventFilter{CriteriaSet: []*logdb.EventCriteria{
+		{Addresses: make([]thor.Address, 1000)},
+	}})
+	assert.NotNil(t, err, "too many query parameters")
+
+	addressSets := make([]*logdb.AddressSet, logdb.MaxCriteria+1)
+	for i := range addressSets {
+		addressSets[i] = &logdb.AddressSet{}
+	}
+	_, err = db.FilterTransfers(context.Background(), &logdb.TransferFilter{AddressSets: addressSets})
+	assert.NotNil(t, err, "too many address sets")
+}
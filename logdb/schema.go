@@ -47,10 +47,97 @@ CREATE INDEX IF NOT EXISTS topicIndex4 ON event(topic4);`
 	amount BLOB
 );
 
-CREATE UNIQUE INDEX IF NOT EXISTS prim ON transfer(blockID, transferIndex);
+CREATE UNIQUE INDEX IF NOT EXISTS transferPrim ON transfer(blockID, transferIndex);
 
-CREATE INDEX IF NOT EXISTS blockNumberIndex ON transfer(blockNumber);
-CREATE INDEX IF NOT EXISTS blockTimeIndex ON transfer(blockTime);
-CREATE INDEX IF NOT EXISTS senderIndex ON transfer(sender);
-CREATE INDEX IF NOT EXISTS recipientIndex ON transfer(recipient);`
+CREATE INDEX IF NOT EXISTS transferBlockNumberIndex ON transfer(blockNumber);
+CREATE INDEX IF NOT EXISTS transferBlockTimeIndex ON transfer(blockTime);
+CREATE INDEX IF NOT EXISTS transferSenderIndex ON transfer(sender);
+CREATE INDEX IF NOT EXISTS transferRecipientIndex ON transfer(recipient);`
+
+	// create a table for transactions, indexed by origin
+	transactionTableSchema = `CREATE TABLE IF NOT EXISTS txn (
+	blockID	BLOB(32),
+	txIndex INTEGER,
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	txID BLOB(32),
+	txOrigin BLOB(20)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS txnPrim ON txn(blockID, txIndex);
+
+CREATE INDEX IF NOT EXISTS txnOriginIndex ON txn(txOrigin, blockNumber, txIndex);
+CREATE INDEX IF NOT EXISTS txnBlockTimeIndex ON txn(blockTime);`
+
+	// create a table for states of log db itself
+	configTableSchema = `CREATE TABLE IF NOT EXISTS config (
+	key TEXT PRIMARY KEY,
+	value BLOB
+);`
+
+	// schema for PostgreSQL, the same tables in its types
+	postgresSchema = `CREATE TABLE IF NOT EXISTS event (
+	blockID	BYTEA,
+	eventIndex INTEGER,
+	blockNumber BIGINT,
+	blockTime BIGINT,
+	txID BYTEA,
+	txOrigin BYTEA,
+	address BYTEA,
+	topic0 BYTEA,
+	topic1 BYTEA,
+	topic2 BYTEA,
+	topic3 BYTEA,
+	topic4 BYTEA,
+	data BYTEA
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS eventPrim ON event(blockID, eventIndex);
+
+CREATE INDEX IF NOT EXISTS eventBlockNumberIndex ON event(blockNumber);
+CREATE INDEX IF NOT EXISTS eventBlockTimeIndex ON event(blockTime);
+CREATE INDEX IF NOT EXISTS eventAddressIndex ON event(address);
+CREATE INDEX IF NOT EXISTS eventTopicIndex0 ON event(topic0);
+CREATE INDEX IF NOT EXISTS eventTopicIndex1 ON event(topic1);
+CREATE INDEX IF NOT EXISTS eventTopicIndex2 ON event(topic2);
+CREATE INDEX IF NOT EXISTS eventTopicIndex3 ON event(topic3);
+CREATE INDEX IF NOT EXISTS eventTopicIndex4 ON event(topic4);
+
+CREATE TABLE IF NOT EXISTS transfer (
+	blockID	BYTEA,
+	transferIndex INTEGER,
+	blockNumber BIGINT,
+	blockTime BIGINT,
+	txID BYTEA,
+	txOrigin BYTEA,
+	sender BYTEA,
+	recipient BYTEA,
+	amount BYTEA
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS transferPrim ON transfer(blockID, transferIndex);
+
+CREATE INDEX IF NOT EXISTS transferBlockNumberIndex ON transfer(blockNumber);
+CREATE INDEX IF NOT EXISTS transferBlockTimeIndex ON transfer(blockTime);
+CREATE INDEX IF NOT EXISTS transferSenderIndex ON transfer(sender);
+CREATE INDEX IF NOT EXISTS transferRecipientIndex ON transfer(recipient);
+
+CREATE TABLE IF NOT EXISTS txn (
+	blockID	BYTEA,
+	txIndex INTEGER,
+	blockNumber BIGINT,
+	blockTime BIGINT,
+	txID BYTEA,
+	txOrigin BYTEA
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS txnPrim ON txn(blockID, txIndex);
+
+CREATE INDEX IF NOT EXISTS txnOriginIndex ON txn(txOrigin, blockNumber, txIndex);
+CREATE INDEX IF NOT EXISTS txnBlockTimeIndex ON txn(blockTime);
+
+CREATE TABLE IF NOT EXISTS config (
+	key TEXT PRIMARY KEY,
+	value BYTEA
+);`
 )
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/vechain/thor/thor"
+)
+
+// secondsPerDay is the length of a UTC day, to aggregate records by day.
+const secondsPerDay = 24 * 3600
+
+// ContractEventCount is the number of events emitted by a contract.
+type ContractEventCount struct {
+	Address thor.Address
+	Count   uint64
+}
+
+// DailyTransfers aggregates VET transfers in a UTC day.
+type DailyTransfers struct {
+	Day    uint64 // timestamp of the beginning of the day
+	Count  uint64
+	Amount *big.Int
+}
+
+// DailyTransactions is the number of transactions in a UTC day.
+type DailyTransactions struct {
+	Day   uint64 // timestamp of the beginning of the day
+	Count uint64
+}
+
+// rangeCondition returns the condition to select records in the range.
+func rangeCondition(rng *Range) (string, []interface{}) {
+	if rng == nil {
+		return "", nil
+	}
+	column := "blockNumber"
+	if rng.Unit == Time {
+		column = "blockTime"
+	}
+	stmt := " AND " + column + " >= ? "
+	args := []interface{}{rng.From}
+	if rng.To >= rng.From {
+		stmt += " AND " + column + " <= ? "
+		args = append(args, rng.To)
+	}
+	return stmt, args
+}
+
+// CountEventsByContract counts events in the range per contract, in descending order by count.
+// At most limit contracts are returned.
+func (db *LogDB) CountEventsByContract(ctx context.Context, rng *Range, limit uint64) ([]*ContractEventCount, error) {
+	cond, args := rangeCondition(rng)
+	stmt := "SELECT address, COUNT(*) AS n FROM event WHERE 1=1" + cond +
+		" GROUP BY address ORDER BY n DESC, address ASC LIMIT ? "
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*ContractEventCount
+	for rows.Next() {
+		var (
+			address []byte
+			count   uint64
+		)
+		if err := rows.Scan(&address, &count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, &ContractEventCount{thor.BytesToAddress(address), count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// DailyTransfers aggregates VET transfers in the range by day, in ascending order.
+// Amounts are stored as blobs, so they are summed up while scanning rows.
+func (db *LogDB) DailyTransfers(ctx context.Context, rng *Range) ([]*DailyTransfers, error) {
+	cond, args := rangeCondition(rng)
+	stmt := "SELECT blockTime, amount FROM transfer WHERE 1=1" + cond + " ORDER BY blockTime ASC "
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []*DailyTransfers
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockTime uint64
+			amount    []byte
+		)
+		if err := rows.Scan(&blockTime, &amount); err != nil {
+			return nil, err
+		}
+		day := blockTime - blockTime%secondsPerDay
+		if len(days) == 0 || days[len(days)-1].Day != day {
+			days = append(days, &DailyTransfers{Day: day, Amount: new(big.Int)})
+		}
+		last := days[len(days)-1]
+		last.Count++
+		last.Amount.Add(last.Amount, new(big.Int).SetBytes(amount))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// DailyTransactions counts transactions in the range by day, in ascending order.
+// The placeholder row of genesis block written by earlier versions, which has zero tx ID, is not counted.
+func (db *LogDB) DailyTransactions(ctx context.Context, rng *Range) ([]*DailyTransactions, error) {
+	cond, args := rangeCondition(rng)
+	stmt := "SELECT blockTime / ? AS day, COUNT(*) FROM txn WHERE txID <> ?" + cond + " GROUP BY day ORDER BY day ASC "
+	rows, err := db.db.QueryContext(ctx, db.dialect.rebind(stmt), append([]interface{}{secondsPerDay, thor.Bytes32{}.Bytes()}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []*DailyTransactions
+	for rows.Next() {
+		var day, count uint64
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		days = append(days, &DailyTransactions{day * secondsPerDay, count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func TestStats(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const day = 24 * 3600
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	origin := thor.BytesToAddress([]byte("origin"))
+
+	header := new(block.Builder).Build().Header()
+	// placeholder tx row with zero ID, as inserted for genesis by earlier versions
+	if err := db.Prepare(header).ForTransaction(thor.Bytes32{}, thor.Address{}).Insert(nil, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+	for i, ts := range []uint64{day + 10, day + 20, 2*day + 5} {
+		header = new(block.Builder).ParentID(header.ID()).Timestamp(ts).Build().Header()
+		addr := addr1
+		if i == 2 {
+			addr = addr2
+		}
+		if err := db.Prepare(header).ForTransaction(thor.BytesToBytes32([]byte{byte(i + 1)}), origin).
+			Insert(tx.Events{{Address: addr1}, {Address: addr}},
+				tx.Transfers{{Sender: origin, Recipient: addr, Amount: big.NewInt(int64(i + 1))}}).
+			Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := db.CountEventsByContract(context.Background(), nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*logdb.ContractEventCount{{addr1, 5}, {addr2, 1}}, counts)
+
+	counts, err = db.CountEventsByContract(context.Background(), &logdb.Range{Unit: logdb.Block, From: 3, To: 3}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*logdb.ContractEventCount{{addr1, 2}}, counts)
+
+	transfers, err := db.DailyTransfers(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*logdb.DailyTransfers{
+		{Day: day, Count: 2, Amount: big.NewInt(3)},
+		{Day: 2 * day, Count: 1, Amount: big.NewInt(3)},
+	}, transfers)
+
+	txs, err := db.DailyTransactions(context.Background(), &logdb.Range{Unit: logdb.Time, From: day + 15, To: 3 * day})
+	assert.Nil(t, err)
+	assert.Equal(t, []*logdb.DailyTransactions{{day, 1}, {2 * day, 1}}, txs)
+
+	txs, err = db.DailyTransactions(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*logdb.DailyTransactions{{day, 2}, {2 * day, 1}}, txs)
+}
@@ -72,6 +72,16 @@ func newTransfer(header *block.Header, index uint32, txID thor.Bytes32, txOrigin
 	}
 }
 
+//Transaction represents a transaction in block, indexed by origin.
+type Transaction struct {
+	BlockID     thor.Bytes32
+	Index       uint32
+	BlockNumber uint32
+	BlockTime   uint64
+	TxID        thor.Bytes32
+	TxOrigin    thor.Address
+}
+
 type RangeType string
 
 const (
@@ -92,18 +102,36 @@ type Range struct {
 	To   uint64
 }
 
+// Options for paging. If cursor is set, records after the cursor are queried and offset is ignored.
+// Paging by cursor stays fast for deep pages, since no skipped records are scanned.
 type Options struct {
 	Offset uint64
 	Limit  uint64
+	Cursor *Cursor `json:",omitempty"`
+}
+
+func (o *Options) offset() uint64 {
+	if o.Cursor != nil {
+		return 0
+	}
+	return o.Offset
+}
+
+// EventCriteria matches events emitted by any of the addresses, with topics at each position.
+// Empty addresses or nil topic matches any.
+type EventCriteria struct {
+	Addresses []thor.Address
+	Topics    [5]*thor.Bytes32
 }
 
 //EventFilter filter
 type EventFilter struct {
-	Address  *thor.Address // always a contract address
-	TopicSet [][5]*thor.Bytes32
-	Range    *Range
-	Options  *Options
-	Order    Order //default asc
+	Address     *thor.Address // always a contract address
+	TopicSet    [][5]*thor.Bytes32
+	CriteriaSet []*EventCriteria // events matching any of the criteria
+	Range       *Range
+	Options     *Options
+	Order       Order //default asc
 }
 
 type AddressSet struct {
@@ -119,3 +147,11 @@ type TransferFilter struct {
 	Options     *Options
 	Order       Order //default asc
 }
+
+//TransactionFilter filter
+type TransactionFilter struct {
+	TxOrigin thor.Address
+	Range    *Range
+	Options  *Options
+	Order    Order //default asc
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/vechain/thor/kv"
 )
 
-var _ kv.GetPutCloser = (*LevelDB)(nil)
+var _ kv.Store = (*LevelDB)(nil)
 
 // Options options for creating level db instance.
 type Options struct {
@@ -28,7 +28,8 @@ var readOpt = opt.ReadOptions{}
 
 // LevelDB wraps level db impls.
 type LevelDB struct {
-	db *leveldb.DB
+	db  *leveldb.DB
+	stg storage.Storage
 }
 
 // New create a persistent level db instance.
@@ -64,9 +65,10 @@ func openLevelDB(stg storage.Storage, cacheSize, openFilesCacheCapacity int) (*L
 	})
 
 	if err != nil {
+		stg.Close()
 		return nil, errors.Wrap(err, "open level db")
 	}
-	return &LevelDB{db: db}, nil
+	return &LevelDB{db: db, stg: stg}, nil
 }
 
 // IsNotFound to check if the error returned by Get indicates key not found.
@@ -95,10 +97,19 @@ func (ldb *LevelDB) Delete(key []byte) error {
 	return ldb.db.Delete(key, &writeOpt)
 }
 
+// Compact compacts the whole key range of the level db.
+func (ldb *LevelDB) Compact() error {
+	return ldb.db.CompactRange(util.Range{})
+}
+
 // Close close the level db.
 // Later operations will all fail.
 func (ldb *LevelDB) Close() error {
-	return ldb.db.Close()
+	if err := ldb.db.Close(); err != nil {
+		return err
+	}
+	// the storage is not closed along with db, which holds the file lock
+	return ldb.stg.Close()
 }
 
 // NewBatch create a batch for writing ops.
@@ -0,0 +1,46 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package metric_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/metric"
+)
+
+func TestHandler(t *testing.T) {
+	counter := metric.NewCounter("test", "counter", "test counter")
+	gauge := metric.NewGauge("test", "gauge", "test gauge")
+	histogram := metric.NewHistogramVec("test", "duration_seconds", "test histogram", nil, "name")
+
+	counter.Add(3)
+	gauge.Set(7)
+	histogram.WithLabelValues("foo").Observe(time.Second.Seconds())
+
+	ts := httptest.NewServer(metric.Handler())
+	defer ts.Close()
+
+	res, err := ts.Client().Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(body)
+
+	assert.True(t, strings.Contains(text, "thor_test_counter 3"))
+	assert.True(t, strings.Contains(text, "thor_test_gauge 7"))
+	assert.True(t, strings.Contains(text, `thor_test_duration_seconds_count{name="foo"} 1`))
+	assert.True(t, strings.Contains(text, "go_goroutines"))
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/pkg/errors"
+)
+
+// Allowlist is the set of nodes allowed to connect in permissioned mode.
+// It's backed by a file with one enode URL per line, where the address part can be omitted
+// (e.g. 'enode://<node id>') if the node is never dialed. Empty lines and lines start with '#' are ignored.
+type Allowlist struct {
+	path  string
+	lock  sync.RWMutex
+	nodes map[discover.NodeID]*discover.Node
+}
+
+// LoadAllowlist loads the allowlist from file at path. The file is created on first change if not exist.
+func LoadAllowlist(path string) (*Allowlist, error) {
+	al := &Allowlist{
+		path:  path,
+		nodes: make(map[discover.NodeID]*discover.Node),
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return al, nil
+		}
+		return nil, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		node, err := discover.ParseNode(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %v", lineNum)
+		}
+		al.nodes[node.ID] = node
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return al, nil
+}
+
+// Contains returns whether the node is allowed.
+func (al *Allowlist) Contains(id discover.NodeID) bool {
+	al.lock.RLock()
+	defer al.lock.RUnlock()
+	return al.nodes[id] != nil
+}
+
+// Nodes returns all allowed nodes, sorted by ID.
+func (al *Allowlist) Nodes() []*discover.Node {
+	al.lock.RLock()
+	defer al.lock.RUnlock()
+	return al.sortedNodes()
+}
+
+// Add adds the node, or replaces the one with the same ID, and saves the file.
+func (al *Allowlist) Add(node *discover.Node) error {
+	al.lock.Lock()
+	defer al.lock.Unlock()
+
+	prev := al.nodes[node.ID]
+	al.nodes[node.ID] = node
+	if err := al.save(); err != nil {
+		if prev != nil {
+			al.nodes[node.ID] = prev
+		} else {
+			delete(al.nodes, node.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove removes the node and saves the file. It returns the removed node, or nil if not found.
+func (al *Allowlist) Remove(id discover.NodeID) (*discover.Node, error) {
+	al.lock.Lock()
+	defer al.lock.Unlock()
+
+	node := al.nodes[id]
+	if node == nil {
+		return nil, nil
+	}
+	delete(al.nodes, id)
+	if err := al.save(); err != nil {
+		al.nodes[id] = node
+		return nil, err
+	}
+	return node, nil
+}
+
+func (al *Allowlist) sortedNodes() []*discover.Node {
+	nodes := make([]*discover.Node, 0, len(al.nodes))
+	for _, node := range al.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i].ID[:], nodes[j].ID[:]) < 0
+	})
+	return nodes
+}
+
+// save writes nodes to the file atomically. lock should be held.
+func (al *Allowlist) save() error {
+	var buf bytes.Buffer
+	for _, node := range al.sortedNodes() {
+		buf.WriteString(node.String())
+		buf.WriteByte('\n')
+	}
+	tmp := al.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, al.path)
+}
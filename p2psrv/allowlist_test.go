@@ -0,0 +1,80 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/p2psrv"
+)
+
+func newNodeID() discover.NodeID {
+	key, _ := crypto.GenerateKey()
+	return discover.PubkeyID(&key.PublicKey)
+}
+
+func TestAllowlist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "allowlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "allowlist")
+
+	withAddr := discover.NewNode(newNodeID(), net.ParseIP("127.0.0.1"), 11235, 11235)
+	idOnly := discover.NewNode(newNodeID(), nil, 0, 0)
+	content := "# consortium members\n\n" + withAddr.String() + "\n  " + idOnly.String() + "  \n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	al, err := p2psrv.LoadAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(al.Nodes()))
+	assert.True(t, al.Contains(withAddr.ID))
+	assert.True(t, al.Contains(idOnly.ID))
+
+	other := discover.NewNode(newNodeID(), net.ParseIP("10.0.0.1"), 11235, 11235)
+	assert.False(t, al.Contains(other.ID))
+	assert.Nil(t, al.Add(other))
+
+	removed, err := al.Remove(withAddr.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, withAddr.ID, removed.ID)
+	removed, err = al.Remove(withAddr.ID)
+	assert.Nil(t, err)
+	assert.Nil(t, removed)
+
+	// reload
+	al, err = p2psrv.LoadAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(al.Nodes()))
+	assert.False(t, al.Contains(withAddr.ID))
+	assert.True(t, al.Contains(idOnly.ID))
+	assert.True(t, al.Contains(other.ID))
+
+	// missing file means empty allowlist
+	al, err = p2psrv.LoadAllowlist(filepath.Join(dir, "none"))
+	assert.Nil(t, err)
+	assert.Empty(t, al.Nodes())
+
+	if err := ioutil.WriteFile(path, []byte("bad enode\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = p2psrv.LoadAllowlist(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package dnsdisc resolves node lists published in DNS, as specified by EIP-1459.
+//
+// A list is addressed by url 'enrtree://<public key>@<domain>'. The TXT record of the domain is the
+// signed root, which points to the tree of nodes and the tree of links to other lists. Each tree entry
+// is stored at subdomain '<hash>.<domain>', where hash is the base32 of the first 16 bytes of keccak256
+// of the entry, so the whole tree is authenticated by the root signature.
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/pkg/errors"
+)
+
+const (
+	rootPrefix   = "enrtree-root:v1"
+	branchPrefix = "enrtree-branch:"
+	linkPrefix   = "enrtree://"
+	enrPrefix    = "enr:"
+
+	maxLinkDepth = 4    // max depth of following links to other lists
+	maxEntries   = 5000 // max tree entries resolved for a list, including linked ones
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Resolver looks up TXT records. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// Client resolves nodes from DNS node lists.
+type Client struct {
+	resolver Resolver
+}
+
+// NewClient create a client. net.DefaultResolver is used if resolver is nil.
+func NewClient(resolver Resolver) *Client {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Client{resolver}
+}
+
+// ParseURL parses list url in form of 'enrtree://<base32 compressed public key>@<domain>'.
+func ParseURL(url string) (*ecdsa.PublicKey, string, error) {
+	if !strings.HasPrefix(url, linkPrefix) {
+		return nil, "", errors.New("missing 'enrtree://' scheme")
+	}
+	parts := strings.SplitN(url[len(linkPrefix):], "@", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, "", errors.New("missing domain")
+	}
+	keyBytes, err := b32.DecodeString(parts[0])
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid public key")
+	}
+	key, err := crypto.DecompressPubkey(keyBytes)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid public key")
+	}
+	return key, parts[1], nil
+}
+
+// Resolve returns all nodes in the list at url, and in lists linked from it.
+func (c *Client) Resolve(ctx context.Context, url string) ([]*discover.Node, error) {
+	w := &walker{
+		client: c,
+		seen:   make(map[string]bool),
+	}
+	if err := w.walkList(ctx, url, 0); err != nil {
+		return nil, err
+	}
+	return w.nodes, nil
+}
+
+type root struct {
+	eroot string // root hash of the nodes tree
+	lroot string // root hash of the links tree
+	seq   uint
+}
+
+// resolveRoot looks up the root of the list at domain, and verifies its signature.
+func (c *Client) resolveRoot(ctx context.Context, key *ecdsa.PublicKey, domain string) (*root, error) {
+	txts, err := c.resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, rootPrefix) {
+			continue
+		}
+		var (
+			r   root
+			sig string
+		)
+		if _, err := fmt.Sscanf(txt, rootPrefix+" e=%s l=%s seq=%d sig=%s", &r.eroot, &r.lroot, &r.seq, &sig); err != nil {
+			return nil, errors.Wrap(err, "invalid root")
+		}
+		sigBytes, err := base64.RawURLEncoding.DecodeString(sig)
+		if err != nil || len(sigBytes) != 65 {
+			return nil, errors.New("invalid root signature")
+		}
+		signed := fmt.Sprintf(rootPrefix+" e=%s l=%s seq=%d", r.eroot, r.lroot, r.seq)
+		if !crypto.VerifySignature(crypto.CompressPubkey(key), crypto.Keccak256([]byte(signed)), sigBytes[:64]) {
+			return nil, errors.New("bad root signature")
+		}
+		return &r, nil
+	}
+	return nil, errors.New("root not found")
+}
+
+// resolveEntry looks up the tree entry at '<hash>.<domain>', and verifies it against the hash.
+func (c *Client) resolveEntry(ctx context.Context, domain, hash string) (string, error) {
+	txts, err := c.resolver.LookupTXT(ctx, hash+"."+domain)
+	if err != nil {
+		return "", err
+	}
+	for _, txt := range txts {
+		if strings.EqualFold(entryHash(txt), hash) {
+			return txt, nil
+		}
+	}
+	return "", fmt.Errorf("entry %v not found", hash)
+}
+
+func entryHash(entry string) string {
+	return b32.EncodeToString(crypto.Keccak256([]byte(entry))[:16])
+}
+
+// walker walks trees of a list and linked lists, and collects nodes.
+type walker struct {
+	client  *Client
+	seen    map[string]bool // domains of lists walked
+	entries int
+	nodes   []*discover.Node
+}
+
+func (w *walker) walkList(ctx context.Context, url string, depth int) error {
+	key, domain, err := ParseURL(url)
+	if err != nil {
+		return err
+	}
+	if w.seen[domain] || depth > maxLinkDepth {
+		return nil
+	}
+	w.seen[domain] = true
+
+	r, err := w.client.resolveRoot(ctx, key, domain)
+	if err != nil {
+		return errors.WithMessage(err, domain)
+	}
+	if err := w.walkTree(ctx, domain, r.eroot, false, depth); err != nil {
+		return errors.WithMessage(err, domain)
+	}
+	if err := w.walkTree(ctx, domain, r.lroot, true, depth); err != nil {
+		return errors.WithMessage(err, domain)
+	}
+	return nil
+}
+
+// walkTree walks the tree from the entry of hash. Leaves are links to other lists if links is true, or nodes otherwise.
+func (w *walker) walkTree(ctx context.Context, domain, hash string, links bool, depth int) error {
+	if w.entries++; w.entries > maxEntries {
+		return errors.New("too many entries")
+	}
+	entry, err := w.client.resolveEntry(ctx, domain, hash)
+	if err != nil {
+		return err
+	}
+	switch {
+	case strings.HasPrefix(entry, branchPrefix):
+		for _, child := range strings.Split(entry[len(branchPrefix):], ",") {
+			if child = strings.TrimSpace(child); child == "" {
+				continue
+			}
+			if err := w.walkTree(ctx, domain, child, links, depth); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(entry, enrPrefix) && !links:
+		node, err := parseENR(entry[len(enrPrefix):])
+		if err != nil {
+			return errors.WithMessage(err, "invalid enr "+hash)
+		}
+		w.nodes = append(w.nodes, node)
+	case strings.HasPrefix(entry, linkPrefix) && links:
+		if err := w.walkList(ctx, entry, depth+1); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unexpected entry %v", hash)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapResolver map[string][]string
+
+func (m mapResolver) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	if txts, ok := m[domain]; ok {
+		return txts, nil
+	}
+	return nil, fmt.Errorf("no such host %v", domain)
+}
+
+func newENR(ip string, port uint16) (string, discover.NodeID) {
+	key, _ := crypto.GenerateKey()
+	content := []interface{}{
+		uint64(1),
+		"id", "v4",
+		"ip", []byte(net.ParseIP(ip).To4()),
+		"secp256k1", crypto.CompressPubkey(&key.PublicKey),
+		"tcp", port,
+		"udp", port,
+	}
+	data, _ := rlp.EncodeToBytes(content)
+	sig, _ := crypto.Sign(crypto.Keccak256(data), key)
+	data, _ = rlp.EncodeToBytes(append([]interface{}{sig[:64]}, content...))
+	return enrPrefix + base64.RawURLEncoding.EncodeToString(data), discover.PubkeyID(&key.PublicKey)
+}
+
+// publish publishes a list with given leaves at domain.
+func publish(r mapResolver, key *ecdsa.PrivateKey, domain string, enrs, links []string) string {
+	put := func(entry string) string {
+		hash := entryHash(entry)
+		r[hash+"."+domain] = []string{entry}
+		return hash
+	}
+	branch := func(leaves []string) string {
+		var hashes []string
+		for _, leaf := range leaves {
+			hashes = append(hashes, put(leaf))
+		}
+		return put(branchPrefix + strings.Join(hashes, ","))
+	}
+	e, l := branch(enrs), branch(links)
+	signed := fmt.Sprintf(rootPrefix+" e=%s l=%s seq=%d", e, l, 1)
+	sig, _ := crypto.Sign(crypto.Keccak256([]byte(signed)), key)
+	r[domain] = []string{"v=spf1 -all", signed + " sig=" + base64.RawURLEncoding.EncodeToString(sig)}
+	return linkPrefix + b32.EncodeToString(crypto.CompressPubkey(&key.PublicKey)) + "@" + domain
+}
+
+func TestResolve(t *testing.T) {
+	r := make(mapResolver)
+
+	enr1, id1 := newENR("10.0.0.1", 11235)
+	enr2, id2 := newENR("10.0.0.2", 11235)
+	enr3, id3 := newENR("10.0.0.3", 11235)
+
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	linked := publish(r, key2, "linked.example.org", []string{enr3}, nil)
+	url := publish(r, key1, "nodes.example.org", []string{enr1, enr2}, []string{linked})
+
+	nodes, err := NewClient(r).Resolve(context.Background(), url)
+	assert.Nil(t, err)
+	var ids []discover.NodeID
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	assert.Equal(t, []discover.NodeID{id1, id2, id3}, ids)
+	assert.Equal(t, "10.0.0.1", nodes[0].IP.String())
+	assert.Equal(t, uint16(11235), nodes[0].TCP)
+
+	// root signed by other key
+	_, domain, _ := ParseURL(url)
+	wrongURL := linkPrefix + b32.EncodeToString(crypto.CompressPubkey(&key2.PublicKey)) + "@" + domain
+	_, err = NewClient(r).Resolve(context.Background(), wrongURL)
+	assert.Error(t, err)
+
+	// tampered entry
+	for name, txts := range r {
+		if strings.HasPrefix(txts[0], enrPrefix) && strings.HasSuffix(name, domain) {
+			r[name] = []string{enr3}
+		}
+	}
+	_, err = NewClient(r).Resolve(context.Background(), url)
+	assert.Error(t, err)
+
+	_, _, err = ParseURL("enode://abc@example.org")
+	assert.Error(t, err)
+	_, _, err = ParseURL("enrtree://AAAA@example.org")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dnsdisc
+
+import (
+	"encoding/base64"
+	"net"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+)
+
+// parseENR decodes the base64 encoded node record of 'v4' identity scheme (EIP-778),
+// and verifies its signature.
+func parseENR(text string) (*discover.Node, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, err
+	}
+	// [signature, seq, k, v, ...]
+	var elems []rlp.RawValue
+	if err := rlp.DecodeBytes(data, &elems); err != nil {
+		return nil, err
+	}
+	if len(elems) < 2 || len(elems)%2 != 0 {
+		return nil, errors.New("malformed record")
+	}
+
+	var (
+		sig    []byte
+		id     string
+		pubkey []byte
+		ip     net.IP
+		tcp    uint16
+		udp    uint16
+	)
+	if err := rlp.DecodeBytes(elems[0], &sig); err != nil {
+		return nil, err
+	}
+	for i := 2; i < len(elems); i += 2 {
+		var key string
+		if err := rlp.DecodeBytes(elems[i], &key); err != nil {
+			return nil, err
+		}
+		val := elems[i+1]
+		switch key {
+		case "id":
+			err = rlp.DecodeBytes(val, &id)
+		case "secp256k1":
+			err = rlp.DecodeBytes(val, &pubkey)
+		case "ip":
+			var b []byte
+			if err = rlp.DecodeBytes(val, &b); err == nil && len(b) == net.IPv4len {
+				ip = net.IP(b)
+			}
+		case "ip6":
+			var b []byte
+			if err = rlp.DecodeBytes(val, &b); err == nil && len(b) == net.IPv6len && ip == nil {
+				ip = net.IP(b)
+			}
+		case "tcp":
+			err = rlp.DecodeBytes(val, &tcp)
+		case "udp":
+			err = rlp.DecodeBytes(val, &udp)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "decode "+key)
+		}
+	}
+	if id != "v4" {
+		return nil, errors.New("unsupported identity scheme")
+	}
+	key, err := crypto.DecompressPubkey(pubkey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	content, err := rlp.EncodeToBytes(elems[1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 64 || !crypto.VerifySignature(pubkey, crypto.Keccak256(content), sig) {
+		return nil, errors.New("bad signature")
+	}
+	if ip == nil || tcp == 0 {
+		return nil, errors.New("missing endpoint")
+	}
+	if udp == 0 {
+		udp = tcp
+	}
+	return discover.NewNode(discover.PubkeyID(key), ip, udp, tcp), nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/netutil"
+)
+
+// NATStatus status of the external endpoint, detected via NAT mechanism.
+type NATStatus struct {
+	Mechanism  string // e.g. 'UPnP', 'NAT-PMP', 'ExtIP(1.2.3.4)'
+	ExternalIP net.IP // nil if not detected yet
+	Port       int
+	// Reachable is true if the external endpoint is verified by dialing it, or by inbound connections
+	// from public network.
+	Reachable bool
+}
+
+// NATStatus returns status of the external endpoint, or nil if NAT is not configured.
+func (s *Server) NATStatus() *NATStatus {
+	if s.srv.NAT == nil {
+		return nil
+	}
+	s.natStatus.Lock()
+	defer s.natStatus.Unlock()
+	return &NATStatus{
+		Mechanism:  s.srv.NAT.String(),
+		ExternalIP: s.natStatus.ip,
+		Port:       s.listenPort(),
+		Reachable:  s.natStatus.reachable,
+	}
+}
+
+// natLoop detects the external IP periodically, and verifies if the listening port is reachable through it.
+func (s *Server) natLoop() {
+	const initialDelay = 5 * time.Second // port mapping is set up by p2p server asynchronously
+	const checkInterval = 10 * time.Minute
+
+	timer := time.NewTimer(initialDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-timer.C:
+			s.checkNAT()
+			timer.Reset(checkInterval)
+		}
+	}
+}
+
+func (s *Server) checkNAT() {
+	ip, err := s.srv.NAT.ExternalIP()
+	if err != nil {
+		log.Warn("failed to detect external IP", "nat", s.srv.NAT, "err", err)
+		return
+	}
+	port := s.listenPort()
+	dialed := dialable(ip, port)
+
+	s.natStatus.Lock()
+	changed := !ip.Equal(s.natStatus.ip)
+	if changed {
+		s.natStatus.ip = ip
+		s.natStatus.reachable = false
+	}
+	if dialed && !s.natStatus.reachable {
+		s.natStatus.reachable = true
+		changed = true
+	}
+	reachable := s.natStatus.reachable
+	s.natStatus.Unlock()
+
+	if changed {
+		log.Info("external endpoint detected", "nat", s.srv.NAT, "ip", ip, "port", port, "reachable", reachable)
+		if !reachable {
+			log.Warn("external endpoint not verified reachable, inbound connections may fail", "ip", ip, "port", port)
+		}
+	}
+}
+
+// markReachable marks the external endpoint reachable, if the inbound peer is from public network.
+func (s *Server) markReachable(peer *p2p.Peer) {
+	addr, ok := peer.RemoteAddr().(*net.TCPAddr)
+	if !ok || netutil.IsLAN(addr.IP) {
+		return
+	}
+	s.natStatus.Lock()
+	defer s.natStatus.Unlock()
+	if s.natStatus.ip != nil {
+		s.natStatus.reachable = true
+	}
+}
+
+func (s *Server) listenPort() int {
+	_, port, err := net.SplitHostPort(s.srv.ListenAddr)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(port)
+	return n
+}
+
+// dialable checks if the endpoint accepts TCP connections.
+// It relies on hairpinning support of the NAT device, so false negatives are possible.
+func dialable(ip net.IP, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip.String(), strconv.Itoa(port)), 5*time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
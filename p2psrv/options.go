@@ -44,6 +44,11 @@ type Options struct {
 	// protocol.
 	BootstrapNodes Nodes
 
+	// DNSNodeLists are urls of EIP-1459 DNS node lists, in form of 'enrtree://<key>@<domain>'.
+	// Nodes resolved are used as bootstrap nodes and dial candidates, and refreshed periodically,
+	// so that bootstrap nodes can be rotated by updating DNS records.
+	DNSNodeLists []string
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
@@ -56,4 +61,8 @@ type Options struct {
 
 	// If NoDial is true, the server will not dial any peers.
 	NoDial bool
+
+	// If Allowlist is set, the server runs in permissioned mode, where only allowed nodes
+	// can connect, and discovery is disabled. Allowed nodes with address are dialed as static nodes.
+	Allowlist *Allowlist
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+	"github.com/vechain/thor/metric"
+)
+
+// payloads smaller than this are not worth compressing
+const compressThreshold = 1024
+
+// compression ratio is compressed_bytes_total / raw_bytes_total
+var (
+	metricRawBytes        = metric.NewCounter("p2p", "compression_raw_bytes_total", "size of compressed payloads before compression")
+	metricCompressedBytes = metric.NewCounter("p2p", "compression_compressed_bytes_total", "size of compressed payloads after compression")
+)
+
+// encodePayload encodes the payload, and compresses it if compress is true and it's large enough.
+// The returned bool indicates whether it's compressed.
+func encodePayload(payload interface{}, compress bool) (interface{}, bool, error) {
+	data, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, false, err
+	}
+	if compress && len(data) >= compressThreshold {
+		if compressed := snappy.Encode(nil, data); len(compressed) < len(data) {
+			metricRawBytes.Add(float64(len(data)))
+			metricCompressedBytes.Add(float64(len(compressed)))
+			return compressed, true, nil
+		}
+	}
+	return rlp.RawValue(data), false, nil
+}
+
+// decompressPayload decodes the compressed payload from stream, and returns the decompressed rlp bytes.
+func decompressPayload(stream *rlp.Stream, maxSize uint32) ([]byte, error) {
+	compressed, err := stream.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, err
+	}
+	if size > int(maxSize) {
+		return nil, errMsgTooLarge
+	}
+	return snappy.Decode(nil, compressed)
+}
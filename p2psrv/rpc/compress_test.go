@@ -0,0 +1,50 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompression(t *testing.T) {
+	large := bytes.Repeat([]byte("thor"), compressThreshold)
+
+	// not compressed if disabled or too small
+	_, compressed, err := encodePayload(large, false)
+	assert.Nil(t, err)
+	assert.False(t, compressed)
+	_, compressed, err = encodePayload([]byte("thor"), true)
+	assert.Nil(t, err)
+	assert.False(t, compressed)
+
+	payload, compressed, err := encodePayload(large, true)
+	assert.Nil(t, err)
+	assert.True(t, compressed)
+
+	data, _ := rlp.EncodeToBytes(&msgData{1, flagCompressed, payload})
+	stream := rlp.NewStream(bytes.NewReader(data), 0)
+	stream.List()
+	stream.Decode(new(uint32))
+	stream.Decode(new(uint))
+
+	decompressed, err := decompressPayload(stream, uint32(len(large)*2))
+	assert.Nil(t, err)
+	var decoded []byte
+	assert.Nil(t, rlp.DecodeBytes(decompressed, &decoded))
+	assert.Equal(t, large, decoded)
+
+	// decompressed size over limit
+	stream = rlp.NewStream(bytes.NewReader(data), 0)
+	stream.List()
+	stream.Decode(new(uint32))
+	stream.Decode(new(uint))
+	_, err = decompressPayload(stream, 100)
+	assert.Equal(t, errMsgTooLarge, err)
+}
@@ -6,9 +6,11 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p"
@@ -43,6 +45,7 @@ type RPC struct {
 	pendings map[uint32]*resultListener
 	lock     sync.Mutex
 	logger   log15.Logger
+	compress int32 // 1 if payloads to the peer can be compressed
 }
 
 // New create a new RPC instance.
@@ -64,6 +67,17 @@ func New(peer *p2p.Peer, rw p2p.MsgReadWriter) *RPC {
 	}
 }
 
+// SetCompression sets whether large payloads sent to the peer are compressed.
+// It should be enabled only if the peer is known to accept compressed payloads.
+// Compressed payloads from the peer are always accepted.
+func (r *RPC) SetCompression(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&r.compress, v)
+}
+
 // Done returns a channel to indicates whether peer disconnected.
 func (r *RPC) Done() <-chan struct{} {
 	return r.doneCh
@@ -93,19 +107,27 @@ func (r *RPC) Serve(handleFunc HandleFunc, maxMsgSize uint32) error {
 			return err
 		}
 		var (
-			callID   uint32
-			isResult bool
+			callID uint32
+			flags  uint
 		)
 		if err := stream.Decode(&callID); err != nil {
 			r.logger.Debug("failed to decode msg call id", "err", err)
 			return err
 		}
-		if err := stream.Decode(&isResult); err != nil {
-			r.logger.Debug("failed to decode msg dir flag", "err", err)
+		if err := stream.Decode(&flags); err != nil {
+			r.logger.Debug("failed to decode msg flags", "err", err)
 			return err
 		}
+		if flags&flagCompressed != 0 {
+			data, err := decompressPayload(stream, maxMsgSize)
+			if err != nil {
+				r.logger.Debug("failed to decompress msg", "err", err)
+				return err
+			}
+			msg.Payload, msg.Size = bytes.NewReader(data), uint32(len(data))
+		}
 
-		if isResult {
+		if flags&flagResult != 0 {
 			if err := r.handleResult(callID, &msg); err != nil {
 				r.logger.Debug("handle result", "msg", msg.Code, "callid", callID, "err", err)
 				return err
@@ -113,7 +135,7 @@ func (r *RPC) Serve(handleFunc HandleFunc, maxMsgSize uint32) error {
 		} else {
 			if err := handleFunc(&msg, func(result interface{}) {
 				if callID != 0 {
-					p2p.Send(r.rw, msg.Code, &msgData{callID, true, result})
+					r.send(msg.Code, callID, flagResult, result)
 				}
 				// here we skip result for Notify (callID == 0)
 			}); err != nil {
@@ -178,9 +200,20 @@ func (r *RPC) finalizeCall(id uint32) {
 	delete(r.pendings, id)
 }
 
+func (r *RPC) send(msgCode uint64, id uint32, flags uint, payload interface{}) error {
+	payload, compressed, err := encodePayload(payload, atomic.LoadInt32(&r.compress) == 1)
+	if err != nil {
+		return err
+	}
+	if compressed {
+		flags |= flagCompressed
+	}
+	return p2p.Send(r.rw, msgCode, &msgData{id, flags, payload})
+}
+
 // Notify notifies a message to the peer.
 func (r *RPC) Notify(ctx context.Context, msgCode uint64, arg interface{}) error {
-	return p2p.Send(r.rw, msgCode, &msgData{0, false, arg})
+	return r.send(msgCode, 0, 0, arg)
 }
 
 // Call send a call to the peer and wait for result.
@@ -200,7 +233,7 @@ func (r *RPC) Call(ctx context.Context, msgCode uint64, arg interface{}, result
 	})
 	defer r.finalizeCall(id)
 
-	if err := p2p.Send(r.rw, msgCode, &msgData{id, false, arg}); err != nil {
+	if err := r.send(msgCode, id, 0, arg); err != nil {
 		return err
 	}
 
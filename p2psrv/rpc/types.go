@@ -7,10 +7,16 @@ package rpc
 
 import "github.com/ethereum/go-ethereum/p2p"
 
+// flags of msg. It was a bool of isResult, whose encoding is compatible.
+const (
+	flagResult     = 1 << iota // msg is the result of a call
+	flagCompressed             // payload is snappy compressed rlp bytes
+)
+
 type msgData struct {
-	ID       uint32
-	IsResult bool
-	Payload  interface{}
+	ID      uint32
+	Flags   uint
+	Payload interface{}
 }
 
 type resultListener struct {
@@ -6,7 +6,10 @@
 package p2psrv
 
 import (
+	"context"
 	"math"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -14,12 +17,19 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/cache"
 	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/p2psrv/dnsdisc"
 )
 
 var log = log15.New("pkg", "p2psrv")
 
+var (
+	errNotAllowed      = errors.New("not in allowlist")
+	errNotPermissioned = errors.New("not in permissioned mode")
+)
+
 // Server p2p server wraps ethereum's p2p.Server, and handles discovery v5 stuff.
 type Server struct {
 	srv             *p2p.Server
@@ -28,6 +38,14 @@ type Server struct {
 	knownNodes      *cache.PrioCache
 	discoveredNodes *cache.RandCache
 	dialingNodes    *nodeMap
+	allowlist       *Allowlist
+	bootstrapNodes  []*discv5.Node
+	dnsNodeLists    []string
+	natStatus       struct {
+		sync.Mutex
+		ip        net.IP
+		reachable bool
+	}
 }
 
 // New create a p2p server.
@@ -52,7 +70,7 @@ func New(opts *Options) *Server {
 				PrivateKey:       opts.PrivateKey,
 				MaxPeers:         opts.MaxPeers,
 				NoDiscovery:      true,
-				DiscoveryV5:      !opts.NoDiscovery,
+				DiscoveryV5:      !opts.NoDiscovery && opts.Allowlist == nil,
 				ListenAddr:       opts.ListenAddr,
 				BootstrapNodesV5: v5nodes,
 				NetRestrict:      opts.NetRestrict,
@@ -65,6 +83,9 @@ func New(opts *Options) *Server {
 		knownNodes:      knownNodes,
 		discoveredNodes: discoveredNodes,
 		dialingNodes:    newNodeMap(),
+		allowlist:       opts.Allowlist,
+		bootstrapNodes:  v5nodes,
+		dnsNodeLists:    opts.DNSNodeLists,
 	}
 }
 
@@ -86,7 +107,14 @@ func (s *Server) Start(protocols []*Protocol) error {
 			}
 			log := log.New("peer", peer, "dir", dir)
 
+			if s.allowlist != nil && !s.allowlist.Contains(peer.ID()) {
+				log.Debug("peer refused", "reason", "not in allowlist")
+				return errNotAllowed
+			}
 			log.Debug("peer connected")
+			if peer.Inbound() && s.srv.NAT != nil {
+				s.markReachable(peer)
+			}
 			startTime := mclock.Now()
 			defer func() {
 				log.Debug("peer disconnected", "reason", err)
@@ -105,12 +133,27 @@ func (s *Server) Start(protocols []*Protocol) error {
 	}
 	log.Debug("start up", "self", s.Self())
 
-	for _, proto := range protocols {
-		topicToRegister := discv5.Topic(proto.DiscTopic)
-		log.Debug("registering topic", "topic", topicToRegister)
-		s.goes.Go(func() {
-			s.srv.DiscV5.RegisterTopic(topicToRegister, s.done)
-		})
+	if s.srv.NAT != nil {
+		s.goes.Go(s.natLoop)
+	}
+
+	if s.allowlist != nil {
+		for _, node := range s.allowlist.Nodes() {
+			if !node.Incomplete() {
+				s.srv.AddPeer(node)
+			}
+		}
+		return nil
+	}
+
+	if s.srv.DiscV5 != nil {
+		for _, proto := range protocols {
+			topicToRegister := discv5.Topic(proto.DiscTopic)
+			log.Debug("registering topic", "topic", topicToRegister)
+			s.goes.Go(func() {
+				s.srv.DiscV5.RegisterTopic(topicToRegister, s.done)
+			})
+		}
 	}
 
 	if len(protocols) > 0 {
@@ -119,6 +162,9 @@ func (s *Server) Start(protocols []*Protocol) error {
 		s.goes.Go(func() { s.discoverLoop(topicToSearch) })
 		s.goes.Go(s.dialLoop)
 	}
+	if len(s.dnsNodeLists) > 0 {
+		s.goes.Go(s.dnsLoop)
+	}
 	return nil
 }
 
@@ -151,6 +197,49 @@ func (s *Server) RemoveStatic(node *discover.Node) {
 	s.srv.RemovePeer(node)
 }
 
+// AllowedNodes returns nodes in the allowlist.
+func (s *Server) AllowedNodes() ([]*discover.Node, error) {
+	if s.allowlist == nil {
+		return nil, errNotPermissioned
+	}
+	return s.allowlist.Nodes(), nil
+}
+
+// AllowNode adds the node into the allowlist, and connects to it if its address is given.
+func (s *Server) AllowNode(node *discover.Node) error {
+	if s.allowlist == nil {
+		return errNotPermissioned
+	}
+	if err := s.allowlist.Add(node); err != nil {
+		return err
+	}
+	if !node.Incomplete() {
+		s.srv.AddPeer(node)
+	}
+	return nil
+}
+
+// DisallowNode removes the node from the allowlist, and disconnects from it.
+// It returns false if the node is not in the allowlist.
+func (s *Server) DisallowNode(id discover.NodeID) (bool, error) {
+	if s.allowlist == nil {
+		return false, errNotPermissioned
+	}
+	node, err := s.allowlist.Remove(id)
+	if err != nil || node == nil {
+		return false, err
+	}
+	if !node.Incomplete() {
+		s.srv.RemovePeer(node)
+	}
+	for _, peer := range s.srv.Peers() {
+		if peer.ID() == id {
+			peer.Disconnect(p2p.DiscRequested)
+		}
+	}
+	return true, nil
+}
+
 // NodeInfo gathers and returns a collection of metadata known about the host.
 func (s *Server) NodeInfo() *p2p.NodeInfo {
 	return s.srv.NodeInfo()
@@ -202,6 +291,61 @@ func (s *Server) discoverLoop(topic discv5.Topic) {
 	}
 }
 
+// dnsLoop resolves DNS node lists periodically. Nodes resolved are added as fallback nodes of discovery,
+// and candidates to dial.
+func (s *Server) dnsLoop() {
+	const refreshInterval = 30 * time.Minute
+	const resolveTimeout = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.goes.Go(func() {
+		<-s.done
+		cancel()
+	})
+
+	client := dnsdisc.NewClient(nil)
+	resolve := func() {
+		var nodes []*discover.Node
+		for _, url := range s.dnsNodeLists {
+			ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+			found, err := client.Resolve(ctx, url)
+			cancel()
+			if err != nil {
+				log.Warn("failed to resolve DNS node list", "url", url, "err", err)
+				continue
+			}
+			log.Debug("DNS node list resolved", "url", url, "nodes", len(found))
+			nodes = append(nodes, found...)
+		}
+		if len(nodes) == 0 {
+			return
+		}
+
+		v5nodes := append([]*discv5.Node(nil), s.bootstrapNodes...)
+		for _, node := range nodes {
+			s.discoveredNodes.Set(node.ID, node)
+			v5nodes = append(v5nodes, discv5.NewNode(discv5.NodeID(node.ID), node.IP, node.UDP, node.TCP))
+		}
+		if s.srv.DiscV5 != nil {
+			if err := s.srv.DiscV5.SetFallbackNodes(v5nodes); err != nil {
+				log.Warn("failed to set discovery fallback nodes", "err", err)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		resolve()
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		}
+	}
+}
+
 func (s *Server) dialLoop() {
 	const fastDialDur = 500 * time.Millisecond
 	const nonFastDialDur = 2 * time.Second
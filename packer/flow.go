@@ -23,6 +23,7 @@ type Flow struct {
 	parentHeader *block.Header
 	runtime      *runtime.Runtime
 	processedTxs map[thor.Bytes32]bool // txID -> reverted
+	features     tx.Features           // supported tx features
 	gasUsed      uint64
 	txs          tx.Transactions
 	receipts     tx.Receipts
@@ -38,6 +39,7 @@ func newFlow(
 		parentHeader: parentHeader,
 		runtime:      runtime,
 		processedTxs: make(map[thor.Bytes32]bool),
+		features:     tx.SupportedFeatures(packer.forkConfig, runtime.Context().Number),
 	}
 }
 
@@ -65,15 +67,28 @@ func (f *Flow) findTx(txID thor.Bytes32) (found bool, reverted bool, err error)
 	return true, txMeta.Reverted, nil
 }
 
+// Txs returns txs adopted by the flow, in order of adoption.
+func (f *Flow) Txs() tx.Transactions {
+	return f.txs
+}
+
+// Adopted returns whether the tx is already adopted by the flow.
+func (f *Flow) Adopted(txID thor.Bytes32) bool {
+	_, ok := f.processedTxs[txID]
+	return ok
+}
+
 // Adopt try to execute the given transaction.
 // If the tx is valid and can be executed on current state (regardless of VM error),
 // it will be adopted by the new block.
+// A tx depending on another one is adoptable once the dependency is in the chain or adopted by the flow,
+// so a dependency chain can be packed into one block if adopted in order.
 func (f *Flow) Adopt(tx *tx.Transaction) error {
 	switch {
 	case tx.ChainTag() != f.packer.chain.Tag():
 		return badTxError{"chain tag mismatch"}
-	case tx.HasReservedFields():
-		return badTxError{"reserved fields not empty"}
+	case tx.TestFeatures(f.features) != nil:
+		return badTxError{"unsupported features"}
 	case f.runtime.Context().Number < tx.BlockRef().Number():
 		return errTxNotAdoptableNow
 	case tx.IsExpired(f.runtime.Context().Number):
@@ -127,7 +142,14 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey) (*block.Block, *state.Stage, t
 	if f.packer.proposer != thor.Address(crypto.PubkeyToAddress(privateKey.PublicKey)) {
 		return nil, nil, nil, errors.New("private key mismatch")
 	}
+	return f.PackWith(func(hash thor.Bytes32) ([]byte, error) {
+		return crypto.Sign(hash.Bytes(), privateKey)
+	})
+}
 
+// PackWith build the new block and sign it by the given func, which may delegate to an external signer.
+// The signature is verified to be of the proposer.
+func (f *Flow) PackWith(sign func(hash thor.Bytes32) ([]byte, error)) (*block.Block, *state.Stage, tx.Receipts, error) {
 	if err := f.runtime.Seeker().Err(); err != nil {
 		return nil, nil, nil, err
 	}
@@ -152,9 +174,15 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey) (*block.Block, *state.Stage, t
 	}
 	newBlock := builder.Build()
 
-	sig, err := crypto.Sign(newBlock.Header().SigningHash().Bytes(), privateKey)
+	sig, err := sign(newBlock.Header().SigningHash())
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, errors.WithMessage(err, "sign")
+	}
+	newBlock = newBlock.WithSignature(sig)
+	if signer, err := newBlock.Header().Signer(); err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "signature")
+	} else if signer != f.packer.proposer {
+		return nil, nil, nil, errors.New("signer mismatch")
 	}
-	return newBlock.WithSignature(sig), stage, f.receipts, nil
+	return newBlock, stage, f.receipts, nil
 }
@@ -24,6 +24,7 @@ type Packer struct {
 	proposer       thor.Address
 	beneficiary    thor.Address
 	targetGasLimit uint64
+	forkConfig     thor.ForkConfig
 }
 
 // New create a new Packer instance.
@@ -31,7 +32,8 @@ func New(
 	chain *chain.Chain,
 	stateCreator *state.Creator,
 	proposer thor.Address,
-	beneficiary thor.Address) *Packer {
+	beneficiary thor.Address,
+	forkConfig thor.ForkConfig) *Packer {
 
 	return &Packer{
 		chain,
@@ -39,6 +41,7 @@ func New(
 		proposer,
 		beneficiary,
 		0,
+		forkConfig,
 	}
 }
 
@@ -83,7 +86,8 @@ func (p *Packer) Schedule(parent *block.Header, nowTimestamp uint64) (flow *Flow
 			Time:        newBlockTime,
 			GasLimit:    p.gasLimit(parent.GasLimit()),
 			TotalScore:  parent.TotalScore() + score,
-		})
+		},
+		p.forkConfig)
 
 	return newFlow(p, parent, rt), nil
 }
@@ -107,7 +111,8 @@ func (p *Packer) Mock(parent *block.Header, targetTime uint64) (*Flow, error) {
 			Time:        targetTime,
 			GasLimit:    p.gasLimit(parent.GasLimit()),
 			TotalScore:  parent.TotalScore() + 1,
-		})
+		},
+		p.forkConfig)
 
 	return newFlow(p, parent, rt), nil
 }
@@ -60,6 +60,51 @@ func (ti *txIterator) Next() *tx.Transaction {
 func (ti *txIterator) OnProcessed(txID thor.Bytes32, err error) {
 }
 
+func TestAdoptDependency(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	defer kv.Close()
+
+	g, _ := genesis.NewDevnet()
+	stateCreator := state.NewCreator(kv)
+	b0, _, _ := g.Build(stateCreator)
+	c, _ := chain.New(kv, b0)
+
+	a0 := genesis.DevAccounts()[0]
+	p := packer.New(c, stateCreator, a0.Address, a0.Address, thor.NoFork)
+	flow, err := p.Mock(b0.Header(), b0.Header().Timestamp()+thor.BlockInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTx := func(dependsOn *thor.Bytes32) *tx.Transaction {
+		trx := new(tx.Builder).
+			ChainTag(c.Tag()).
+			Clause(tx.NewClause(&a0.Address)).
+			Gas(21000).Nonce(nonce).DependsOn(dependsOn).Expiration(math.MaxUint32).Build()
+		nonce++
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), a0.PrivateKey)
+		return trx.WithSignature(sig)
+	}
+
+	dep := newTx(nil)
+	depID := dep.ID()
+	dependent := newTx(&depID)
+
+	assert.True(t, packer.IsTxNotAdoptableNow(flow.Adopt(dependent)))
+	assert.Nil(t, flow.Adopt(dep))
+	assert.Nil(t, flow.Adopt(dependent))
+
+	blk, _, _, err := flow.Pack(a0.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := blk.Transactions()
+	if assert.Equal(t, 2, len(txs)) {
+		assert.Equal(t, depID, txs[0].ID())
+		assert.Equal(t, dependent.ID(), txs[1].ID())
+	}
+}
+
 func TestP(t *testing.T) {
 
 	kv, _ := lvldb.NewMem()
@@ -83,7 +128,7 @@ func TestP(t *testing.T) {
 
 	for {
 		best := c.BestBlock()
-		p := packer.New(c, stateCreator, a1.Address, a1.Address)
+		p := packer.New(c, stateCreator, a1.Address, a1.Address, thor.NoFork)
 		flow, err := p.Schedule(best.Header(), uint64(time.Now().Unix()))
 		if err != nil {
 			t.Fatal(err)
@@ -91,13 +136,15 @@ func TestP(t *testing.T) {
 		iter := &txIterator{chainTag: b0.Header().ID()[31]}
 		for iter.HasNext() {
 			tx := iter.Next()
-			flow.Adopt(tx)
+			if err := flow.Adopt(tx); err == nil {
+				assert.True(t, flow.Adopted(tx.ID()))
+			}
 		}
 
 		blk, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
 		root, _ := stage.Commit()
 		assert.Equal(t, root, blk.Header().StateRoot())
-		fmt.Println(consensus.New(c, stateCreator).Process(blk, uint64(time.Now().Unix()*2)))
+		fmt.Println(consensus.New(c, stateCreator, thor.NoFork).Process(blk, uint64(time.Now().Unix()*2)))
 
 		if _, err := c.AddBlock(blk, receipts); err != nil {
 			t.Fatal(err)
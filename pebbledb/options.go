@@ -0,0 +1,17 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package pebbledb implements kv store on top of pebble, an alternative to level db
+// with less compaction stalls under heavy writes.
+//
+// Pebble requires a newer Go than thor, so it's built in only with build tag 'pebble'.
+// Otherwise New and NewMem always fail.
+package pebbledb
+
+// Options options for creating pebble db instance.
+type Options struct {
+	CacheSize    int // in MB
+	MaxOpenFiles int
+}
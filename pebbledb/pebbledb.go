@@ -0,0 +1,220 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build pebble
+
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/kv"
+)
+
+var _ kv.Store = (*PebbleDB)(nil)
+
+const mb = 1024 * 1024
+
+// writes are not synced, the same as level db
+var writeOpt = pebble.NoSync
+
+// PebbleDB wraps pebble db.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+// New create a persistent pebble db instance.
+// Create an empty one if not exists, or open if already there.
+func New(path string, opts Options) (*PebbleDB, error) {
+	db, err := open(path, vfs.Default, opts.CacheSize, opts.MaxOpenFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "new persistent pebble db")
+	}
+	return db, nil
+}
+
+// NewMem create a pebble db in memory.
+func NewMem() (*PebbleDB, error) {
+	return open("", vfs.NewMem(), 0, 0)
+}
+
+func open(path string, fs vfs.FS, cacheSize, maxOpenFiles int) (*PebbleDB, error) {
+	if cacheSize < 16 {
+		cacheSize = 16
+	}
+	if maxOpenFiles < 16 {
+		maxOpenFiles = 16
+	}
+
+	cache := pebble.NewCache(int64(cacheSize / 2 * mb))
+	defer cache.Unref()
+
+	db, err := pebble.Open(path, &pebble.Options{
+		FS:           fs,
+		Cache:        cache,
+		MaxOpenFiles: maxOpenFiles,
+		MemTableSize: uint64(cacheSize / 4 * mb),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "open pebble db")
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// IsNotFound to check if the error returned by Get indicates key not found.
+func (pdb *PebbleDB) IsNotFound(err error) bool {
+	return err == pebble.ErrNotFound
+}
+
+// Get retrieve value for given key.
+// It returns an error if key not found. The error can be checked via IsNotFound.
+func (pdb *PebbleDB) Get(key []byte) ([]byte, error) {
+	value, closer, err := pdb.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	// value is only valid before closer closed
+	return append([]byte(nil), value...), nil
+}
+
+// Has returns whether a key exists.
+func (pdb *PebbleDB) Has(key []byte) (bool, error) {
+	_, closer, err := pdb.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+// Put save value fo give key.
+func (pdb *PebbleDB) Put(key, value []byte) error {
+	return pdb.db.Set(key, value, writeOpt)
+}
+
+// Delete deletes the give key and its value.
+func (pdb *PebbleDB) Delete(key []byte) error {
+	return pdb.db.Delete(key, writeOpt)
+}
+
+// Compact compacts the whole key range of the pebble db.
+func (pdb *PebbleDB) Compact() error {
+	it := pdb.db.NewIter(nil)
+	if !it.First() {
+		return it.Close()
+	}
+	first := append([]byte(nil), it.Key()...)
+	it.Last()
+	// end key is exclusive
+	last := append(append([]byte(nil), it.Key()...), 0)
+	if err := it.Close(); err != nil {
+		return err
+	}
+	return pdb.db.Compact(first, last, true)
+}
+
+// Close close the pebble db.
+// Later operations will all fail.
+func (pdb *PebbleDB) Close() error {
+	return pdb.db.Close()
+}
+
+// NewBatch create a batch for writing ops.
+func (pdb *PebbleDB) NewBatch() kv.Batch {
+	return &pebbleBatch{
+		pdb.db,
+		pdb.db.NewBatch(),
+	}
+}
+
+// NewIterator create a iterator by range.
+func (pdb *PebbleDB) NewIterator(r kv.Range) kv.Iterator {
+	return &pebbleIterator{
+		it: pdb.db.NewIter(&pebble.IterOptions{
+			LowerBound: r.From,
+			UpperBound: r.To,
+		}),
+	}
+}
+
+//////
+
+// pebbleBatch wraps batch operations.
+type pebbleBatch struct {
+	db    *pebble.DB
+	batch *pebble.Batch
+}
+
+// Put adds a put operation.
+func (b *pebbleBatch) Put(key, value []byte) error {
+	return b.batch.Set(key, value, nil)
+}
+
+// Delete adds a delete operation.
+func (b *pebbleBatch) Delete(key []byte) error {
+	return b.batch.Delete(key, nil)
+}
+
+func (b *pebbleBatch) NewBatch() kv.Batch {
+	return &pebbleBatch{
+		b.db,
+		b.db.NewBatch(),
+	}
+}
+
+// Len returns ops in the batch.
+func (b *pebbleBatch) Len() int {
+	return int(b.batch.Count())
+}
+
+// Write perform all ops in this batch.
+func (b *pebbleBatch) Write() error {
+	return b.batch.Commit(writeOpt)
+}
+
+//////
+
+// pebbleIterator adapts pebble iterator, which has to be positioned before the first Next call.
+type pebbleIterator struct {
+	it       *pebble.Iterator
+	started  bool
+	released bool
+	err      error
+}
+
+func (i *pebbleIterator) Next() bool {
+	if !i.started {
+		i.started = true
+		return i.it.First()
+	}
+	return i.it.Next()
+}
+
+func (i *pebbleIterator) Release() {
+	if !i.released {
+		i.released = true
+		i.err = i.it.Close()
+	}
+}
+
+func (i *pebbleIterator) Error() error {
+	if i.released {
+		return i.err
+	}
+	return i.it.Error()
+}
+
+func (i *pebbleIterator) Key() []byte {
+	return i.it.Key()
+}
+
+func (i *pebbleIterator) Value() []byte {
+	return i.it.Value()
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build pebble
+
+package pebbledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/kv"
+)
+
+func TestPebbleDB(t *testing.T) {
+	db, err := NewMem()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	key := []byte("123")
+	value := []byte("456")
+
+	assert.Nil(t, db.Put(key, value))
+	got, err := db.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, value, got)
+
+	has, err := db.Has(key)
+	assert.Nil(t, err)
+	assert.True(t, has)
+	has, err = db.Has([]byte("abc"))
+	assert.Nil(t, err)
+	assert.False(t, has)
+
+	assert.Nil(t, db.Delete(key))
+	_, err = db.Get(key)
+	assert.True(t, db.IsNotFound(err))
+	assert.Nil(t, db.Compact())
+}
+
+func TestPebbleDBBatchAndIterator(t *testing.T) {
+	db, err := NewMem()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	batch := db.NewBatch()
+	for _, k := range []string{"a1", "a2", "b1"} {
+		assert.Nil(t, batch.Put([]byte(k), []byte(k)))
+	}
+	assert.Equal(t, 3, batch.Len())
+	assert.Nil(t, batch.Write())
+
+	it := db.NewIterator(*kv.NewRangeWithBytesPrefix([]byte("a")))
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	assert.Nil(t, it.Error())
+	assert.Equal(t, []string{"a1", "a2"}, keys)
+	assert.Nil(t, db.Compact())
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build !pebble
+
+package pebbledb
+
+import (
+	"errors"
+
+	"github.com/vechain/thor/kv"
+)
+
+var errNotBuiltIn = errors.New("pebble not built in, rebuild with tag 'pebble'")
+
+// PebbleDB is never instantiated, since pebble is not built in.
+type PebbleDB struct {
+	kv.Store
+}
+
+// New always fails.
+func New(path string, opts Options) (*PebbleDB, error) {
+	return nil, errNotBuiltIn
+}
+
+// NewMem always fails.
+func NewMem() (*PebbleDB, error) {
+	return nil, errNotBuiltIn
+}
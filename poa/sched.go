@@ -62,14 +62,7 @@ func (s *Scheduler) whoseTurn(t uint64) Proposer {
 // Schedule to determine time of the proposer to produce a block, according to `nowTime`.
 // `newBlockTime` is promised to be >= nowTime and > parentBlockTime
 func (s *Scheduler) Schedule(nowTime uint64) (newBlockTime uint64) {
-	const T = thor.BlockInterval
-
-	newBlockTime = s.parentBlockTime + T
-
-	if nowTime > newBlockTime {
-		// ensure T aligned, and >= nowTime
-		newBlockTime += (nowTime - newBlockTime + T - 1) / T * T
-	}
+	newBlockTime = nextSlot(s.parentBlockTime, nowTime)
 
 	for {
 		p := s.whoseTurn(newBlockTime)
@@ -78,7 +71,7 @@ func (s *Scheduler) Schedule(nowTime uint64) (newBlockTime uint64) {
 		}
 
 		// try next time slot
-		newBlockTime += T
+		newBlockTime += thor.BlockInterval
 	}
 }
 
@@ -127,6 +120,50 @@ func (s *Scheduler) Updates(newBlockTime uint64) (updates []Proposer, score uint
 	return
 }
 
+// Slot a time slot and the proposer whose turn it is.
+type Slot struct {
+	Time     uint64
+	Proposer thor.Address
+}
+
+// Upcoming returns n time slots to produce the block upon the parent, starting from nowTime,
+// along with the active proposer scheduled in each slot.
+// It's a view from outside, since inactive proposers are also scheduled by themselves.
+func Upcoming(proposers []Proposer, parentBlockNumber uint32, parentBlockTime uint64, nowTime uint64, n int) []Slot {
+	actives := make([]Proposer, 0, len(proposers))
+	for _, p := range proposers {
+		if p.Active {
+			actives = append(actives, p)
+		}
+	}
+	if len(actives) == 0 {
+		return nil
+	}
+	s := &Scheduler{
+		actives:           actives,
+		parentBlockNumber: parentBlockNumber,
+		parentBlockTime:   parentBlockTime,
+	}
+
+	slots := make([]Slot, 0, n)
+	for t := nextSlot(parentBlockTime, nowTime); len(slots) < n; t += thor.BlockInterval {
+		slots = append(slots, Slot{t, s.whoseTurn(t).Address})
+	}
+	return slots
+}
+
+// nextSlot returns the first time slot after the parent block, which is >= nowTime.
+func nextSlot(parentBlockTime uint64, nowTime uint64) uint64 {
+	const T = thor.BlockInterval
+
+	t := parentBlockTime + T
+	if nowTime > t {
+		// ensure T aligned, and >= nowTime
+		t += (nowTime - t + T - 1) / T * T
+	}
+	return t
+}
+
 // dprp deterministic pseudo-random process.
 // H(B, t)[:8]
 func dprp(blockNumber uint32, time uint64) uint64 {
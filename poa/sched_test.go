@@ -80,3 +80,24 @@ func TestUpdates(t *testing.T) {
 		assert.Equal(t, tt.want, score)
 	}
 }
+
+func TestUpcoming(t *testing.T) {
+	all := []poa.Proposer{
+		{p1, true},
+		{p2, false},
+		{p3, true},
+	}
+	assert.Empty(t, poa.Upcoming(proposers[2:], 1, parentTime, 0, 10), "no active proposer")
+
+	now := parentTime + thor.BlockInterval*3 + 1
+	slots := poa.Upcoming(all, 1, parentTime, now, 10)
+	assert.Equal(t, 10, len(slots))
+	for i, slot := range slots {
+		assert.Equal(t, parentTime+thor.BlockInterval*uint64(4+i), slot.Time)
+		assert.NotEqual(t, p2, slot.Proposer, "inactive proposer")
+
+		// consistent with the proposer's own schedule
+		sched, _ := poa.NewScheduler(slot.Proposer, all, 1, parentTime)
+		assert.True(t, sched.IsTheTime(slot.Time))
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package runtime
+
+import (
+	"errors"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/xenv"
+)
+
+// NewBlockContext creates the context to execute txs in the block with the given header.
+func NewBlockContext(header *block.Header) (*xenv.BlockContext, error) {
+	signer, err := header.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return &xenv.BlockContext{
+		Beneficiary: header.Beneficiary(),
+		Signer:      signer,
+		Number:      header.Number(),
+		Time:        header.Timestamp(),
+		GasLimit:    header.GasLimit(),
+		TotalScore:  header.TotalScore(),
+	}, nil
+}
+
+// NewForBlock creates a runtime to re-execute txs of the block with the given header,
+// on the state of its parent. The block can be any one in the chain, as long as
+// the state of its parent is not pruned.
+func NewForBlock(chain *chain.Chain, stateCreator *state.Creator, header *block.Header, forkConfig thor.ForkConfig) (*Runtime, error) {
+	if header.Number() == 0 {
+		return nil, errors.New("genesis block has no parent")
+	}
+	parent, err := chain.GetBlockHeader(header.ParentID())
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := NewBlockContext(header)
+	if err != nil {
+		return nil, err
+	}
+	state, err := stateCreator.NewState(parent.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	return New(chain.NewSeeker(header.ParentID()), state, ctx, forkConfig), nil
+}
@@ -21,6 +21,7 @@ import (
 type ResolvedTransaction struct {
 	tx           *tx.Transaction
 	Origin       thor.Address
+	Delegator    *thor.Address // who pays gas if not nil
 	IntrinsicGas uint64
 	Clauses      []*tx.Clause
 }
@@ -31,6 +32,10 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 	if err != nil {
 		return nil, err
 	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return nil, err
+	}
 	intrinsicGas, err := tx.IntrinsicGas()
 	if err != nil {
 		return nil, err
@@ -56,6 +61,7 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 	return &ResolvedTransaction{
 		tx,
 		origin,
+		delegator,
 		intrinsicGas,
 		clauses,
 	}, nil
@@ -103,6 +109,14 @@ func (r *ResolvedTransaction) BuyGas(state *state.State, blockTime uint64) (
 	}
 
 	prepaid := new(big.Int).Mul(new(big.Int).SetUint64(r.tx.Gas()), gasPrice)
+	if r.Delegator != nil {
+		// the delegator agreed to pay by signing, no fallback
+		if energy.Sub(*r.Delegator, prepaid) {
+			return baseGasPrice, gasPrice, *r.Delegator, func(rgas uint64) { doReturnGas(rgas) }, nil
+		}
+		return nil, nil, thor.Address{}, nil, errors.New("insufficient energy")
+	}
+
 	commonTo := r.CommonTo()
 	if commonTo != nil {
 		binding := builtin.Prototype.Native(state).Bind(*commonTo)
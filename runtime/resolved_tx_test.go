@@ -174,6 +174,12 @@ func (tr *testResolvedTransaction) TestBuyGas() {
 		genesis.DevAccounts()[2].Address,
 		buyGas(txSign(txBuild().Clause(clause().WithValue(big.NewInt(100))))),
 	)
+
+	// delegator takes precedence over credit plan
+	tr.assert.Equal(
+		genesis.DevAccounts()[3].Address,
+		buyGas(txSignDelegated(txBuild().Clause(clause().WithValue(big.NewInt(100))), genesis.DevAccounts()[3])),
+	)
 }
 
 func clause() *tx.Clause {
@@ -195,3 +201,10 @@ func txSign(builder *tx.Builder) *tx.Transaction {
 	sig, _ := crypto.Sign(transaction.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
 	return transaction.WithSignature(sig)
 }
+
+func txSignDelegated(builder *tx.Builder, delegator genesis.DevAccount) *tx.Transaction {
+	transaction := builder.Features(tx.DelegationFeature).Build()
+	sig, _ := crypto.Sign(transaction.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	dsig, _ := crypto.Sign(transaction.DelegatorSigningHash(genesis.DevAccounts()[0].Address).Bytes(), delegator.PrivateKey)
+	return transaction.WithSignature(append(sig, dsig...))
+}
@@ -39,19 +39,22 @@ func init() {
 	}
 }
 
-var chainConfig = params.ChainConfig{
-	ChainId:             big.NewInt(0),
-	HomesteadBlock:      big.NewInt(0),
-	DAOForkBlock:        big.NewInt(0),
-	DAOForkSupport:      false,
-	EIP150Block:         big.NewInt(0),
-	EIP150Hash:          common.Hash{},
-	EIP155Block:         big.NewInt(0),
-	EIP158Block:         big.NewInt(0),
-	ByzantiumBlock:      big.NewInt(0),
-	ConstantinopleBlock: nil,
-	Ethash:              nil,
-	Clique:              nil,
+var baseChainConfig = vm.ChainConfig{
+	ChainConfig: params.ChainConfig{
+		ChainId:             big.NewInt(0),
+		HomesteadBlock:      big.NewInt(0),
+		DAOForkBlock:        big.NewInt(0),
+		DAOForkSupport:      false,
+		EIP150Block:         big.NewInt(0),
+		EIP150Hash:          common.Hash{},
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: nil,
+		Ethash:              nil,
+		Clique:              nil,
+	},
+	IstanbulBlock: nil,
 }
 
 // Output output of clause execution.
@@ -61,28 +64,44 @@ type Output struct {
 	Transfers       tx.Transfers
 	LeftOverGas     uint64
 	RefundGas       uint64
-	VMErr           error         // VMErr identify the execution result of the contract function, not evm function's err.
-	ContractAddress *thor.Address // if create a new contract, or is nil.
+	VMErr           error            // VMErr identify the execution result of the contract function, not evm function's err.
+	VMErrCategory   vm.ErrorCategory // category of VMErr, ErrCategoryNone if no error.
+	ContractAddress *thor.Address    // if create a new contract, or is nil.
+	GasProfile      *vm.GasProfile   // if gas profile enabled by vm config, or is nil.
 }
 
 // Runtime bases on EVM and VeChain Thor builtins.
 type Runtime struct {
-	vmConfig vm.Config
-	seeker   *chain.Seeker
-	state    *state.State
-	ctx      *xenv.BlockContext
+	vmConfig    vm.Config
+	chainConfig vm.ChainConfig
+	forkConfig  thor.ForkConfig
+	seeker      *chain.Seeker
+	state       *state.State
+	ctx         *xenv.BlockContext
 }
 
 // New create a Runtime object.
+// EVM forks are activated according to forkConfig.
 func New(
 	seeker *chain.Seeker,
 	state *state.State,
 	ctx *xenv.BlockContext,
+	forkConfig thor.ForkConfig,
 ) *Runtime {
+	chainConfig := baseChainConfig
+	chainConfig.ConstantinopleBlock = new(big.Int).SetUint64(uint64(forkConfig.Constantinople))
+	chainConfig.IstanbulBlock = new(big.Int).SetUint64(uint64(forkConfig.Istanbul))
+	if seeker != nil {
+		// genesis id is used as chain id, which is unique per network
+		genesisID := seeker.GetID(0)
+		chainConfig.ChainId = new(big.Int).SetBytes(genesisID[:])
+	}
 	return &Runtime{
-		seeker: seeker,
-		state:  state,
-		ctx:    ctx,
+		chainConfig: chainConfig,
+		forkConfig:  forkConfig,
+		seeker:      seeker,
+		state:       state,
+		ctx:         ctx,
 	}
 }
 
@@ -227,7 +246,7 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 		BlockNumber: new(big.Int).SetUint64(uint64(rt.ctx.Number)),
 		Time:        new(big.Int).SetUint64(rt.ctx.Time),
 		Difficulty:  &big.Int{},
-	}, stateDB, &chainConfig, rt.vmConfig)
+	}, stateDB, &rt.chainConfig, rt.vmConfig)
 }
 
 // ExecuteClause executes single clause.
@@ -258,7 +277,9 @@ func (rt *Runtime) ExecuteClause(
 		LeftOverGas:     leftOverGas,
 		RefundGas:       stateDB.GetRefund(),
 		VMErr:           vmErr,
+		VMErrCategory:   vm.CategorizeError(vmErr),
 		ContractAddress: contractAddr,
+		GasProfile:      evm.GasProfile(),
 	}
 	output.Events, output.Transfers = stateDB.GetLogs()
 	return output
@@ -267,6 +288,20 @@ func (rt *Runtime) ExecuteClause(
 // ExecuteTransaction executes a transaction.
 // If some clause failed, receipt.Outputs will be nil and vmOutputs may shorter than clause count.
 func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt, err error) {
+	return rt.executeTransaction(tx, nil)
+}
+
+// TraceTransaction executes a transaction as ExecuteTransaction does, with VM tracer
+// attached to each clause. The tracer is created by newTracer, and the clause is not
+// traced if nil returned.
+func (rt *Runtime) TraceTransaction(tx *tx.Transaction, newTracer func(clauseIndex uint32) vm.Tracer) (*tx.Receipt, error) {
+	return rt.executeTransaction(tx, newTracer)
+}
+
+func (rt *Runtime) executeTransaction(tx *tx.Transaction, newTracer func(clauseIndex uint32) vm.Tracer) (receipt *tx.Receipt, err error) {
+	if err := tx.TestFeatures(Tx.SupportedFeatures(rt.forkConfig, rt.ctx.Number)); err != nil {
+		return nil, err
+	}
 	resolvedTx, err := ResolveTransaction(tx)
 	if err != nil {
 		return nil, err
@@ -285,7 +320,17 @@ func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt,
 	receipt = &Tx.Receipt{Outputs: make([]*Tx.Output, 0, len(resolvedTx.Clauses))}
 
 	txCtx := resolvedTx.ToContext(gasPrice, rt.ctx.Number, rt.seeker.GetID)
+
+	vmConfig := rt.vmConfig
+	defer func() { rt.vmConfig = vmConfig }()
+
 	for i, clause := range resolvedTx.Clauses {
+		if newTracer != nil {
+			rt.vmConfig = vmConfig
+			if tracer := newTracer(uint32(i)); tracer != nil {
+				rt.vmConfig.Debug, rt.vmConfig.Tracer = true, tracer
+			}
+		}
 		output := rt.ExecuteClause(clause, uint32(i), leftOverGas, txCtx)
 
 		gasUsed := leftOverGas - output.LeftOverGas
@@ -305,6 +350,9 @@ func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt,
 			// revert all executed clauses
 			rt.state.RevertTo(checkpoint)
 			receipt.Reverted = true
+			receipt.VMErrorCategory = output.VMErrCategory.String()
+			// empty if not reverted with reason
+			receipt.RevertReason, _ = abi.DecodeRevertReason(output.Data)
 			receipt.Outputs = nil
 			break
 		}
@@ -10,8 +10,10 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/abi"
 	"github.com/vechain/thor/builtin"
@@ -22,6 +24,7 @@ import (
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
 	"github.com/vechain/thor/xenv"
 )
 
@@ -71,7 +74,7 @@ func TestContractSuicide(t *testing.T) {
 	}
 
 	origin := genesis.DevAccounts()[0].Address
-	out := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Time: time}).
+	out := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Time: time}, thor.NoFork).
 		ExecuteClause(tx.NewClause(&addr).WithData(methodData), 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
 	if out.VMErr != nil {
 		t.Fatal(out.VMErr)
@@ -115,7 +118,7 @@ func TestCall(t *testing.T) {
 
 	state, _ := state.New(b0.Header().StateRoot(), kv)
 
-	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{})
+	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{}, thor.NoFork)
 
 	method, _ := builtin.Params.ABI.MethodByName("executor")
 	data, err := method.EncodeInput()
@@ -139,6 +142,193 @@ func TestCall(t *testing.T) {
 	assert.Equal(t, thor.Address(addr), genesis.DevAccounts()[0].Address)
 }
 
+func TestForkConfig(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+
+	// PUSH1 1 PUSH1 1 SHL STOP
+	code := []byte{0x60, 0x01, 0x60, 0x01, 0x1b, 0x00}
+	exec := func(forkConfig thor.ForkConfig, number uint32) error {
+		state, _ := state.New(b0.Header().StateRoot(), kv)
+		rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Number: number}, forkConfig)
+		return rt.ExecuteClause(tx.NewClause(nil).WithData(code), 0, math.MaxUint64, &xenv.TransactionContext{}).VMErr
+	}
+
+	assert.NotNil(t, exec(thor.NoFork, 1), "SHL should be invalid without Constantinople")
+	assert.Nil(t, exec(thor.SoloFork, 1))
+	forkConfig := thor.NoFork
+	forkConfig.Constantinople = 10
+	assert.NotNil(t, exec(forkConfig, 9))
+	assert.Nil(t, exec(forkConfig, 10))
+}
+
+func TestIstanbul(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+
+	exec := func(forkConfig thor.ForkConfig, code []byte) *runtime.Output {
+		state, _ := state.New(b0.Header().StateRoot(), kv)
+		rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Number: 1}, forkConfig)
+		return rt.ExecuteClause(tx.NewClause(nil).WithData(code), 0, math.MaxUint64, &xenv.TransactionContext{})
+	}
+	// returns the word on top of stack
+	ret := []byte{0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3} // PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+
+	// CHAINID
+	chainID := append([]byte{0x46}, ret...)
+	assert.NotNil(t, exec(thor.NoFork, chainID).VMErr)
+	out := exec(thor.SoloFork, chainID)
+	if assert.Nil(t, out.VMErr) {
+		assert.Equal(t, b0.Header().ID().Bytes(), out.Data, "genesis id as chain id")
+	}
+
+	// PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 CREATE2
+	create2 := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0xf5}, ret...)
+	assert.NotNil(t, exec(thor.NoFork, create2).VMErr)
+	out = exec(thor.SoloFork, create2)
+	if assert.Nil(t, out.VMErr) {
+		expected := crypto.Keccak256([]byte{0xff}, out.ContractAddress.Bytes(), make([]byte, 32), crypto.Keccak256(nil))[12:]
+		assert.Equal(t, expected, out.Data[12:])
+	}
+}
+
+func TestVMErrCategory(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+
+	exec := func(code []byte, gas uint64) *runtime.Output {
+		state, _ := state.New(b0.Header().StateRoot(), kv)
+		rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Number: 1}, thor.NoFork)
+		return rt.ExecuteClause(tx.NewClause(nil).WithData(code), 0, gas, &xenv.TransactionContext{})
+	}
+
+	tests := []struct {
+		code     []byte
+		gas      uint64
+		category vm.ErrorCategory
+	}{
+		{[]byte{0x00}, math.MaxUint64, vm.ErrCategoryNone},
+		{[]byte{0x60, 0x00, 0x60, 0x00, 0xfd}, math.MaxUint64, vm.ErrCategoryRevert}, // PUSH1 0 PUSH1 0 REVERT
+		{[]byte{0x60, 0x01, 0x00}, 1, vm.ErrCategoryOutOfGas},                        // PUSH1 1 STOP
+		{[]byte{0xfe}, math.MaxUint64, vm.ErrCategoryInvalidOpCode},                  // INVALID
+		{[]byte{0x01}, math.MaxUint64, vm.ErrCategoryStackUnderflow},                 // ADD
+	}
+	for _, tt := range tests {
+		out := exec(tt.code, tt.gas)
+		assert.Equal(t, tt.category, out.VMErrCategory, "code %x", tt.code)
+		assert.Equal(t, tt.category, vm.CategorizeError(out.VMErr))
+	}
+}
+
+func TestTraceTransaction(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, _ := chain.New(kv, b0)
+
+	state, _ := state.New(b0.Header().StateRoot(), kv)
+
+	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{
+		Number:   1,
+		Time:     b0.Header().Timestamp() + thor.BlockInterval,
+		GasLimit: b0.Header().GasLimit(),
+	}, thor.NoFork)
+
+	method, _ := builtin.Params.ABI.MethodByName("executor")
+	data, err := method.EncodeInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := thor.BytesToAddress([]byte("to"))
+	trx := new(tx.Builder).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+		Clause(tx.NewClause(&builtin.Params.Address).WithData(data)).
+		Gas(100000).
+		Expiration(math.MaxUint32).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	var (
+		callTracer   = vm.NewCallTracer()
+		structLogger = vm.NewStructLogger(nil)
+	)
+	receipt, err := rt.TraceTransaction(trx, func(clauseIndex uint32) vm.Tracer {
+		if clauseIndex == 0 {
+			return nil
+		}
+		return &multiTracer{callTracer, structLogger}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, receipt.Reverted)
+
+	root := callTracer.Result()
+	assert.NotNil(t, root)
+	assert.Equal(t, "CALL", root.Type)
+	assert.Equal(t, common.Address(builtin.Params.Address), root.To)
+	assert.Empty(t, root.Error)
+	assert.NotZero(t, root.GasUsed)
+	assert.NotEmpty(t, structLogger.StructLogs())
+}
+
+type multiTracer []vm.Tracer
+
+func (mt multiTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	for _, t := range mt {
+		t.CaptureStart(from, to, create, input, gas, value)
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	for _, t := range mt {
+		t.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	for _, t := range mt {
+		t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	for _, t := range mt {
+		t.CaptureEnd(output, gasUsed, d, err)
+	}
+	return nil
+}
+
 func TestExecuteTransaction(t *testing.T) {
 
 	// kv, _ := lvldb.NewMem()
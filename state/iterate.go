@@ -0,0 +1,106 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// preimagePrefix (prefix, hashed trie key) -> trie key
+// Preimages are recorded on commit, to resolve keys when iterating tries.
+//
+// Limitations:
+//   - only keys changed since the upgrade are recorded, and there is no backfill, since storage keys
+//     can't be recovered from their hashes
+//   - fast sync transfers trie nodes only, so a fast synced node has preimages of keys changed after sync
+//   - every commit writes a preimage per changed key, which is never pruned, even if the key
+//     is deleted or only appears in pruned states. Repeated writes of the same key overwrite in place,
+//     so the space grows with count of distinct keys, but writes grow with count of changes.
+var preimagePrefix = []byte("k")
+
+func preimageKey(hash []byte) []byte {
+	return append(append([]byte(nil), preimagePrefix...), hash...)
+}
+
+func loadPreimage(kv kv.Getter, hash []byte) ([]byte, error) {
+	data, err := kv.Get(preimageKey(hash))
+	if err != nil {
+		if kv.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// ForEachAccount iterates all accounts of the state root, in order of hashed address.
+// Changes not committed are not visited.
+// addr is nil if the preimage of hashed address was not recorded, e.g. committed by old version.
+// The iteration stops if cb returns false.
+func (s *State) ForEachAccount(cb func(hash thor.Bytes32, addr *thor.Address, acc *Account) bool) error {
+	tr, err := trie.New(s.root, newNodeDB(s.kv))
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return err
+		}
+		preimage, err := loadPreimage(s.kv, it.Key)
+		if err != nil {
+			return err
+		}
+		var addr *thor.Address
+		if len(preimage) > 0 {
+			a := thor.BytesToAddress(preimage)
+			addr = &a
+		}
+		if !cb(thor.BytesToBytes32(it.Key), addr, &acc) {
+			return nil
+		}
+	}
+	return it.Err
+}
+
+// ForEachStorage iterates storage slots of the account committed at the state root, in order of hashed key,
+// starting from the given hashed key (inclusive). Zero start to iterate all.
+// key is nil if the preimage of hashed key was not recorded. value is the raw storage value.
+// The iteration stops if cb returns false.
+func (s *State) ForEachStorage(addr thor.Address, start thor.Bytes32, cb func(hash thor.Bytes32, key *thor.Bytes32, value []byte) bool) error {
+	acc, err := loadAccount(s.trie, addr)
+	if err != nil {
+		return err
+	}
+	storageRoot := thor.BytesToBytes32(acc.StorageRoot)
+	if storageRoot.IsZero() {
+		return nil
+	}
+	tr, err := trie.New(storageRoot, newNodeDB(s.kv))
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(tr.NodeIterator(start[:]))
+	for it.Next() {
+		preimage, err := loadPreimage(s.kv, it.Key)
+		if err != nil {
+			return err
+		}
+		var key *thor.Bytes32
+		if len(preimage) > 0 {
+			k := thor.BytesToBytes32(preimage)
+			key = &k
+		}
+		if !cb(thor.BytesToBytes32(it.Key), key, it.Value) {
+			return nil
+		}
+	}
+	return it.Err
+}
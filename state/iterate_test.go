@@ -0,0 +1,83 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestForEach(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := New(thor.Bytes32{}, kv)
+
+	addr1 := thor.BytesToAddress([]byte("account1"))
+	addr2 := thor.BytesToAddress([]byte("account2"))
+	key := thor.BytesToBytes32([]byte("key"))
+
+	st.SetBalance(addr1, big.NewInt(1))
+	st.SetBalance(addr2, big.NewInt(2))
+	st.SetStorage(addr2, key, thor.BytesToBytes32([]byte("value")))
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	st, _ = New(root, kv)
+	// not committed
+	st.SetBalance(thor.BytesToAddress([]byte("account3")), big.NewInt(3))
+
+	balances := make(map[thor.Address]*big.Int)
+	assert.Nil(t, st.ForEachAccount(func(hash thor.Bytes32, addr *thor.Address, acc *Account) bool {
+		assert.NotNil(t, addr)
+		assert.Equal(t, thor.Blake2b(addr[:]), hash)
+		balances[*addr] = acc.Balance
+		return true
+	}))
+	assert.Equal(t, map[thor.Address]*big.Int{addr1: big.NewInt(1), addr2: big.NewInt(2)}, balances)
+
+	count := 0
+	assert.Nil(t, st.ForEachAccount(func(thor.Bytes32, *thor.Address, *Account) bool {
+		count++
+		return false
+	}))
+	assert.Equal(t, 1, count, "should stop")
+
+	var keys []thor.Bytes32
+	assert.Nil(t, st.ForEachStorage(addr2, thor.Bytes32{}, func(hash thor.Bytes32, k *thor.Bytes32, value []byte) bool {
+		assert.NotNil(t, k)
+		keys = append(keys, *k)
+		var v thor.Bytes32
+		assert.Nil(t, decodeStorage(value, &v))
+		assert.Equal(t, thor.BytesToBytes32([]byte("value")), v)
+		return true
+	}))
+	assert.Equal(t, []thor.Bytes32{key}, keys)
+
+	// start is inclusive
+	count = 0
+	assert.Nil(t, st.ForEachStorage(addr2, thor.Blake2b(key[:]), func(thor.Bytes32, *thor.Bytes32, []byte) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, 1, count)
+
+	var last thor.Bytes32
+	for i := range last {
+		last[i] = 0xff
+	}
+	assert.Nil(t, st.ForEachStorage(addr2, last, func(thor.Bytes32, *thor.Bytes32, []byte) bool {
+		t.Fatal("no storage expected after start")
+		return false
+	}))
+
+	assert.Nil(t, st.ForEachStorage(addr1, thor.Bytes32{}, func(thor.Bytes32, *thor.Bytes32, []byte) bool {
+		t.Fatal("no storage expected")
+		return false
+	}))
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	metricNodeCacheHits   = metric.NewCounter("state", "trie_node_cache_hits_total", "number of trie nodes read from cache")
+	metricNodeCacheMisses = metric.NewCounter("state", "trie_node_cache_misses_total", "number of trie nodes read from database")
+)
+
+// nCache is shared by all states, so that trie nodes loaded by block execution can be reused by API calls, and vice versa.
+// It's disabled by default.
+var nCache *nodeCache
+
+// SetNodeCacheSize sets the number of trie nodes cached in memory. 0 to disable.
+// It should be called before any state is created.
+func SetNodeCacheSize(size int) {
+	if size <= 0 {
+		nCache = nil
+		return
+	}
+	cache, _ := lru.New(size)
+	nCache = &nodeCache{cache}
+}
+
+// nodeCache caches encoded trie nodes.
+// Nodes are keyed by hash, so they never change once stored.
+type nodeCache struct {
+	cache *lru.Cache
+}
+
+type nodeKey struct {
+	kv   kv.GetPutter
+	hash thor.Bytes32
+}
+
+func (nc *nodeCache) Get(kv kv.GetPutter, key []byte) ([]byte, error) {
+	if len(key) != len(thor.Bytes32{}) {
+		return kv.Get(key)
+	}
+	nk := nodeKey{kv, thor.BytesToBytes32(key)}
+	if v, ok := nc.cache.Get(nk); ok {
+		metricNodeCacheHits.Inc()
+		return v.([]byte), nil
+	}
+	metricNodeCacheMisses.Inc()
+	v, err := kv.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	nc.cache.Add(nk, v)
+	return v, nil
+}
+
+// nodeDB implements trie.Database to read trie nodes through the node cache.
+type nodeDB struct {
+	kv    kv.GetPutter
+	cache *nodeCache
+}
+
+func newNodeDB(kv kv.GetPutter) *nodeDB {
+	return &nodeDB{kv, nCache}
+}
+
+func (db *nodeDB) Get(key []byte) ([]byte, error) {
+	if db.cache == nil {
+		return db.kv.Get(key)
+	}
+	return db.cache.Get(db.kv, key)
+}
+
+func (db *nodeDB) Has(key []byte) (bool, error) {
+	return db.kv.Has(key)
+}
+
+func (db *nodeDB) Put(key, value []byte) error {
+	return db.kv.Put(key, value)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestNodeCache(t *testing.T) {
+	SetNodeCacheSize(16)
+	defer SetNodeCacheSize(0)
+
+	kv, _ := lvldb.NewMem()
+	hash := thor.BytesToBytes32([]byte("node"))
+	kv.Put(hash[:], []byte("value"))
+	kv.Put([]byte("key"), []byte("value"))
+
+	db := newNodeDB(kv)
+	v, err := db.Get(hash[:])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), v)
+	v, err = db.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), v)
+
+	kv.Delete(hash[:])
+	kv.Delete([]byte("key"))
+
+	// cached
+	v, err = db.Get(hash[:])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), v)
+
+	// only nodes are cached
+	_, err = db.Get([]byte("key"))
+	assert.True(t, kv.IsNotFound(err))
+
+	// not shared among kv stores
+	kv2, _ := lvldb.NewMem()
+	_, err = newNodeDB(kv2).Get(hash[:])
+	assert.True(t, kv2.IsNotFound(err))
+}
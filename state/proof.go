@@ -0,0 +1,45 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// ProveAccount constructs merkle proof of the account at addr, in the state of root.
+// For absent account, the proof proves the absence.
+func ProveAccount(kv kv.GetPutter, root thor.Bytes32, addr thor.Address) (trie.ProofList, error) {
+	tr, err := trie.NewSecure(root, newNodeDB(kv), 0)
+	if err != nil {
+		return nil, err
+	}
+	var proof trie.ProofList
+	if err := tr.Prove(addr[:], 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyAccountProof verifies the proof constructed by ProveAccount against the state root,
+// and returns the proved account, which is empty if absent.
+func VerifyAccountProof(root thor.Bytes32, addr thor.Address, proof trie.ProofList) (*Account, error) {
+	key := thor.Blake2b(addr[:])
+	data, err, _ := trie.VerifyProof(root, key[:], proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return emptyAccount(), nil
+	}
+	var a Account
+	if err := rlp.DecodeBytes(data, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestAccountProof(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	state, _ := New(thor.Bytes32{}, kv)
+
+	addr1 := thor.BytesToAddress([]byte("acc1"))
+	addr2 := thor.BytesToAddress([]byte("acc2"))
+	state.SetBalance(addr1, big.NewInt(10))
+	state.SetBalance(addr2, big.NewInt(20))
+	root, err := state.Stage().Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof, err := ProveAccount(kv, root, addr1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := VerifyAccountProof(root, addr1, proof)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), acc.Balance)
+
+	// proof mismatches account
+	_, err = VerifyAccountProof(root, addr2, proof)
+	assert.NotNil(t, err)
+	// proof mismatches root
+	_, err = VerifyAccountProof(thor.Bytes32{1}, addr1, proof)
+	assert.NotNil(t, err)
+
+	absent := thor.BytesToAddress([]byte("absent"))
+	proof, err = ProveAccount(kv, root, absent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err = VerifyAccountProof(root, absent, proof)
+	assert.Nil(t, err)
+	assert.True(t, acc.IsEmpty())
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// Pruner deletes trie nodes of obsolete states.
+// Nodes reachable from marked roots are kept, and nodes only reachable from swept roots are deleted on Commit.
+//
+// Usage:
+//  1. Mark roots of states to retain
+//  2. Sweep roots of states to prune
+//  3. Hold the root of the state to be swept next, if sweeping in batches
+//  4. Mark roots of states committed during 1 to 3 if any
+//  5. Commit, and repeat from 2 for the next batch
+//
+// Sweeping in batches bounds the memory of collected nodes. Holding the next state prevents
+// nodes it shares with the swept ones from being deleted before it's swept, otherwise sweeping it
+// would stop at the missing nodes and leave the rest of it behind.
+//
+// Codes are never deleted.
+type Pruner struct {
+	kv      kv.GetPutter
+	kept    map[thor.Bytes32]struct{}
+	held    map[thor.Bytes32]struct{} // kept until next Commit
+	garbage map[thor.Bytes32]struct{}
+}
+
+// NewPruner create a pruner.
+func NewPruner(kv kv.GetPutter) *Pruner {
+	return &Pruner{
+		kv:      kv,
+		kept:    make(map[thor.Bytes32]struct{}),
+		held:    make(map[thor.Bytes32]struct{}),
+		garbage: make(map[thor.Bytes32]struct{}),
+	}
+}
+
+// Mark marks all nodes of the state as kept.
+func (p *Pruner) Mark(root thor.Bytes32) error {
+	return p.walk(root, func(hash thor.Bytes32) bool {
+		if _, ok := p.kept[hash]; ok {
+			// subtree already marked
+			return false
+		}
+		p.kept[hash] = struct{}{}
+		return true
+	})
+}
+
+// Hold marks nodes of the state as kept until next Commit.
+func (p *Pruner) Hold(root thor.Bytes32) error {
+	return p.walk(root, func(hash thor.Bytes32) bool {
+		if p.isKept(hash) {
+			return false
+		}
+		p.held[hash] = struct{}{}
+		return true
+	})
+}
+
+// Sweep collects nodes of the state not marked as kept.
+func (p *Pruner) Sweep(root thor.Bytes32) error {
+	return p.walk(root, func(hash thor.Bytes32) bool {
+		if p.isKept(hash) {
+			// the whole subtree is kept
+			return false
+		}
+		if _, ok := p.garbage[hash]; ok {
+			return false
+		}
+		p.garbage[hash] = struct{}{}
+		return true
+	})
+}
+
+// Collected returns count of nodes collected since last Commit.
+func (p *Pruner) Collected() int {
+	return len(p.garbage)
+}
+
+// Commit deletes collected nodes, and returns count of deleted nodes.
+func (p *Pruner) Commit() (int, error) {
+	batch := p.kv.NewBatch()
+	n := 0
+	for hash := range p.garbage {
+		if p.isKept(hash) {
+			continue
+		}
+		if err := batch.Delete(hash[:]); err != nil {
+			return 0, err
+		}
+		n++
+		if batch.Len() >= 4096 {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch = p.kv.NewBatch()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	p.garbage = make(map[thor.Bytes32]struct{})
+	p.held = make(map[thor.Bytes32]struct{})
+	return n, nil
+}
+
+func (p *Pruner) isKept(hash thor.Bytes32) bool {
+	if _, ok := p.kept[hash]; ok {
+		return true
+	}
+	_, ok := p.held[hash]
+	return ok
+}
+
+// walk iterates nodes of the accounts trie and storage tries.
+// If visit returns false, the subtree of the node is skipped.
+func (p *Pruner) walk(root thor.Bytes32, visit func(hash thor.Bytes32) bool) error {
+	return p.walkTrie(root, visit, func(blob []byte) error {
+		var acc Account
+		if err := rlp.DecodeBytes(blob, &acc); err != nil {
+			return err
+		}
+		if len(acc.StorageRoot) == 0 {
+			return nil
+		}
+		return p.walkTrie(thor.BytesToBytes32(acc.StorageRoot), visit, nil)
+	})
+}
+
+func (p *Pruner) walkTrie(root thor.Bytes32, visit func(hash thor.Bytes32) bool, onLeaf func(blob []byte) error) error {
+	tr, err := trie.New(root, p.kv)
+	if err != nil {
+		return err
+	}
+	it := tr.NodeIterator(nil)
+	for descend := true; it.Next(descend); {
+		descend = true
+		// zero hash for embedded nodes and values, which are not stored separately
+		if hash := it.Hash(); !hash.IsZero() {
+			if !visit(hash) {
+				descend = false
+				continue
+			}
+		}
+		if onLeaf != nil && it.Leaf() {
+			if err := onLeaf(it.LeafBlob()); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestPruner(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	commit := func(parent thor.Bytes32, balance int64) thor.Bytes32 {
+		state, _ := New(parent, kv)
+		for i := 0; i < 100; i++ {
+			addr := thor.BytesToAddress([]byte{byte(i)})
+			state.SetBalance(addr, big.NewInt(balance))
+			state.SetStorage(addr, thor.BytesToBytes32([]byte{byte(i)}), thor.BytesToBytes32(big.NewInt(balance).Bytes()))
+		}
+		root, err := state.Stage().Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return root
+	}
+
+	root1 := commit(thor.Bytes32{}, 1)
+	root2 := commit(root1, 2)
+
+	pruner := NewPruner(kv)
+	assert.Nil(t, pruner.Mark(root2))
+	assert.Nil(t, pruner.Sweep(root1))
+	n, err := pruner.Commit()
+	assert.Nil(t, err)
+	assert.NotZero(t, n)
+
+	// pruned state is no longer complete
+	assert.NotNil(t, NewPruner(kv).Mark(root1))
+	// retained state is intact
+	assert.Nil(t, NewPruner(kv).Mark(root2))
+
+	// nothing left to prune
+	pruner = NewPruner(kv)
+	assert.Nil(t, pruner.Mark(root2))
+	n, err = pruner.Commit()
+	assert.Nil(t, err)
+	assert.Zero(t, n)
+
+	// in batches
+	root3 := commit(root2, 3)
+	root4 := commit(root3, 4)
+	pruner = NewPruner(kv)
+	assert.Nil(t, pruner.Mark(root4))
+	assert.Nil(t, pruner.Sweep(root2))
+	assert.NotZero(t, pruner.Collected())
+	assert.Nil(t, pruner.Hold(root3))
+	n, err = pruner.Commit()
+	assert.Nil(t, err)
+	assert.NotZero(t, n)
+	assert.Zero(t, pruner.Collected())
+
+	// held state is intact until swept
+	assert.Nil(t, pruner.Sweep(root3))
+	n, err = pruner.Commit()
+	assert.Nil(t, err)
+	assert.NotZero(t, n)
+	assert.NotNil(t, NewPruner(kv).Mark(root3))
+	assert.Nil(t, NewPruner(kv).Mark(root4))
+}
@@ -19,6 +19,7 @@ type Stage struct {
 	accountTrie  *trie.SecureTrie
 	storageTries []*trie.SecureTrie
 	codes        []codeWithHash
+	preimages    map[thor.Bytes32][]byte
 }
 
 type codeWithHash struct {
@@ -35,6 +36,7 @@ func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*chan
 
 	storageTries := make([]*trie.SecureTrie, 0, len(changes))
 	codes := make([]codeWithHash, 0, len(changes))
+	preimages := make(map[thor.Bytes32][]byte)
 
 	for addr, obj := range changes {
 		dataCpy := obj.data
@@ -57,6 +59,7 @@ func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*chan
 					if err := saveStorage(strie, k, v); err != nil {
 						return &Stage{err: err}
 					}
+					preimages[thor.Blake2b(k[:])] = append([]byte(nil), k[:]...)
 				}
 				dataCpy.StorageRoot = strie.Hash().Bytes()
 			}
@@ -65,12 +68,14 @@ func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*chan
 		if err := saveAccount(accountTrie, addr, &dataCpy); err != nil {
 			return &Stage{err: err}
 		}
+		preimages[thor.Blake2b(addr[:])] = append([]byte(nil), addr[:]...)
 	}
 	return &Stage{
 		kv:           kv,
 		accountTrie:  accountTrie,
 		storageTries: storageTries,
 		codes:        codes,
+		preimages:    preimages,
 	}
 }
 
@@ -95,6 +100,13 @@ func (s *Stage) Commit() (thor.Bytes32, error) {
 		}
 	}
 
+	// write preimages of trie keys, see preimagePrefix for costs
+	for hash, preimage := range s.preimages {
+		if err := batch.Put(preimageKey(hash[:]), preimage); err != nil {
+			return thor.Bytes32{}, err
+		}
+	}
+
 	// commit storage tries
 	for _, strie := range s.storageTries {
 		root, err := strie.CommitTo(batch)
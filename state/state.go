@@ -159,53 +159,6 @@ func (s *State) updateAccount(addr thor.Address, acc *Account) {
 	s.sm.Put(addr, acc)
 }
 
-// ForEachStorage iterates all storage key-value pairs for given address.
-// It's for debug purpose.
-// func (s *State) ForEachStorage(addr thor.Address, cb func(key thor.Bytes32, value []byte) bool) {
-// 	// skip if no code
-// 	if (s.GetCodeHash(addr) == thor.Bytes32{}) {
-// 		return
-// 	}
-
-// 	// build ongoing key-value pairs
-// 	ongoing := make(map[thor.Bytes32][]byte)
-// 	s.sm.Journal(func(k, v interface{}) bool {
-// 		if key, ok := k.(storageKey); ok {
-// 			if key.addr == addr {
-// 				ongoing[key.key] = v.([]byte)
-// 			}
-// 		}
-// 		return true
-// 	})
-
-// 	for k, v := range ongoing {
-// 		if !cb(k, v) {
-// 			return
-// 		}
-// 	}
-
-// 	co := s.getCachedObject(addr)
-// 	strie, err := trie.NewSecure(thor.BytesToBytes32(co.data.StorageRoot), s.kv, 0)
-// 	if err != nil {
-// 		s.setError(err)
-// 		return
-// 	}
-
-// 	iter := trie.NewIterator(strie.NodeIterator(nil))
-// 	for iter.Next() {
-// 		if s.err != nil {
-// 			return
-// 		}
-// 		// skip cached values
-// 		key := thor.BytesToBytes32(strie.GetKey(iter.Key))
-// 		if _, ok := ongoing[key]; !ok {
-// 			if !cb(key, iter.Value) {
-// 				return
-// 			}
-// 		}
-// 	}
-// }
-
 // Err returns first occurred error.
 func (s *State) Err() error {
 	return s.err
@@ -270,6 +223,7 @@ func (s *State) SetStorage(addr thor.Address, key, value thor.Bytes32) {
 //   *string
 //   *uintx
 //   *big.Int
+//   pointer to struct, slice or array (rlp decoded)
 // ]
 func (s *State) GetStructuredStorage(addr thor.Address, key thor.Bytes32, val interface{}) {
 	data := s.GetRawStorage(addr, key)
@@ -291,6 +245,7 @@ func (s *State) GetStructuredStorage(addr thor.Address, key thor.Bytes32, val in
 //    string
 //    uintx
 //    *big.Int
+//    struct, slice or array, or pointer to them (rlp encoded, zero value to empty)
 // ]
 // If 'val' is nil, the storage is cleared.
 func (s *State) SetStructuredStorage(addr thor.Address, key thor.Bytes32, val interface{}) {
@@ -103,6 +103,9 @@ func encodeStorage(val interface{}) ([]byte, error) {
 		}
 		return rlp.EncodeToBytes(v)
 	}
+	if isComposite(reflect.TypeOf(val)) {
+		return encodeComposite(val)
+	}
 	return nil, errors.New("encode storage value: type " + reflect.TypeOf(val).String())
 }
 
@@ -179,5 +182,115 @@ func decodeStorage(data []byte, val interface{}) error {
 		}
 		return rlp.DecodeBytes(data, v)
 	}
+	if typ := reflect.TypeOf(val); typ != nil && typ.Kind() == reflect.Ptr && isComposite(typ.Elem()) {
+		return decodeComposite(data, reflect.ValueOf(val))
+	}
 	return errors.New("decode storage value: type " + reflect.TypeOf(val).String())
 }
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// isComposite returns whether the type is struct, slice or array, or pointer to them.
+// Composite values are rlp encoded, so rlp struct tags are respected.
+func isComposite(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		return typ != bigIntType
+	case reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// encodeComposite encodes composite value in rlp. As other types, zero value is encoded to empty.
+func encodeComposite(val interface{}) ([]byte, error) {
+	if isZeroValue(reflect.ValueOf(val)) {
+		return nil, nil
+	}
+	return rlp.EncodeToBytes(val)
+}
+
+// decodeComposite decodes data into ptr. For empty data, the value is reset to zero, with
+// *big.Int fields allocated, so it's the same as decoded from encoding of zero value.
+func decodeComposite(data []byte, ptr reflect.Value) error {
+	if len(data) == 0 {
+		ptr.Elem().Set(reflect.Zero(ptr.Elem().Type()))
+		allocBigInts(ptr.Elem())
+		return nil
+	}
+	return rlp.DecodeBytes(data, ptr.Interface())
+}
+
+// isZeroValue returns whether all fields rlp encoded are zero.
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		if v.Type().Elem() == bigIntType {
+			return v.Interface().(*big.Int).Sign() == 0
+		}
+		return isZeroValue(v.Elem())
+	case reflect.Interface:
+		return v.IsNil() || isZeroValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			bi := v.Interface().(big.Int)
+			return bi.Sign() == 0
+		}
+		for i := 0; i < v.NumField(); i++ {
+			// unexported fields are not encoded
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if !isZeroValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		return v.Len() == 0
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZeroValue(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	}
+	return false
+}
+
+// allocBigInts allocates nil *big.Int fields of struct, recursively.
+func allocBigInts(v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case field.Type() == reflect.PtrTo(bigIntType):
+			if field.IsNil() {
+				field.Set(reflect.New(bigIntType))
+			}
+		case field.Kind() == reflect.Struct:
+			allocBigInts(field)
+		}
+	}
+}
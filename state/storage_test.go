@@ -79,6 +79,55 @@ func TestStorageCodec(t *testing.T) {
 	}
 }
 
+func TestStorageCodecComposite(t *testing.T) {
+	type inner struct {
+		Amount *big.Int
+	}
+	type composite struct {
+		Owner  thor.Address
+		Name   string
+		Inner  inner
+		Ptr    *thor.Bytes32 `rlp:"nil"`
+		Values []uint64
+	}
+
+	v := composite{
+		Owner:  thor.BytesToAddress([]byte("owner")),
+		Name:   "foo",
+		Inner:  inner{big.NewInt(10)},
+		Values: []uint64{1, 2},
+	}
+	for _, val := range []interface{}{v, &v} {
+		data, err := encodeStorage(val)
+		assert.Nil(t, err)
+		var decoded composite
+		assert.Nil(t, decodeStorage(data, &decoded))
+		assert.Equal(t, v, decoded)
+	}
+
+	// zero value
+	data, err := encodeStorage(composite{Inner: inner{&big.Int{}}})
+	assert.Nil(t, err)
+	assert.Zero(t, len(data))
+
+	decoded := v
+	assert.Nil(t, decodeStorage(nil, &decoded))
+	assert.Equal(t, composite{Inner: inner{&big.Int{}}}, decoded)
+
+	// slice
+	addrs := []thor.Address{thor.BytesToAddress([]byte("a")), thor.BytesToAddress([]byte("b"))}
+	data, err = encodeStorage(addrs)
+	assert.Nil(t, err)
+	var decodedAddrs []thor.Address
+	assert.Nil(t, decodeStorage(data, &decodedAddrs))
+	assert.Equal(t, addrs, decodedAddrs)
+
+	// unsupported
+	_, err = encodeStorage(10)
+	assert.NotNil(t, err)
+	assert.NotNil(t, decodeStorage(nil, v))
+}
+
 func BenchmarkStorageSet(b *testing.B) {
 	kv, _ := lvldb.NewMem()
 	st, _ := New(thor.Bytes32{}, kv)
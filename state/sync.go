@@ -0,0 +1,78 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// Sync schedules downloading of a whole state, including the accounts trie, storage tries and codes.
+type Sync struct {
+	sched *trie.TrieSync
+	codes map[thor.Bytes32]struct{}
+}
+
+// NewSync create a state sync for the given state root.
+// Nodes already in kv are skipped.
+func NewSync(root thor.Bytes32, kv kv.Getter) *Sync {
+	s := &Sync{codes: make(map[thor.Bytes32]struct{})}
+	s.sched = trie.NewTrieSync(root, kv, func(leaf []byte, parent thor.Bytes32) error {
+		var acc Account
+		if err := rlp.DecodeBytes(leaf, &acc); err != nil {
+			return err
+		}
+		if len(acc.StorageRoot) > 0 {
+			s.sched.AddSubTrie(thor.BytesToBytes32(acc.StorageRoot), 64, parent, nil)
+		}
+		if len(acc.CodeHash) > 0 {
+			codeHash := thor.BytesToBytes32(acc.CodeHash)
+			s.codes[codeHash] = struct{}{}
+			s.sched.AddRawEntry(codeHash, 64, parent)
+		}
+		return nil
+	})
+	return s
+}
+
+// Missing returns at most max hashes of nodes or codes to be downloaded.
+// Returned hashes are considered in-flight, and should be processed.
+func (s *Sync) Missing(max int) []thor.Bytes32 {
+	return s.sched.Missing(max)
+}
+
+// Process verifies and injects downloaded data.
+func (s *Sync) Process(hash thor.Bytes32, data []byte) error {
+	var actual thor.Bytes32
+	if _, isCode := s.codes[hash]; isCode {
+		actual = thor.Bytes32(crypto.Keccak256Hash(data))
+	} else {
+		actual = thor.Blake2b(data)
+	}
+	if actual != hash {
+		return fmt.Errorf("hash mismatch: want %v, have %v", hash, actual)
+	}
+	if _, _, err := s.sched.Process([]trie.SyncResult{{Hash: hash, Data: data}}); err != nil {
+		return err
+	}
+	delete(s.codes, hash)
+	return nil
+}
+
+// Commit flushes completed nodes and codes into w.
+func (s *Sync) Commit(w kv.Putter) (int, error) {
+	return s.sched.Commit(w)
+}
+
+// Pending returns the number of nodes and codes pending to be completed.
+func (s *Sync) Pending() int {
+	return s.sched.Pending()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestSync(t *testing.T) {
+	srcKV, _ := lvldb.NewMem()
+	state, _ := New(thor.Bytes32{}, srcKV)
+	for i := 0; i < 100; i++ {
+		addr := thor.BytesToAddress([]byte{byte(i)})
+		state.SetBalance(addr, big.NewInt(int64(i+1)))
+		state.SetStorage(addr, thor.BytesToBytes32([]byte{byte(i)}), thor.BytesToBytes32([]byte("value")))
+		state.SetCode(addr, []byte{byte(i)})
+	}
+	root, err := state.Stage().Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstKV, _ := lvldb.NewMem()
+	sync := NewSync(root, dstKV)
+	for sync.Pending() > 0 {
+		hashes := sync.Missing(16)
+		assert.NotZero(t, len(hashes))
+		for _, hash := range hashes {
+			data, err := srcKV.Get(hash[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Nil(t, sync.Process(hash, data))
+		}
+		if _, err := sync.Commit(dstKV); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	synced, _ := New(root, dstKV)
+	for i := 0; i < 100; i++ {
+		addr := thor.BytesToAddress([]byte{byte(i)})
+		assert.Equal(t, big.NewInt(int64(i+1)), synced.GetBalance(addr))
+		assert.Equal(t, thor.BytesToBytes32([]byte("value")), synced.GetStorage(addr, thor.BytesToBytes32([]byte{byte(i)})))
+		assert.Equal(t, []byte{byte(i)}, synced.GetCode(addr))
+	}
+	assert.Nil(t, synced.Err())
+}
+
+func TestSyncBadData(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	root := thor.Blake2b([]byte("root"))
+	sync := NewSync(root, kv)
+	hashes := sync.Missing(1)
+	assert.Equal(t, []thor.Bytes32{root}, hashes)
+	assert.NotNil(t, sync.Process(root, []byte("bad")))
+}
@@ -40,7 +40,7 @@ func (tc *trieCache) Get(root thor.Bytes32, kv kv.GetPutter, copy bool) (*trie.S
 			return entry.trie, nil
 		}
 	}
-	tr, err := trie.NewSecure(root, kv, 16)
+	tr, err := trie.NewSecure(root, newNodeDB(kv), 16)
 	if err != nil {
 		return nil, err
 	}
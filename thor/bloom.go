@@ -0,0 +1,80 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const (
+	// BloomByteLength byte length of bloom bits.
+	BloomByteLength = 256
+	// BloomBitLength bit length of bloom bits.
+	BloomBitLength = 8 * BloomByteLength
+
+	// MaxBloomK max number of hash functions, limited by blake2b-256 output (4 bytes each).
+	MaxBloomK = 8
+)
+
+// Bloom a fixed size bloom filter, with k hash functions derived from blake2b-256.
+type Bloom struct {
+	Bits [BloomByteLength]byte
+	K    uint32
+}
+
+// NewBloom creates a bloom with k hash functions. k is clamped into [1, MaxBloomK].
+func NewBloom(k uint32) *Bloom {
+	if k < 1 {
+		k = 1
+	} else if k > MaxBloomK {
+		k = MaxBloomK
+	}
+	return &Bloom{K: k}
+}
+
+// Add adds an item.
+func (b *Bloom) Add(item []byte) {
+	b.distribute(item, func(index int, bit byte) bool {
+		b.Bits[index] |= bit
+		return true
+	})
+}
+
+// Test returns whether the item may be in the bloom. False positive is possible, but false negative is not.
+func (b *Bloom) Test(item []byte) bool {
+	found := true
+	b.distribute(item, func(index int, bit byte) bool {
+		found = b.Bits[index]&bit == bit
+		return found
+	})
+	return found
+}
+
+func (b *Bloom) distribute(item []byte, cb func(index int, bit byte) bool) {
+	hash := Blake2b(item)
+	for i := 0; i < int(b.K) && i < MaxBloomK; i++ {
+		d := binary.BigEndian.Uint32(hash[i*4:]) % BloomBitLength
+		if !cb(int(d/8), 1<<(d%8)) {
+			return
+		}
+	}
+}
+
+// EstimateBloomK estimates the optimal k for the given count of items.
+func EstimateBloomK(count int) uint32 {
+	if count <= 0 {
+		return MaxBloomK
+	}
+	k := int(math.Round(float64(BloomBitLength) / float64(count) * math.Ln2))
+	if k < 1 {
+		return 1
+	}
+	if k > MaxBloomK {
+		return MaxBloomK
+	}
+	return uint32(k)
+}
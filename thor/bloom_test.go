@@ -0,0 +1,35 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
+)
+
+func TestBloom(t *testing.T) {
+	items := make([][]byte, 100)
+	for i := range items {
+		items[i] = thor.BytesToAddress([]byte{byte(i)}).Bytes()
+	}
+	bloom := thor.NewBloom(thor.EstimateBloomK(len(items)))
+	for _, item := range items {
+		bloom.Add(item)
+	}
+	for _, item := range items {
+		assert.True(t, bloom.Test(item))
+	}
+	assert.False(t, bloom.Test(thor.BytesToAddress([]byte("absent")).Bytes()))
+}
+
+func TestEstimateBloomK(t *testing.T) {
+	assert.Equal(t, uint32(thor.MaxBloomK), thor.EstimateBloomK(0))
+	assert.Equal(t, uint32(thor.MaxBloomK), thor.EstimateBloomK(10))
+	assert.Equal(t, uint32(7), thor.EstimateBloomK(200))
+	assert.Equal(t, uint32(1), thor.EstimateBloomK(10000))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ForkConfig block numbers where forks are activated, from the block on.
+// Only EVM forks implemented by package vm are configurable. Istanbul there
+// excludes the SSTORE (EIP-2200) and calldata (EIP-2028) repricing, since
+// intrinsic gas is defined by thor itself.
+type ForkConfig struct {
+	Constantinople uint32 `json:"constantinople"` // SHL, SHR, SAR, CREATE2 and EXTCODEHASH opcodes
+	Istanbul       uint32 `json:"istanbul"`       // CHAINID and SELFBALANCE opcodes, state access repricing, blake2f precompile
+	VIP191         uint32 `json:"vip191"`         // fee delegation
+}
+
+// NoFork no fork is activated.
+var NoFork = ForkConfig{
+	Constantinople: math.MaxUint32,
+	Istanbul:       math.MaxUint32,
+	VIP191:         math.MaxUint32,
+}
+
+// SoloFork all forks are activated from genesis.
+var SoloFork = ForkConfig{
+	Constantinople: 0,
+	Istanbul:       0,
+	VIP191:         0,
+}
+
+func (fc ForkConfig) String() string {
+	var strs []string
+	push := func(name string, num uint32) {
+		if num != math.MaxUint32 {
+			strs = append(strs, fmt.Sprintf("%v: #%d", name, num))
+		}
+	}
+	push("Constantinople", fc.Constantinople)
+	push("Istanbul", fc.Istanbul)
+	push("VIP191", fc.VIP191)
+	if len(strs) == 0 {
+		return "none"
+	}
+	return strings.Join(strs, ", ")
+}
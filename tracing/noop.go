@@ -0,0 +1,34 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build !otel
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Init fails, since tracing is not built in.
+func Init(endpoint string, version string, sampleRatio float64) (func(context.Context) error, error) {
+	return nil, errors.New("tracing not built in, rebuild with tag 'otel'")
+}
+
+func start(ctx context.Context, name string, attrs []Attr) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// Extract returns ctx as is.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return ctx
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attr) {}
+func (noopSpan) Fail(err error)              {}
+func (noopSpan) End()                        {}
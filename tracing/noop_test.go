@@ -0,0 +1,27 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build !otel
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/tracing"
+)
+
+func TestNoop(t *testing.T) {
+	_, err := tracing.Init("localhost:4317", "test", 1)
+	assert.NotNil(t, err)
+
+	ctx := context.Background()
+	spanCtx, span := tracing.Start(ctx, "span", tracing.Int64("number", 1))
+	assert.Equal(t, ctx, spanCtx)
+	tracing.End(span, errors.New("failed"))
+}
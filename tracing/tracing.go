@@ -0,0 +1,55 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package tracing traces where time goes with OpenTelemetry spans, and exports them via OTLP.
+// Spans are no-op until tracing is enabled by Init.
+//
+// OpenTelemetry requires a newer Go than thor, so it's built in only with build tag 'otel'.
+// Otherwise spans are always no-op, and Init fails.
+package tracing
+
+import "context"
+
+// Attr is an attribute of span.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr {
+	return Attr{key, value}
+}
+
+// Int returns an int attribute.
+func Int(key string, value int) Attr {
+	return Attr{key, value}
+}
+
+// Int64 returns an int64 attribute.
+func Int64(key string, value int64) Attr {
+	return Attr{key, value}
+}
+
+// Span is a traced operation.
+type Span interface {
+	SetAttributes(attrs ...Attr)
+	// Fail marks the span failed.
+	Fail(err error)
+	End()
+}
+
+// End ends the span, and marks it failed if err is not nil.
+func End(span Span, err error) {
+	if err != nil {
+		span.Fail(err)
+	}
+	span.End()
+}
+
+// Start starts a span as child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, Span) {
+	return start(ctx, name, attrs)
+}
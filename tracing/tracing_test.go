@@ -0,0 +1,43 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build otel
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := tracing.Start(context.Background(), "parent", tracing.Int64("number", 1))
+	_, child := tracing.Start(ctx, "child")
+	tracing.End(child, errors.New("failed"))
+	tracing.End(parent, nil)
+
+	spans := recorder.Ended()
+	if assert.Equal(t, 2, len(spans)) {
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+		assert.Equal(t, "parent", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+		assert.Equal(t, []attribute.KeyValue{attribute.Int64("number", 1)}, spans[1].Attributes())
+	}
+}
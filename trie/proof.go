@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/thor"
@@ -118,6 +119,33 @@ func VerifyProof(rootHash thor.Bytes32, key []byte, proofDb DatabaseReader) (val
 	}
 }
 
+// ProofList is a list of encoded trie nodes, the form to transfer proofs.
+// It collects proof as DatabaseWriter, and is read as DatabaseReader to verify proof,
+// where nodes are keyed by their blake2b hash.
+type ProofList [][]byte
+
+// Put implements DatabaseWriter.
+func (l *ProofList) Put(key []byte, value []byte) error {
+	*l = append(*l, common.CopyBytes(value))
+	return nil
+}
+
+// Get implements DatabaseReader.
+func (l ProofList) Get(key []byte) ([]byte, error) {
+	for _, node := range l {
+		if hash := thor.Blake2b(node); bytes.Equal(hash[:], key) {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("proof node %x missing", key)
+}
+
+// Has implements DatabaseReader.
+func (l ProofList) Has(key []byte) (bool, error) {
+	_, err := l.Get(key)
+	return err == nil, nil
+}
+
 func get(tn node, key []byte) ([]byte, node) {
 	for {
 		switch n := tn.(type) {
@@ -69,6 +69,27 @@ func TestOneElementProof(t *testing.T) {
 	}
 }
 
+func TestProofList(t *testing.T) {
+	trie, vals := randomTrie(100)
+	root := trie.Hash()
+	for _, kv := range vals {
+		var proof ProofList
+		if trie.Prove(kv.k, 0, &proof) != nil {
+			t.Fatalf("missing key %x while constructing proof", kv.k)
+		}
+		val, err, _ := VerifyProof(root, kv.k, proof)
+		if err != nil {
+			t.Fatalf("VerifyProof error for key %x: %v", kv.k, err)
+		}
+		if !bytes.Equal(val, kv.v) {
+			t.Fatalf("VerifyProof returned wrong value for key %x: got %x, want %x", kv.k, val, kv.v)
+		}
+		if _, err, _ := VerifyProof(root, kv.k, proof[1:]); err == nil {
+			t.Fatalf("expected error for proof without root")
+		}
+	}
+}
+
 func TestVerifyBadProof(t *testing.T) {
 	trie, vals := randomTrie(800)
 	root := trie.Hash()
@@ -144,6 +144,12 @@ func (t *SecureTrie) Copy() *SecureTrie {
 	return &cpy
 }
 
+// Prove constructs a merkle proof for key, see Trie.Prove.
+// The key is hashed as in other methods of SecureTrie.
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb DatabaseWriter) error {
+	return t.trie.Prove(t.hashKey(key), fromLevel, proofDb)
+}
+
 // NodeIterator returns an iterator that returns nodes of the underlying trie. Iteration
 // starts at the key after the given start key.
 func (t *SecureTrie) NodeIterator(start []byte) NodeIterator {
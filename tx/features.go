@@ -0,0 +1,39 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import "github.com/vechain/thor/thor"
+
+// Features bitset of tx features, stored as the first reserved field.
+type Features uint32
+
+const (
+	// DelegationFeature see VIP-191, gas is paid by a delegator who co-signs the tx.
+	DelegationFeature Features = 1
+)
+
+// SupportedFeatures returns features supported by txs in the block numbered num.
+func SupportedFeatures(forkConfig thor.ForkConfig, num uint32) Features {
+	var features Features
+	if num >= forkConfig.VIP191 {
+		features |= DelegationFeature
+	}
+	return features
+}
+
+// IsDelegated returns whether the delegation feature is set.
+func (f Features) IsDelegated() bool {
+	return f&DelegationFeature == DelegationFeature
+}
+
+// SetDelegated sets or clears the delegation feature.
+func (f *Features) SetDelegated(flag bool) {
+	if flag {
+		*f |= DelegationFeature
+	} else {
+		*f &= ^DelegationFeature
+	}
+}
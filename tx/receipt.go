@@ -6,6 +6,8 @@
 package tx
 
 import (
+	"bytes"
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -27,6 +29,65 @@ type Receipt struct {
 	Reverted bool
 	// outputs of clauses in tx
 	Outputs []*Output
+	// category of the vm error if reverted, see vm.ErrorCategory.
+	// It's stored along with receipt but not involved in receipts root.
+	VMErrorCategory string
+	// reason string if reverted by 'Error(string)', also not involved in receipts root.
+	RevertReason string
+}
+
+// storedReceipt is the storage form of receipt.
+// VMErrorCategory and RevertReason are put in the tail, and omitted if empty, so receipts stored before are still decodable.
+type storedReceipt struct {
+	GasUsed  uint64
+	GasPayer thor.Address
+	Paid     *big.Int
+	Reward   *big.Int
+	Reverted bool
+	Outputs  []*Output
+	Tail     []string `rlp:"tail"`
+}
+
+// EncodeRLP implements rlp.Encoder
+func (r *Receipt) EncodeRLP(w io.Writer) error {
+	stored := storedReceipt{
+		r.GasUsed,
+		r.GasPayer,
+		r.Paid,
+		r.Reward,
+		r.Reverted,
+		r.Outputs,
+		nil,
+	}
+	if r.RevertReason != "" {
+		stored.Tail = []string{r.VMErrorCategory, r.RevertReason}
+	} else if r.VMErrorCategory != "" {
+		stored.Tail = []string{r.VMErrorCategory}
+	}
+	return rlp.Encode(w, &stored)
+}
+
+// DecodeRLP implements rlp.Decoder
+func (r *Receipt) DecodeRLP(s *rlp.Stream) error {
+	var stored storedReceipt
+	if err := s.Decode(&stored); err != nil {
+		return err
+	}
+	*r = Receipt{
+		GasUsed:  stored.GasUsed,
+		GasPayer: stored.GasPayer,
+		Paid:     stored.Paid,
+		Reward:   stored.Reward,
+		Reverted: stored.Reverted,
+		Outputs:  stored.Outputs,
+	}
+	if len(stored.Tail) > 0 {
+		r.VMErrorCategory = stored.Tail[0]
+	}
+	if len(stored.Tail) > 1 {
+		r.RevertReason = stored.Tail[1]
+	}
+	return nil
 }
 
 // Output output of clause execution.
@@ -56,9 +117,51 @@ func (rs derivableReceipts) Len() int {
 	return len(rs)
 }
 func (rs derivableReceipts) GetRlp(i int) []byte {
-	data, err := rlp.EncodeToBytes(rs[i])
+	// vm error is not part of consensus
+	r := *rs[i]
+	r.VMErrorCategory = ""
+	r.RevertReason = ""
+	data, err := rlp.EncodeToBytes(&r)
 	if err != nil {
 		panic(err)
 	}
 	return data
 }
+
+// Prove constructs merkle proof of the i-th receipt, in the trie whose root is RootHash.
+func (rs Receipts) Prove(i int) (trie.ProofList, error) {
+	var (
+		tr     trie.Trie
+		keybuf bytes.Buffer
+	)
+	list := derivableReceipts(rs)
+	for j := 0; j < list.Len(); j++ {
+		keybuf.Reset()
+		rlp.Encode(&keybuf, uint(j))
+		tr.Update(keybuf.Bytes(), list.GetRlp(j))
+	}
+	key, _ := rlp.EncodeToBytes(uint(i))
+	var proof trie.ProofList
+	if err := tr.Prove(key, 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyReceiptProof verifies the proof constructed by Receipts.Prove against the receipts root,
+// and returns the proved receipt, or nil if absent.
+func VerifyReceiptProof(root thor.Bytes32, i int, proof trie.ProofList) (*Receipt, error) {
+	key, _ := rlp.EncodeToBytes(uint(i))
+	data, err, _ := trie.VerifyProof(root, key, proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var r Receipt
+	if err := rlp.DecodeBytes(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -7,8 +7,11 @@ package tx_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
 	. "github.com/vechain/thor/tx"
 )
 
@@ -19,3 +22,64 @@ func TestReceipt(t *testing.T) {
 	var txs Transactions
 	fmt.Println(txs.RootHash())
 }
+
+func TestReceiptVMError(t *testing.T) {
+	r := &Receipt{GasUsed: 21000, Paid: big.NewInt(1), Reward: big.NewInt(1), Reverted: true}
+	legacy, err := rlp.EncodeToBytes(r)
+	assert.Nil(t, err)
+	root := Receipts{r}.RootHash()
+
+	r.VMErrorCategory = "out-of-gas"
+	data, err := rlp.EncodeToBytes(r)
+	assert.Nil(t, err)
+	assert.NotEqual(t, legacy, data)
+	assert.Equal(t, root, Receipts{r}.RootHash(), "vm error should not affect root")
+
+	var decoded Receipt
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, "out-of-gas", decoded.VMErrorCategory)
+	assert.Equal(t, r.GasUsed, decoded.GasUsed)
+	assert.True(t, decoded.Reverted)
+
+	decoded = Receipt{}
+	assert.Nil(t, rlp.DecodeBytes(legacy, &decoded))
+	assert.Equal(t, "", decoded.VMErrorCategory)
+
+	r.RevertReason = "not allowed"
+	data, err = rlp.EncodeToBytes(r)
+	assert.Nil(t, err)
+	assert.Equal(t, root, Receipts{r}.RootHash(), "revert reason should not affect root")
+
+	decoded = Receipt{}
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, "out-of-gas", decoded.VMErrorCategory)
+	assert.Equal(t, "not allowed", decoded.RevertReason)
+}
+
+func TestReceiptProof(t *testing.T) {
+	var rs Receipts
+	for i := 0; i < 20; i++ {
+		rs = append(rs, &Receipt{GasUsed: uint64(21000 + i), Paid: big.NewInt(int64(i)), Reward: big.NewInt(1)})
+	}
+	root := rs.RootHash()
+
+	for i := range rs {
+		proof, err := rs.Prove(i)
+		assert.Nil(t, err)
+		r, err := VerifyReceiptProof(root, i, proof)
+		assert.Nil(t, err)
+		if assert.NotNil(t, r) {
+			assert.Equal(t, rs[i].GasUsed, r.GasUsed)
+			assert.Equal(t, rs[i].Paid, r.Paid)
+		}
+	}
+
+	proof, err := rs.Prove(100)
+	assert.Nil(t, err)
+	r, err := VerifyReceiptProof(root, 100, proof)
+	assert.Nil(t, err)
+	assert.Nil(t, r, "absent receipt")
+
+	_, err = VerifyReceiptProof(root, 0, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// emptyString is the rlp encoding of empty bytes and zero integer.
+var emptyString = []byte{0x80}
+
+// reserved is the reserved fields of tx body, in which the first one is features.
+// It's encoded as a list with trailing empty items trimmed, so that txs without features
+// are encoded the same as before.
+type reserved struct {
+	Features Features
+	Unused   []rlp.RawValue
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (r *reserved) EncodeRLP(w io.Writer) error {
+	features, err := rlp.EncodeToBytes(r.Features)
+	if err != nil {
+		return err
+	}
+	list := make([]rlp.RawValue, 0, len(r.Unused)+1)
+	list = append(list, features)
+	list = append(list, r.Unused...)
+
+	// trim trailing empty items
+	for len(list) > 0 && bytes.Equal(list[len(list)-1], emptyString) {
+		list = list[:len(list)-1]
+	}
+	return rlp.Encode(w, list)
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (r *reserved) DecodeRLP(s *rlp.Stream) error {
+	var list []rlp.RawValue
+	if err := s.Decode(&list); err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		*r = reserved{}
+		return nil
+	}
+	// otherwise the same tx has more than one encoding
+	if bytes.Equal(list[len(list)-1], emptyString) {
+		return errors.New("rlp: reserved fields not trimmed")
+	}
+
+	var features Features
+	if err := rlp.DecodeBytes(list[0], &features); err != nil {
+		return err
+	}
+	*r = reserved{features, list[1:]}
+	return nil
+}
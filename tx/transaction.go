@@ -26,6 +26,8 @@ var (
 	errIntrinsicGasOverflow = errors.New("intrinsic gas overflow")
 )
 
+const signatureLength = 65
+
 // Transaction is an immutable tx type.
 type Transaction struct {
 	body body
@@ -33,6 +35,7 @@ type Transaction struct {
 	cache struct {
 		signingHash  atomic.Value
 		signer       atomic.Value
+		delegator    atomic.Value
 		id           atomic.Value
 		unprovedWork atomic.Value
 		size         atomic.Value
@@ -50,7 +53,7 @@ type body struct {
 	Gas          uint64
 	DependsOn    *thor.Bytes32 `rlp:"nil"`
 	Nonce        uint64
-	Reserved     []interface{}
+	Reserved     reserved
 	Signature    []byte
 }
 
@@ -130,7 +133,7 @@ func (t *Transaction) EvaluateWork(signer thor.Address) func(nonce uint64) *big.
 		t.body.GasPriceCoef,
 		t.body.Gas,
 		t.body.DependsOn,
-		t.body.Reserved,
+		&t.body.Reserved,
 		signer,
 	})
 
@@ -163,12 +166,23 @@ func (t *Transaction) SigningHash() (hash thor.Bytes32) {
 		t.body.Gas,
 		t.body.DependsOn,
 		t.body.Nonce,
-		t.body.Reserved,
+		&t.body.Reserved,
 	})
 	hw.Sum(hash[:0])
 	return
 }
 
+// DelegatorSigningHash returns hash of tx components for delegator to sign, by assuming the signer as origin.
+// delegatorSigningHash = hash(signingHash, origin)
+func (t *Transaction) DelegatorSigningHash(origin thor.Address) thor.Bytes32 {
+	return thor.Blake2b(t.SigningHash().Bytes(), origin.Bytes())
+}
+
+// Features returns features.
+func (t *Transaction) Features() Features {
+	return t.body.Reserved.Features
+}
+
 // GasPriceCoef returns gas price coef.
 // gas price = bgp + bgp * gpc / 255.
 func (t *Transaction) GasPriceCoef() uint8 {
@@ -199,7 +213,8 @@ func (t *Transaction) Signature() []byte {
 	return append([]byte(nil), t.body.Signature...)
 }
 
-// Signer extract signer of tx from signature.
+// Signer extract signer (origin) of tx from signature.
+// For delegated tx, the signature is the concatenation of origin's and delegator's.
 func (t *Transaction) Signer() (signer thor.Address, err error) {
 	if cached := t.cache.signer.Load(); cached != nil {
 		return cached.(thor.Address), nil
@@ -210,7 +225,14 @@ func (t *Transaction) Signer() (signer thor.Address, err error) {
 		}
 	}()
 
-	pub, err := crypto.SigToPub(t.SigningHash().Bytes(), t.body.Signature)
+	sig := t.body.Signature
+	if t.Features().IsDelegated() {
+		if len(sig) != signatureLength*2 {
+			return thor.Address{}, errors.New("invalid delegated signature length")
+		}
+		sig = sig[:signatureLength]
+	}
+	pub, err := crypto.SigToPub(t.SigningHash().Bytes(), sig)
 	if err != nil {
 		return thor.Address{}, err
 	}
@@ -218,6 +240,34 @@ func (t *Transaction) Signer() (signer thor.Address, err error) {
 	return
 }
 
+// Delegator extract delegator, who pays gas for the tx, from signature.
+// It returns nil if the tx is not delegated.
+func (t *Transaction) Delegator() (delegator *thor.Address, err error) {
+	if !t.Features().IsDelegated() {
+		return nil, nil
+	}
+	if cached := t.cache.delegator.Load(); cached != nil {
+		addr := cached.(thor.Address)
+		return &addr, nil
+	}
+	defer func() {
+		if err == nil {
+			t.cache.delegator.Store(*delegator)
+		}
+	}()
+
+	origin, err := t.Signer()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(t.DelegatorSigningHash(origin).Bytes(), t.body.Signature[signatureLength:])
+	if err != nil {
+		return nil, err
+	}
+	addr := thor.Address(crypto.PubkeyToAddress(*pub))
+	return &addr, nil
+}
+
 // WithSignature create a new tx with signature set.
 func (t *Transaction) WithSignature(sig []byte) *Transaction {
 	newTx := Transaction{
@@ -228,10 +278,17 @@ func (t *Transaction) WithSignature(sig []byte) *Transaction {
 	return &newTx
 }
 
-// HasReservedFields returns if there're reserved fields.
+// TestFeatures returns an error if the tx uses features not supported, or has unused reserved fields.
 // Reserved fields are for backward compatibility purpose.
-func (t *Transaction) HasReservedFields() bool {
-	return len(t.body.Reserved) > 0
+func (t *Transaction) TestFeatures(supported Features) error {
+	r := &t.body.Reserved
+	if r.Features&supported != r.Features {
+		return errors.New("unsupported features")
+	}
+	if len(r.Unused) > 0 {
+		return errors.New("unused reserved slot")
+	}
+	return nil
 }
 
 // EncodeRLP implements rlp.Encoder
@@ -359,6 +416,7 @@ func (t *Transaction) OverallGasPrice(baseGasPrice *big.Int, headBlockNum uint32
 func (t *Transaction) String() string {
 	var (
 		from      string
+		delegator string
 		br        BlockRef
 		dependsOn string
 	)
@@ -368,6 +426,13 @@ func (t *Transaction) String() string {
 	} else {
 		from = signer.String()
 	}
+	if d, err := t.Delegator(); err != nil {
+		delegator = "N/A"
+	} else if d == nil {
+		delegator = "nil"
+	} else {
+		delegator = d.String()
+	}
 
 	binary.BigEndian.PutUint64(br[:], t.body.BlockRef)
 	if t.body.DependsOn == nil {
@@ -379,6 +444,7 @@ func (t *Transaction) String() string {
 	return fmt.Sprintf(`
 	Tx(%v, %v)
 	From:           %v
+	Delegator:      %v
 	Clauses:        %v
 	GasPriceCoef:   %v
 	Gas:            %v
@@ -389,7 +455,7 @@ func (t *Transaction) String() string {
 	Nonce:          %v
 	UnprovedWork:   %v	
 	Signature:      0x%x
-`, t.ID(), t.Size(), from, t.body.Clauses, t.body.GasPriceCoef, t.body.Gas,
+`, t.ID(), t.Size(), from, delegator, t.body.Clauses, t.body.GasPriceCoef, t.body.Gas,
 		t.body.ChainTag, br.Number(), br[4:], t.body.Expiration, dependsOn, t.body.Nonce, t.UnprovedWork(), t.body.Signature)
 }
 
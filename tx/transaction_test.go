@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/thor"
@@ -35,6 +36,65 @@ func TestTx(t *testing.T) {
 	assert.Equal(data1, data2)
 }
 
+func TestDelegatedTx(t *testing.T) {
+	origin, _ := crypto.GenerateKey()
+	delegator, _ := crypto.GenerateKey()
+
+	var feat Features
+	feat.SetDelegated(true)
+	trx := new(Builder).Gas(21000).Clause(NewClause(nil)).Features(feat).Build()
+
+	originSig, _ := crypto.Sign(trx.SigningHash().Bytes(), origin)
+	_, err := trx.WithSignature(originSig).Signer()
+	assert.NotNil(t, err, "delegator signature missing")
+
+	originAddr := thor.Address(crypto.PubkeyToAddress(origin.PublicKey))
+	delegatorSig, _ := crypto.Sign(trx.DelegatorSigningHash(originAddr).Bytes(), delegator)
+	trx = trx.WithSignature(append(originSig, delegatorSig...))
+
+	signer, err := trx.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, originAddr, signer)
+	d, err := trx.Delegator()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(delegator.PublicKey)), *d)
+
+	assert.NotNil(t, trx.TestFeatures(0))
+	assert.Nil(t, trx.TestFeatures(DelegationFeature))
+
+	data, _ := rlp.EncodeToBytes(trx)
+	var decoded Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, trx.ID(), decoded.ID())
+	assert.True(t, decoded.Features().IsDelegated())
+}
+
+func TestReservedEncoding(t *testing.T) {
+	trx := new(Builder).Build()
+	assert.Nil(t, trx.TestFeatures(0))
+	d, err := trx.Delegator()
+	assert.Nil(t, err)
+	assert.Nil(t, d)
+
+	// reserved fields of tx without features encoded as empty list
+	data, _ := rlp.EncodeToBytes(trx)
+	assert.Equal(t, byte(0xc0), data[len(data)-2])
+
+	// not trimmed
+	var body []interface{}
+	assert.Nil(t, rlp.DecodeBytes(data, &body))
+	body[8] = []interface{}{uint(0)}
+	data, _ = rlp.EncodeToBytes(body)
+	assert.NotNil(t, rlp.DecodeBytes(data, &Transaction{}))
+
+	// unused slots
+	body[8] = []interface{}{uint(0), uint(1)}
+	data, _ = rlp.EncodeToBytes(body)
+	var decoded Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.NotNil(t, decoded.TestFeatures(DelegationFeature))
+}
+
 func BenchmarkTxMining(b *testing.B) {
 	tx := new(tx.Builder).Build()
 	signer := thor.BytesToAddress([]byte("acc1"))
@@ -6,48 +6,73 @@
 package txpool
 
 import (
+	"math/big"
 	Sort "sort"
 	"sync"
 
 	Cache "github.com/vechain/thor/cache"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/thor"
 )
 
-type mechanism int
-
-const (
-	random mechanism = iota
-	prior
-)
+var metricPoolSize = metric.NewGauge("txpool", "size", "number of txs in pool")
 
 type entry struct {
-	lock    sync.Mutex
-	dirty   bool
-	all     cache
-	pending txObjects
-	sorted  bool
-	quota   quota
-}
-
-func newEntry(size int) *entry {
-	e := &entry{
-		all:   newPriorCache(size),
-		quota: make(quota),
+	lock        sync.Mutex
+	dirty       bool
+	all         *Cache.PrioCache
+	limit       int
+	originLimit int
+	pending     txObjects
+	sorted      bool
+	quota       quota
+	slots       map[slot]thor.Bytes32
+}
+
+// slot identifies txs which can replace each other.
+type slot struct {
+	origin    thor.Address
+	nonce     uint64
+	dependsOn thor.Bytes32
+}
+
+func slotOf(obj *txObject) slot {
+	s := slot{origin: obj.signer, nonce: obj.tx.Nonce()}
+	if dep := obj.tx.DependsOn(); dep != nil {
+		s.dependsOn = *dep
 	}
-	switch cacheMechanism {
-	case random:
-		e.all = Cache.NewRandCache(size)
-	case prior:
-		e.all = newPriorCache(size)
+	return s
+}
+
+// canReplace returns whether newObj offers gas price at least replaceBump percent
+// higher than obj. Gas price is proportional to 255 + gas price coef.
+func canReplace(newObj, obj *txObject) bool {
+	return (255+uint64(newObj.tx.GasPriceCoef()))*100 >= (255+uint64(obj.tx.GasPriceCoef()))*(100+replaceBump)
+}
+
+func newEntry(limit, originLimit int) *entry {
+	return &entry{
+		// capacity is one more than limit, eviction is done by entry itself
+		all:         Cache.NewPrioCache(limit + 1),
+		limit:       limit,
+		originLimit: originLimit,
+		quota:       make(quota),
+		slots:       make(map[slot]thor.Bytes32),
 	}
-	return e
+}
+
+// evictionPriority returns priority of tx object for eviction. The tx with lowest
+// gas price coef is evicted first, and the newer one if gas price coefs are equal.
+func evictionPriority(obj *txObject) float64 {
+	// creation time in seconds fits in 40 bits, so the result is exact in float64
+	return float64(obj.tx.GasPriceCoef())*(1<<40) - float64(obj.creationTime)
 }
 
 func (e *entry) find(id thor.Bytes32) *txObject {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	if value, ok := e.all.Get(id); ok {
+	if value, _, ok := e.all.Get(id); ok {
 		if obj, ok := value.(*txObject); ok {
 			return obj
 		}
@@ -59,11 +84,18 @@ func (e *entry) delete(id thor.Bytes32) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	if value, ok := e.all.Get(id); ok {
-		if obj, ok := value.(*txObject); ok {
+	e.remove(id)
+}
+
+func (e *entry) remove(id thor.Bytes32) {
+	if ent := e.all.Remove(id); ent != nil {
+		if obj, ok := ent.Value.(*txObject); ok {
+			if s := slotOf(obj); e.slots[s] == id {
+				delete(e.slots, s)
+			}
 			e.quota.dec(obj.signer)
-			e.all.Remove(id)
 			obj.deleted = true
+			metricPoolSize.Set(float64(e.all.Len()))
 		}
 	}
 }
@@ -72,15 +104,33 @@ func (e *entry) save(obj *txObject) error {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	if _, ok := e.all.Get(obj.tx.ID()); !ok {
-		if e.quota.quota(obj.signer) >= quotaSignerTx {
+	priority := evictionPriority(obj)
+	if !e.all.Contains(obj.tx.ID()) {
+		slot := slotOf(obj)
+		if id, ok := e.slots[slot]; ok {
+			value, _, _ := e.all.Get(id)
+			if !canReplace(obj, value.(*txObject)) {
+				return rejectedTxErr{"replacement transaction underpriced"}
+			}
+			e.remove(id)
+		}
+		if e.quota.quota(obj.signer) >= uint(e.originLimit) {
 			return rejectedTxErr{"quota exceeds limit"}
 		}
+		if e.all.Len() >= e.limit {
+			lowest := e.all.Lowest()
+			if lowest.Priority >= priority {
+				return rejectedTxErr{"pool is full"}
+			}
+			e.remove(lowest.Key.(thor.Bytes32))
+		}
 		e.quota.inc(obj.signer)
+		e.slots[slot] = obj.tx.ID()
 	}
 
-	e.all.Set(obj.tx.ID(), obj)
+	e.all.Set(obj.tx.ID(), obj, priority)
 	e.dirty = true
+	metricPoolSize.Set(float64(e.all.Len()))
 	return nil
 }
 
@@ -96,6 +146,7 @@ func (e *entry) dumpPending(sort bool) txObjects {
 		Sort.Slice(e.pending, func(i, j int) bool {
 			return e.pending[i].overallGP.Cmp(e.pending[j].overallGP) > 0
 		})
+		e.pending = e.pending.orderByDependency()
 		e.sorted = true
 	}
 
@@ -114,7 +165,7 @@ func (e *entry) dumpAll() txObjects {
 	defer e.lock.Unlock()
 
 	all := make(txObjects, 0, e.all.Len())
-	e.all.ForEach(func(entry *Cache.Entry) bool {
+	e.all.ForEach(func(entry *Cache.PrioEntry) bool {
 		if obj, ok := entry.Value.(*txObject); ok {
 			all = append(all, obj)
 			return true
@@ -125,6 +176,43 @@ func (e *entry) dumpAll() txObjects {
 	return all
 }
 
+// dumpAllWithStatus returns all tx objects, along with their status snapshotted under lock.
+func (e *entry) dumpAllWithStatus() (txObjects, []objectStatus) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	all := make(txObjects, 0, e.all.Len())
+	status := make([]objectStatus, 0, e.all.Len())
+	e.all.ForEach(func(entry *Cache.PrioEntry) bool {
+		if obj, ok := entry.Value.(*txObject); ok {
+			all = append(all, obj)
+			status = append(status, obj.status)
+			return true
+		}
+		return false
+	})
+	return all, status
+}
+
+// setStatus updates status of the tx object, and refreshes its overall gas price by overallGP
+// when it turns pending. It returns false if the tx object is no longer in pool.
+func (e *entry) setStatus(obj *txObject, status objectStatus, overallGP func() *big.Int) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.all.Contains(obj.tx.ID()) {
+		return false
+	}
+	if obj.status != status {
+		obj.status = status
+		if status == Pending {
+			obj.overallGP = overallGP()
+		}
+		e.dirty = true
+	}
+	return true
+}
+
 func (e *entry) cachePending(pending txObjects) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/tx"
+)
+
+// journal is a rotating log of local txs, in RLP stream format.
+// It makes local txs survive node restarts.
+type journal struct {
+	path   string
+	lock   sync.Mutex
+	writer io.WriteCloser
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads txs from the journal file and feeds them to add.
+// Missing journal file is not an error.
+func (j *journal) load(add func(tx *tx.Transaction) error) (total int, dropped int, err error) {
+	file, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	stream := rlp.NewStream(file, 0)
+	for {
+		var trx tx.Transaction
+		if err := stream.Decode(&trx); err != nil {
+			if err == io.EOF {
+				return total, dropped, nil
+			}
+			// the tail may be corrupted by a crash
+			return total, dropped, errors.Wrap(err, "decode tx")
+		}
+		total++
+		if err := add(&trx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends a tx to the journal.
+func (j *journal) insert(trx *tx.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return errors.New("journal not opened")
+	}
+	return rlp.Encode(j.writer, trx)
+}
+
+// rotate regenerates the journal with the given txs, and reopens it for appending.
+func (j *journal) rotate(txs []*tx.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+		j.writer = nil
+	}
+
+	tmp, err := os.OpenFile(j.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	for _, trx := range txs {
+		if err := rlp.Encode(tmp, trx); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(j.path+".new", j.path); err != nil {
+		return err
+	}
+
+	writer, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	j.writer = writer
+	return nil
+}
+
+// close flushes the journal and closes it.
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"sync"
+
+	"github.com/vechain/thor/thor"
+)
+
+// schedule holds txs whose blockRef points to a future block, until they become executable.
+// Scheduled txs are not counted in pool size or origin quota of pool, but limited by
+// origin limit on their own, and their lifetime starts when promoted into pool.
+type schedule struct {
+	lock        sync.Mutex
+	limit       int
+	originLimit int
+	objs        map[thor.Bytes32]*txObject
+	quota       quota
+}
+
+func newSchedule(limit, originLimit int) *schedule {
+	return &schedule{
+		limit:       limit,
+		originLimit: originLimit,
+		objs:        make(map[thor.Bytes32]*txObject),
+		quota:       make(quota),
+	}
+}
+
+func (s *schedule) contains(id thor.Bytes32) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.objs[id]
+	return ok
+}
+
+func (s *schedule) add(obj *txObject) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.objs) >= s.limit {
+		return rejectedTxErr{"schedule is full"}
+	}
+	if s.quota.quota(obj.signer) >= uint(s.originLimit) {
+		return rejectedTxErr{"quota exceeds limit"}
+	}
+	s.objs[obj.tx.ID()] = obj
+	s.quota.inc(obj.signer)
+	return nil
+}
+
+func (s *schedule) remove(id thor.Bytes32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if obj, ok := s.objs[id]; ok {
+		delete(s.objs, id)
+		s.quota.dec(obj.signer)
+	}
+}
+
+// popReady removes and returns txs executable on top of the best block.
+func (s *schedule) popReady(bestBlockNum uint32) txObjects {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var ready txObjects
+	for id, obj := range s.objs {
+		if obj.tx.BlockRef().Number() <= bestBlockNum+1 {
+			ready = append(ready, obj)
+			delete(s.objs, id)
+			s.quota.dec(obj.signer)
+		}
+	}
+	return ready
+}
+
+func (s *schedule) dumpAll() txObjects {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	all := make(txObjects, 0, len(s.objs))
+	for _, obj := range s.objs {
+		all = append(all, obj)
+	}
+	return all
+}
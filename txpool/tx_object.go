@@ -8,7 +8,6 @@ package txpool
 import (
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -29,23 +28,27 @@ type txObject struct {
 	overallGP    *big.Int
 	creationTime int64
 	deleted      bool
+	local        bool // submitted locally
 }
 
-func (txObjs *txObject) currentState(chain *chain.Chain, bestBlockNum uint32) objectStatus {
+//currentState returns status of the tx object on top of the best block.
+//A tx depending on another one is held until the dependency is in trunk, or is pending in pool
+//as told by isPendingInPool, so that both can be packed into the same block.
+func (txObjs *txObject) currentState(chain *chain.Chain, bestBlockNum uint32, isPendingInPool func(thor.Bytes32) bool) objectStatus {
+	if txObjs.tx.BlockRef().Number() > bestBlockNum+1 {
+		return Queued
+	}
+
 	dependsOn := txObjs.tx.DependsOn()
-	if dependsOn != nil {
+	if dependsOn != nil && !isPendingInPool(*dependsOn) {
 		if _, err := chain.GetTrunkTransactionMeta(*dependsOn); err != nil {
 			if !chain.IsNotFound(err) {
-				log.Error("err", err)
+				log.Error("failed to get dependency tx meta", "err", err)
 			}
 			return Queued
 		}
 	}
 
-	if txObjs.tx.BlockRef().Number() > bestBlockNum+1 {
-		return Queued
-	}
-
 	return Pending
 }
 
@@ -60,3 +63,38 @@ func (txObjs txObjects) parseTxs() []*tx.Transaction {
 	}
 	return txs
 }
+
+//orderByDependency reorders tx objects so that each one is placed right after its dependency
+//if the dependency is also in the list, otherwise the original order is kept.
+func (txObjs txObjects) orderByDependency() txObjects {
+	index := make(map[thor.Bytes32]bool, len(txObjs))
+	for _, obj := range txObjs {
+		index[obj.tx.ID()] = true
+	}
+
+	ordered := make(txObjects, 0, len(txObjs))
+	placed := make(map[thor.Bytes32]bool, len(txObjs))
+	// dependency ID -> objects waiting for it
+	waiting := make(map[thor.Bytes32]txObjects)
+
+	var place func(obj *txObject)
+	place = func(obj *txObject) {
+		id := obj.tx.ID()
+		ordered = append(ordered, obj)
+		placed[id] = true
+		dependents := waiting[id]
+		delete(waiting, id)
+		for _, dependent := range dependents {
+			place(dependent)
+		}
+	}
+
+	for _, obj := range txObjs {
+		if dep := obj.tx.DependsOn(); dep != nil && index[*dep] && !placed[*dep] {
+			waiting[*dep] = append(waiting[*dep], obj)
+			continue
+		}
+		place(obj)
+	}
+	return ordered
+}
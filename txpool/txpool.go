@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/runtime"
@@ -19,44 +20,64 @@ import (
 )
 
 const (
-	maxTxSize      = 32 * 1024 // Reject transactions over 32KB to prevent DOS attacks
-	quotaSignerTx  = 100       // Each signer saves up to 100 txs
-	cacheMechanism = prior
+	maxTxSize             = 32 * 1024   // Reject transactions over 32KB to prevent DOS attacks
+	journalRotateInterval = time.Minute // Interval to regenerate local tx journal
+	replaceBump           = 10          // Minimum gas price bump percentage to replace a tx
 )
 
+var log = log15.New("pkg", "txpool")
+
 //PoolConfig PoolConfig
 type PoolConfig struct {
-	PoolSize int           // Maximum number of executable transaction slots for all accounts
-	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+	PoolSize    int           // Maximum number of transactions in pool
+	OriginLimit int           // Maximum number of transactions of each origin
+	Lifetime    time.Duration // Maximum amount of time transactions stay in pool
+
+	ScheduleSize  int    // Maximum number of scheduled transactions, whose blockRef points to a future block
+	ScheduleRange uint32 // Maximum number of blocks the blockRef of scheduled transactions ahead of best block
 }
 
-//DefaultTxPoolConfig DefaultTxPoolConfig
-var defaultTxPoolConfig = PoolConfig{
-	PoolSize: 20000,
-	Lifetime: 1000,
+//DefaultPoolConfig DefaultPoolConfig
+var DefaultPoolConfig = PoolConfig{
+	PoolSize:    20000,
+	OriginLimit: 100,
+	Lifetime:    20 * time.Minute,
+
+	ScheduleSize:  5000,
+	ScheduleRange: 8640, // about a day
 }
 
 //TxPool TxPool
 type TxPool struct {
-	config PoolConfig
-	chain  *chain.Chain
-	stateC *state.Creator
-	goes   co.Goes
-	done   chan struct{}
-	txFeed event.Feed
-	scope  event.SubscriptionScope
-	entry  *entry
-}
-
-//New construct a new txpool
-func New(chain *chain.Chain, stateC *state.Creator) *TxPool {
+	config     PoolConfig
+	chain      *chain.Chain
+	stateC     *state.Creator
+	forkConfig thor.ForkConfig
+	goes     co.Goes
+	done     chan struct{}
+	txFeed   event.Feed
+	scope    event.SubscriptionScope
+	entry    *entry
+	schedule *schedule
+	journal  *journal
+}
+
+//New construct a new txpool. When the pool is full, the tx with lowest gas price coef
+//is evicted, and the newer one is evicted first if gas price coefs are equal.
+//A tx replaces the one in pool with the same origin, nonce and dependsOn, if it offers
+//gas price at least 10 percent higher.
+//A tx whose blockRef points to a future block is held in schedule, and promoted into pool
+//when it becomes executable.
+func New(chain *chain.Chain, stateC *state.Creator, forkConfig thor.ForkConfig, config PoolConfig) *TxPool {
 	pool := &TxPool{
-		config: defaultTxPoolConfig,
-		chain:  chain,
-		stateC: stateC,
-		done:   make(chan struct{}),
+		config:     config,
+		chain:      chain,
+		stateC:     stateC,
+		forkConfig: forkConfig,
+		done:       make(chan struct{}),
 	}
-	pool.entry = newEntry(pool.config.PoolSize)
+	pool.entry = newEntry(config.PoolSize, config.OriginLimit)
+	pool.schedule = newSchedule(config.ScheduleSize, config.OriginLimit)
 	pool.goes.Go(pool.updateLoop)
 	return pool
 }
@@ -66,61 +87,141 @@ func (pool *TxPool) Close() {
 	close(pool.done)
 	pool.scope.Close()
 	pool.goes.Wait()
+	if pool.journal != nil {
+		if err := pool.journal.rotate(pool.locals()); err != nil {
+			log.New("txpool", pool).Warn("failed to rotate tx journal", "err", err)
+		}
+		pool.journal.close()
+	}
 }
 
 //Add transaction
 func (pool *TxPool) Add(txs ...*tx.Transaction) error {
 	for _, tx := range txs {
-		tx := tx // it's for closure
-		txID := tx.ID()
+		if err := pool.add(tx, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		repeatedTx, err := pool.isAlreadyInChain(txID)
-		if err != nil {
+//AddLocal adds locally submitted transactions, which are journaled if journal opened
+func (pool *TxPool) AddLocal(txs ...*tx.Transaction) error {
+	for _, tx := range txs {
+		if err := pool.add(tx, true); err != nil {
 			return err
 		}
-		if repeatedTx {
-			return rejectedTxErr{"transaction already packed"}
+		if pool.journal != nil {
+			if err := pool.journal.insert(tx); err != nil {
+				log.New("txpool", pool).Warn("failed to journal local tx", "err", err)
+			}
 		}
+	}
+	return nil
+}
 
-		if obj := pool.entry.find(txID); obj != nil {
-			return rejectedTxErr{"known transaction"}
-		}
+//OpenJournal loads local transactions from journal file at path, and journals
+//local transactions afterwards. It should be called before any AddLocal.
+func (pool *TxPool) OpenJournal(path string) error {
+	j := newJournal(path)
+	total, dropped, err := j.load(func(tx *tx.Transaction) error {
+		return pool.add(tx, true)
+	})
+	if err != nil {
+		log.New("txpool", pool).Warn("failed to load tx journal", "err", err)
+	}
+	if total > 0 {
+		log.New("txpool", pool).Info("loaded local txs from journal", "total", total, "dropped", dropped)
+	}
+	if err := j.rotate(pool.locals()); err != nil {
+		return err
+	}
+	pool.journal = j
+	pool.goes.Go(pool.journalLoop)
+	return nil
+}
 
-		// If the transaction fails basic validation, discard it
-		signer, err := pool.validateTx(tx)
-		if err != nil {
-			return err
-		}
+func (pool *TxPool) add(tx *tx.Transaction, local bool) error {
+	txID := tx.ID()
+
+	repeatedTx, err := pool.isAlreadyInChain(txID)
+	if err != nil {
+		return err
+	}
+	if repeatedTx {
+		return rejectedTxErr{"transaction already packed"}
+	}
+
+	if obj := pool.entry.find(txID); obj != nil || pool.schedule.contains(txID) {
+		return rejectedTxErr{"known transaction"}
+	}
 
-		if err := pool.entry.save(&txObject{
-			tx:           tx,
-			signer:       signer,
-			overallGP:    new(big.Int),
-			creationTime: time.Now().Unix(),
-			status:       Queued,
-		}); err != nil {
+	// If the transaction fails basic validation, discard it
+	signer, err := pool.validateTx(tx)
+	if err != nil {
+		return err
+	}
+
+	obj := &txObject{
+		tx:           tx,
+		signer:       signer,
+		overallGP:    new(big.Int),
+		creationTime: time.Now().Unix(),
+		status:       Queued,
+		local:        local,
+	}
+	bestBlockNum := pool.chain.BestBlock().Header().Number()
+	if refNum := tx.BlockRef().Number(); refNum > bestBlockNum+1 {
+		if uint64(refNum) > uint64(bestBlockNum)+uint64(pool.config.ScheduleRange) {
+			return rejectedTxErr{"block ref too far in future"}
+		}
+		if err := pool.schedule.add(obj); err != nil {
 			return err
 		}
-
-		pool.goes.Go(func() { pool.txFeed.Send(tx) })
+	} else if err := pool.entry.save(obj); err != nil {
+		return err
 	}
 
+	pool.goes.Go(func() { pool.txFeed.Send(tx) })
 	return nil
 }
 
+// locals returns local txs in pool and schedule.
+func (pool *TxPool) locals() []*tx.Transaction {
+	var txs []*tx.Transaction
+	for _, objs := range []txObjects{pool.entry.dumpAll(), pool.schedule.dumpAll()} {
+		for _, obj := range objs {
+			if obj.local {
+				txs = append(txs, obj.tx)
+			}
+		}
+	}
+	return txs
+}
+
 //Remove remove transaction by txID with TransactionCategory
 func (pool *TxPool) Remove(txIDs ...thor.Bytes32) {
 	for _, txID := range txIDs {
 		pool.entry.delete(txID)
+		pool.schedule.remove(txID)
 	}
 }
 
+//Get returns the tx in pool by ID, or nil if not found.
+func (pool *TxPool) Get(txID thor.Bytes32) *tx.Transaction {
+	if obj := pool.entry.find(txID); obj != nil {
+		return obj.tx
+	}
+	return nil
+}
+
 //SubscribeNewTransaction receivers will receive a tx
 func (pool *TxPool) SubscribeNewTransaction(ch chan *tx.Transaction) event.Subscription {
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
-//Pending return all pending txs
+//Pending return all pending txs. If sort is true, txs are sorted by overall gas price,
+//except that a tx depending on another pending one follows it.
 func (pool *TxPool) Pending(sort bool) tx.Transactions {
 	if pool.entry.isDirty() {
 		pool.updateData(pool.chain.BestBlock())
@@ -128,6 +229,47 @@ func (pool *TxPool) Pending(sort bool) tx.Transactions {
 	return pool.entry.dumpPending(sort).parseTxs()
 }
 
+//TxInfo describes a tx in pool
+type TxInfo struct {
+	Tx        *tx.Transaction
+	Origin    thor.Address
+	Pending   bool  // false means queued or scheduled
+	Scheduled bool  // held until blockRef reached
+	AddTime   int64 // unix time when the tx added
+}
+
+//Dump returns info of all txs in pool
+func (pool *TxPool) Dump() []*TxInfo {
+	if pool.entry.isDirty() {
+		pool.updateData(pool.chain.BestBlock())
+	}
+	all, status := pool.entry.dumpAllWithStatus()
+	scheduled := pool.schedule.dumpAll()
+	infos := make([]*TxInfo, 0, len(all)+len(scheduled))
+	for i, obj := range all {
+		infos = append(infos, &TxInfo{
+			Tx:      obj.tx,
+			Origin:  obj.signer,
+			Pending: status[i] == Pending,
+			AddTime: obj.creationTime,
+		})
+	}
+	for _, obj := range scheduled {
+		infos = append(infos, &TxInfo{
+			Tx:        obj.tx,
+			Origin:    obj.signer,
+			Scheduled: true,
+			AddTime:   obj.creationTime,
+		})
+	}
+	return infos
+}
+
+// supportedFeatures returns tx features supported by the block numbered num.
+func (pool *TxPool) supportedFeatures(num uint32) tx.Features {
+	return tx.SupportedFeatures(pool.forkConfig, num)
+}
+
 func (pool *TxPool) validateTx(tx *tx.Transaction) (thor.Address, error) {
 	if tx.Size() > maxTxSize {
 		return thor.Address{}, rejectedTxErr{"tx too large"}
@@ -137,12 +279,12 @@ func (pool *TxPool) validateTx(tx *tx.Transaction) (thor.Address, error) {
 		return thor.Address{}, badTxErr{"chain tag mismatched"}
 	}
 
-	if tx.HasReservedFields() {
-		return thor.Address{}, badTxErr{"reserved fields not empty"}
-	}
-
 	bestBlock := pool.chain.BestBlock()
 
+	if err := tx.TestFeatures(pool.supportedFeatures(bestBlock.Header().Number() + 1)); err != nil {
+		return thor.Address{}, badTxErr{err.Error()}
+	}
+
 	if tx.Gas() > bestBlock.Header().GasLimit() {
 		return thor.Address{}, badTxErr{"tx gas exceeded"}
 	}
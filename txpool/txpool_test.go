@@ -6,8 +6,12 @@
 package txpool
 
 import (
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +48,8 @@ func TestTxPool(t *testing.T) {
 		t.Fatal(err)
 	}
 	testPending(t, pool, count)
+	testDump(t, pool, count)
+	assert.Equal(t, txID, pool.Get(txID).ID())
 
 	// test pool quota
 	err := pool.Add(generateTxs(t, 1)...)
@@ -52,6 +58,7 @@ func TestTxPool(t *testing.T) {
 	// test remove tx
 	pool.Remove(txID)
 	testPending(t, pool, count-1)
+	assert.Nil(t, pool.Get(txID))
 
 	// test pool quota
 	if err := pool.Add(generateTxs(t, 1)...); err != nil {
@@ -60,35 +67,286 @@ func TestTxPool(t *testing.T) {
 	testPending(t, pool, count)
 }
 
-func testPending(t *testing.T, pool *TxPool, count int) {
-	txs := pool.Pending(true)
-	assert.Equal(t, len(txs), count)
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txpool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal")
+
+	pool := initPool(t)
+	if err := pool.OpenJournal(path); err != nil {
+		t.Fatal(err)
+	}
+	txs := generateTxs(t, 3)
+	if err := pool.AddLocal(txs[:2]...); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Add(txs[2]); err != nil {
+		t.Fatal(err)
+	}
+	pool.Close()
+
+	// only local txs are restored
+	pool = New(c, pool.stateC, thor.NoFork, DefaultPoolConfig)
+	defer pool.Close()
+	if err := pool.OpenJournal(path); err != nil {
+		t.Fatal(err)
+	}
+	infos := pool.Dump()
+	assert.Equal(t, 2, len(infos))
+	for _, info := range infos {
+		assert.NotEqual(t, txs[2].ID(), info.Tx.ID())
+	}
 }
 
-func generateTxs(t *testing.T, count int) tx.Transactions {
-	txs := make(tx.Transactions, count, count)
-	address := thor.BytesToAddress([]byte("addr"))
-	for i := 0; i < count; i++ {
-		cla := tx.NewClause(&address).WithValue(big.NewInt(10 + int64(nonce))).WithData(nil)
-		tx := new(tx.Builder).
-			GasPriceCoef(1).
+func TestEviction(t *testing.T) {
+	pool := initPoolWithConfig(t, PoolConfig{PoolSize: 2, OriginLimit: 10, Lifetime: time.Hour})
+	defer pool.Close()
+
+	low, high := newTx(t, 1), newTx(t, 2)
+	if err := pool.Add(low, high); err != nil {
+		t.Fatal(err)
+	}
+
+	// not prior to the lowest one
+	assert.Equal(t, rejectedTxErr{"pool is full"}, pool.Add(newTx(t, 1)))
+
+	higher := newTx(t, 3)
+	if err := pool.Add(higher); err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[thor.Bytes32]bool)
+	for _, info := range pool.Dump() {
+		ids[info.Tx.ID()] = true
+	}
+	assert.Equal(t, map[thor.Bytes32]bool{high.ID(): true, higher.ID(): true}, ids)
+}
+
+func TestOriginLimit(t *testing.T) {
+	pool := initPoolWithConfig(t, PoolConfig{PoolSize: 10, OriginLimit: 2, Lifetime: time.Hour})
+	defer pool.Close()
+
+	if err := pool.Add(generateTxs(t, 2)...); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rejectedTxErr{"quota exceeds limit"}, pool.Add(newTx(t, 1)))
+}
+
+func TestReplacement(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	newSlotTx := func(gasPriceCoef uint8) *tx.Transaction {
+		address := thor.BytesToAddress([]byte("addr"))
+		trx := new(tx.Builder).
+			GasPriceCoef(gasPriceCoef).
 			Gas(1000000).
 			Expiration(100).
-			Clause(cla).
+			Clause(tx.NewClause(&address).WithValue(big.NewInt(int64(gasPriceCoef)))).
 			Nonce(1).
 			ChainTag(c.Tag()).
 			Build()
-		sig, err := crypto.Sign(tx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trx.WithSignature(sig)
+	}
+
+	origin := newSlotTx(0)
+	if err := pool.Add(origin); err != nil {
+		t.Fatal(err)
+	}
+	// 255 * 110% > 255 + 20
+	assert.Equal(t, rejectedTxErr{"replacement transaction underpriced"}, pool.Add(newSlotTx(20)))
+
+	bumped := newSlotTx(30)
+	if err := pool.Add(bumped); err != nil {
+		t.Fatal(err)
+	}
+	infos := pool.Dump()
+	assert.Equal(t, 1, len(infos))
+	assert.Equal(t, bumped.ID(), infos[0].Tx.ID())
+}
+
+func TestDependency(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	dep := newTx(t, 1)
+	depID := dep.ID()
+	dependent := newDependentTx(t, 100, &depID)
+	dependentID := dependent.ID()
+	chained := newDependentTx(t, 200, &dependentID)
+	unknownID := thor.BytesToBytes32([]byte("unknown"))
+	orphan := newDependentTx(t, 255, &unknownID)
+	other := newTx(t, 50)
+
+	// dependents added first
+	if err := pool.Add(chained, orphan, dependent, dep, other); err != nil {
+		t.Fatal(err)
+	}
+
+	// dependents follow the dependency, regardless of gas price
+	assert.Equal(t, []thor.Bytes32{other.ID(), depID, dependentID, chained.ID()}, txIDs(pool.Pending(true)))
+	for _, info := range pool.Dump() {
+		assert.Equal(t, info.Tx.ID() != orphan.ID(), info.Pending)
+	}
+
+	// dependents held again once the dependency dropped
+	pool.Remove(depID)
+	pool.updateData(c.BestBlock())
+	assert.Equal(t, []thor.Bytes32{other.ID()}, txIDs(pool.Pending(true)))
+}
+
+func TestSchedule(t *testing.T) {
+	pool := initPoolWithConfig(t, PoolConfig{PoolSize: 10, OriginLimit: 10, Lifetime: time.Hour, ScheduleSize: 1, ScheduleRange: 10})
+	defer pool.Close()
+
+	newScheduledTx := func(refNum uint32) *tx.Transaction {
+		address := thor.BytesToAddress([]byte("addr"))
+		trx := new(tx.Builder).
+			Gas(1000000).
+			BlockRef(tx.NewBlockRef(refNum)).
+			Expiration(100).
+			Clause(tx.NewClause(&address)).
+			Nonce(uint64(nonce)).
+			ChainTag(c.Tag()).
+			Build()
+		nonce++
+		sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
 		if err != nil {
 			t.Fatal(err)
 		}
-		txs[i] = tx.WithSignature(sig)
+		return trx.WithSignature(sig)
+	}
+
+	// best block is #1
+	assert.Equal(t, rejectedTxErr{"block ref too far in future"}, pool.Add(newScheduledTx(12)))
+
+	scheduled := newScheduledTx(4)
+	if err := pool.Add(scheduled); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rejectedTxErr{"known transaction"}, pool.Add(scheduled))
+	assert.Equal(t, rejectedTxErr{"schedule is full"}, pool.Add(newScheduledTx(5)))
+
+	infos := pool.Dump()
+	if assert.Equal(t, 1, len(infos)) {
+		assert.True(t, infos[0].Scheduled)
+		assert.False(t, infos[0].Pending)
+	}
+	assert.Equal(t, 0, len(pool.Pending(true)))
+
+	// promoted once executable
+	for i := 0; i < 2; i++ {
+		best := c.BestBlock()
+		blk := new(block.Builder).
+			ParentID(best.Header().ID()).
+			StateRoot(best.Header().StateRoot()).
+			TotalScore(best.Header().TotalScore() + 1).
+			GasLimit(best.Header().GasLimit()).
+			Build()
+		if _, err := c.AddBlock(blk, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.updateData(c.BestBlock())
+	assert.Equal(t, []thor.Bytes32{scheduled.ID()}, txIDs(pool.Pending(true)))
+}
+
+func TestScheduleOriginLimit(t *testing.T) {
+	pool := initPoolWithConfig(t, PoolConfig{PoolSize: 10, OriginLimit: 2, Lifetime: time.Hour, ScheduleSize: 10, ScheduleRange: 10})
+	defer pool.Close()
+
+	newScheduledTx := func() *tx.Transaction {
+		address := thor.BytesToAddress([]byte("addr"))
+		trx := new(tx.Builder).
+			Gas(1000000).
+			BlockRef(tx.NewBlockRef(5)).
+			Expiration(100).
+			Clause(tx.NewClause(&address)).
+			Nonce(uint64(nonce)).
+			ChainTag(c.Tag()).
+			Build()
 		nonce++
+		sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trx.WithSignature(sig)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := pool.Add(newScheduledTx()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, rejectedTxErr{"quota exceeds limit"}, pool.Add(newScheduledTx()))
+}
+
+func txIDs(txs tx.Transactions) []thor.Bytes32 {
+	ids := make([]thor.Bytes32, 0, len(txs))
+	for _, tx := range txs {
+		ids = append(ids, tx.ID())
+	}
+	return ids
+}
+
+func testPending(t *testing.T, pool *TxPool, count int) {
+	txs := pool.Pending(true)
+	assert.Equal(t, len(txs), count)
+}
+
+func testDump(t *testing.T, pool *TxPool, count int) {
+	infos := pool.Dump()
+	assert.Equal(t, count, len(infos))
+	for _, info := range infos {
+		assert.True(t, info.Pending)
+		assert.Equal(t, genesis.DevAccounts()[0].Address, info.Origin)
+		assert.NotZero(t, info.AddTime)
+	}
+}
+
+func generateTxs(t *testing.T, count int) tx.Transactions {
+	txs := make(tx.Transactions, count, count)
+	for i := 0; i < count; i++ {
+		txs[i] = newTx(t, 1)
 	}
 	return txs
 }
 
+func newTx(t *testing.T, gasPriceCoef uint8) *tx.Transaction {
+	return newDependentTx(t, gasPriceCoef, nil)
+}
+
+func newDependentTx(t *testing.T, gasPriceCoef uint8, dependsOn *thor.Bytes32) *tx.Transaction {
+	address := thor.BytesToAddress([]byte("addr"))
+	cla := tx.NewClause(&address).WithValue(big.NewInt(10 + int64(nonce))).WithData(nil)
+	tx := new(tx.Builder).
+		GasPriceCoef(gasPriceCoef).
+		Gas(1000000).
+		Expiration(100).
+		Clause(cla).
+		Nonce(uint64(nonce)).
+		DependsOn(dependsOn).
+		ChainTag(c.Tag()).
+		Build()
+	sig, err := crypto.Sign(tx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce++
+	return tx.WithSignature(sig)
+}
+
 func initPool(t *testing.T) *TxPool {
+	return initPoolWithConfig(t, DefaultPoolConfig)
+}
+
+func initPoolWithConfig(t *testing.T, config PoolConfig) *TxPool {
 	db, _ := lvldb.NewMem()
 	stateC := state.NewCreator(db)
 	gen, err := genesis.NewDevnet()
@@ -104,9 +362,11 @@ func initPool(t *testing.T) *TxPool {
 	blk := new(block.Builder).
 		ParentID(best.Header().ID()).
 		StateRoot(best.Header().StateRoot()).
+		TotalScore(best.Header().TotalScore() + 1).
+		GasLimit(best.Header().GasLimit()).
 		Build()
 	if _, err := c.AddBlock(blk, nil); err != nil {
 		t.Fatal(err)
 	}
-	return New(c, stateC)
+	return New(c, stateC, thor.NoFork, config)
 }
@@ -6,9 +6,9 @@
 package txpool
 
 import (
+	"math/big"
 	"time"
 
-	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/builtin"
 	"github.com/vechain/thor/thor"
@@ -36,7 +36,18 @@ func (pool *TxPool) updateLoop() {
 }
 
 func (pool *TxPool) updateData(bestBlock *block.Block) {
-	log := log15.New("txpool", pool)
+	log := log.New("txpool", pool)
+	bestBlockNum := bestBlock.Header().Number()
+	bestBlockID := bestBlock.Header().ID()
+
+	// promote scheduled txs, lifetime starts from now
+	for _, obj := range pool.schedule.popReady(bestBlockNum) {
+		obj.creationTime = time.Now().Unix()
+		if err := pool.entry.save(obj); err != nil {
+			log.Debug("failed to promote scheduled tx", "id", obj.tx.ID(), "err", err)
+		}
+	}
+
 	allObjs := pool.entry.dumpAll()
 	pending := make(txObjects, 0, len(allObjs))
 
@@ -47,12 +58,10 @@ func (pool *TxPool) updateData(bestBlock *block.Block) {
 	}
 
 	baseGasPrice := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
-	bestBlockNum := bestBlock.Header().Number()
-	bestBlockID := bestBlock.Header().ID()
 
-	//can be pendinged txObjects
+	alive := make(map[thor.Bytes32]*txObject, len(allObjs))
 	for _, obj := range allObjs {
-		if obj.tx.IsExpired(bestBlockNum) || time.Now().Unix()-obj.creationTime > int64(pool.config.Lifetime) {
+		if obj.tx.IsExpired(bestBlockNum) || time.Now().Unix()-obj.creationTime > int64(pool.config.Lifetime/time.Second) {
 			pool.entry.delete(obj.tx.ID())
 			continue
 		}
@@ -66,22 +75,59 @@ func (pool *TxPool) updateData(bestBlock *block.Block) {
 			pool.entry.delete(obj.tx.ID())
 			continue
 		}
+		alive[obj.tx.ID()] = obj
+	}
 
-		if obj.status == Queued {
-			state := obj.currentState(pool.chain, bestBlockNum)
-			if state != Pending {
-				continue
-			}
+	// status of dependency is resolved before the dependent.
+	// pending txs are also re-evaluated, since the dependency may be dropped from pool.
+	states := make(map[thor.Bytes32]objectStatus, len(alive))
+	var resolve func(obj *txObject) objectStatus
+	resolve = func(obj *txObject) objectStatus {
+		id := obj.tx.ID()
+		if state, ok := states[id]; ok {
+			return state
+		}
+		// guard against dependency cycle
+		states[id] = Queued
+		state := obj.currentState(pool.chain, bestBlockNum, func(dep thor.Bytes32) bool {
+			depObj, ok := alive[dep]
+			return ok && resolve(depObj) == Pending
+		})
+		states[id] = state
+		return state
+	}
 
-			obj.status = state
-			obj.overallGP = obj.tx.OverallGasPrice(baseGasPrice, bestBlockNum, pool.chain.NewSeeker(bestBlockID).GetID)
-			pool.entry.save(obj)
+	//can be pendinged txObjects
+	for _, obj := range allObjs {
+		if _, ok := alive[obj.tx.ID()]; !ok {
+			continue
 		}
 
-		if obj.status == Pending {
+		state := resolve(obj)
+		inPool := pool.entry.setStatus(obj, state, func() *big.Int {
+			return obj.tx.OverallGasPrice(baseGasPrice, bestBlockNum, pool.chain.NewSeeker(bestBlockID).GetID)
+		})
+
+		if inPool && state == Pending {
 			pending = append(pending, obj)
 		}
 	}
 
 	pool.entry.cachePending(pending)
 }
+
+func (pool *TxPool) journalLoop() {
+	ticker := time.NewTicker(journalRotateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pool.done:
+			return
+		case <-ticker.C:
+			if err := pool.journal.rotate(pool.locals()); err != nil {
+				log.New("txpool", pool).Warn("failed to rotate tx journal", "err", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// CallFrame is a call captured by CallTracer.
+type CallFrame struct {
+	Type    string                `json:"type"`
+	From    common.Address        `json:"from"`
+	To      common.Address        `json:"to"`
+	Value   *math.HexOrDecimal256 `json:"value,omitempty"`
+	Gas     uint64                `json:"gas"`
+	GasUsed uint64                `json:"gasUsed"`
+	Input   hexutil.Bytes         `json:"input"`
+	Output  hexutil.Bytes         `json:"output"`
+	Error   string                `json:"error,omitempty"`
+	Calls   []*CallFrame          `json:"calls,omitempty"`
+}
+
+type callFrameContext struct {
+	frame       *CallFrame
+	callerDepth int    // depth of the op that made the call
+	gasIn       uint64 // gas of caller before the call op
+	gasCost     uint64 // cost of the call op, including gas passed to callee
+	entered     bool   // whether callee code started to run
+}
+
+// CallTracer is a Tracer which builds the call tree of an execution.
+// It tracks CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE, CREATE2 and SELFDESTRUCT ops.
+type CallTracer struct {
+	root  *CallFrame
+	stack []*callFrameContext
+}
+
+// NewCallTracer create a call tracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// Result returns the root call frame.
+func (t *CallTracer) Result() *CallFrame { return t.root }
+
+func (t *CallTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := CALL.String()
+	if create {
+		typ = CREATE.String()
+	}
+	t.root = &CallFrame{
+		Type:  typ,
+		From:  from,
+		To:    to,
+		Value: (*math.HexOrDecimal256)(new(big.Int).Set(value)),
+		Gas:   gas,
+		Input: common.CopyBytes(input),
+	}
+	t.stack = []*callFrameContext{{frame: t.root, entered: true}}
+	return nil
+}
+
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	t.unwind(env, depth, gas, stack)
+
+	if err != nil {
+		t.setError(depth, err)
+		return nil
+	}
+
+	from := contract.Address()
+	switch op {
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+		var (
+			value        *big.Int
+			inOff, inLen = stack.Back(2), stack.Back(3)
+			calleeGas    = env.callGasTemp
+		)
+		if op == CALL || op == CALLCODE {
+			value = stack.Back(2)
+			inOff, inLen = stack.Back(3), stack.Back(4)
+			if value.Sign() != 0 {
+				calleeGas += params.CallStipend
+			}
+		}
+		frame := &CallFrame{
+			Type:  op.String(),
+			From:  from,
+			To:    common.BigToAddress(stack.Back(1)),
+			Gas:   calleeGas,
+			Input: memory.Get(inOff.Int64(), inLen.Int64()),
+		}
+		if value != nil {
+			frame.Value = (*math.HexOrDecimal256)(new(big.Int).Set(value))
+		}
+		t.push(frame, depth, gas, cost)
+	case CREATE, CREATE2:
+		left := gas - cost
+		frame := &CallFrame{
+			Type:  op.String(),
+			From:  from,
+			Value: (*math.HexOrDecimal256)(new(big.Int).Set(stack.Back(0))),
+			Gas:   left - left/64,
+			Input: memory.Get(stack.Back(1).Int64(), stack.Back(2).Int64()),
+		}
+		t.push(frame, depth, gas, cost)
+	case SELFDESTRUCT:
+		top := t.stack[len(t.stack)-1].frame
+		top.Calls = append(top.Calls, &CallFrame{
+			Type:  op.String(),
+			From:  from,
+			To:    common.BigToAddress(stack.Back(0)),
+			Value: (*math.HexOrDecimal256)(new(big.Int).Set(env.StateDB.GetBalance(from))),
+		})
+	}
+	return nil
+}
+
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	t.setError(depth, err)
+	return nil
+}
+
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	t.root.Output = common.CopyBytes(output)
+	t.root.GasUsed = gasUsed
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+	t.stack = nil
+	return nil
+}
+
+func (t *CallTracer) push(frame *CallFrame, callerDepth int, gasIn, gasCost uint64) {
+	t.stack = append(t.stack, &callFrameContext{
+		frame:       frame,
+		callerDepth: callerDepth,
+		gasIn:       gasIn,
+		gasCost:     gasCost,
+	})
+}
+
+// unwind closes frames of calls which have returned to the given depth.
+func (t *CallTracer) unwind(env *EVM, depth int, gas uint64, stack *Stack) {
+	for len(t.stack) > 1 {
+		ctx := t.stack[len(t.stack)-1]
+		if !ctx.entered && depth == ctx.callerDepth+1 {
+			// first op of callee
+			ctx.entered = true
+			ctx.frame.Gas = gas
+			return
+		}
+		if depth > ctx.callerDepth {
+			return
+		}
+		// back to caller, the result of call op is on top of stack
+		frame := ctx.frame
+		if leftOver := gas - (ctx.gasIn - ctx.gasCost); frame.Gas > leftOver {
+			frame.GasUsed = frame.Gas - leftOver
+		}
+		if frame.Type == CREATE.String() || frame.Type == CREATE2.String() {
+			frame.To = common.BigToAddress(stack.Back(0))
+		} else {
+			frame.Output = common.CopyBytes(env.interpreter.returnData)
+		}
+		if stack.Back(0).Sign() == 0 && frame.Error == "" {
+			frame.Error = "execution failed"
+		}
+
+		t.stack = t.stack[:len(t.stack)-1]
+		parent := t.stack[len(t.stack)-1].frame
+		parent.Calls = append(parent.Calls, frame)
+	}
+}
+
+// setError sets error to the frame running at the given depth.
+func (t *CallTracer) setError(depth int, err error) {
+	for i := len(t.stack) - 1; i >= 0; i-- {
+		if ctx := t.stack[i]; ctx.entered && ctx.callerDepth+1 == depth {
+			ctx.frame.Error = err.Error()
+			return
+		}
+	}
+}
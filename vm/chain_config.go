@@ -0,0 +1,41 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainConfig extends params.ChainConfig with forks not known by the go-ethereum version in use.
+type ChainConfig struct {
+	params.ChainConfig
+	IstanbulBlock *big.Int `json:"istanbulBlock,omitempty"` // Istanbul switch block (nil = no fork, 0 = already on istanbul)
+}
+
+// IsIstanbul returns whether num is either equal to the Istanbul fork block or greater.
+func (c *ChainConfig) IsIstanbul(num *big.Int) bool {
+	return isForked(c.IstanbulBlock, num)
+}
+
+// GasTable returns the gas table corresponding to the current phase.
+// Istanbul reprices state access opcodes (EIP-1884) on top of the EIP158 table.
+func (c *ChainConfig) GasTable(num *big.Int) params.GasTable {
+	gt := c.ChainConfig.GasTable(num)
+	if c.IsIstanbul(num) {
+		gt.Balance = 700
+		gt.SLoad = 800
+	}
+	return gt
+}
+
+func isForked(s, head *big.Int) bool {
+	if s == nil || head == nil {
+		return false
+	}
+	return s.Cmp(head) <= 0
+}
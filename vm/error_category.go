@@ -0,0 +1,78 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import "fmt"
+
+// ErrorCategory categorizes errors that abort contract execution,
+// so that clients can tell the cause without matching error messages.
+type ErrorCategory uint8
+
+// error categories
+const (
+	ErrCategoryNone ErrorCategory = iota
+	ErrCategoryRevert
+	ErrCategoryOutOfGas
+	ErrCategoryInvalidOpCode
+	ErrCategoryStackUnderflow
+	ErrCategoryWriteProtection
+	ErrCategoryOther
+)
+
+var errorCategoryNames = map[ErrorCategory]string{
+	ErrCategoryNone:            "",
+	ErrCategoryRevert:          "revert",
+	ErrCategoryOutOfGas:        "out-of-gas",
+	ErrCategoryInvalidOpCode:   "invalid-opcode",
+	ErrCategoryStackUnderflow:  "stack-underflow",
+	ErrCategoryWriteProtection: "write-protection",
+	ErrCategoryOther:           "other",
+}
+
+// CategorizeError returns the category of error returned by EVM.Call, EVM.Create, etc.
+func CategorizeError(err error) ErrorCategory {
+	switch err.(type) {
+	case nil:
+		return ErrCategoryNone
+	case *ErrInvalidOpCode:
+		return ErrCategoryInvalidOpCode
+	case *ErrStackUnderflow:
+		return ErrCategoryStackUnderflow
+	}
+	switch err {
+	case errExecutionReverted:
+		return ErrCategoryRevert
+	case ErrOutOfGas, ErrCodeStoreOutOfGas, errGasUintOverflow:
+		return ErrCategoryOutOfGas
+	case errWriteProtection:
+		return ErrCategoryWriteProtection
+	}
+	return ErrCategoryOther
+}
+
+// String returns name of the category. Empty string for ErrCategoryNone.
+func (c ErrorCategory) String() string {
+	if name, ok := errorCategoryNames[c]; ok {
+		return name
+	}
+	return errorCategoryNames[ErrCategoryOther]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ErrorCategory) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ErrorCategory) UnmarshalText(text []byte) error {
+	for category, name := range errorCategoryNames {
+		if name == string(text) {
+			*c = category
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown error category %q", text)
+}
@@ -16,7 +16,10 @@
 
 package vm
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrOutOfGas                 = errors.New("out of gas")
@@ -26,3 +29,22 @@ var (
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrContractAddressCollision = errors.New("contract address collision")
 )
+
+// ErrInvalidOpCode is returned when an undefined opcode is encountered.
+type ErrInvalidOpCode struct {
+	OpCode OpCode
+}
+
+func (e *ErrInvalidOpCode) Error() string {
+	return fmt.Sprintf("invalid opcode 0x%x", int(e.OpCode))
+}
+
+// ErrStackUnderflow is returned when there are not enough items on stack for an operation.
+type ErrStackUnderflow struct {
+	StackLen int
+	Required int
+}
+
+func (e *ErrStackUnderflow) Error() string {
+	return fmt.Sprintf("stack underflow (%d <=> %d)", e.StackLen, e.Required)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OpcodeGas gas consumed by an opcode.
+type OpcodeGas struct {
+	Count uint64 `json:"count"`
+	Gas   uint64 `json:"gas"`
+}
+
+// FrameGas gas consumed by ops of a call frame, excluding its sub calls.
+type FrameGas struct {
+	Depth   int            `json:"depth"`
+	Address common.Address `json:"address"`
+	Gas     uint64         `json:"gas"`
+}
+
+// GasProfile gas consumed by an execution, aggregated per opcode and per call frame.
+// Gas passed to callees is not counted to call ops, and frames are in order of entered.
+type GasProfile struct {
+	Opcodes map[string]*OpcodeGas `json:"opcodes"`
+	Frames  []*FrameGas           `json:"frames"`
+}
+
+type gasProfiler struct {
+	profile GasProfile
+	stack   []*FrameGas
+}
+
+func newGasProfiler() *gasProfiler {
+	return &gasProfiler{
+		profile: GasProfile{
+			Opcodes: make(map[string]*OpcodeGas),
+			Frames:  []*FrameGas{},
+		},
+	}
+}
+
+func (p *gasProfiler) enter(contract *Contract, depth int) {
+	frame := &FrameGas{Depth: depth, Address: contract.Address()}
+	p.profile.Frames = append(p.profile.Frames, frame)
+	p.stack = append(p.stack, frame)
+}
+
+func (p *gasProfiler) exit() {
+	p.stack = p.stack[:len(p.stack)-1]
+}
+
+// record records cost of an op. callGas is the gas passed to callee if op is a call.
+func (p *gasProfiler) record(op OpCode, cost uint64, callGas uint64) {
+	switch op {
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+		cost -= callGas
+	}
+	name := op.String()
+	opGas, ok := p.profile.Opcodes[name]
+	if !ok {
+		opGas = &OpcodeGas{}
+		p.profile.Opcodes[name] = opGas
+	}
+	opGas.Count++
+	opGas.Gas += cost
+	if len(p.stack) > 0 {
+		p.stack[len(p.stack)-1].Gas += cost
+	}
+}
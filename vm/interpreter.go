@@ -17,7 +17,6 @@
 package vm
 
 import (
-	"fmt"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common/math"
@@ -35,6 +34,8 @@ type Config struct {
 	NoRecursion bool
 	// Enable recording of SHA3/keccak preimages
 	EnablePreimageRecording bool
+	// GasProfile enables aggregating gas consumed per opcode and per call frame
+	GasProfile bool
 	// JumpTable contains the EVM instruction table. This
 	// may be left uninitialised and will be set to the default
 	// table.
@@ -53,6 +54,8 @@ type Interpreter struct {
 
 	readOnly   bool   // Whether to throw on stateful modifications
 	returnData []byte // Last CALL's return data for subsequent reuse
+
+	profiler *gasProfiler // non-nil if gas profile enabled
 }
 
 // NewInterpreter returns a new instance of the Interpreter.
@@ -62,6 +65,8 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 	// we'll set the default jump table.
 	if !cfg.JumpTable[STOP].valid {
 		switch {
+		case evm.ChainConfig().IsIstanbul(evm.BlockNumber):
+			cfg.JumpTable = istanbulInstructionSet
 		case evm.ChainConfig().IsConstantinople(evm.BlockNumber):
 			cfg.JumpTable = constantinopleInstructionSet
 		case evm.ChainConfig().IsByzantium(evm.BlockNumber):
@@ -73,12 +78,16 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 		}
 	}
 
-	return &Interpreter{
+	in := &Interpreter{
 		evm:      evm,
 		cfg:      cfg,
 		gasTable: evm.ChainConfig().GasTable(evm.BlockNumber),
 		intPool:  newIntPool(),
 	}
+	if cfg.GasProfile {
+		in.profiler = newGasProfiler()
+	}
+	return in
 }
 
 func (in *Interpreter) enforceRestrictions(op OpCode, operation operation, stack *Stack) error {
@@ -128,6 +137,11 @@ func (in *Interpreter) Run(contract *Contract, input []byte) (ret []byte, err er
 		return nil, nil
 	}
 
+	if in.profiler != nil {
+		in.profiler.enter(contract, in.evm.depth)
+		defer in.profiler.exit()
+	}
+
 	var (
 		op    OpCode        // current opcode
 		mem   = NewMemory() // bound memory
@@ -170,7 +184,7 @@ func (in *Interpreter) Run(contract *Contract, input []byte) (ret []byte, err er
 		op = contract.GetOp(pc)
 		operation := in.cfg.JumpTable[op]
 		if !operation.valid {
-			return nil, fmt.Errorf("invalid opcode 0x%x", int(op))
+			return nil, &ErrInvalidOpCode{op}
 		}
 		if err := operation.validateStack(stack); err != nil {
 			return nil, err
@@ -200,6 +214,9 @@ func (in *Interpreter) Run(contract *Contract, input []byte) (ret []byte, err er
 		if err != nil || !contract.UseGas(cost) {
 			return nil, ErrOutOfGas
 		}
+		if in.profiler != nil {
+			in.profiler.record(op, cost, in.evm.callGasTemp)
+		}
 		if memorySize > 0 {
 			mem.Resize(memorySize)
 		}
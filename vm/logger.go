@@ -92,6 +92,8 @@ func (s *StructLog) ErrorString() string {
 // current VM state.
 // Note that reference types are actual VM data structures; make copies
 // if you need to retain them beyond the current call.
+//
+// Tracers implemented outside the package can be plugged in by RegisterTracer.
 type Tracer interface {
 	CaptureStart(from common.Address, to common.Address, call bool, input []byte, gas uint64, value *big.Int) error
 	CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error
@@ -110,6 +112,7 @@ type StructLogger struct {
 	logs          []StructLog
 	changedValues map[common.Address]Storage
 	output        []byte
+	gasUsed       uint64
 	err           error
 }
 
@@ -184,6 +187,7 @@ func (l *StructLogger) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost ui
 
 func (l *StructLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
 	l.output = output
+	l.gasUsed = gasUsed
 	l.err = err
 	if l.cfg.Debug {
 		fmt.Printf("0x%x\n", output)
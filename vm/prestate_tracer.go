@@ -0,0 +1,113 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// PrestateAccount state of an account before the execution.
+// Storage only contains slots touched by the execution.
+type PrestateAccount struct {
+	Balance *math.HexOrDecimal256       `json:"balance"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// PrestateTracer is a Tracer which collects states of accounts touched by an execution,
+// as they were before the execution.
+type PrestateTracer struct {
+	stateDB  StateDB
+	accounts map[common.Address]*PrestateAccount
+	created  map[common.Address]bool
+}
+
+// NewPrestateTracer create a prestate tracer.
+func NewPrestateTracer() *PrestateTracer {
+	return &PrestateTracer{
+		accounts: make(map[common.Address]*PrestateAccount),
+		created:  make(map[common.Address]bool),
+	}
+}
+
+// Result returns collected accounts.
+func (t *PrestateTracer) Result() map[common.Address]*PrestateAccount {
+	return t.accounts
+}
+
+func (t *PrestateTracer) setStateDB(stateDB StateDB) {
+	t.stateDB = stateDB
+}
+
+func (t *PrestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	if t.stateDB == nil {
+		return nil
+	}
+	// value is already transferred when capture started
+	t.lookupAccount(from).Balance = (*math.HexOrDecimal256)(new(big.Int).Add(t.stateDB.GetBalance(from), value))
+	if create {
+		t.created[to] = true
+		return nil
+	}
+	if to != from {
+		t.lookupAccount(to).Balance = (*math.HexOrDecimal256)(new(big.Int).Sub(t.stateDB.GetBalance(to), value))
+	}
+	return nil
+}
+
+func (t *PrestateTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if t.stateDB == nil || err != nil {
+		return nil
+	}
+	switch op {
+	case SLOAD, SSTORE:
+		t.lookupStorage(contract.Address(), common.BigToHash(stack.Back(0)))
+	case EXTCODECOPY, EXTCODESIZE, BALANCE, SELFDESTRUCT:
+		t.lookupAccount(common.BigToAddress(stack.Back(0)))
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+		t.lookupAccount(common.BigToAddress(stack.Back(1)))
+	}
+	return nil
+}
+
+func (t *PrestateTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+func (t *PrestateTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	// contracts created by the execution didn't exist before
+	for addr := range t.created {
+		delete(t.accounts, addr)
+	}
+	return nil
+}
+
+// lookupAccount records the account if not yet.
+func (t *PrestateTracer) lookupAccount(addr common.Address) *PrestateAccount {
+	if acc, ok := t.accounts[addr]; ok {
+		return acc
+	}
+	acc := &PrestateAccount{
+		Balance: (*math.HexOrDecimal256)(new(big.Int).Set(t.stateDB.GetBalance(addr))),
+		Code:    t.stateDB.GetCode(addr),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+	t.accounts[addr] = acc
+	return acc
+}
+
+// lookupStorage records the storage slot if not yet.
+func (t *PrestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	acc := t.lookupAccount(addr)
+	if _, ok := acc.Storage[key]; !ok {
+		acc.Storage[key] = t.stateDB.GetState(addr, key)
+	}
+}
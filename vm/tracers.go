@@ -0,0 +1,109 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnsupportedTracer returned by NewTracer if the tracer name is unknown.
+var ErrUnsupportedTracer = errors.New("unsupported tracer")
+
+// JSONTracer is a Tracer gives its result in JSON.
+// It's attached by Config.Tracer, with Config.Debug enabled.
+type JSONTracer interface {
+	Tracer
+	GetResult() (json.RawMessage, error)
+}
+
+// stateTracer is a Tracer requires to read state, which is set when EVM created.
+type stateTracer interface {
+	setStateDB(StateDB)
+}
+
+var tracers = struct {
+	sync.RWMutex
+	m map[string]func() JSONTracer
+}{m: map[string]func() JSONTracer{
+	"structLogger":   func() JSONTracer { return NewStructLogger(nil) },
+	"callTracer":     func() JSONTracer { return NewCallTracer() },
+	"prestateTracer": func() JSONTracer { return NewPrestateTracer() },
+}}
+
+// RegisterTracer registers a tracer by name, to be created by NewTracer.
+// It allows external packages to plug in tracers, e.g. gas profilers, without modifying the VM.
+// A tracer registered with an existing name replaces the old one.
+func RegisterTracer(name string, newTracer func() JSONTracer) {
+	if name == "" || newTracer == nil {
+		panic("vm: invalid tracer registration")
+	}
+	tracers.Lock()
+	defer tracers.Unlock()
+	tracers.m[name] = newTracer
+}
+
+// TracerNames returns names of registered tracers, in ascending order.
+func TracerNames() []string {
+	tracers.RLock()
+	defer tracers.RUnlock()
+	names := make([]string, 0, len(tracers.m))
+	for name := range tracers.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewTracer creates a registered tracer by name. Empty name means 'structLogger'.
+func NewTracer(name string) (JSONTracer, error) {
+	if name == "" {
+		name = "structLogger"
+	}
+	tracers.RLock()
+	newTracer, ok := tracers.m[name]
+	tracers.RUnlock()
+	if !ok {
+		return nil, ErrUnsupportedTracer
+	}
+	return newTracer(), nil
+}
+
+// StructLoggerResult result of StructLogger.
+type StructLoggerResult struct {
+	Gas         uint64      `json:"gas"`
+	Failed      bool        `json:"failed"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []StructLog `json:"structLogs"`
+}
+
+// GetResult implements JSONTracer.
+func (l *StructLogger) GetResult() (json.RawMessage, error) {
+	logs := l.logs
+	if logs == nil {
+		logs = []StructLog{}
+	}
+	return json.Marshal(&StructLoggerResult{
+		Gas:         l.gasUsed,
+		Failed:      l.err != nil,
+		ReturnValue: hexutil.Encode(l.output),
+		StructLogs:  logs,
+	})
+}
+
+// GetResult implements JSONTracer.
+func (t *CallTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.root)
+}
+
+// GetResult implements JSONTracer.
+func (t *PrestateTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.Result())
+}
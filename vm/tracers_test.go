@@ -0,0 +1,86 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTracer(t *testing.T) {
+	for _, name := range append(TracerNames(), "") {
+		tracer, err := NewTracer(name)
+		assert.Nil(t, err, name)
+		assert.NotNil(t, tracer, name)
+	}
+	_, err := NewTracer("unknown")
+	assert.Equal(t, ErrUnsupportedTracer, err)
+}
+
+func TestRegisterTracer(t *testing.T) {
+	RegisterTracer("testTracer", func() JSONTracer { return NewCallTracer() })
+	assert.Contains(t, TracerNames(), "testTracer")
+
+	tracer, err := NewTracer("testTracer")
+	assert.Nil(t, err)
+	assert.IsType(t, &CallTracer{}, tracer)
+
+	assert.Panics(t, func() { RegisterTracer("", func() JSONTracer { return nil }) })
+}
+
+func TestStructLoggerResult(t *testing.T) {
+	logger := NewStructLogger(nil)
+	logger.CaptureEnd([]byte{1}, 100, 0, nil)
+
+	data, err := logger.GetResult()
+	assert.Nil(t, err)
+
+	var result StructLoggerResult
+	assert.Nil(t, json.Unmarshal(data, &result))
+	assert.Equal(t, uint64(100), result.Gas)
+	assert.Equal(t, "0x01", result.ReturnValue)
+	assert.False(t, result.Failed)
+	assert.NotNil(t, result.StructLogs)
+}
+
+type balanceStateDB struct {
+	NoopStateDB
+	balances map[common.Address]*big.Int
+}
+
+func (db *balanceStateDB) GetBalance(addr common.Address) *big.Int {
+	if b, ok := db.balances[addr]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	from, to, created := common.Address{1}, common.Address{2}, common.Address{3}
+	db := &balanceStateDB{balances: map[common.Address]*big.Int{
+		// balances after value transferred
+		from: big.NewInt(90),
+		to:   big.NewInt(10),
+	}}
+
+	tracer := NewPrestateTracer()
+	tracer.setStateDB(db)
+	tracer.CaptureStart(from, to, false, nil, 0, big.NewInt(10))
+	tracer.CaptureEnd(nil, 0, 0, nil)
+	assert.Equal(t, 0, big.NewInt(100).Cmp((*big.Int)(tracer.Result()[from].Balance)))
+	assert.Equal(t, 0, (*big.Int)(tracer.Result()[to].Balance).Sign())
+
+	tracer = NewPrestateTracer()
+	tracer.setStateDB(db)
+	tracer.CaptureStart(from, created, true, nil, 0, big.NewInt(0))
+	tracer.CaptureEnd(nil, 0, 0, nil)
+	assert.Contains(t, tracer.Result(), from)
+	assert.NotContains(t, tracer.Result(), created, "created contract should be excluded")
+}